@@ -1,12 +1,15 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	"nordic-bank/internal/account/adapter"
 	"nordic-bank/internal/account/application"
@@ -14,12 +17,17 @@ import (
 	accountgrpc "nordic-bank/internal/account/grpc"
 	accounthttp "nordic-bank/internal/account/http"
 	sharedauth "nordic-bank/internal/shared/auth"
+	"nordic-bank/internal/shared/blob"
 	"nordic-bank/internal/shared/database"
+	"nordic-bank/internal/shared/vault"
 	pb "nordic-bank/pkg/pb/account/v1"
+	transactionpb "nordic-bank/pkg/pb/transaction/v1"
 
 	"github.com/gin-gonic/gin"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/reflection"
+	"gorm.io/gorm/clause"
 )
 
 func main() {
@@ -56,14 +64,181 @@ func main() {
 	}
 
 	// Run Migrations for Account Service
-	if err := db.AutoMigrate(&domain.Account{}, &domain.LedgerEntry{}, &domain.AccountRequest{}); err != nil {
+	if err := db.AutoMigrate(&domain.Account{}, &domain.AccountHolder{}, &domain.LedgerEntry{}, &domain.AccountRequest{}, &domain.FundReservation{}, &domain.InterestTier{}, &domain.InterestAccrual{}, &domain.Statement{}, &domain.Card{}, &domain.StatusChange{}, &domain.SubBalance{}, &domain.Pot{}, &domain.PotEntry{}, &domain.Product{}); err != nil {
 		log.Fatalf("failed to migrate account database: %v", err)
 	}
 
+	// Final statements on closure may cover a single day, which the original
+	// valid_period check did not allow
+	if err := db.Exec(`DO $$ BEGIN
+		IF EXISTS (
+			SELECT 1 FROM pg_constraint
+			WHERE conname = 'valid_period'
+			  AND conrelid = 'account.account_statements'::regclass
+			  AND pg_get_constraintdef(oid) NOT LIKE '%>=%'
+		) THEN
+			ALTER TABLE account.account_statements DROP CONSTRAINT valid_period;
+			ALTER TABLE account.account_statements ADD CONSTRAINT valid_period CHECK (period_end_date >= period_start_date);
+		END IF;
+	END $$;`).Error; err != nil {
+		log.Printf("warning: failed to relax statement period check: %v", err)
+	}
+
+	// Entries and holds made before sub-balances existed are all in the
+	// account's own currency
+	for _, table := range []string{"account.account_ledger", "account.fund_reservations"} {
+		if err := db.Exec(`UPDATE ` + table + ` t SET currency = a.currency
+			FROM account.accounts a
+			WHERE a.id = t.account_id AND t.currency IS NULL`).Error; err != nil {
+			log.Printf("warning: failed to backfill %s currency: %v", table, err)
+		}
+	}
+
+	// Entries made before value dating were valued and posted when booked
+	if err := db.Exec(`UPDATE account.account_ledger
+		SET value_date = COALESCE(value_date, (entry_date AT TIME ZONE 'UTC')::date),
+		    posted_date = COALESCE(posted_date, (entry_date AT TIME ZONE 'UTC')::date)
+		WHERE value_date IS NULL OR posted_date IS NULL`).Error; err != nil {
+		log.Printf("warning: failed to backfill ledger value dates: %v", err)
+	}
+
+	// Interest rates moved from the fractional interest_rate column to basis
+	// points; carry over rates set before and drop the old column so there
+	// is one source of truth
+	if err := db.Exec(`DO $$ BEGIN
+		IF EXISTS (
+			SELECT 1 FROM information_schema.columns
+			WHERE table_schema = 'account' AND table_name = 'accounts' AND column_name = 'interest_rate'
+		) THEN
+			UPDATE account.accounts
+				SET interest_rate_bps = ROUND(interest_rate * 10000)
+				WHERE interest_rate IS NOT NULL AND interest_rate_bps = 0;
+			ALTER TABLE account.accounts DROP COLUMN interest_rate;
+		END IF;
+	END $$;`).Error; err != nil {
+		log.Printf("warning: failed to migrate interest rates to basis points: %v", err)
+	}
+
+	// Transaction legs are posted once however often a caller retries them
+	legIndexes := []string{
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_ledger_transaction_leg
+			ON account.account_ledger (transaction_id, leg) WHERE leg <> ''`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_reservation_transaction_leg
+			ON account.fund_reservations (transaction_id, leg) WHERE leg <> ''`,
+	}
+	for _, query := range legIndexes {
+		if err := db.Exec(query).Error; err != nil {
+			log.Printf("warning: failed to create leg index: %v", err)
+		}
+	}
+
+	// Ledger queries page through an account's entries by (entry_date, id)
+	if err := db.Exec(`CREATE INDEX IF NOT EXISTS idx_ledger_account_date
+		ON account.account_ledger (account_id, entry_date DESC, id DESC)`).Error; err != nil {
+		log.Printf("warning: failed to create ledger paging index: %v", err)
+	}
+
+	// Seed the catalogue with the products the bank offered before it existed
+	// so older clients and pending requests keep working. Existing products
+	// are left as the employees configured them.
+	defaults := []domain.Product{
+		{Code: "CHECKING-DKK", Name: "Checking Account", AccountType: domain.AccountTypeChecking, Currency: "DKK", OverdraftEligible: true, MinAge: 18, RequiresKYC: true, Active: true},
+		{Code: "SAVINGS-DKK", Name: "Savings Account", AccountType: domain.AccountTypeSavings, Currency: "DKK", DayCountConvention: domain.DayCountAct365, RequiresKYC: true, Active: true},
+	}
+	if err := db.Clauses(clause.OnConflict{DoNothing: true}).Create(&defaults).Error; err != nil {
+		log.Printf("warning: failed to seed default products: %v", err)
+	}
+
 	// Initialize Dependencies
 	repo := adapter.NewPostgresAccountRepository(db)
 	service := application.NewAccountService(repo)
 
+	documentDir := os.Getenv("STATEMENT_DOCUMENT_DIR")
+	if documentDir == "" {
+		documentDir = "./data/statements"
+	}
+	documents, err := blob.NewLocalStore(documentDir)
+	if err != nil {
+		log.Fatalf("failed to open statement document store: %v", err)
+	}
+	service.SetDocumentStore(documents)
+
+	vaultDir := os.Getenv("CARD_VAULT_DIR")
+	if vaultDir == "" {
+		vaultDir = "./data/vault"
+	}
+	vaultSecret := os.Getenv("CARD_VAULT_SECRET")
+	if vaultSecret == "" {
+		vaultSecret = "default-development-vault-secret-do-not-use-in-prod"
+	}
+	vaultStore, err := blob.NewLocalStore(vaultDir)
+	if err != nil {
+		log.Fatalf("failed to open card vault: %v", err)
+	}
+	cardVault, err := vault.NewLocalVault(vaultStore, vaultSecret)
+	if err != nil {
+		log.Fatalf("failed to open card vault: %v", err)
+	}
+	service.SetCardVault(cardVault)
+
+	dormancy := application.DefaultDormancyPolicy
+	if v := os.Getenv("DORMANCY_MONTHS"); v != "" {
+		months, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("invalid DORMANCY_MONTHS: %v", err)
+		}
+		dormancy.InactivityMonths = months
+	}
+	if v := os.Getenv("DORMANCY_NOTICE_DAYS"); v != "" {
+		days, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("invalid DORMANCY_NOTICE_DAYS: %v", err)
+		}
+		dormancy.NoticePeriod = time.Duration(days) * 24 * time.Hour
+	}
+	if err := service.SetDormancyPolicy(dormancy); err != nil {
+		log.Fatalf("invalid dormancy policy: %v", err)
+	}
+
+	if v := os.Getenv("REQUEST_SLA_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("invalid REQUEST_SLA_HOURS: %v", err)
+		}
+		if err := service.SetRequestSLA(time.Duration(hours) * time.Hour); err != nil {
+			log.Fatalf("invalid request SLA: %v", err)
+		}
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "default-development-secret-do-not-use-in-prod"
+	}
+
+	// The transaction service pays out the balance of accounts being closed
+	transactionSvcAddr := os.Getenv("TRANSACTION_SERVICE_ADDR")
+	if transactionSvcAddr == "" {
+		transactionSvcAddr = "transaction-service:9080"
+	}
+	transactionConn, err := grpc.NewClient(transactionSvcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(sharedauth.ServiceCredentials([]byte(jwtSecret), "account-service")),
+	)
+	if err != nil {
+		log.Fatalf("failed to create transaction service client: %v", err)
+	}
+	defer transactionConn.Close()
+	service.SetTransactionClient(transactionpb.NewTransactionServiceClient(transactionConn))
+
+	// Background jobs
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go service.RunReservationSweeper(jobsCtx, time.Minute)
+	go service.RunInterestScheduler(jobsCtx, time.Hour)
+	go service.RunStatementScheduler(jobsCtx, time.Hour)
+	go service.RunDocumentRenderer(jobsCtx, 5*time.Minute)
+	go service.RunDormancyScheduler(jobsCtx, time.Hour)
+
 	// Error channel for servers
 	errChan := make(chan error, 2)
 
@@ -78,7 +253,7 @@ func main() {
 		router.Use(sharedauth.CORSMiddleware())
 		log.Println("CORS Middleware applied")
 
-		handler := accounthttp.NewHandler(service)
+		handler := accounthttp.NewHandler(service, jwtSecret)
 		handler.RegisterRoutes(router)
 
 		httpPort := os.Getenv("HTTP_PORT")
@@ -103,7 +278,8 @@ func main() {
 			return
 		}
 
-		grpcServer := grpc.NewServer()
+		// Only other services and employees may call the account service
+		grpcServer := grpc.NewServer(grpc.UnaryInterceptor(sharedauth.UnaryServerInterceptor([]byte(jwtSecret), sharedauth.ServiceRole, "employee")))
 		accountServer := accountgrpc.NewAccountServiceServer(service)
 		pb.RegisterAccountServiceServer(grpcServer, accountServer)
 
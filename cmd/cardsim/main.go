@@ -0,0 +1,113 @@
+// Command cardsim replays a file of card scheme messages against the
+// transaction service, one JSON message per line, and prints each response.
+//
+//	cardsim -file cmd/cardsim/example.jsonl -card tok_... -token eyJ...
+//
+// Blank lines and lines starting with # are skipped. The -card flag fills in
+// card_token on messages that leave it empty, so one file can be replayed
+// against any card. The service only accepts the messages when started with
+// CARD_SIMULATOR_ENABLED=true, from an employee whose access token is passed
+// with -token.
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/transaction/application"
+)
+
+func main() {
+	addr := flag.String("addr", "http://localhost:8084", "transaction service HTTP address")
+	file := flag.String("file", "", "file of JSON scheme messages, one per line (default stdin)")
+	card := flag.String("card", "", "card token for messages without one")
+	token := flag.String("token", "", "employee access token")
+	flag.Parse()
+
+	in := os.Stdin
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatalf("opening messages: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	url := strings.TrimRight(*addr, "/") + "/api/v1/card-authorizations"
+
+	scanner := bufio.NewScanner(in)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		var msg application.CardMessage
+		if err := json.Unmarshal([]byte(text), &msg); err != nil {
+			log.Fatalf("line %d: %v", line, err)
+		}
+		if msg.CardToken == "" {
+			msg.CardToken = *card
+		}
+
+		res, err := send(client, url, *token, msg)
+		if err != nil {
+			log.Fatalf("line %d: %v", line, err)
+		}
+
+		outcome := "DECLINED"
+		if res.Approved {
+			outcome = "APPROVED"
+		}
+		fmt.Printf("%s rrn=%s stan=%s amount=%d -> %s %s code=%s auth=%s authorized=%d",
+			msg.MTI, msg.RRN, msg.STAN, msg.Amount, res.MTI, outcome, res.ResponseCode, res.AuthCode, res.AuthorizedAmount)
+		if res.Duplicate {
+			fmt.Print(" (duplicate)")
+		}
+		fmt.Println()
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("reading messages: %v", err)
+	}
+}
+
+func send(client *http.Client, url, token string, msg application.CardMessage) (*application.CardResponse, error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		b, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(b)))
+	}
+
+	var res application.CardResponse
+	if err := json.NewDecoder(resp.Body).Decode(&res); err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
@@ -1,15 +1,19 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net"
 	"os"
 	"os/signal"
+	"strconv"
 	"syscall"
+	"time"
 
 	sharedauth "nordic-bank/internal/shared/auth"
 	"nordic-bank/internal/shared/database"
+	"nordic-bank/internal/shared/idempotency"
 	"nordic-bank/internal/transaction/adapter"
 	"nordic-bank/internal/transaction/application"
 	"nordic-bank/internal/transaction/domain"
@@ -58,17 +62,97 @@ func main() {
 	}
 
 	// Run Migrations for Transaction Service
-	if err := db.AutoMigrate(&domain.Transaction{}); err != nil {
+	if err := db.AutoMigrate(&domain.Transaction{}, &domain.CardAuthorization{}, &domain.CardHold{}, &domain.CardMessage{}, &domain.TillSession{}, &domain.TransferStep{}); err != nil {
 		log.Fatalf("failed to migrate transaction database: %v", err)
 	}
 
+	// Cash deposits only have a destination and withdrawals only a source,
+	// as in the original schema's valid_accounts check. Card payments settle
+	// outside the bank and keep only their source.
+	if err := db.Exec(`DO $$ BEGIN
+		IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'valid_accounts' AND conrelid = 'transaction.transactions'::regclass) THEN
+			ALTER TABLE transaction.transactions ADD CONSTRAINT valid_accounts CHECK (
+				(type = 'deposit' AND source_account_id IS NULL AND destination_account_id IS NOT NULL) OR
+				(type = 'withdrawal' AND source_account_id IS NOT NULL AND destination_account_id IS NULL) OR
+				(type = 'transfer' AND source_account_id IS NOT NULL AND destination_account_id IS NOT NULL) OR
+				(type = 'payment' AND source_account_id IS NOT NULL)
+			);
+		END IF;
+	END $$;`).Error; err != nil {
+		log.Printf("warning: failed to add valid_accounts check: %v", err)
+	}
+
+	// Idempotency keys belong to the caller, so the same key may appear once
+	// per caller; the original schema made them unique across the bank
+	for _, query := range []string{
+		`ALTER TABLE transaction.transactions DROP CONSTRAINT IF EXISTS transactions_idempotency_key_key`,
+		`DROP INDEX IF EXISTS transaction.idx_transaction_transactions_idempotency_key`,
+	} {
+		if err := db.Exec(query).Error; err != nil {
+			log.Printf("warning: failed to drop global idempotency key index: %v", err)
+		}
+	}
+
+	// Transaction search: keyset pages on (created_at, id) and full-text
+	// search of descriptions. The 'simple' configuration does not stem, so
+	// Danish and English descriptions match alike.
+	searchQueries := []string{
+		`CREATE INDEX IF NOT EXISTS idx_transactions_created_id ON transaction.transactions (created_at DESC, id DESC)`,
+		`ALTER TABLE transaction.transactions ADD COLUMN IF NOT EXISTS description_tsv tsvector
+			GENERATED ALWAYS AS (to_tsvector('simple', coalesce(description, ''))) STORED`,
+		`CREATE INDEX IF NOT EXISTS idx_transactions_description_tsv ON transaction.transactions USING GIN (description_tsv)`,
+	}
+	for _, query := range searchQueries {
+		if err := db.Exec(query).Error; err != nil {
+			log.Printf("warning: failed to prepare transaction search: %v", err)
+		}
+	}
+
+	// Categorise transactions made before categories were recorded; card
+	// payments by their merchant category code
+	if err := db.Exec(`UPDATE transaction.transactions
+		SET category = CASE type WHEN 'transfer' THEN 'transfer' ELSE 'cash' END
+		WHERE COALESCE(category, '') = '' AND type IN ('transfer', 'deposit', 'withdrawal')`).Error; err != nil {
+		log.Printf("warning: failed to categorise transactions: %v", err)
+	}
+	for _, r := range domain.MCCCategories {
+		if err := db.Exec(`UPDATE transaction.transactions t SET category = ?
+			FROM transaction.card_authorizations ca
+			WHERE ca.transaction_id = t.id AND COALESCE(t.category, '') = ''
+				AND ca.merchant_category_code BETWEEN ? AND ?`, r.Category, r.From, r.To).Error; err != nil {
+			log.Printf("warning: failed to categorise card payments: %v", err)
+		}
+	}
+	if err := db.Exec(`UPDATE transaction.transactions SET category = ? WHERE COALESCE(category, '') = ''`, domain.CategoryOther).Error; err != nil {
+		log.Printf("warning: failed to categorise transactions: %v", err)
+	}
+
+	keyStore := idempotency.NewGormStore(db, "transaction.idempotency_keys")
+	if err := keyStore.Migrate(); err != nil {
+		log.Fatalf("failed to migrate idempotency keys: %v", err)
+	}
+
+	// A till has at most one teller at a time
+	if err := db.Exec(`CREATE UNIQUE INDEX IF NOT EXISTS idx_till_sessions_open
+		ON transaction.till_sessions (branch_code, till_id) WHERE closed_at IS NULL`).Error; err != nil {
+		log.Printf("warning: failed to create open till index: %v", err)
+	}
+
+	jwtSecret := os.Getenv("JWT_SECRET")
+	if jwtSecret == "" {
+		jwtSecret = "default-development-secret-do-not-use-in-prod"
+	}
+
 	// Initialize Account gRPC Client
 	accountSvcAddr := os.Getenv("ACCOUNT_SERVICE_ADDR")
 	if accountSvcAddr == "" {
 		accountSvcAddr = "account-service-v2:9083"
 	}
 
-	conn, err := grpc.Dial(accountSvcAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	conn, err := grpc.Dial(accountSvcAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithPerRPCCredentials(sharedauth.ServiceCredentials([]byte(jwtSecret), "transaction-service")),
+	)
 	if err != nil {
 		log.Fatalf("did not connect to account service: %v", err)
 	}
@@ -79,6 +163,44 @@ func main() {
 	repo := adapter.NewPostgresTransactionRepository(db)
 	service := application.NewTransactionService(repo, accountClient)
 
+	if v := os.Getenv("TRANSFER_RECOVERY_AFTER_SECONDS"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil {
+			log.Fatalf("invalid TRANSFER_RECOVERY_AFTER_SECONDS: %v", err)
+		}
+		if err := service.SetTransferRecoveryDelay(time.Duration(seconds) * time.Second); err != nil {
+			log.Fatalf("invalid transfer recovery delay: %v", err)
+		}
+	}
+	var keyOptions idempotency.Options
+	if v := os.Getenv("IDEMPOTENCY_RETENTION_HOURS"); v != "" {
+		hours, err := strconv.Atoi(v)
+		if err != nil || hours <= 0 {
+			log.Fatalf("invalid IDEMPOTENCY_RETENTION_HOURS: %q", v)
+		}
+		keyOptions.Retention = time.Duration(hours) * time.Hour
+	}
+	keys := idempotency.New(keyStore, keyOptions)
+	if err := service.SetIdempotencyRetention(keys.Retention()); err != nil {
+		log.Fatalf("invalid idempotency retention: %v", err)
+	}
+
+	recoveryInterval := time.Minute
+	if v := os.Getenv("TRANSFER_RECOVERY_INTERVAL_SECONDS"); v != "" {
+		seconds, err := strconv.Atoi(v)
+		if err != nil || seconds <= 0 {
+			log.Fatalf("invalid TRANSFER_RECOVERY_INTERVAL_SECONDS: %q", v)
+		}
+		recoveryInterval = time.Duration(seconds) * time.Second
+	}
+
+	// Background jobs: resume or compensate transfers interrupted by a crash,
+	// starting with those left over from the last run
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	go service.RunTransferRecovery(jobsCtx, recoveryInterval)
+	go keys.RunPurger(jobsCtx, time.Hour)
+
 	// Error channel for servers
 	errChan := make(chan error, 2)
 
@@ -92,7 +214,11 @@ func main() {
 		// Apply CORS middleware
 		router.Use(sharedauth.CORSMiddleware())
 
-		handler := txhttp.NewHandler(service)
+		handler := txhttp.NewHandler(service, jwtSecret, keys)
+		if os.Getenv("CARD_SIMULATOR_ENABLED") == "true" {
+			log.Println("Card network simulator enabled")
+			handler.EnableCardSimulator()
+		}
 		handler.RegisterRoutes(router)
 
 		httpPort := os.Getenv("HTTP_PORT")
@@ -117,7 +243,11 @@ func main() {
 			return
 		}
 
-		grpcServer := grpc.NewServer()
+		// Only other services and employees may call the transaction service
+		grpcServer := grpc.NewServer(grpc.ChainUnaryInterceptor(
+			sharedauth.UnaryServerInterceptor([]byte(jwtSecret), sharedauth.ServiceRole, "employee"),
+			keys.UnaryServerInterceptor(),
+		))
 		transactionServer := txgrpc.NewTransactionServiceServer(service)
 		pb.RegisterTransactionServiceServer(grpcServer, transactionServer)
 
@@ -5,8 +5,8 @@ import { useParams, useRouter } from 'next/navigation';
 
 import { useAuth } from '@/context/AuthContext';
 import DashboardLayout from '@/components/dashboard/DashboardLayout';
-import { apiRequest } from '@/lib/api';
-import { Wallet, ArrowLeft, Download, Send, Loader2 } from 'lucide-react';
+import { apiRequest, apiDownload } from '@/lib/api';
+import { Wallet, ArrowLeft, Download, Send, Loader2, FileText } from 'lucide-react';
 import styles from './page.module.css';
 
 interface Account {
@@ -18,6 +18,14 @@ interface Account {
     Status: string;
 }
 
+interface Statement {
+    ID: string;
+    StatementDate: string;
+    PeriodStartDate: string;
+    PeriodEndDate: string;
+    ClosingBalance: number;
+}
+
 interface Transaction {
     ID: string;
     Amount: number;
@@ -32,9 +40,11 @@ export default function AccountDetailPage() {
     const params = useParams();
     const router = useRouter();
     const accountId = params.id as string;
+    const documentLang = params.locale === 'da' ? 'da' : 'en';
 
     const [account, setAccount] = useState<Account | null>(null);
     const [transactions, setTransactions] = useState<Transaction[]>([]);
+    const [statements, setStatements] = useState<Statement[]>([]);
     const [isLoading, setIsLoading] = useState(true);
     const [error, setError] = useState<string | null>(null);
 
@@ -60,6 +70,14 @@ export default function AccountDetailPage() {
                     '8084'
                 );
                 setTransactions(txData || []);
+
+                // Fetch statements
+                const statementData = await apiRequest<Statement[]>(
+                    `/accounts/${accountId}/statements`,
+                    { method: 'GET' },
+                    '8083'
+                );
+                setStatements(statementData || []);
             } catch (err: unknown) {
                 console.error('Failed to fetch account data', err);
                 setError('Failed to load account details');
@@ -86,6 +104,19 @@ export default function AccountDetailPage() {
         });
     };
 
+    const downloadStatement = async (statement: Statement, format: 'pdf' | 'csv') => {
+        try {
+            await apiDownload(
+                `/accounts/${accountId}/statements/${statement.ID}/document?format=${format}&lang=${documentLang}`,
+                `statement-${statement.StatementDate.slice(0, 7)}-${documentLang}.${format}`,
+                '8083'
+            );
+        } catch (err: unknown) {
+            console.error('Failed to download statement', err);
+            setError('Failed to download statement');
+        }
+    };
+
     if (isLoading) {
         return (
             <DashboardLayout>
@@ -177,6 +208,43 @@ export default function AccountDetailPage() {
                         </div>
                     )}
                 </div>
+
+                {/* Statements */}
+                <div className={styles.transactionsSection}>
+                    <h3 className={styles.sectionTitle}>Statements</h3>
+
+                    {statements.length > 0 ? (
+                        <div className={styles.transactionsList}>
+                            {statements.map((st) => (
+                                <div key={st.ID} className={styles.transaction}>
+                                    <FileText size={20} className={styles.statementIcon} />
+                                    <div className={styles.txInfo}>
+                                        <p className={styles.txDescription}>
+                                            {formatDate(st.PeriodStartDate)} – {formatDate(st.PeriodEndDate)}
+                                        </p>
+                                        <p className={styles.txDate}>
+                                            Closing balance {formatAmount(st.ClosingBalance, account.Currency)}
+                                        </p>
+                                    </div>
+                                    <div className={styles.statementActions}>
+                                        <button className="btn btn-secondary" onClick={() => downloadStatement(st, 'pdf')}>
+                                            <Download size={16} />
+                                            PDF
+                                        </button>
+                                        <button className="btn btn-secondary" onClick={() => downloadStatement(st, 'csv')}>
+                                            <Download size={16} />
+                                            CSV
+                                        </button>
+                                    </div>
+                                </div>
+                            ))}
+                        </div>
+                    ) : (
+                        <div className={styles.noTransactions}>
+                            <p>No statements yet</p>
+                        </div>
+                    )}
+                </div>
             </div>
         </DashboardLayout>
     );
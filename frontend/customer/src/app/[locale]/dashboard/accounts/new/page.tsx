@@ -1,6 +1,6 @@
 'use client';
 
-import { useState } from 'react';
+import { useEffect, useState } from 'react';
 import { useRouter, useParams } from 'next/navigation';
 
 import { useAuth } from '@/context/AuthContext';
@@ -12,10 +12,17 @@ import styles from './page.module.css';
 
 interface CreateRequestPayload {
     customer_id: string;
-    requested_type: string;
+    product_code: string;
     reason: string;
 }
 
+interface Product {
+    Code: string;
+    Name: string;
+    AccountType: string;
+    Currency: string;
+}
+
 export default function RequestAccountPage() {
 
     const { user } = useAuth();
@@ -23,15 +30,25 @@ export default function RequestAccountPage() {
     const params = useParams();
     const locale = params.locale as string;
 
-    const [accountType, setAccountType] = useState('savings');
+    const [products, setProducts] = useState<Product[]>([]);
+    const [productCode, setProductCode] = useState('');
     const [reason, setReason] = useState('');
     const [isSubmitting, setIsSubmitting] = useState(false);
     const [error, setError] = useState<string | null>(null);
     const [success, setSuccess] = useState(false);
 
+    useEffect(() => {
+        apiRequest<Product[]>('/products', {}, '8083')
+            .then((list) => {
+                setProducts(list);
+                if (list.length > 0) setProductCode(list[0].Code);
+            })
+            .catch((err: unknown) => setError((err as Error).message || 'Failed to load products'));
+    }, []);
+
     const handleSubmit = async (e: React.FormEvent) => {
         e.preventDefault();
-        if (!user) return;
+        if (!user || !productCode) return;
 
         setIsSubmitting(true);
         setError(null);
@@ -39,7 +56,7 @@ export default function RequestAccountPage() {
         try {
             const payload: CreateRequestPayload = {
                 customer_id: user.id,
-                requested_type: accountType,
+                product_code: productCode,
                 reason: reason
             };
 
@@ -92,18 +109,19 @@ export default function RequestAccountPage() {
                             )}
 
                             <div className={styles.formGroup}>
-                                <label className={styles.label}>Account Type</label>
+                                <label className={styles.label}>Account</label>
                                 <select
                                     className={styles.select}
-                                    value={accountType}
-                                    onChange={(e) => setAccountType(e.target.value)}
+                                    value={productCode}
+                                    onChange={(e) => setProductCode(e.target.value)}
                                 >
-                                    <option value="savings">Savings Account</option>
-                                    <option value="checking">Checking Account</option>
-                                    <option value="investment">Investment Account</option>
-                                    <option value="loan">Loan Account</option>
+                                    {products.map((product) => (
+                                        <option key={product.Code} value={product.Code}>
+                                            {product.Name} ({product.Currency})
+                                        </option>
+                                    ))}
                                 </select>
-                                <p className={styles.helperText}>Select the type of account you wish to open.</p>
+                                <p className={styles.helperText}>Select the account you wish to open.</p>
                             </div>
 
                             <div className={styles.formGroup}>
@@ -121,7 +139,7 @@ export default function RequestAccountPage() {
                                 <button
                                     type="submit"
                                     className={`btn btn-primary ${styles.submitBtn}`}
-                                    disabled={isSubmitting}
+                                    disabled={isSubmitting || !productCode}
                                 >
                                     {isSubmitting ? (
                                         <>
@@ -32,8 +32,23 @@ interface AccountResponse {
     ID: string;
 }
 
-interface TransactionListResponse {
-    total: number;
+interface FlowBucket {
+    start: string;
+    inflow: number;
+    outflow: number;
+    net: number;
+}
+
+interface AccountAnalyticsResponse {
+    currency: string;
+    count: number;
+    daily: FlowBucket[];
+}
+
+interface SpendingTrend {
+    data: number[];
+    net: number;
+    currency: string;
 }
 
 export default function RightSidebar({ user }: RightSidebarProps) {
@@ -44,6 +59,7 @@ export default function RightSidebar({ user }: RightSidebarProps) {
     });
 
     const [manager, setManager] = useState<ManagerProfile | null>(null);
+    const [trend, setTrend] = useState<SpendingTrend | null>(null);
 
     useEffect(() => {
         const fetchData = async () => {
@@ -117,23 +133,33 @@ export default function RightSidebar({ user }: RightSidebarProps) {
                 const accountCount = accounts ? accounts.length : 0;
                 let transactionCount = 0;
 
-                // 4. Fetch transactions for each account
                 if (accounts && accounts.length > 0) {
+                    // 4. Daily spending over the last 7 days, in the first account's currency
                     // NOTE: '8084' is transaction-service port
-                    const txPromises = accounts.map(acc =>
-                        apiRequest<TransactionListResponse>(
-                            `/transactions/account/${acc.ID}?page=1&limit=1`,
+                    const from = new Date();
+                    from.setDate(from.getDate() - 6);
+                    const analytics = await Promise.all(accounts.map(acc =>
+                        apiRequest<AccountAnalyticsResponse>(
+                            `/transactions/account/${acc.ID}/analytics?from=${from.toISOString().slice(0, 10)}&counterparties=1`,
                             { method: 'GET' },
                             '8084'
-                        )
-                    );
-
-                    const txResults = await Promise.all(txPromises);
-                    txResults.forEach(res => {
-                        if (res && res.total) {
-                            transactionCount += res.total;
-                        }
+                        ).catch(() => null)
+                    ));
+                    analytics.forEach(a => {
+                        if (a) transactionCount += a.count;
                     });
+                    const first = analytics.find(a => a !== null);
+                    if (first) {
+                        const data = first.daily.map(() => 0);
+                        let net = 0;
+                        analytics
+                            .filter((a): a is AccountAnalyticsResponse => a !== null && a.currency === first.currency)
+                            .forEach(a => a.daily.forEach((bucket, i) => {
+                                if (i < data.length) data[i] += bucket.outflow;
+                                net += bucket.net;
+                            }));
+                        setTrend({ data, net, currency: first.currency });
+                    }
                 }
 
                 setStats({
@@ -153,9 +179,14 @@ export default function RightSidebar({ user }: RightSidebarProps) {
 
     if (!user) return null;
 
-    // Mock data - will be replaced with real API calls
     const greeting = getGreeting();
-    const balanceData = [20, 35, 30, 45, 40, 55, 60]; // Last 7 days mock data
+    const formatNet = (amount: number, currency: string) => {
+        const formatted = new Intl.NumberFormat(undefined, {
+            style: 'currency',
+            currency,
+        }).format(amount / 100);
+        return amount > 0 ? `+${formatted}` : formatted;
+    };
 
     // Logic to determine display name
     // If manager contains _customerFirstName (which we don't have in type, but logic was checking for it), using it.
@@ -187,10 +218,10 @@ export default function RightSidebar({ user }: RightSidebarProps) {
 
             {/* Statistics Card */}
             <StatisticsCard
-                title="Balance Trend"
-                data={balanceData}
-                metric="+12.5%"
-                metricLabel="vs last week"
+                title="Spending, Last 7 Days"
+                data={trend ? trend.data : [0, 0, 0, 0, 0, 0, 0]}
+                metric={trend ? formatNet(trend.net, trend.currency) : '-'}
+                metricLabel="net this week"
             />
 
             {/* Quick Stats Grid */}
@@ -207,7 +238,7 @@ export default function RightSidebar({ user }: RightSidebarProps) {
                     <div className={styles.quickStatValue}>
                         {stats.isLoading ? '-' : stats.transactionCount}
                     </div>
-                    <div className={styles.quickStatLabel}>Transactions</div>
+                    <div className={styles.quickStatLabel}>Transactions this week</div>
                 </div>
             </div>
 
@@ -11,7 +11,7 @@ interface StatisticsCardProps {
 }
 
 export default function StatisticsCard({ title, data, metric, metricLabel }: StatisticsCardProps) {
-    const maxValue = Math.max(...data);
+    const maxValue = Math.max(...data, 1);
     const normalizedData = data.map(val => (val / maxValue) * 100);
 
     return (
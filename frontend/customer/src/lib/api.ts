@@ -31,3 +31,29 @@ export async function apiRequest<T>(
 
     return response.json();
 }
+
+export async function apiDownload(
+    endpoint: string,
+    filename: string,
+    port: ServicePort = '8081'
+): Promise<void> {
+    const token = typeof window !== 'undefined' ? localStorage.getItem('auth_token') : null;
+
+    const headers = new Headers();
+    if (token) {
+        headers.set('Authorization', `Bearer ${token}`);
+    }
+
+    const response = await fetch(`${BASE_URL}:${port}/api/v1${endpoint}`, { headers });
+    if (!response.ok) {
+        const error = await response.json().catch(() => ({ error: 'An unknown error occurred' }));
+        throw new Error(error.error || `HTTP error! status: ${response.status}`);
+    }
+
+    const url = URL.createObjectURL(await response.blob());
+    const link = document.createElement('a');
+    link.href = url;
+    link.download = filename;
+    link.click();
+    URL.revokeObjectURL(url);
+}
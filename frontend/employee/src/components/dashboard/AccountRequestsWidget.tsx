@@ -13,6 +13,13 @@ interface AccountRequest {
     Status: string;
     Reason: string;
     CreatedAt: string;
+    age_hours: number;
+    sla_state: 'on_track' | 'at_risk' | 'breached' | 'met';
+}
+
+interface RequestPage {
+    requests: AccountRequest[];
+    next_cursor: string;
 }
 
 export default function AccountRequestsWidget() {
@@ -25,12 +32,12 @@ export default function AccountRequestsWidget() {
         if (!user) return;
         setIsLoading(true);
         try {
-            const data = await apiRequest<AccountRequest[]>(
+            const data = await apiRequest<RequestPage>(
                 '/requests/?status=pending',
                 { headers: { 'Authorization': `Bearer ${localStorage.getItem('auth_token')}` } },
                 '8083'
             );
-            setRequests(data || []);
+            setRequests(data?.requests || []);
         } catch (error) {
             console.error('Failed to fetch requests', error);
         } finally {
@@ -44,6 +51,12 @@ export default function AccountRequestsWidget() {
 
     const handleAction = async (id: string, status: 'approved' | 'rejected') => {
         if (!user) return;
+
+        let rejectionReason = '';
+        if (status === 'rejected') {
+            rejectionReason = window.prompt('Reason for rejecting (shown to the customer)')?.trim() || '';
+            if (!rejectionReason) return;
+        }
         setProcessingId(id);
 
         try {
@@ -54,7 +67,7 @@ export default function AccountRequestsWidget() {
                     headers: { 'Authorization': `Bearer ${localStorage.getItem('auth_token')}` },
                     body: JSON.stringify({
                         status: status,
-                        processed_by: user.id
+                        rejection_reason: rejectionReason
                     })
                 },
                 '8083'
@@ -95,8 +108,8 @@ export default function AccountRequestsWidget() {
                                 <div className={styles.itemInfo}>
                                     <div className={styles.itemHeader}>
                                         <span className={styles.type}>{req.RequestedType} Account</span>
-                                        <span className={styles.date}>
-                                            {new Date(req.CreatedAt).toLocaleDateString()}
+                                        <span className={styles.date} title={`SLA: ${req.sla_state.replace('_', ' ')}`}>
+                                            {new Date(req.CreatedAt).toLocaleDateString()} · {Math.floor(req.age_hours)}h
                                         </span>
                                     </div>
                                     <p className={styles.reason}>
@@ -2,11 +2,16 @@ package adapter
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
 
 	"nordic-bank/internal/account/domain"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresAccountRepository struct {
@@ -17,6 +22,12 @@ func NewPostgresAccountRepository(db *gorm.DB) *PostgresAccountRepository {
 	return &PostgresAccountRepository{db: db}
 }
 
+func (r *PostgresAccountRepository) WithTx(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PostgresAccountRepository{db: tx})
+	})
+}
+
 func (r *PostgresAccountRepository) Create(ctx context.Context, account *domain.Account) error {
 	return r.db.WithContext(ctx).Create(account).Error
 }
@@ -29,9 +40,21 @@ func (r *PostgresAccountRepository) GetByID(ctx context.Context, id uuid.UUID) (
 	return &account, nil
 }
 
+func (r *PostgresAccountRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	var account domain.Account
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&account, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
 func (r *PostgresAccountRepository) GetByAccountNumber(ctx context.Context, number string) (*domain.Account, error) {
 	var account domain.Account
-	if err := r.db.WithContext(ctx).First(&account, "account_number = ?", number).Error; err != nil {
+	err := r.db.WithContext(ctx).First(&account, "account_number = ?", number).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAccountNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &account, nil
@@ -39,7 +62,30 @@ func (r *PostgresAccountRepository) GetByAccountNumber(ctx context.Context, numb
 
 func (r *PostgresAccountRepository) ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*domain.Account, error) {
 	var accounts []*domain.Account
-	if err := r.db.WithContext(ctx).Find(&accounts, "customer_id = ?", customerID).Error; err != nil {
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ?", customerID).
+		Or("id IN (?)", r.db.Model(&domain.AccountHolder{}).
+			Select("account_id").
+			Where("customer_id = ? AND removed_at IS NULL", customerID)).
+		Order("opened_at").
+		Find(&accounts).Error
+	if err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *PostgresAccountRepository) ListByType(ctx context.Context, accountType domain.AccountType) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	if err := r.db.WithContext(ctx).Find(&accounts, "account_type = ?", accountType).Error; err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+func (r *PostgresAccountRepository) List(ctx context.Context) ([]*domain.Account, error) {
+	var accounts []*domain.Account
+	if err := r.db.WithContext(ctx).Order("created_at").Find(&accounts).Error; err != nil {
 		return nil, err
 	}
 	return accounts, nil
@@ -49,29 +95,599 @@ func (r *PostgresAccountRepository) Update(ctx context.Context, account *domain.
 	return r.db.WithContext(ctx).Save(account).Error
 }
 
+func (r *PostgresAccountRepository) ListInactiveAccounts(ctx context.Context, before time.Time) ([]*domain.InactiveAccount, error) {
+	// Every account gets a ledger entry when it is opened, but fall back to
+	// the opening date for accounts that predate that.
+	var rows []struct {
+		ID           uuid.UUID
+		LastActivity time.Time
+	}
+	err := r.db.WithContext(ctx).Table("account.accounts AS a").
+		Select("a.id, COALESCE(MAX(l.entry_date), a.opened_at) AS last_activity").
+		Joins("LEFT JOIN account.account_ledger AS l ON l.account_id = a.id").
+		Where("a.status = ?", domain.AccountStatusActive).
+		Group("a.id").
+		Having("COALESCE(MAX(l.entry_date), a.opened_at) < ?", before).
+		Scan(&rows).Error
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	var accounts []*domain.Account
+	if err := r.db.WithContext(ctx).Where("id IN ?", ids).Find(&accounts).Error; err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*domain.Account, len(accounts))
+	for _, account := range accounts {
+		byID[account.ID] = account
+	}
+
+	inactive := make([]*domain.InactiveAccount, 0, len(rows))
+	for _, row := range rows {
+		if account, ok := byID[row.ID]; ok {
+			inactive = append(inactive, &domain.InactiveAccount{Account: account, LastActivity: row.LastActivity})
+		}
+	}
+	return inactive, nil
+}
+
+func (r *PostgresAccountRepository) CreateSubBalance(ctx context.Context, balance *domain.SubBalance) error {
+	return r.db.WithContext(ctx).Create(balance).Error
+}
+
+func (r *PostgresAccountRepository) GetSubBalanceForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*domain.SubBalance, error) {
+	var balance domain.SubBalance
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).
+		First(&balance, "account_id = ? AND currency = ?", accountID, currency).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCurrencyNotHeld
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
+
+func (r *PostgresAccountRepository) UpdateSubBalance(ctx context.Context, balance *domain.SubBalance) error {
+	return r.db.WithContext(ctx).Save(balance).Error
+}
+
+func (r *PostgresAccountRepository) ListSubBalances(ctx context.Context, accountID uuid.UUID) ([]domain.SubBalance, error) {
+	var balances []domain.SubBalance
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("currency").Find(&balances).Error
+	return balances, err
+}
+
+func (r *PostgresAccountRepository) CreatePot(ctx context.Context, pot *domain.Pot) error {
+	return r.db.WithContext(ctx).Create(pot).Error
+}
+
+func (r *PostgresAccountRepository) GetPotForUpdate(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	var pot domain.Pot
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&pot, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrPotNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pot, nil
+}
+
+func (r *PostgresAccountRepository) UpdatePot(ctx context.Context, pot *domain.Pot) error {
+	return r.db.WithContext(ctx).Save(pot).Error
+}
+
+func (r *PostgresAccountRepository) ListPots(ctx context.Context, accountID uuid.UUID) ([]*domain.Pot, error) {
+	var pots []*domain.Pot
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("created_at").Find(&pots).Error
+	return pots, err
+}
+
+func (r *PostgresAccountRepository) CreatePotEntry(ctx context.Context, entry *domain.PotEntry) error {
+	return r.db.WithContext(ctx).Create(entry).Error
+}
+
+func (r *PostgresAccountRepository) CreateProduct(ctx context.Context, product *domain.Product) error {
+	return r.db.WithContext(ctx).Create(product).Error
+}
+
+func (r *PostgresAccountRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	var product domain.Product
+	err := r.db.WithContext(ctx).First(&product, "code = ?", code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrProductNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &product, nil
+}
+
+func (r *PostgresAccountRepository) ListProducts(ctx context.Context, includeRetired bool) ([]*domain.Product, error) {
+	var products []*domain.Product
+	q := r.db.WithContext(ctx).Order("code")
+	if !includeRetired {
+		q = q.Where("active")
+	}
+	err := q.Find(&products).Error
+	return products, err
+}
+
+func (r *PostgresAccountRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	return r.db.WithContext(ctx).Save(product).Error
+}
+
+// GetCustomerProfile reads customer.customers, which belongs to the customer
+// service.
+func (r *PostgresAccountRepository) GetCustomerProfile(ctx context.Context, customerID uuid.UUID) (*domain.CustomerProfile, error) {
+	var profiles []*domain.CustomerProfile
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT id, date_of_birth, kyc_status::text AS kyc_status
+		FROM customer.customers
+		WHERE id = ?`, customerID).Scan(&profiles).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(profiles) == 0 {
+		return nil, domain.ErrCustomerNotFound
+	}
+	return profiles[0], nil
+}
+
+func (r *PostgresAccountRepository) CreateStatusChange(ctx context.Context, change *domain.StatusChange) error {
+	return r.db.WithContext(ctx).Create(change).Error
+}
+
+func (r *PostgresAccountRepository) ListStatusChanges(ctx context.Context, accountID uuid.UUID) ([]*domain.StatusChange, error) {
+	var changes []*domain.StatusChange
+	err := r.db.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("changed_at ASC").
+		Find(&changes).Error
+	return changes, err
+}
+
+func (r *PostgresAccountRepository) SaveHolder(ctx context.Context, holder *domain.AccountHolder) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "account_id"}, {Name: "customer_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{
+			"relationship_type", "permission_level",
+			"can_withdraw", "can_transfer", "can_view_balance", "can_view_transactions",
+			"added_at", "added_by", "removed_at",
+		}),
+	}).Create(holder).Error
+}
+
+func (r *PostgresAccountRepository) GetHolder(ctx context.Context, accountID, customerID uuid.UUID) (*domain.AccountHolder, error) {
+	var holder domain.AccountHolder
+	err := r.db.WithContext(ctx).
+		First(&holder, "account_id = ? AND customer_id = ? AND removed_at IS NULL", accountID, customerID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrHolderNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &holder, nil
+}
+
+func (r *PostgresAccountRepository) ListHolders(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountHolder, error) {
+	var holders []*domain.AccountHolder
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND removed_at IS NULL", accountID).
+		Order("added_at").
+		Find(&holders).Error
+	return holders, err
+}
+
+func (r *PostgresAccountRepository) ListHoldingsByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*domain.AccountHolder, error) {
+	var holders []*domain.AccountHolder
+	err := r.db.WithContext(ctx).
+		Where("customer_id = ? AND removed_at IS NULL", customerID).
+		Find(&holders).Error
+	return holders, err
+}
+
+func (r *PostgresAccountRepository) RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID, at time.Time) error {
+	res := r.db.WithContext(ctx).Model(&domain.AccountHolder{}).
+		Where("account_id = ? AND customer_id = ? AND removed_at IS NULL", accountID, customerID).
+		Update("removed_at", at)
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return domain.ErrHolderNotFound
+	}
+	return nil
+}
+
+func (r *PostgresAccountRepository) CreateCard(ctx context.Context, card *domain.Card) error {
+	return r.db.WithContext(ctx).Create(card).Error
+}
+
+func (r *PostgresAccountRepository) GetCardByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	var card domain.Card
+	err := r.db.WithContext(ctx).First(&card, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *PostgresAccountRepository) GetCardByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	var card domain.Card
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&card, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *PostgresAccountRepository) GetCardByToken(ctx context.Context, token string) (*domain.Card, error) {
+	var card domain.Card
+	err := r.db.WithContext(ctx).First(&card, "card_number_token = ?", token).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCardNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+func (r *PostgresAccountRepository) ListCardsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Card, error) {
+	var cards []*domain.Card
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("issued_at DESC").Find(&cards).Error
+	return cards, err
+}
+
+func (r *PostgresAccountRepository) UpdateCard(ctx context.Context, card *domain.Card) error {
+	return r.db.WithContext(ctx).Save(card).Error
+}
+
+func (r *PostgresAccountRepository) CountScheduledTransactions(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	// The table belongs to the transaction schema and only exists where the
+	// SQL migrations have been applied.
+	var exists bool
+	if err := r.db.WithContext(ctx).Raw("SELECT to_regclass('transaction.scheduled_transactions') IS NOT NULL").Scan(&exists).Error; err != nil || !exists {
+		return 0, err
+	}
+
+	var count int64
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT COUNT(*) FROM transaction.scheduled_transactions
+		WHERE is_active AND (from_account_id = ? OR to_account_id = ?)`,
+		accountID, accountID).
+		Scan(&count).Error
+	return count, err
+}
+
+// CreateLedgerEntry posts the entry on today's date and, unless it was
+// value-dated, values it today too.
 func (r *PostgresAccountRepository) CreateLedgerEntry(ctx context.Context, entry *domain.LedgerEntry) error {
+	if entry.PostedDate.IsZero() {
+		entry.PostedDate = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if entry.ValueDate.IsZero() {
+		entry.ValueDate = entry.PostedDate
+	}
 	return r.db.WithContext(ctx).Create(entry).Error
 }
 
+func (r *PostgresAccountRepository) HasLedgerLeg(ctx context.Context, transactionID uuid.UUID, leg string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.LedgerEntry{}).
+		Where("transaction_id = ? AND leg = ?", transactionID, leg).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostgresAccountRepository) GetBalanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	// Entries booked in one database transaction share their entry_date, so
+	// the last one is not well defined; their sum is
+	var balance int64
+	err := r.db.WithContext(ctx).Model(&domain.LedgerEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_id = ? AND entry_date < ?", accountID, at).
+		Where("currency = (?)", ownCurrency(r.db, accountID)).
+		Scan(&balance).Error
+	return balance, err
+}
+
+func (r *PostgresAccountRepository) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, currency string, at time.Time, basis domain.BalanceBasis) (int64, error) {
+	query := r.db.WithContext(ctx).Model(&domain.LedgerEntry{}).
+		Select("COALESCE(SUM(amount), 0)").
+		Where("account_id = ? AND currency = ?", accountID, currency)
+	if basis == domain.BalanceBasisValue {
+		query = query.Where("value_date <= ?", at.UTC().Format("2006-01-02"))
+	} else {
+		query = query.Where("entry_date <= ?", at)
+	}
+
+	var balance int64
+	err := query.Scan(&balance).Error
+	return balance, err
+}
+
+func (r *PostgresAccountRepository) ListLedgerEntries(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.LedgerEntry, error) {
+	var entries []*domain.LedgerEntry
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND entry_date >= ? AND entry_date < ?", accountID, from, to).
+		Where("currency = (?)", ownCurrency(r.db, accountID)).
+		Order("entry_date").
+		Find(&entries).Error
+	return entries, err
+}
+
+// QueryLedger sums the running balance over the whole ledger before applying
+// the filter, so it stays correct however the entries are narrowed down. Only
+// the upper bounds can be pushed into the window, since a running balance
+// depends on earlier entries alone.
+func (r *PostgresAccountRepository) QueryLedger(ctx context.Context, accountID uuid.UUID, filter domain.LedgerFilter) ([]*domain.LedgerLine, error) {
+	inner := r.db.Model(&domain.LedgerEntry{}).
+		Select("*, SUM(amount) OVER (PARTITION BY currency ORDER BY entry_date, id) AS running_balance").
+		Where("account_id = ?", accountID)
+	if filter.To != nil {
+		inner = inner.Where("entry_date < ?", *filter.To)
+	}
+	if filter.Before != nil {
+		inner = inner.Where("(entry_date, id) < (?, ?)", filter.Before.At, filter.Before.ID)
+	}
+	if filter.Currency != "" {
+		inner = inner.Where("currency = ?", filter.Currency)
+	}
+
+	query := r.db.WithContext(ctx).Table("(?) AS l", inner)
+	if filter.From != nil {
+		query = query.Where("entry_date >= ?", *filter.From)
+	}
+	if filter.EntryType != nil {
+		query = query.Where("entry_type = ?", *filter.EntryType)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("ABS(amount) >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("ABS(amount) <= ?", *filter.MaxAmount)
+	}
+	if filter.Reference != "" {
+		query = query.Where("reference ILIKE ?", likePrefix(filter.Reference))
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var lines []*domain.LedgerLine
+	err := query.Order("entry_date DESC, id DESC").Scan(&lines).Error
+	return lines, err
+}
+
+// likePrefix escapes s for use as a LIKE prefix pattern.
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
+
+// ownCurrency is a subquery for the account's own currency, which keeps
+// sub-balance entries out of statements.
+func ownCurrency(db *gorm.DB, accountID uuid.UUID) *gorm.DB {
+	return db.Model(&domain.Account{}).Select("currency").Where("id = ?", accountID)
+}
+
+// ListTransactionDetails reads transaction.transactions, which belongs to the
+// transaction service, joining the other leg's account for the counterparty.
+func (r *PostgresAccountRepository) ListTransactionDetails(ctx context.Context, accountID uuid.UUID, ids []uuid.UUID) ([]*domain.TransactionDetail, error) {
+	var details []*domain.TransactionDetail
+	if len(ids) == 0 {
+		return details, nil
+	}
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT t.id, t.type, t.reference, t.description,
+			COALESCE(c.account_number, '') AS counterparty_account_number,
+			COALESCE(c.account_name, '') AS counterparty_name
+		FROM transaction.transactions t
+		LEFT JOIN account.accounts c ON c.id = CASE
+			WHEN t.source_account_id = @account THEN t.destination_account_id
+			ELSE t.source_account_id
+		END
+		WHERE t.id IN @ids`,
+		sql.Named("account", accountID), sql.Named("ids", ids)).
+		Scan(&details).Error
+	return details, err
+}
+
+func (r *PostgresAccountRepository) GetInterestTiers(ctx context.Context, accountID uuid.UUID) ([]domain.InterestTier, error) {
+	var tiers []domain.InterestTier
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("from_balance").Find(&tiers).Error
+	return tiers, err
+}
+
+func (r *PostgresAccountRepository) ReplaceInterestTiers(ctx context.Context, accountID uuid.UUID, tiers []domain.InterestTier) error {
+	if err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Delete(&domain.InterestTier{}).Error; err != nil {
+		return err
+	}
+	if len(tiers) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Create(&tiers).Error
+}
+
+func (r *PostgresAccountRepository) HasInterestAccrual(ctx context.Context, accountID uuid.UUID, day time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.InterestAccrual{}).
+		Where("account_id = ? AND accrual_date = ?", accountID, day).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostgresAccountRepository) CreateInterestAccrual(ctx context.Context, accrual *domain.InterestAccrual) error {
+	return r.db.WithContext(ctx).Create(accrual).Error
+}
+
+func (r *PostgresAccountRepository) ListUncapitalisedAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.InterestAccrual, error) {
+	var accruals []*domain.InterestAccrual
+	err := r.db.WithContext(ctx).
+		Where("account_id = ? AND accrual_date >= ? AND accrual_date < ? AND ledger_entry_id IS NULL", accountID, from, to).
+		Order("accrual_date").
+		Find(&accruals).Error
+	return accruals, err
+}
+
+func (r *PostgresAccountRepository) UpdateInterestAccrual(ctx context.Context, accrual *domain.InterestAccrual) error {
+	return r.db.WithContext(ctx).Save(accrual).Error
+}
+
+func (r *PostgresAccountRepository) MarkAccrualsCapitalised(ctx context.Context, ids []uuid.UUID, ledgerEntryID uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	return r.db.WithContext(ctx).Model(&domain.InterestAccrual{}).
+		Where("id IN ?", ids).
+		Update("ledger_entry_id", ledgerEntryID).Error
+}
+
+func (r *PostgresAccountRepository) CreateReservation(ctx context.Context, res *domain.FundReservation) error {
+	return r.db.WithContext(ctx).Create(res).Error
+}
+
+func (r *PostgresAccountRepository) GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.FundReservation, error) {
+	var res domain.FundReservation
+	if err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&res, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *PostgresAccountRepository) GetReservationByLeg(ctx context.Context, transactionID uuid.UUID, leg string) (*domain.FundReservation, error) {
+	var res domain.FundReservation
+	err := r.db.WithContext(ctx).First(&res, "transaction_id = ? AND leg = ?", transactionID, leg).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrReservationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &res, nil
+}
+
+func (r *PostgresAccountRepository) UpdateReservation(ctx context.Context, res *domain.FundReservation) error {
+	return r.db.WithContext(ctx).Save(res).Error
+}
+
+func (r *PostgresAccountRepository) ListExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*domain.FundReservation, error) {
+	var reservations []*domain.FundReservation
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND expires_at < ?", domain.ReservationStatusActive, now).
+		Order("expires_at").
+		Limit(limit).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *PostgresAccountRepository) CreateStatement(ctx context.Context, statement *domain.Statement) error {
+	return r.db.WithContext(ctx).Create(statement).Error
+}
+
+func (r *PostgresAccountRepository) GetStatementByID(ctx context.Context, id uuid.UUID) (*domain.Statement, error) {
+	var statement domain.Statement
+	if err := r.db.WithContext(ctx).First(&statement, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+func (r *PostgresAccountRepository) HasStatement(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.Statement{}).
+		Where("account_id = ? AND statement_date = ?", accountID, statementDate).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostgresAccountRepository) GetLatestStatement(ctx context.Context, accountID uuid.UUID) (*domain.Statement, error) {
+	var statement domain.Statement
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("period_end_date DESC").First(&statement).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrStatementNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &statement, nil
+}
+
+func (r *PostgresAccountRepository) ListStatements(ctx context.Context, accountID uuid.UUID) ([]*domain.Statement, error) {
+	var statements []*domain.Statement
+	err := r.db.WithContext(ctx).Where("account_id = ?", accountID).Order("statement_date DESC").Find(&statements).Error
+	return statements, err
+}
+
+func (r *PostgresAccountRepository) ListStatementsWithoutDocument(ctx context.Context, limit int) ([]*domain.Statement, error) {
+	var statements []*domain.Statement
+	err := r.db.WithContext(ctx).
+		Where("document_url IS NULL OR document_url = ''").
+		Order("statement_date").
+		Limit(limit).
+		Find(&statements).Error
+	return statements, err
+}
+
+// SetStatementDocument is the only write allowed on a finalised statement; it
+// touches nothing but the document columns.
+func (r *PostgresAccountRepository) SetStatementDocument(ctx context.Context, id uuid.UUID, url string, generatedAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&domain.Statement{}).
+		Where("id = ?", id).
+		UpdateColumns(map[string]interface{}{
+			"document_url":          url,
+			"document_generated_at": generatedAt,
+		}).Error
+}
+
 func (r *PostgresAccountRepository) CreateRequest(ctx context.Context, req *domain.AccountRequest) error {
 	return r.db.WithContext(ctx).Create(req).Error
 }
 
-func (r *PostgresAccountRepository) GetRequestByID(ctx context.Context, id uuid.UUID) (*domain.AccountRequest, error) {
+func (r *PostgresAccountRepository) GetRequestByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.AccountRequest, error) {
 	var req domain.AccountRequest
-	if err := r.db.WithContext(ctx).First(&req, "id = ?", id).Error; err != nil {
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&req, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrRequestNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &req, nil
 }
 
-func (r *PostgresAccountRepository) ListRequests(ctx context.Context, status *domain.RequestStatus) ([]*domain.AccountRequest, error) {
+func (r *PostgresAccountRepository) ListRequests(ctx context.Context, filter domain.RequestFilter) ([]*domain.AccountRequest, error) {
 	var requests []*domain.AccountRequest
 	query := r.db.WithContext(ctx)
-	if status != nil {
-		query = query.Where("status = ?", *status)
+	if filter.Status != nil {
+		query = query.Where("status = ?", *filter.Status)
 	}
-	if err := query.Order("created_at desc").Find(&requests).Error; err != nil {
+	if filter.CustomerID != nil {
+		query = query.Where("customer_id = ?", *filter.CustomerID)
+	}
+	if filter.RequestedType != nil {
+		query = query.Where("requested_type = ?", *filter.RequestedType)
+	}
+	if filter.After != nil {
+		query = query.Where("(created_at, id) > (?, ?)", filter.After.At, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+	if err := query.Order("created_at, id").Find(&requests).Error; err != nil {
 		return nil, err
 	}
 	return requests, nil
@@ -80,3 +696,11 @@ func (r *PostgresAccountRepository) ListRequests(ctx context.Context, status *do
 func (r *PostgresAccountRepository) UpdateRequest(ctx context.Context, req *domain.AccountRequest) error {
 	return r.db.WithContext(ctx).Save(req).Error
 }
+
+func (r *PostgresAccountRepository) HasPendingRequest(ctx context.Context, accountID uuid.UUID, kind domain.RequestKind) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.AccountRequest{}).
+		Where("account_id = ? AND kind = ? AND status = ?", accountID, kind, domain.RequestStatusPending).
+		Count(&count).Error
+	return count > 0, err
+}
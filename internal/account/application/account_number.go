@@ -0,0 +1,57 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/iban"
+)
+
+// registrationNumber is Nordic Bank's Danish bank registration number
+// (registreringsnummer), the first four digits of every account number.
+const registrationNumber = "9870"
+
+// accountNumberAttempts bounds how often newAccountNumber draws again after
+// hitting an account number that is already taken.
+const accountNumberAttempts = 10
+
+var tenDigits = big.NewInt(10_000_000_000)
+
+// newAccountNumber draws a random ten-digit account number under our
+// registration number and returns it as a Danish IBAN, retrying on the rare
+// collision with an existing account.
+func (s *AccountService) newAccountNumber(ctx context.Context) (string, error) {
+	for i := 0; i < accountNumberAttempts; i++ {
+		n, err := rand.Int(rand.Reader, tenDigits)
+		if err != nil {
+			return "", err
+		}
+
+		number, err := iban.New("DK", fmt.Sprintf("%s%010d", registrationNumber, n))
+		if err != nil {
+			return "", err
+		}
+
+		_, err = s.repo.GetByAccountNumber(ctx, number)
+		if errors.Is(err, domain.ErrAccountNotFound) {
+			return number, nil
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+	return "", fmt.Errorf("no free account number after %d attempts", accountNumberAttempts)
+}
+
+// GetAccountByNumber looks up an account by its IBAN, given in electronic or
+// paper format.
+func (s *AccountService) GetAccountByNumber(ctx context.Context, number string) (*domain.Account, error) {
+	if err := iban.Validate(number); err != nil {
+		return nil, fmt.Errorf("%w: %v", domain.ErrInvalidAccountNumber, err)
+	}
+	return s.repo.GetByAccountNumber(ctx, iban.Normalize(number))
+}
@@ -0,0 +1,55 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/iban"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) GetByAccountNumber(ctx context.Context, number string) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, acc := range r.accounts {
+		if acc.AccountNumber == number {
+			acc := acc
+			return &acc, nil
+		}
+	}
+	return nil, domain.ErrAccountNotFound
+}
+
+func TestNewAccountNumber_IsValidDanishIBAN(t *testing.T) {
+	service := NewAccountService(newMemoryRepository())
+
+	number, err := service.newAccountNumber(context.Background())
+	require.NoError(t, err)
+
+	assert.NoError(t, iban.Validate(number))
+	assert.True(t, strings.HasPrefix(number, "DK"))
+	assert.Equal(t, registrationNumber, number[4:8])
+}
+
+func TestGetAccountByNumber_AcceptsPaperFormat(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+
+	number, err := service.newAccountNumber(context.Background())
+	require.NoError(t, err)
+	id := seedAccount(t, repo, 0)
+	acc := repo.accounts[id]
+	acc.AccountNumber = number
+	repo.accounts[id] = acc
+
+	got, err := service.GetAccountByNumber(context.Background(), strings.ToLower(iban.Format(number)))
+	require.NoError(t, err)
+	assert.Equal(t, id, got.ID)
+
+	_, err = service.GetAccountByNumber(context.Background(), "DK00"+number[4:])
+	assert.ErrorIs(t, err, domain.ErrInvalidAccountNumber)
+}
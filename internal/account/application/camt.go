@@ -0,0 +1,111 @@
+package application
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"nordic-bank/internal/account/camt"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// bankBIC identifies Nordic Bank as the account servicer in ISO 20022
+// messages.
+const bankBIC = "NBNKDKKK"
+
+// ExportCamt053 writes an ISO 20022 camt.053 statement covering the booked
+// ledger entries from periodStart to periodEnd (both inclusive dates). Like a
+// regular statement, it can only be produced once the period is over.
+func (s *AccountService) ExportCamt053(ctx context.Context, w io.Writer, accountID uuid.UUID, periodStart, periodEnd time.Time) error {
+	periodStart, periodEnd = startOfDay(periodStart), startOfDay(periodEnd)
+	if periodEnd.Before(periodStart) {
+		return domain.ErrInvalidPeriod
+	}
+	if !periodEnd.Before(startOfDay(time.Now())) {
+		return domain.ErrPeriodNotEnded
+	}
+
+	report, err := s.camtReport(ctx, accountID, periodStart, periodEnd.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	return camt.WriteStatement(w, report)
+}
+
+// ExportCamt052 writes an ISO 20022 camt.052 intraday report of today's
+// booked entries up to now.
+func (s *AccountService) ExportCamt052(ctx context.Context, w io.Writer, accountID uuid.UUID) error {
+	now := time.Now()
+	report, err := s.camtReport(ctx, accountID, startOfDay(now), now)
+	if err != nil {
+		return err
+	}
+	return camt.WriteIntradayReport(w, report)
+}
+
+// camtReport collects the ledger entries in [from, to) together with the
+// transactions behind them.
+func (s *AccountService) camtReport(ctx context.Context, accountID uuid.UUID, from, to time.Time) (camt.Report, error) {
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return camt.Report{}, err
+	}
+	opening, err := s.repo.GetBalanceAt(ctx, accountID, from)
+	if err != nil {
+		return camt.Report{}, err
+	}
+	closing, err := s.repo.GetBalanceAt(ctx, accountID, to)
+	if err != nil {
+		return camt.Report{}, err
+	}
+	entries, err := s.repo.ListLedgerEntries(ctx, accountID, from, to)
+	if err != nil {
+		return camt.Report{}, err
+	}
+
+	var ids []uuid.UUID
+	for _, entry := range entries {
+		if id, ok := ledgerTransactionID(entry); ok {
+			ids = append(ids, id)
+		}
+	}
+	details, err := s.repo.ListTransactionDetails(ctx, accountID, ids)
+	if err != nil {
+		return camt.Report{}, err
+	}
+	byID := make(map[uuid.UUID]*domain.TransactionDetail, len(details))
+	for _, d := range details {
+		byID[d.ID] = d
+	}
+
+	report := camt.Report{
+		CreatedAt:      time.Now(),
+		ServicerBIC:    bankBIC,
+		Account:        account,
+		From:           from,
+		To:             to,
+		OpeningBalance: opening,
+		ClosingBalance: closing,
+	}
+	for _, entry := range entries {
+		e := camt.Entry{Ledger: entry}
+		if id, ok := ledgerTransactionID(entry); ok {
+			e.Transaction = byID[id]
+		}
+		report.Entries = append(report.Entries, e)
+	}
+
+	return report, nil
+}
+
+// ledgerTransactionID returns the transaction a ledger entry belongs to.
+// Captured holds record it directly; transfer legs carry it as the reference.
+func ledgerTransactionID(entry *domain.LedgerEntry) (uuid.UUID, bool) {
+	if entry.TransactionID != nil {
+		return *entry.TransactionID, true
+	}
+	id, err := uuid.Parse(entry.Reference)
+	return id, err == nil
+}
@@ -0,0 +1,63 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListTransactionDetails(ctx context.Context, accountID uuid.UUID, ids []uuid.UUID) ([]*domain.TransactionDetail, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var details []*domain.TransactionDetail
+	for _, id := range ids {
+		for i := range r.details {
+			if r.details[i].ID == id {
+				d := r.details[i]
+				details = append(details, &d)
+			}
+		}
+	}
+	return details, nil
+}
+
+func TestExportCamt053_LinksTransactions(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+
+	txID := uuid.New()
+	repo.details = append(repo.details, domain.TransactionDetail{
+		ID:                        txID,
+		Type:                      "transfer",
+		Reference:                 "RENT-MARCH",
+		CounterpartyAccountNumber: "DK8930000000012345",
+		CounterpartyName:          "Udlejer ApS",
+	})
+	appendLedger(repo, id, time.Date(2024, time.March, 2, 9, 0, 0, 0, time.UTC), 50_000, "DEPOSIT")
+	appendLedger(repo, id, time.Date(2024, time.March, 5, 9, 0, 0, 0, time.UTC), -12_500, txID.String())
+
+	var buf bytes.Buffer
+	err := service.ExportCamt053(ctx, &buf, id,
+		time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	doc := buf.String()
+	assert.Contains(t, doc, "<EndToEndId>RENT-MARCH</EndToEndId>")
+	assert.Contains(t, doc, "<Nm>Udlejer ApS</Nm>")
+	assert.Contains(t, doc, "<IBAN>DK8930000000012345</IBAN>")
+	assert.Contains(t, doc, `<Amt Ccy="DKK">375.00</Amt>`)
+
+	today := startOfDay(time.Now())
+	err = service.ExportCamt053(ctx, &buf, id, today.AddDate(0, 0, -3), today)
+	assert.ErrorIs(t, err, domain.ErrPeriodNotEnded)
+}
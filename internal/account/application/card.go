@@ -0,0 +1,305 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/pan"
+	"nordic-bank/internal/shared/vault"
+
+	"github.com/google/uuid"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	// Default card limits in øre
+	defaultCardDailyLimit   = 2_500_000  // 25.000 kr
+	defaultCardMonthlyLimit = 10_000_000 // 100.000 kr
+	defaultATMDailyLimit    = 800_000    // 8.000 kr
+
+	cardValidityYears = 4
+	cardNumberLength  = 16
+
+	// maxPINAttempts wrong PINs in a row lock the card for pinLockout.
+	maxPINAttempts = 3
+	pinLockout     = 24 * time.Hour
+)
+
+// cardBINs are the issuer prefixes our debit cards are numbered under.
+var cardBINs = map[domain.CardBrand]string{
+	domain.CardBrandVisa:       "457199",
+	domain.CardBrandMastercard: "521999",
+}
+
+// SetCardVault configures where card numbers are tokenised. Cards cannot be
+// issued without one.
+func (s *AccountService) SetCardVault(v vault.Vault) {
+	s.cardVault = v
+}
+
+// IssuedCard is a freshly issued card together with its number, which is only
+// ever returned this once.
+type IssuedCard struct {
+	*domain.Card
+	PAN string
+}
+
+// CardLimits are the spending limits of a card in minor units.
+type CardLimits struct {
+	Daily    int64
+	Monthly  int64
+	ATMDaily int64
+}
+
+func (l CardLimits) valid() bool {
+	return l.Daily >= 0 && l.Monthly >= 0 && l.ATMDaily >= 0 && l.ATMDaily <= l.Daily
+}
+
+// IssueCard issues an inactive debit card on the account to one of its
+// holders. The holder must be allowed to withdraw from the account.
+func (s *AccountService) IssueCard(ctx context.Context, accountID, customerID uuid.UUID, brand domain.CardBrand) (*IssuedCard, error) {
+	bin, ok := cardBINs[brand]
+	if !ok {
+		return nil, domain.ErrInvalidCardBrand
+	}
+	if s.cardVault == nil {
+		return nil, domain.ErrCardVaultMissing
+	}
+
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != domain.AccountStatusActive {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAccountNotActive, account.Status)
+	}
+	if err := s.Authorize(ctx, accountID, customerID, domain.PermissionWithdraw); err != nil {
+		return nil, err
+	}
+
+	number, err := pan.New(bin, cardNumberLength)
+	if err != nil {
+		return nil, err
+	}
+	token, err := s.cardVault.Tokenize(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	expiry := now.AddDate(cardValidityYears, 0, 0)
+	card := &domain.Card{
+		AccountID:           accountID,
+		CustomerID:          customerID,
+		CardNumberToken:     token,
+		CardLastFour:        pan.LastFour(number),
+		CardType:            "debit",
+		CardBrand:           brand,
+		ExpiryMonth:         int(expiry.Month()),
+		ExpiryYear:          expiry.Year(),
+		DailyLimit:          defaultCardDailyLimit,
+		MonthlyLimit:        defaultCardMonthlyLimit,
+		ATMDailyLimit:       defaultATMDailyLimit,
+		Status:              domain.CardStatusInactive,
+		ThreeDSecureEnabled: true,
+		IssuedAt:            now,
+	}
+	if err := s.repo.CreateCard(ctx, card); err != nil {
+		return nil, err
+	}
+
+	return &IssuedCard{Card: card, PAN: number}, nil
+}
+
+func (s *AccountService) GetCard(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	return s.repo.GetCardByID(ctx, id)
+}
+
+func (s *AccountService) GetCardByToken(ctx context.Context, token string) (*domain.Card, error) {
+	return s.repo.GetCardByToken(ctx, token)
+}
+
+func (s *AccountService) ListCards(ctx context.Context, accountID uuid.UUID) ([]*domain.Card, error) {
+	return s.repo.ListCardsByAccountID(ctx, accountID)
+}
+
+// ActivateCard turns an issued card on. Cards past their expiry cannot be
+// activated.
+func (s *AccountService) ActivateCard(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status != domain.CardStatusInactive {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.Status = domain.CardStatusActive
+		card.ActivatedAt = &now
+		return nil
+	})
+}
+
+// BlockCard stops an active card from being used until it is unblocked.
+func (s *AccountService) BlockCard(ctx context.Context, id uuid.UUID, reason string) (*domain.Card, error) {
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status != domain.CardStatusActive {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.Status = domain.CardStatusBlocked
+		card.BlockedAt = &now
+		card.BlockReason = reason
+		return nil
+	})
+}
+
+func (s *AccountService) UnblockCard(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status != domain.CardStatusBlocked {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.Status = domain.CardStatusActive
+		card.BlockedAt = nil
+		card.BlockReason = ""
+		return nil
+	})
+}
+
+// CancelCard permanently ends a card. It cannot be undone; the customer gets
+// a new card instead.
+func (s *AccountService) CancelCard(ctx context.Context, id uuid.UUID, reason string) (*domain.Card, error) {
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status == domain.CardStatusCancelled {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.Status = domain.CardStatusCancelled
+		card.CancelledAt = &now
+		if reason != "" {
+			card.BlockReason = reason
+		}
+		return nil
+	})
+}
+
+// SetCardLimits replaces the daily, monthly and ATM limits of a card.
+func (s *AccountService) SetCardLimits(ctx context.Context, id uuid.UUID, limits CardLimits) (*domain.Card, error) {
+	if !limits.valid() {
+		return nil, domain.ErrInvalidCardLimit
+	}
+
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status == domain.CardStatusCancelled {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.DailyLimit = limits.Daily
+		card.MonthlyLimit = limits.Monthly
+		card.ATMDailyLimit = limits.ATMDaily
+		return nil
+	})
+}
+
+// SetCardPIN sets or changes the PIN. Choosing a new PIN also lifts a PIN
+// lockout.
+func (s *AccountService) SetCardPIN(ctx context.Context, id uuid.UUID, pin string) (*domain.Card, error) {
+	if !validPIN(pin) {
+		return nil, domain.ErrInvalidPIN
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status == domain.CardStatusCancelled || card.Status == domain.CardStatusExpired {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		card.PinHash = string(hash)
+		card.PinAttempts = 0
+		card.PinLockedUntil = nil
+		return nil
+	})
+}
+
+// VerifyCardPIN checks a PIN entered at a terminal. After maxPINAttempts wrong
+// PINs in a row the card is locked for pinLockout; a correct PIN resets the
+// count. The attempt is recorded even when the PIN is wrong.
+func (s *AccountService) VerifyCardPIN(ctx context.Context, id uuid.UUID, pin string) error {
+	var verr error
+	_, err := s.updateCard(ctx, id, func(card *domain.Card, now time.Time) error {
+		if card.Status != domain.CardStatusActive {
+			return fmt.Errorf("%w: %s", domain.ErrCardStatus, card.Status)
+		}
+		if card.PinHash == "" {
+			return domain.ErrPINNotSet
+		}
+		if card.PinLocked(now) {
+			return domain.ErrPINLocked
+		}
+
+		if bcrypt.CompareHashAndPassword([]byte(card.PinHash), []byte(pin)) == nil {
+			card.PinAttempts = 0
+			card.PinLockedUntil = nil
+			return nil
+		}
+
+		card.PinAttempts++
+		verr = domain.ErrIncorrectPIN
+		if card.PinAttempts >= maxPINAttempts {
+			until := now.Add(pinLockout)
+			card.PinLockedUntil = &until
+			card.PinAttempts = 0
+			verr = domain.ErrPINLocked
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return verr
+}
+
+// updateCard loads the card under a row lock, applies fn and saves it. A card
+// found past its expiry is marked expired instead and ErrCardExpired returned.
+func (s *AccountService) updateCard(ctx context.Context, id uuid.UUID, fn func(card *domain.Card, now time.Time) error) (*domain.Card, error) {
+	var (
+		card    *domain.Card
+		expired bool
+	)
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		card, err = repo.GetCardByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		if card.Status != domain.CardStatusCancelled && card.Status != domain.CardStatusExpired && card.Expired(now) {
+			card.Status = domain.CardStatusExpired
+			expired = true
+			return repo.UpdateCard(ctx, card)
+		}
+
+		if err := fn(card, now); err != nil {
+			return err
+		}
+		return repo.UpdateCard(ctx, card)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if expired {
+		return nil, domain.ErrCardExpired
+	}
+
+	return card, nil
+}
+
+func validPIN(pin string) bool {
+	if len(pin) != 4 {
+		return false
+	}
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
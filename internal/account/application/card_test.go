@@ -0,0 +1,221 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/blob"
+	"nordic-bank/internal/shared/pan"
+	"nordic-bank/internal/shared/vault"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) CreateCard(ctx context.Context, card *domain.Card) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if card.ID == uuid.Nil {
+		card.ID = uuid.New()
+	}
+	r.cards[card.ID] = *card
+	return nil
+}
+
+func (r *memoryRepository) GetCardByID(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	card, ok := r.cards[id]
+	if !ok {
+		return nil, domain.ErrCardNotFound
+	}
+	return &card, nil
+}
+
+func (r *memoryRepository) UpdateCard(ctx context.Context, card *domain.Card) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cards[card.ID] = *card
+	return nil
+}
+
+// Card writes inside a memoryTx go straight to the parent; the tests only
+// need the row lock.
+func (t *memoryTx) GetCardByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Card, error) {
+	l := t.parent.rowLock(id)
+	l.Lock()
+	t.locked = append(t.locked, l)
+	return t.parent.GetCardByID(ctx, id)
+}
+
+func (t *memoryTx) UpdateCard(ctx context.Context, card *domain.Card) error {
+	return t.parent.UpdateCard(ctx, card)
+}
+
+func newCardService(t *testing.T) (*AccountService, *memoryRepository, vault.Vault) {
+	t.Helper()
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+
+	store, err := blob.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	v, err := vault.NewLocalVault(store, "test-secret")
+	require.NoError(t, err)
+	service.SetCardVault(v)
+
+	return service, repo, v
+}
+
+func TestIssueCard_TokenisesNumber(t *testing.T) {
+	service, repo, v := newCardService(t)
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	issued, err := service.IssueCard(ctx, id, owner, domain.CardBrandVisa)
+	require.NoError(t, err)
+
+	assert.True(t, pan.Valid(issued.PAN))
+	assert.Equal(t, domain.CardStatusInactive, issued.Status)
+	assert.Equal(t, issued.PAN[12:], issued.CardLastFour)
+	assert.NotContains(t, issued.CardNumberToken, issued.PAN)
+	assert.False(t, issued.Expired(time.Now().AddDate(3, 11, 0)))
+
+	number, err := v.Detokenize(ctx, issued.CardNumberToken)
+	require.NoError(t, err)
+	assert.Equal(t, issued.PAN, number)
+
+	_, err = service.IssueCard(ctx, id, owner, "amex")
+	assert.ErrorIs(t, err, domain.ErrInvalidCardBrand)
+
+	// A customer with no claim on the account cannot get a card on it
+	_, err = service.IssueCard(ctx, id, uuid.New(), domain.CardBrandVisa)
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+}
+
+func TestCardLifecycle(t *testing.T) {
+	service, repo, _ := newCardService(t)
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	issued, err := service.IssueCard(ctx, id, owner, domain.CardBrandMastercard)
+	require.NoError(t, err)
+	cardID := issued.ID
+
+	_, err = service.BlockCard(ctx, cardID, "lost")
+	assert.ErrorIs(t, err, domain.ErrCardStatus)
+
+	card, err := service.ActivateCard(ctx, cardID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardStatusActive, card.Status)
+	assert.NotNil(t, card.ActivatedAt)
+
+	card, err = service.BlockCard(ctx, cardID, "misplaced")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardStatusBlocked, card.Status)
+	assert.Equal(t, "misplaced", card.BlockReason)
+
+	card, err = service.UnblockCard(ctx, cardID)
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardStatusActive, card.Status)
+	assert.Empty(t, card.BlockReason)
+
+	card, err = service.CancelCard(ctx, cardID, "stolen")
+	require.NoError(t, err)
+	assert.Equal(t, domain.CardStatusCancelled, card.Status)
+
+	for _, op := range []func() (*domain.Card, error){
+		func() (*domain.Card, error) { return service.ActivateCard(ctx, cardID) },
+		func() (*domain.Card, error) { return service.UnblockCard(ctx, cardID) },
+		func() (*domain.Card, error) { return service.CancelCard(ctx, cardID, "") },
+		func() (*domain.Card, error) { return service.SetCardPIN(ctx, cardID, "1234") },
+	} {
+		_, err := op()
+		assert.ErrorIs(t, err, domain.ErrCardStatus)
+	}
+}
+
+func TestCard_ExpiredCardCannotBeActivated(t *testing.T) {
+	service, repo, _ := newCardService(t)
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	issued, err := service.IssueCard(ctx, id, owner, domain.CardBrandVisa)
+	require.NoError(t, err)
+
+	card := repo.cards[issued.ID]
+	card.ExpiryYear, card.ExpiryMonth = 2020, 12
+	repo.cards[issued.ID] = card
+
+	_, err = service.ActivateCard(ctx, issued.ID)
+	assert.ErrorIs(t, err, domain.ErrCardExpired)
+	assert.Equal(t, domain.CardStatusExpired, repo.cards[issued.ID].Status)
+}
+
+func TestVerifyCardPIN_LocksAfterRepeatedFailures(t *testing.T) {
+	service, repo, _ := newCardService(t)
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	issued, err := service.IssueCard(ctx, id, owner, domain.CardBrandVisa)
+	require.NoError(t, err)
+	_, err = service.ActivateCard(ctx, issued.ID)
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "1234"), domain.ErrPINNotSet)
+
+	_, err = service.SetCardPIN(ctx, issued.ID, "12a4")
+	assert.ErrorIs(t, err, domain.ErrInvalidPIN)
+	card, err := service.SetCardPIN(ctx, issued.ID, "4821")
+	require.NoError(t, err)
+	assert.NotEqual(t, "4821", card.PinHash)
+
+	require.NoError(t, service.VerifyCardPIN(ctx, issued.ID, "4821"))
+
+	// A correct PIN resets the count, so two misses never lock the card
+	assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "0000"), domain.ErrIncorrectPIN)
+	assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "0000"), domain.ErrIncorrectPIN)
+	require.NoError(t, service.VerifyCardPIN(ctx, issued.ID, "4821"))
+	assert.Equal(t, 0, repo.cards[issued.ID].PinAttempts)
+
+	for i := 0; i < maxPINAttempts-1; i++ {
+		assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "0000"), domain.ErrIncorrectPIN)
+	}
+	assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "0000"), domain.ErrPINLocked)
+
+	// Even the right PIN is refused while locked
+	assert.ErrorIs(t, service.VerifyCardPIN(ctx, issued.ID, "4821"), domain.ErrPINLocked)
+
+	// Choosing a new PIN lifts the lock
+	_, err = service.SetCardPIN(ctx, issued.ID, "9999")
+	require.NoError(t, err)
+	assert.NoError(t, service.VerifyCardPIN(ctx, issued.ID, "9999"))
+}
+
+func TestSetCardLimits(t *testing.T) {
+	service, repo, _ := newCardService(t)
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	issued, err := service.IssueCard(ctx, id, owner, domain.CardBrandVisa)
+	require.NoError(t, err)
+	assert.Equal(t, int64(defaultCardDailyLimit), issued.DailyLimit)
+
+	card, err := service.SetCardLimits(ctx, issued.ID, CardLimits{Daily: 500_000, Monthly: 2_000_000, ATMDaily: 200_000})
+	require.NoError(t, err)
+	assert.Equal(t, int64(500_000), card.DailyLimit)
+	assert.Equal(t, int64(2_000_000), card.MonthlyLimit)
+	assert.Equal(t, int64(200_000), card.ATMDailyLimit)
+
+	_, err = service.SetCardLimits(ctx, issued.ID, CardLimits{Daily: 100_000, Monthly: 2_000_000, ATMDaily: 200_000})
+	assert.ErrorIs(t, err, domain.ErrInvalidCardLimit)
+	_, err = service.SetCardLimits(ctx, issued.ID, CardLimits{Daily: -1})
+	assert.ErrorIs(t, err, domain.ErrInvalidCardLimit)
+}
@@ -0,0 +1,262 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/notify"
+	commonpb "nordic-bank/pkg/pb/common/v1"
+	transactionpb "nordic-bank/pkg/pb/transaction/v1"
+
+	"github.com/google/uuid"
+)
+
+// Closure asks for an account to be closed.
+type Closure struct {
+	// SweepAccountID receives whatever is left in the account, interest
+	// included. It can be left empty when the account is already empty.
+	SweepAccountID uuid.UUID
+	Reason         string
+	// RequestedBy is the customer closing the account, who must own it;
+	// uuid.Nil for employees.
+	RequestedBy uuid.UUID
+	// ClosedBy is the user behind the closure, recorded in the status
+	// history.
+	ClosedBy uuid.UUID
+}
+
+func (c Closure) actor() *uuid.UUID {
+	if c.ClosedBy == uuid.Nil {
+		return nil
+	}
+	return &c.ClosedBy
+}
+
+// ClosedAccount is the outcome of a closure.
+type ClosedAccount struct {
+	Account          *domain.Account   `json:"account"`
+	FinalStatement   *domain.Statement `json:"final_statement"`
+	SweepTransaction string            `json:"sweep_transaction_id,omitempty"`
+}
+
+// SetTransactionClient configures the transaction service used to pay out
+// the balance of accounts being closed.
+func (s *AccountService) SetTransactionClient(client transactionpb.TransactionServiceClient) {
+	s.transactions = client
+}
+
+// CloseAccount closes an account for good. The account must have no holds,
+// live cards or scheduled transactions. Accrued interest is paid in, the
+// balance is swept to the nominated account through the transaction service,
+// and a final statement covering everything since the last regular one is
+// issued. Closed accounts cannot be reopened.
+func (s *AccountService) CloseAccount(ctx context.Context, id uuid.UUID, closure Closure) (*ClosedAccount, error) {
+	account, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+	if closure.RequestedBy != uuid.Nil {
+		if err := s.requireOwner(ctx, account, closure.RequestedBy); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.checkClosable(ctx, account); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if _, err := s.capitaliseAccount(ctx, id, time.Time{}, now, "Interest to closure"); err != nil {
+		return nil, fmt.Errorf("paying accrued interest: %w", err)
+	}
+	if account, err = s.repo.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	closed := &ClosedAccount{}
+	switch {
+	case account.Balance < 0:
+		return nil, fmt.Errorf("%w: the account is overdrawn by %d", domain.ErrClosureBlocked, -account.Balance)
+	case account.Balance > 0:
+		txID, err := s.sweepBalance(ctx, account, closure.SweepAccountID)
+		if err != nil {
+			return nil, err
+		}
+		closed.SweepTransaction = txID
+	}
+
+	err = s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if account.Status == domain.AccountStatusClosed {
+			return domain.ErrAccountClosed
+		}
+		// Money that arrived while the balance was being swept
+		if account.Balance != 0 || account.ReservedAmount != 0 {
+			return fmt.Errorf("%w: the balance changed during closure, try again", domain.ErrClosureBlocked)
+		}
+
+		closedAt := time.Now()
+		account.ClosedAt = &closedAt
+		account.ClosureReason = closure.Reason
+		if err := changeStatus(ctx, repo, account, domain.AccountStatusClosed, closure.Reason, closure.actor()); err != nil {
+			return err
+		}
+
+		return repo.CreateLedgerEntry(ctx, &domain.LedgerEntry{
+			AccountID:     account.ID,
+			EntryType:     domain.EntryTypeDebit,
+			Amount:        0,
+			Currency:      account.Currency,
+			BalanceBefore: 0,
+			BalanceAfter:  0,
+			Description:   "Account closed",
+			Reference:     domain.ReferenceClosure,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+	closed.Account = account
+
+	closed.FinalStatement, err = s.issueFinalStatement(ctx, account)
+	if err != nil {
+		return nil, fmt.Errorf("account closed, but the final statement failed: %w", err)
+	}
+
+	if err := s.notifier.Notify(ctx, notify.Message{
+		CustomerID:    account.CustomerID,
+		Subject:       "Your account has been closed",
+		Body:          fmt.Sprintf("Account %s has been closed. Your final statement is available in online banking.", account.AccountNumber),
+		ReferenceType: "account",
+		ReferenceID:   account.ID,
+	}); err != nil {
+		log.Printf("closure: notifying customer %s about account %s: %v", account.CustomerID, account.ID, err)
+	}
+
+	return closed, nil
+}
+
+// checkClosable refuses closure while anything could still move money on the
+// account.
+func (s *AccountService) checkClosable(ctx context.Context, account *domain.Account) error {
+	if account.ReservedAmount != 0 {
+		return fmt.Errorf("%w: %d is still on hold", domain.ErrClosureBlocked, account.ReservedAmount)
+	}
+
+	if account.SavedAmount != 0 {
+		return fmt.Errorf("%w: the pots must be emptied first", domain.ErrClosureBlocked)
+	}
+
+	balances, err := s.repo.ListSubBalances(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	for _, b := range balances {
+		if b.Balance != 0 || b.ReservedAmount != 0 {
+			return fmt.Errorf("%w: the %s balance must be paid out first", domain.ErrClosureBlocked, b.Currency)
+		}
+	}
+
+	cards, err := s.repo.ListCardsByAccountID(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	for _, card := range cards {
+		if card.Status != domain.CardStatusCancelled && card.Status != domain.CardStatusExpired {
+			return fmt.Errorf("%w: card ending %s must be cancelled first", domain.ErrClosureBlocked, card.CardLastFour)
+		}
+	}
+
+	scheduled, err := s.repo.CountScheduledTransactions(ctx, account.ID)
+	if err != nil {
+		return err
+	}
+	if scheduled > 0 {
+		return fmt.Errorf("%w: %d scheduled transactions must be cancelled first", domain.ErrClosureBlocked, scheduled)
+	}
+
+	return nil
+}
+
+// sweepBalance transfers the whole balance to the sweep account and returns
+// the transaction ID.
+func (s *AccountService) sweepBalance(ctx context.Context, account *domain.Account, sweepAccountID uuid.UUID) (string, error) {
+	if sweepAccountID == uuid.Nil {
+		return "", domain.ErrSweepAccountRequired
+	}
+	if account.Status != domain.AccountStatusActive {
+		return "", fmt.Errorf("%w: %s accounts cannot pay out their balance", domain.ErrAccountNotActive, account.Status)
+	}
+	target, err := s.repo.GetByID(ctx, sweepAccountID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", domain.ErrInvalidSweepAccount, err)
+	}
+	if target.ID == account.ID || target.Status != domain.AccountStatusActive || target.Currency != account.Currency {
+		return "", domain.ErrInvalidSweepAccount
+	}
+	if s.transactions == nil {
+		return "", domain.ErrTransferClientMissing
+	}
+
+	// A fresh key per attempt: a retry only gets here if the previous sweep
+	// left money behind.
+	res, err := s.transactions.CreateTransfer(ctx, &transactionpb.CreateTransferRequest{
+		SourceAccountId:      account.ID.String(),
+		DestinationAccountId: target.ID.String(),
+		Amount:               &commonpb.Money{Amount: account.Balance, Currency: account.Currency},
+		Reference:            domain.ReferenceClosure,
+		Description:          fmt.Sprintf("Closing balance of account %s", account.AccountNumber),
+		IdempotencyKey:       "closure:" + account.ID.String() + ":" + uuid.NewString(),
+	})
+	if err != nil {
+		return "", fmt.Errorf("sweeping the balance: %w", err)
+	}
+	if res.Transaction.Status != "completed" {
+		return "", fmt.Errorf("sweeping the balance: transfer %s is %s", res.Transaction.Id, res.Transaction.Status)
+	}
+
+	return res.Transaction.Id, nil
+}
+
+// issueFinalStatement covers the ledger from the day after the last regular
+// statement, or from the opening date, up to and including today.
+func (s *AccountService) issueFinalStatement(ctx context.Context, account *domain.Account) (*domain.Statement, error) {
+	periodStart := startOfDay(account.OpenedAt)
+	latest, err := s.repo.GetLatestStatement(ctx, account.ID)
+	switch {
+	case err == nil:
+		periodStart = startOfDay(latest.PeriodEndDate).AddDate(0, 0, 1)
+	case !errors.Is(err, domain.ErrStatementNotFound):
+		return nil, err
+	}
+
+	periodEnd := startOfDay(*account.ClosedAt)
+	if periodStart.After(periodEnd) {
+		periodStart = periodEnd
+	}
+
+	return s.issueStatement(ctx, account.ID, periodStart, periodEnd, true)
+}
+
+// requireOwner checks that the customer is the primary owner or a joint owner
+// of the account.
+func (s *AccountService) requireOwner(ctx context.Context, account *domain.Account, customerID uuid.UUID) error {
+	if customerID == account.CustomerID {
+		return nil
+	}
+	holder, err := s.repo.GetHolder(ctx, account.ID, customerID)
+	if errors.Is(err, domain.ErrHolderNotFound) || (err == nil && !holder.IsOwner()) {
+		return fmt.Errorf("%w: only owners can do this", domain.ErrPermissionDenied)
+	}
+	return err
+}
@@ -0,0 +1,250 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	transactionpb "nordic-bank/pkg/pb/transaction/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+func (r *memoryRepository) ListCardsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*domain.Card, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var cards []*domain.Card
+	for _, card := range r.cards {
+		if card.AccountID == accountID {
+			card := card
+			cards = append(cards, &card)
+		}
+	}
+	return cards, nil
+}
+
+func (r *memoryRepository) CountScheduledTransactions(ctx context.Context, accountID uuid.UUID) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.scheduled[accountID], nil
+}
+
+func (r *memoryRepository) GetLatestStatement(ctx context.Context, accountID uuid.UUID) (*domain.Statement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var latest *domain.Statement
+	for i := range r.statements {
+		st := r.statements[i]
+		if st.AccountID == accountID && (latest == nil || st.PeriodEndDate.After(latest.PeriodEndDate)) {
+			latest = &st
+		}
+	}
+	if latest == nil {
+		return nil, domain.ErrStatementNotFound
+	}
+	return latest, nil
+}
+
+func (t *memoryTx) ListUncapitalisedAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.InterestAccrual, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	var accruals []*domain.InterestAccrual
+	for i := range t.parent.accruals {
+		a := t.parent.accruals[i]
+		if a.AccountID == accountID && a.LedgerEntryID == nil && !a.AccrualDate.Before(from) && a.AccrualDate.Before(to) {
+			accruals = append(accruals, &a)
+		}
+	}
+	return accruals, nil
+}
+
+func (t *memoryTx) MarkAccrualsCapitalised(ctx context.Context, ids []uuid.UUID, ledgerEntryID uuid.UUID) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for i := range t.parent.accruals {
+		for _, id := range ids {
+			if t.parent.accruals[i].ID == id {
+				t.parent.accruals[i].LedgerEntryID = &ledgerEntryID
+			}
+		}
+	}
+	return nil
+}
+
+// transferClient books transfers straight onto the memory repository, the
+// way the transaction service would through AdjustBalance.
+type transferClient struct {
+	transactionpb.TransactionServiceClient
+	service *AccountService
+}
+
+func (c *transferClient) CreateTransfer(ctx context.Context, in *transactionpb.CreateTransferRequest, _ ...grpc.CallOption) (*transactionpb.CreateTransferResponse, error) {
+	src, dst := uuid.MustParse(in.SourceAccountId), uuid.MustParse(in.DestinationAccountId)
+	id := uuid.NewString()
+	if _, err := c.service.AdjustBalance(ctx, src, -in.Amount.Amount, in.Amount.Currency, id, in.Description); err != nil {
+		return nil, err
+	}
+	if _, err := c.service.AdjustBalance(ctx, dst, in.Amount.Amount, in.Amount.Currency, id, in.Description); err != nil {
+		return nil, err
+	}
+	return &transactionpb.CreateTransferResponse{Transaction: &transactionpb.Transaction{Id: id, Status: "completed"}}, nil
+}
+
+func newClosureService(t *testing.T) (*AccountService, *memoryRepository, *recordingNotifier) {
+	t.Helper()
+	service, repo, notifier := newDormancyService(t)
+	repo.scheduled = make(map[uuid.UUID]int64)
+	service.SetTransactionClient(&transferClient{service: service})
+	return service, repo, notifier
+}
+
+func seedClosableAccount(t *testing.T, repo *memoryRepository, owner uuid.UUID, balance int64) uuid.UUID {
+	t.Helper()
+	id := seedOwnedAccount(t, repo, owner, balance)
+	acc := repo.accounts[id]
+	acc.AccountNumber = "DK0000000000000001"
+	acc.OpenedAt = time.Now().AddDate(0, -3, 0)
+	repo.accounts[id] = acc
+	appendLedger(repo, id, acc.OpenedAt, balance, "DEPOSIT")
+	return id
+}
+
+func TestCloseAccount_SweepsBalanceAndInterest(t *testing.T) {
+	service, repo, notifier := newClosureService(t)
+	ctx := context.Background()
+	owner := uuid.New()
+
+	id := seedClosableAccount(t, repo, owner, 10_000)
+	target := seedOwnedAccount(t, repo, owner, 0)
+	acc := repo.accounts[id]
+	acc.InterestAccrued = 150
+	repo.accounts[id] = acc
+	repo.accruals = append(repo.accruals, domain.InterestAccrual{
+		ID: uuid.New(), AccountID: id, AccrualDate: startOfDay(time.Now()).AddDate(0, 0, -1), Amount: 150,
+	})
+
+	closed, err := service.CloseAccount(ctx, id, Closure{SweepAccountID: target, Reason: "Moving abroad", RequestedBy: owner})
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.AccountStatusClosed, closed.Account.Status)
+	assert.Equal(t, "Moving abroad", closed.Account.ClosureReason)
+	require.NotNil(t, closed.Account.ClosedAt)
+	assert.NotEmpty(t, closed.SweepTransaction)
+
+	assert.Equal(t, int64(0), repo.accounts[id].Balance)
+	assert.Equal(t, int64(0), repo.accounts[id].InterestAccrued)
+	assert.Equal(t, int64(10_150), repo.accounts[target].Balance)
+
+	st := closed.FinalStatement
+	require.NotNil(t, st)
+	assert.True(t, st.IsFinal)
+	assert.True(t, st.Balanced())
+	assert.Equal(t, startOfDay(acc.OpenedAt), st.PeriodStartDate)
+	assert.Equal(t, startOfDay(time.Now()), st.PeriodEndDate)
+	assert.Equal(t, int64(0), st.ClosingBalance)
+	assert.Equal(t, int64(150), st.InterestEarned)
+	assert.Equal(t, int64(10_150), st.TotalDebits)
+
+	require.Len(t, notifier.messages, 1)
+	assert.Equal(t, owner, notifier.messages[0].CustomerID)
+}
+
+func TestCloseAccount_FinalStatementFollowsLastStatement(t *testing.T) {
+	service, repo, _ := newClosureService(t)
+	ctx := context.Background()
+
+	id := seedClosableAccount(t, repo, uuid.New(), 0)
+	lastEnd := startOfDay(time.Now()).AddDate(0, 0, -10)
+	repo.statements = append(repo.statements, domain.Statement{AccountID: id, PeriodStartDate: lastEnd.AddDate(0, -1, 0), PeriodEndDate: lastEnd, StatementDate: lastEnd})
+
+	closed, err := service.CloseAccount(ctx, id, Closure{Reason: "No longer needed"})
+	require.NoError(t, err)
+	assert.Equal(t, lastEnd.AddDate(0, 0, 1), closed.FinalStatement.PeriodStartDate)
+	assert.Empty(t, closed.SweepTransaction)
+}
+
+func TestCloseAccount_Refusals(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("funds on hold", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 0)
+		acc := repo.accounts[id]
+		acc.ReservedAmount = 100
+		repo.accounts[id] = acc
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrClosureBlocked)
+	})
+
+	t.Run("live card", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 0)
+		repo.cards[uuid.New()] = domain.Card{AccountID: id, Status: domain.CardStatusBlocked, CardLastFour: "4242"}
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrClosureBlocked)
+	})
+
+	t.Run("scheduled transactions", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 0)
+		repo.scheduled[id] = 2
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrClosureBlocked)
+	})
+
+	t.Run("overdrawn", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), -500)
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrClosureBlocked)
+	})
+
+	t.Run("money left without sweep account", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 500)
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrSweepAccountRequired)
+	})
+
+	t.Run("sweep account in another currency", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 500)
+		target := seedAccount(t, repo, 0)
+		acc := repo.accounts[target]
+		acc.Currency = "EUR"
+		repo.accounts[target] = acc
+		_, err := service.CloseAccount(ctx, id, Closure{SweepAccountID: target, Reason: "x"})
+		assert.ErrorIs(t, err, domain.ErrInvalidSweepAccount)
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		service, repo, _ := newClosureService(t)
+		id := seedClosableAccount(t, repo, uuid.New(), 0)
+		_, err := service.CloseAccount(ctx, id, Closure{Reason: "x", RequestedBy: uuid.New()})
+		assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+	})
+}
+
+func TestClosedAccountCannotBeReopened(t *testing.T) {
+	service, repo, _ := newClosureService(t)
+	ctx := context.Background()
+
+	id := seedClosableAccount(t, repo, uuid.New(), 0)
+
+	_, err := service.UpdateStatus(ctx, id, domain.AccountStatusClosed, "Customer asked", uuid.New())
+	assert.ErrorIs(t, err, domain.ErrClosureRequired)
+
+	_, err = service.CloseAccount(ctx, id, Closure{Reason: "Duplicate account"})
+	require.NoError(t, err)
+
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusActive, "Reopen", uuid.New())
+	assert.ErrorIs(t, err, domain.ErrAccountClosed)
+	_, err = service.CloseAccount(ctx, id, Closure{Reason: "again"})
+	assert.ErrorIs(t, err, domain.ErrAccountClosed)
+	_, err = service.AdjustBalance(ctx, id, 100, "DKK", "TEST", "deposit")
+	assert.ErrorIs(t, err, domain.ErrAccountNotActive)
+}
@@ -0,0 +1,144 @@
+package application
+
+import (
+	"context"
+	"fmt"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/currency"
+
+	"github.com/google/uuid"
+)
+
+// OpenSubBalance lets an account hold money in another currency. When
+// customerID is set the customer must own the account.
+func (s *AccountService) OpenSubBalance(ctx context.Context, accountID, customerID uuid.UUID, code string) (*domain.SubBalance, error) {
+	code, err := supportedCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+	if customerID != uuid.Nil {
+		if err := s.requireOwner(ctx, account, customerID); err != nil {
+			return nil, err
+		}
+	}
+	if code == account.Currency {
+		return nil, domain.ErrSubBalanceExists
+	}
+
+	balances, err := s.repo.ListSubBalances(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	for _, b := range balances {
+		if b.Currency == code {
+			return nil, domain.ErrSubBalanceExists
+		}
+	}
+
+	balance := &domain.SubBalance{AccountID: accountID, Currency: code}
+	if err := s.repo.CreateSubBalance(ctx, balance); err != nil {
+		return nil, err
+	}
+	return balance, nil
+}
+
+// withSubBalances fills in the account's balances in other currencies.
+func (s *AccountService) withSubBalances(ctx context.Context, account *domain.Account) (*domain.Account, error) {
+	balances, err := s.repo.ListSubBalances(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+	account.SubBalances = balances
+	return account, nil
+}
+
+// supportedCurrency normalises an ISO 4217 code and checks the bank keeps
+// balances in it.
+func supportedCurrency(code string) (string, error) {
+	if err := currency.Validate(code); err != nil {
+		return "", fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, code)
+	}
+	return currency.Normalize(code), nil
+}
+
+// funds is the part of a locked account held in one currency: the account
+// row itself for its own currency, or one of its sub-balances.
+type funds struct {
+	account *domain.Account
+	sub     *domain.SubBalance
+}
+
+// lockFunds picks the balance of account in the given currency, locking the
+// sub-balance row when it is not the account's own currency. An empty code
+// means the account's own currency. It must run inside WithTx.
+func lockFunds(ctx context.Context, repo domain.AccountRepository, account *domain.Account, code string) (*funds, error) {
+	if code == "" || code == account.Currency {
+		return &funds{account: account}, nil
+	}
+	code, err := supportedCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+	if code == account.Currency {
+		return &funds{account: account}, nil
+	}
+
+	sub, err := repo.GetSubBalanceForUpdate(ctx, account.ID, code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", err, code)
+	}
+	return &funds{account: account, sub: sub}, nil
+}
+
+func (f *funds) currency() string {
+	if f.sub != nil {
+		return f.sub.Currency
+	}
+	return f.account.Currency
+}
+
+func (f *funds) balance() int64 {
+	if f.sub != nil {
+		return f.sub.Balance
+	}
+	return f.account.Balance
+}
+
+// canDebit applies the account's minimum balance and overdraft to its own
+// currency; sub-balances cannot go below zero.
+func (f *funds) canDebit(amount int64) bool {
+	if f.sub != nil {
+		return f.sub.AvailableBalance-amount >= 0
+	}
+	return f.account.CanDebit(amount)
+}
+
+// move changes the booked and reserved amounts, keeping the available
+// balance in step.
+func (f *funds) move(booked, reserved int64) {
+	if f.sub != nil {
+		f.sub.Balance += booked
+		f.sub.ReservedAmount += reserved
+		f.sub.AvailableBalance += booked - reserved
+		return
+	}
+	f.account.Balance += booked
+	f.account.ReservedAmount += reserved
+	f.account.AvailableBalance += booked - reserved
+}
+
+func (f *funds) save(ctx context.Context, repo domain.AccountRepository) error {
+	if f.sub != nil {
+		return repo.UpdateSubBalance(ctx, f.sub)
+	}
+	return repo.Update(ctx, f.account)
+}
@@ -0,0 +1,149 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) CreateSubBalance(ctx context.Context, balance *domain.SubBalance) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.subs[subKey(balance.AccountID, balance.Currency)] = *balance
+	return nil
+}
+
+func (t *memoryTx) CreateReservation(ctx context.Context, res *domain.FundReservation) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	res.ID = uuid.New()
+	t.parent.reservations = append(t.parent.reservations, *res)
+	return nil
+}
+
+func (t *memoryTx) GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.FundReservation, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, res := range t.parent.reservations {
+		if res.ID == id {
+			return &res, nil
+		}
+	}
+	return nil, errors.New("record not found")
+}
+
+func (t *memoryTx) UpdateReservation(ctx context.Context, res *domain.FundReservation) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for i := range t.parent.reservations {
+		if t.parent.reservations[i].ID == res.ID {
+			t.parent.reservations[i] = *res
+		}
+	}
+	return nil
+}
+
+func TestSubBalance_KeepsCurrenciesApart(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+	_, err := service.AdjustBalance(ctx, id, 10_000, "DKK", "TEST", "krone deposit")
+	require.NoError(t, err)
+
+	_, err = service.AdjustBalance(ctx, id, 500, "EUR", "TEST", "euro deposit")
+	assert.ErrorIs(t, err, domain.ErrCurrencyNotHeld)
+
+	eur, err := service.OpenSubBalance(ctx, id, uuid.Nil, "eur")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", eur.Currency)
+
+	acc, err := service.AdjustBalance(ctx, id, 500, "EUR", "TEST", "euro deposit")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000), acc.Balance, "DKK balance untouched")
+	require.Len(t, acc.SubBalances, 1)
+	assert.Equal(t, int64(500), acc.SubBalances[0].Balance)
+	assert.Equal(t, int64(500), acc.SubBalances[0].AvailableBalance)
+
+	entry := repo.ledger[len(repo.ledger)-1]
+	assert.Equal(t, "EUR", entry.Currency)
+	assert.Equal(t, int64(0), entry.BalanceBefore)
+	assert.Equal(t, int64(500), entry.BalanceAfter)
+
+	// Sub-balances have no overdraft
+	_, err = service.AdjustBalance(ctx, id, -501, "EUR", "TEST", "too much")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	acc, err = service.AdjustBalance(ctx, id, -200, "DKK", "TEST", "krone debit")
+	require.NoError(t, err)
+	assert.Equal(t, int64(9_800), acc.Balance)
+	assert.Equal(t, int64(500), acc.SubBalances[0].Balance)
+
+	balance, err := repo.GetBalanceAt(ctx, id, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(9_800), balance, "statements only see the account's own currency")
+}
+
+func TestSubBalance_Refusals(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	owner := uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+
+	_, err := service.OpenSubBalance(ctx, id, uuid.Nil, "GBP")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedCurrency)
+	_, err = service.OpenSubBalance(ctx, id, uuid.Nil, "DKK")
+	assert.ErrorIs(t, err, domain.ErrSubBalanceExists)
+	_, err = service.OpenSubBalance(ctx, id, uuid.New(), "SEK")
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+
+	_, err = service.OpenSubBalance(ctx, id, owner, "SEK")
+	require.NoError(t, err)
+	_, err = service.OpenSubBalance(ctx, id, owner, "SEK")
+	assert.ErrorIs(t, err, domain.ErrSubBalanceExists)
+
+	_, err = service.AdjustBalance(ctx, id, 100, "XYZ", "TEST", "bogus")
+	assert.ErrorIs(t, err, domain.ErrUnsupportedCurrency)
+	_, err = service.ReserveFunds(ctx, id, uuid.New(), "", 100, "USD", 0)
+	assert.ErrorIs(t, err, domain.ErrCurrencyNotHeld)
+}
+
+func TestReservation_InSubBalance(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 10_000)
+
+	_, err := service.OpenSubBalance(ctx, id, uuid.Nil, "NOK")
+	require.NoError(t, err)
+	_, err = service.AdjustBalance(ctx, id, 1_000, "NOK", "TEST", "deposit")
+	require.NoError(t, err)
+
+	_, err = service.ReserveFunds(ctx, id, uuid.New(), "", 1_001, "NOK", 0)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	hold, err := service.ReserveFunds(ctx, id, uuid.New(), "", 600, "NOK", 0)
+	require.NoError(t, err)
+	assert.Equal(t, "NOK", hold.Currency)
+	nok := repo.subs[subKey(id, "NOK")]
+	assert.Equal(t, int64(600), nok.ReservedAmount)
+	assert.Equal(t, int64(400), nok.AvailableBalance)
+
+	_, acc, err := service.CaptureReservation(ctx, hold.ID, 250, "TEST", "partial")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_000), acc.Balance)
+	assert.Equal(t, int64(10_000), acc.AvailableBalance)
+	require.Len(t, acc.SubBalances, 1)
+	assert.Equal(t, int64(750), acc.SubBalances[0].Balance)
+	assert.Equal(t, int64(750), acc.SubBalances[0].AvailableBalance)
+	assert.Equal(t, int64(0), acc.SubBalances[0].ReservedAmount)
+	assert.Equal(t, "NOK", repo.ledger[len(repo.ledger)-1].Currency)
+}
@@ -0,0 +1,181 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/account/render"
+	"nordic-bank/internal/shared/blob"
+
+	"github.com/google/uuid"
+)
+
+type DocumentFormat string
+
+const (
+	DocumentFormatPDF DocumentFormat = "pdf"
+	DocumentFormatCSV DocumentFormat = "csv"
+)
+
+func (f DocumentFormat) ContentType() string {
+	if f == DocumentFormatCSV {
+		return "text/csv; charset=utf-8"
+	}
+	return "application/pdf"
+}
+
+// SetDocumentStore configures where rendered statement documents are kept.
+// Without one, statements are still generated but never rendered.
+func (s *AccountService) SetDocumentStore(store blob.Store) {
+	s.documents = store
+}
+
+// RenderStatementDocuments renders a finalised statement as PDF and CSV in
+// every supported language, stores the files and records the Danish PDF as
+// the statement's document. Rendering again overwrites the files, so it is
+// safe to retry.
+func (s *AccountService) RenderStatementDocuments(ctx context.Context, statement *domain.Statement) (*domain.Statement, error) {
+	if s.documents == nil {
+		return nil, domain.ErrDocumentStoreMissing
+	}
+	if !statement.IsFinalized {
+		return nil, domain.ErrStatementNotFinalized
+	}
+
+	account, err := s.repo.GetByID(ctx, statement.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.ListLedgerEntries(ctx, statement.AccountID, statement.PeriodStartDate, statement.PeriodEndDate.AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	data := render.Statement{Account: account, Statement: statement, Entries: entries}
+
+	var documentURL string
+	for _, lang := range render.Languages {
+		for _, format := range []DocumentFormat{DocumentFormatPDF, DocumentFormatCSV} {
+			var buf bytes.Buffer
+			if format == DocumentFormatPDF {
+				err = render.WritePDF(&buf, data, lang)
+			} else {
+				err = render.WriteCSV(&buf, data, lang)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("render %s %s: %w", lang, format, err)
+			}
+
+			url, err := s.documents.Put(ctx, statementDocumentKey(statement, format, lang), format.ContentType(), &buf)
+			if err != nil {
+				return nil, err
+			}
+			if format == DocumentFormatPDF && lang == render.Danish {
+				documentURL = url
+			}
+		}
+	}
+
+	now := time.Now()
+	if err := s.repo.SetStatementDocument(ctx, statement.ID, documentURL, now); err != nil {
+		return nil, err
+	}
+	statement.DocumentURL = documentURL
+	statement.DocumentGeneratedAt = &now
+
+	return statement, nil
+}
+
+// OpenStatementDocument returns a rendered statement document of the account,
+// rendering it first if that has not happened yet. The caller must close the
+// returned reader.
+func (s *AccountService) OpenStatementDocument(ctx context.Context, accountID, statementID uuid.UUID, format DocumentFormat, lang render.Language) (io.ReadCloser, error) {
+	if format != DocumentFormatPDF && format != DocumentFormatCSV {
+		return nil, fmt.Errorf("%w: format %q", domain.ErrUnsupportedDocument, format)
+	}
+	if !lang.Valid() {
+		return nil, fmt.Errorf("%w: language %q", domain.ErrUnsupportedDocument, lang)
+	}
+	if s.documents == nil {
+		return nil, domain.ErrDocumentStoreMissing
+	}
+
+	statement, err := s.GetStatement(ctx, accountID, statementID)
+	if err != nil {
+		return nil, err
+	}
+	if statement.DocumentGeneratedAt == nil {
+		if _, err := s.RenderStatementDocuments(ctx, statement); err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := s.documents.Open(ctx, statementDocumentKey(statement, format, lang))
+	if errors.Is(err, blob.ErrNotFound) {
+		// The files were lost from the store; render them again.
+		if _, err := s.RenderStatementDocuments(ctx, statement); err != nil {
+			return nil, err
+		}
+		return s.documents.Open(ctx, statementDocumentKey(statement, format, lang))
+	}
+	return r, err
+}
+
+// RenderPendingDocuments renders up to limit statements that have no document
+// yet. It returns the number rendered.
+func (s *AccountService) RenderPendingDocuments(ctx context.Context, limit int) (int, error) {
+	statements, err := s.repo.ListStatementsWithoutDocument(ctx, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	rendered := 0
+	var errs []error
+	for _, statement := range statements {
+		// One failing statement must not hold up the others
+		if _, err := s.RenderStatementDocuments(ctx, statement); err != nil {
+			err = fmt.Errorf("documents for statement %s: %w", statement.ID, err)
+			log.Print(err)
+			errs = append(errs, err)
+			continue
+		}
+		rendered++
+	}
+	return rendered, errors.Join(errs...)
+}
+
+// RunDocumentRenderer renders documents for new statements every interval.
+func (s *AccountService) RunDocumentRenderer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			n, err := s.RenderPendingDocuments(ctx, 100)
+			if err != nil {
+				log.Printf("document renderer: %v", err)
+			}
+			if n > 0 {
+				log.Printf("document renderer: rendered %d statements", n)
+			}
+		}
+	}
+}
+
+// StatementDocumentName is the file name offered when a document is
+// downloaded, e.g. "statement-2026-09-da.pdf".
+func StatementDocumentName(statement *domain.Statement, format DocumentFormat, lang render.Language) string {
+	return fmt.Sprintf("statement-%s-%s.%s", statement.StatementDate.Format("2006-01"), lang, format)
+}
+
+func statementDocumentKey(statement *domain.Statement, format DocumentFormat, lang render.Language) string {
+	return fmt.Sprintf("statements/%s/%s/%s.%s", statement.AccountID, statement.ID, lang, format)
+}
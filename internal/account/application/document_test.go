@@ -0,0 +1,83 @@
+package application
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/account/render"
+	"nordic-bank/internal/shared/blob"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) GetStatementByID(ctx context.Context, id uuid.UUID) (*domain.Statement, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.statements {
+		if r.statements[i].ID == id {
+			st := r.statements[i]
+			return &st, nil
+		}
+	}
+	return nil, domain.ErrStatementNotFound
+}
+
+func (r *memoryRepository) SetStatementDocument(ctx context.Context, id uuid.UUID, url string, generatedAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.statements {
+		if r.statements[i].ID == id {
+			r.statements[i].DocumentURL = url
+			r.statements[i].DocumentGeneratedAt = &generatedAt
+		}
+	}
+	return nil
+}
+
+func TestOpenStatementDocument_RendersOnFirstDownload(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	store, err := blob.NewLocalStore(t.TempDir())
+	require.NoError(t, err)
+	service.SetDocumentStore(store)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+
+	appendLedger(repo, id, time.Date(2024, time.March, 4, 12, 0, 0, 0, time.UTC), 123_456, "DEPOSIT")
+	appendLedger(repo, id, time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC), -2_500, "TRANSFER")
+
+	st, err := service.GenerateStatement(ctx, id,
+		time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	r, err := service.OpenStatementDocument(ctx, id, st.ID, DocumentFormatCSV, render.Danish)
+	require.NoError(t, err)
+	csv, err := io.ReadAll(r)
+	require.NoError(t, err)
+	r.Close()
+	assert.Contains(t, string(csv), "2024-03-04;;DEPOSIT;1234,56;1234,56;DKK")
+
+	r, err = service.OpenStatementDocument(ctx, id, st.ID, DocumentFormatPDF, render.English)
+	require.NoError(t, err)
+	pdf, err := io.ReadAll(r)
+	require.NoError(t, err)
+	r.Close()
+	assert.True(t, strings.HasPrefix(string(pdf), "%PDF-"))
+
+	stored, err := repo.GetStatementByID(ctx, st.ID)
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(stored.DocumentURL, "/da.pdf"))
+	assert.NotNil(t, stored.DocumentGeneratedAt)
+
+	_, err = service.OpenStatementDocument(ctx, id, st.ID, "docx", render.Danish)
+	assert.ErrorIs(t, err, domain.ErrUnsupportedDocument)
+	_, err = service.OpenStatementDocument(ctx, uuid.New(), st.ID, DocumentFormatPDF, render.Danish)
+	assert.ErrorIs(t, err, domain.ErrStatementNotFound)
+}
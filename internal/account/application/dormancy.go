@@ -0,0 +1,256 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/notify"
+
+	"github.com/google/uuid"
+)
+
+// DormancyPolicy controls when accounts without activity are marked dormant.
+type DormancyPolicy struct {
+	InactivityMonths int           // Months without ledger activity before an account goes dormant
+	NoticePeriod     time.Duration // How long the customer is warned in advance
+}
+
+// DefaultDormancyPolicy marks accounts dormant after 12 months without
+// activity, warning the customer 30 days ahead.
+var DefaultDormancyPolicy = DormancyPolicy{
+	InactivityMonths: 12,
+	NoticePeriod:     30 * 24 * time.Hour,
+}
+
+// DormancyRun reports what a dormancy scan did.
+type DormancyRun struct {
+	Notified int `json:"notified"`
+	Dormant  int `json:"dormant"`
+}
+
+// SetDormancyPolicy replaces the default dormancy policy.
+func (s *AccountService) SetDormancyPolicy(policy DormancyPolicy) error {
+	if policy.InactivityMonths <= 0 || policy.NoticePeriod <= 0 {
+		return fmt.Errorf("dormancy inactivity and notice periods must be positive")
+	}
+	s.dormancy = policy
+	return nil
+}
+
+// SetNotifier replaces the notifier used to reach customers.
+func (s *AccountService) SetNotifier(notifier notify.Notifier) {
+	s.notifier = notifier
+}
+
+// DetectDormancy warns the customers of accounts that are about to go dormant
+// and marks accounts dormant once they have been inactive for the whole
+// period. An account is never marked dormant before its customer has had the
+// full notice period; any activity after a notice withdraws it, and the next
+// inactive spell gets a notice of its own.
+func (s *AccountService) DetectDormancy(ctx context.Context, now time.Time) (DormancyRun, error) {
+	var run DormancyRun
+	dueBefore := now.AddDate(0, -s.dormancy.InactivityMonths, 0)
+
+	candidates, err := s.repo.ListInactiveAccounts(ctx, dueBefore.Add(s.dormancy.NoticePeriod))
+	if err != nil {
+		return run, err
+	}
+
+	for _, c := range candidates {
+		notice := c.Account.DormancyNoticeAt
+		switch {
+		case notice == nil || notice.Before(c.LastActivity):
+			if err := s.sendDormancyNotice(ctx, c, now); err != nil {
+				return run, err
+			}
+			run.Notified++
+		case c.LastActivity.Before(dueBefore) && !now.Before(notice.Add(s.dormancy.NoticePeriod)):
+			marked, err := s.markDormant(ctx, c.Account.ID, now)
+			if err != nil {
+				return run, err
+			}
+			if marked {
+				run.Dormant++
+			}
+		}
+	}
+
+	return run, nil
+}
+
+func (s *AccountService) sendDormancyNotice(ctx context.Context, c *domain.InactiveAccount, now time.Time) error {
+	dormantOn := c.LastActivity.AddDate(0, s.dormancy.InactivityMonths, 0)
+	if earliest := now.Add(s.dormancy.NoticePeriod); dormantOn.Before(earliest) {
+		dormantOn = earliest
+	}
+
+	err := s.notifier.Notify(ctx, notify.Message{
+		CustomerID: c.Account.CustomerID,
+		Subject:    "Your account will become dormant",
+		Body: fmt.Sprintf("There has been no activity on account %s since %s. Unless it is used before %s, "+
+			"the account will be marked dormant and payments out of it will need to be reactivated by the bank.",
+			c.Account.AccountNumber, c.LastActivity.Format("2 January 2006"), dormantOn.Format("2 January 2006")),
+		ReferenceType: "account",
+		ReferenceID:   c.Account.ID,
+	})
+	if err != nil {
+		return fmt.Errorf("notifying customer of dormancy on %s: %w", c.Account.ID, err)
+	}
+
+	return s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		account, err := repo.GetByIDForUpdate(ctx, c.Account.ID)
+		if err != nil {
+			return err
+		}
+		account.DormancyNoticeAt = &now
+		return repo.Update(ctx, account)
+	})
+}
+
+// markDormant flags the account dormant unless something changed its status
+// since it was listed.
+func (s *AccountService) markDormant(ctx context.Context, id uuid.UUID, now time.Time) (bool, error) {
+	var account *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if account.Status != domain.AccountStatusActive {
+			account = nil
+			return nil
+		}
+
+		since := startOfDay(now)
+		account.DormantSince = &since
+		reason := fmt.Sprintf("No activity for %d months", s.dormancy.InactivityMonths)
+		return changeStatus(ctx, repo, account, domain.AccountStatusDormant, reason, nil)
+	})
+	if err != nil || account == nil {
+		return false, err
+	}
+
+	if err := s.notifier.Notify(ctx, notify.Message{
+		CustomerID: account.CustomerID,
+		Subject:    "Your account is now dormant",
+		Body: fmt.Sprintf("Account %s has been marked dormant after %d months without activity. "+
+			"Deposits are still received, but you need to ask us to reactivate the account before money can be taken out.",
+			account.AccountNumber, s.dormancy.InactivityMonths),
+		ReferenceType: "account",
+		ReferenceID:   account.ID,
+	}); err != nil {
+		log.Printf("dormancy: notifying customer %s about account %s: %v", account.CustomerID, account.ID, err)
+	}
+
+	return true, nil
+}
+
+// RequestReactivation asks the bank to reactivate a dormant account. Only the
+// account's owners can ask, and an employee has to approve the request.
+func (s *AccountService) RequestReactivation(ctx context.Context, accountID, customerID uuid.UUID, reason string) (*domain.AccountRequest, error) {
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != domain.AccountStatusDormant {
+		return nil, domain.ErrAccountNotDormant
+	}
+	if err := s.requireOwner(ctx, account, customerID); err != nil {
+		return nil, err
+	}
+
+	pending, err := s.repo.HasPendingRequest(ctx, accountID, domain.RequestKindReactivate)
+	if err != nil {
+		return nil, err
+	}
+	if pending {
+		return nil, domain.ErrRequestPending
+	}
+
+	req := &domain.AccountRequest{
+		CustomerID:    customerID,
+		Kind:          domain.RequestKindReactivate,
+		AccountID:     &accountID,
+		RequestedType: account.AccountType,
+		Status:        domain.RequestStatusPending,
+		Reason:        reason,
+	}
+	if err := s.repo.CreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// reactivate makes a dormant account active again. The zero-amount ledger
+// entry restarts the inactivity clock. It must run inside WithTx.
+func reactivate(ctx context.Context, repo domain.AccountRepository, id, approvedBy uuid.UUID) (*domain.Account, error) {
+	account, err := repo.GetByIDForUpdate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status != domain.AccountStatusDormant {
+		return nil, domain.ErrAccountNotDormant
+	}
+
+	account.DormantSince = nil
+	account.DormancyNoticeAt = nil
+	if err := changeStatus(ctx, repo, account, domain.AccountStatusActive, "Reactivation request approved", &approvedBy); err != nil {
+		return nil, err
+	}
+
+	err = repo.CreateLedgerEntry(ctx, &domain.LedgerEntry{
+		AccountID:     account.ID,
+		EntryType:     domain.EntryTypeCredit,
+		Amount:        0,
+		Currency:      account.Currency,
+		BalanceBefore: account.Balance,
+		BalanceAfter:  account.Balance,
+		Description:   "Account reactivated",
+		Reference:     domain.ReferenceReactivation,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return account, nil
+}
+
+// notifyReactivated tells the customer their account can be used again.
+func (s *AccountService) notifyReactivated(ctx context.Context, account *domain.Account) {
+	if err := s.notifier.Notify(ctx, notify.Message{
+		CustomerID:    account.CustomerID,
+		Subject:       "Your account has been reactivated",
+		Body:          fmt.Sprintf("Account %s is active again and can be used as normal.", account.AccountNumber),
+		ReferenceType: "account",
+		ReferenceID:   account.ID,
+	}); err != nil {
+		log.Printf("dormancy: notifying customer %s about reactivation of %s: %v", account.CustomerID, account.ID, err)
+	}
+}
+
+// RunDormancyScheduler scans for dormant accounts every interval until ctx
+// is cancelled.
+func (s *AccountService) RunDormancyScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			run, err := s.DetectDormancy(ctx, now)
+			if err != nil {
+				log.Printf("dormancy scheduler: %v", err)
+				continue
+			}
+			if run.Notified > 0 || run.Dormant > 0 {
+				log.Printf("dormancy scheduler: %d notices sent, %d accounts marked dormant", run.Notified, run.Dormant)
+			}
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/notify"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListInactiveAccounts(ctx context.Context, before time.Time) ([]*domain.InactiveAccount, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var inactive []*domain.InactiveAccount
+	for _, acc := range r.accounts {
+		if acc.Status != domain.AccountStatusActive {
+			continue
+		}
+		last := acc.OpenedAt
+		for _, entry := range r.ledger {
+			if entry.AccountID == acc.ID && entry.EntryDate.After(last) {
+				last = entry.EntryDate
+			}
+		}
+		if last.Before(before) {
+			acc := acc
+			inactive = append(inactive, &domain.InactiveAccount{Account: &acc, LastActivity: last})
+		}
+	}
+	return inactive, nil
+}
+
+func (r *memoryRepository) CreateRequest(ctx context.Context, req *domain.AccountRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	req.ID = uuid.New()
+	r.requests = append(r.requests, *req)
+	return nil
+}
+
+func (t *memoryTx) GetRequestByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.AccountRequest, error) {
+	l := t.parent.rowLock(id)
+	l.Lock()
+	t.locked = append(t.locked, l)
+
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, req := range t.parent.requests {
+		if req.ID == id {
+			return &req, nil
+		}
+	}
+	return nil, domain.ErrRequestNotFound
+}
+
+func (t *memoryTx) UpdateRequest(ctx context.Context, req *domain.AccountRequest) error {
+	t.requests = append(t.requests, *req)
+	return nil
+}
+
+func (r *memoryRepository) HasPendingRequest(ctx context.Context, accountID uuid.UUID, kind domain.RequestKind) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, req := range r.requests {
+		if req.AccountID != nil && *req.AccountID == accountID && req.Kind == kind && req.Status == domain.RequestStatusPending {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type recordingNotifier struct {
+	messages []notify.Message
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, msg notify.Message) error {
+	n.messages = append(n.messages, msg)
+	return nil
+}
+
+func newDormancyService(t *testing.T) (*AccountService, *memoryRepository, *recordingNotifier) {
+	t.Helper()
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	notifier := &recordingNotifier{}
+	service.SetNotifier(notifier)
+	return service, repo, notifier
+}
+
+func seedIdleAccount(t *testing.T, repo *memoryRepository, owner uuid.UUID, lastActivity time.Time) uuid.UUID {
+	t.Helper()
+	id := seedOwnedAccount(t, repo, owner, 10_000)
+	acc := repo.accounts[id]
+	acc.OpenedAt = lastActivity.AddDate(-1, 0, 0)
+	repo.accounts[id] = acc
+	appendLedger(repo, id, lastActivity, 10_000, "DEPOSIT")
+	return id
+}
+
+func TestDetectDormancy_NoticeBeforeMarking(t *testing.T) {
+	service, repo, notifier := newDormancyService(t)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+	owner := uuid.New()
+
+	idle := seedIdleAccount(t, repo, owner, now.AddDate(-1, -1, 0))
+	recent := seedIdleAccount(t, repo, uuid.New(), now.AddDate(0, -3, 0))
+
+	// Inactive long enough, but the customer has not been warned yet
+	run, err := service.DetectDormancy(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{Notified: 1}, run)
+	require.Len(t, notifier.messages, 1)
+	assert.Equal(t, owner, notifier.messages[0].CustomerID)
+	assert.Equal(t, idle, notifier.messages[0].ReferenceID)
+	assert.Equal(t, domain.AccountStatusActive, repo.accounts[idle].Status)
+
+	// Repeating the run inside the notice period does nothing
+	run, err = service.DetectDormancy(ctx, now.AddDate(0, 0, 10))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{}, run)
+
+	run, err = service.DetectDormancy(ctx, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{Dormant: 1}, run)
+	assert.Len(t, notifier.messages, 2)
+
+	acc := repo.accounts[idle]
+	assert.Equal(t, domain.AccountStatusDormant, acc.Status)
+	require.NotNil(t, acc.DormantSince)
+	assert.Equal(t, time.Date(2026, 7, 1, 0, 0, 0, 0, time.UTC), *acc.DormantSince)
+	assert.Equal(t, domain.AccountStatusActive, repo.accounts[recent].Status)
+}
+
+func TestDetectDormancy_NoticeAheadOfTheDeadline(t *testing.T) {
+	service, repo, notifier := newDormancyService(t)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	// Goes dormant in 20 days: warned now, marked once it is due
+	id := seedIdleAccount(t, repo, uuid.New(), now.AddDate(-1, 0, 20))
+
+	run, err := service.DetectDormancy(ctx, now)
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{Notified: 1}, run)
+
+	run, err = service.DetectDormancy(ctx, now.AddDate(0, 0, 25))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{}, run, "the notice period has not run out yet")
+
+	run, err = service.DetectDormancy(ctx, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{Dormant: 1}, run)
+	assert.Equal(t, domain.AccountStatusDormant, repo.accounts[id].Status)
+	assert.Len(t, notifier.messages, 2)
+}
+
+func TestDetectDormancy_ActivityWithdrawsNotice(t *testing.T) {
+	service, repo, notifier := newDormancyService(t)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 1, 3, 0, 0, 0, time.UTC)
+
+	id := seedIdleAccount(t, repo, uuid.New(), now.AddDate(-1, -1, 0))
+	_, err := service.DetectDormancy(ctx, now)
+	require.NoError(t, err)
+
+	appendLedger(repo, id, now.AddDate(0, 0, 5), -500, "CARD")
+
+	run, err := service.DetectDormancy(ctx, now.AddDate(0, 0, 30))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{}, run)
+	assert.Equal(t, domain.AccountStatusActive, repo.accounts[id].Status)
+
+	// A year later the account is idle again and gets a fresh notice
+	run, err = service.DetectDormancy(ctx, now.AddDate(1, 0, 0))
+	require.NoError(t, err)
+	assert.Equal(t, DormancyRun{Notified: 1}, run)
+	assert.Len(t, notifier.messages, 2)
+}
+
+func TestAdjustBalance_DormantAccountOnlyTakesCredits(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+
+	id := seedAccount(t, repo, 10_000)
+	acc := repo.accounts[id]
+	acc.Status = domain.AccountStatusDormant
+	repo.accounts[id] = acc
+
+	_, err := service.AdjustBalance(ctx, id, -100, "DKK", "TEST", "debit")
+	assert.ErrorIs(t, err, domain.ErrAccountDormant)
+
+	updated, err := service.AdjustBalance(ctx, id, 500, "DKK", "TEST", "credit")
+	require.NoError(t, err)
+	assert.Equal(t, int64(10_500), updated.Balance)
+	assert.Equal(t, domain.AccountStatusDormant, updated.Status)
+}
+
+func TestReactivation_NeedsApprovedRequest(t *testing.T) {
+	service, repo, notifier := newDormancyService(t)
+	ctx := context.Background()
+	owner, employee := uuid.New(), uuid.New()
+
+	id := seedOwnedAccount(t, repo, owner, 10_000)
+	_, err := service.RequestReactivation(ctx, id, owner, "")
+	assert.ErrorIs(t, err, domain.ErrAccountNotDormant)
+
+	acc := repo.accounts[id]
+	since := time.Now().AddDate(0, -2, 0)
+	acc.Status = domain.AccountStatusDormant
+	acc.DormantSince = &since
+	repo.accounts[id] = acc
+
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusActive, "Customer called", employee)
+	assert.ErrorIs(t, err, domain.ErrReactivationRequired)
+
+	_, err = service.RequestReactivation(ctx, id, uuid.New(), "")
+	assert.ErrorIs(t, err, domain.ErrPermissionDenied)
+
+	req, err := service.RequestReactivation(ctx, id, owner, "Back from abroad")
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestKindReactivate, req.Kind)
+	_, err = service.RequestReactivation(ctx, id, owner, "")
+	assert.ErrorIs(t, err, domain.ErrRequestPending)
+
+	approved, err := service.UpdateRequestStatus(ctx, req.ID, domain.RequestStatusApproved, "", employee)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestStatusApproved, approved.Status)
+
+	acc = repo.accounts[id]
+	assert.Equal(t, domain.AccountStatusActive, acc.Status)
+	assert.Nil(t, acc.DormantSince)
+	require.NotEmpty(t, repo.ledger)
+	assert.Equal(t, domain.ReferenceReactivation, repo.ledger[len(repo.ledger)-1].Reference)
+	assert.Len(t, notifier.messages, 1)
+	require.Len(t, repo.history, 1)
+	assert.Equal(t, &employee, repo.history[0].ChangedBy)
+
+	_, err = service.AdjustBalance(ctx, id, -100, "DKK", "TEST", "debit")
+	assert.NoError(t, err)
+}
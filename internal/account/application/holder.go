@@ -0,0 +1,148 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// NewHolder describes a customer being added to an account. The permission
+// flags only apply to authorised users; joint owners get every permission.
+type NewHolder struct {
+	CustomerID          uuid.UUID
+	Relationship        domain.HolderRelationship
+	CanWithdraw         bool
+	CanTransfer         bool
+	CanViewBalance      bool
+	CanViewTransactions bool
+	AddedBy             *uuid.UUID
+}
+
+// AddHolder makes a customer a joint owner or authorised user of an account.
+// A holder that was removed earlier can be added again.
+func (s *AccountService) AddHolder(ctx context.Context, accountID uuid.UUID, req NewHolder) (*domain.AccountHolder, error) {
+	if req.Relationship != domain.RelationshipJointOwner && req.Relationship != domain.RelationshipAuthorizedUser {
+		return nil, domain.ErrInvalidRelationship
+	}
+
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, fmt.Errorf("%w: %s", domain.ErrAccountNotActive, account.Status)
+	}
+	if req.CustomerID == account.CustomerID {
+		return nil, domain.ErrHolderExists
+	}
+	if _, err := s.repo.GetHolder(ctx, accountID, req.CustomerID); err == nil {
+		return nil, domain.ErrHolderExists
+	} else if !errors.Is(err, domain.ErrHolderNotFound) {
+		return nil, err
+	}
+
+	holder := &domain.AccountHolder{
+		AccountID:           accountID,
+		CustomerID:          req.CustomerID,
+		RelationshipType:    req.Relationship,
+		CanWithdraw:         req.CanWithdraw,
+		CanTransfer:         req.CanTransfer,
+		CanViewBalance:      req.CanViewBalance,
+		CanViewTransactions: req.CanViewTransactions,
+		AddedAt:             time.Now(),
+		AddedBy:             req.AddedBy,
+	}
+	if holder.IsOwner() {
+		holder.GrantAll()
+	} else {
+		holder.SetPermissionLevel()
+	}
+
+	if err := s.repo.SaveHolder(ctx, holder); err != nil {
+		return nil, err
+	}
+
+	return holder, nil
+}
+
+// RemoveHolder takes a joint owner or authorised user off an account. The
+// primary owner stays for as long as the account exists.
+func (s *AccountService) RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID) error {
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if customerID == account.CustomerID {
+		return domain.ErrPrimaryOwner
+	}
+
+	return s.repo.RemoveHolder(ctx, accountID, customerID, time.Now())
+}
+
+// ListHolders returns everyone who currently holds the account, primary
+// owner first. Accounts opened before holders were recorded have no row for
+// their owner, so one is filled in from the account.
+func (s *AccountService) ListHolders(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountHolder, error) {
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	holders, err := s.repo.ListHolders(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, holder := range holders {
+		if holder.CustomerID == account.CustomerID {
+			holders[0], holders[i] = holders[i], holders[0]
+			return holders, nil
+		}
+	}
+
+	owner := primaryOwner(account)
+	owner.AddedAt = account.OpenedAt
+	return append([]*domain.AccountHolder{owner}, holders...), nil
+}
+
+// Authorize checks that a customer holds the account with the given
+// permission. Customers without any relationship to the account get the same
+// ErrPermissionDenied, so the answer does not reveal who holds it.
+func (s *AccountService) Authorize(ctx context.Context, accountID, customerID uuid.UUID, permission domain.HolderPermission) error {
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return err
+	}
+	if customerID == account.CustomerID {
+		return nil
+	}
+
+	holder, err := s.repo.GetHolder(ctx, accountID, customerID)
+	if errors.Is(err, domain.ErrHolderNotFound) {
+		return fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+	}
+	if err != nil {
+		return err
+	}
+	if !holder.Allows(permission) {
+		return fmt.Errorf("%w: %s", domain.ErrPermissionDenied, permission)
+	}
+
+	return nil
+}
+
+func primaryOwner(account *domain.Account) *domain.AccountHolder {
+	owner := &domain.AccountHolder{
+		AccountID:        account.ID,
+		CustomerID:       account.CustomerID,
+		RelationshipType: domain.RelationshipOwner,
+		AddedAt:          time.Now(),
+	}
+	owner.GrantAll()
+	return owner
+}
@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) SaveHolder(ctx context.Context, holder *domain.AccountHolder) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, h := range r.holders {
+		if h.AccountID == holder.AccountID && h.CustomerID == holder.CustomerID {
+			r.holders[i] = *holder
+			return nil
+		}
+	}
+	r.holders = append(r.holders, *holder)
+	return nil
+}
+
+func (r *memoryRepository) GetHolder(ctx context.Context, accountID, customerID uuid.UUID) (*domain.AccountHolder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, h := range r.holders {
+		if h.AccountID == accountID && h.CustomerID == customerID && h.RemovedAt == nil {
+			h := h
+			return &h, nil
+		}
+	}
+	return nil, domain.ErrHolderNotFound
+}
+
+func (r *memoryRepository) ListHolders(ctx context.Context, accountID uuid.UUID) ([]*domain.AccountHolder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.AccountHolder
+	for _, h := range r.holders {
+		if h.AccountID == accountID && h.RemovedAt == nil {
+			h := h
+			out = append(out, &h)
+		}
+	}
+	return out, nil
+}
+
+func (r *memoryRepository) ListHoldingsByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*domain.AccountHolder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.AccountHolder
+	for _, h := range r.holders {
+		if h.CustomerID == customerID && h.RemovedAt == nil {
+			h := h
+			out = append(out, &h)
+		}
+	}
+	return out, nil
+}
+
+func (r *memoryRepository) ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*domain.Account, error) {
+	holdings, _ := r.ListHoldingsByCustomerID(ctx, customerID)
+	held := make(map[uuid.UUID]bool)
+	for _, h := range holdings {
+		held[h.AccountID] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var out []*domain.Account
+	for _, acc := range r.accounts {
+		if acc.CustomerID == customerID || held[acc.ID] {
+			acc := acc
+			out = append(out, &acc)
+		}
+	}
+	return out, nil
+}
+
+func (r *memoryRepository) RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, h := range r.holders {
+		if h.AccountID == accountID && h.CustomerID == customerID && h.RemovedAt == nil {
+			r.holders[i].RemovedAt = &at
+			return nil
+		}
+	}
+	return domain.ErrHolderNotFound
+}
+
+func seedOwnedAccount(t *testing.T, repo *memoryRepository, owner uuid.UUID, balance int64) uuid.UUID {
+	t.Helper()
+	id := seedAccount(t, repo, balance)
+	acc := repo.accounts[id]
+	acc.CustomerID = owner
+	repo.accounts[id] = acc
+	return id
+}
+
+func TestAddHolder_JointOwnerGetsFullPermissions(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	owner, partner := uuid.New(), uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 10_000)
+
+	holder, err := service.AddHolder(context.Background(), id, NewHolder{
+		CustomerID:   partner,
+		Relationship: domain.RelationshipJointOwner,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "full", holder.PermissionLevel)
+
+	for _, p := range []domain.HolderPermission{domain.PermissionTransfer, domain.PermissionViewBalance, domain.PermissionManageHolders} {
+		assert.NoError(t, service.Authorize(context.Background(), id, partner, p), p)
+	}
+
+	_, err = service.AddHolder(context.Background(), id, NewHolder{CustomerID: partner, Relationship: domain.RelationshipJointOwner})
+	assert.ErrorIs(t, err, domain.ErrHolderExists)
+	_, err = service.AddHolder(context.Background(), id, NewHolder{CustomerID: owner, Relationship: domain.RelationshipJointOwner})
+	assert.ErrorIs(t, err, domain.ErrHolderExists)
+	_, err = service.AddHolder(context.Background(), id, NewHolder{CustomerID: uuid.New(), Relationship: domain.RelationshipOwner})
+	assert.ErrorIs(t, err, domain.ErrInvalidRelationship)
+}
+
+func TestAuthorize_AuthorisedUserFlags(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	owner, bookkeeper := uuid.New(), uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 10_000)
+
+	holder, err := service.AddHolder(context.Background(), id, NewHolder{
+		CustomerID:          bookkeeper,
+		Relationship:        domain.RelationshipAuthorizedUser,
+		CanViewBalance:      true,
+		CanViewTransactions: true,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "view_only", holder.PermissionLevel)
+
+	ctx := context.Background()
+	assert.NoError(t, service.Authorize(ctx, id, owner, domain.PermissionTransfer))
+	assert.NoError(t, service.Authorize(ctx, id, bookkeeper, domain.PermissionViewBalance))
+	assert.ErrorIs(t, service.Authorize(ctx, id, bookkeeper, domain.PermissionTransfer), domain.ErrPermissionDenied)
+	assert.ErrorIs(t, service.Authorize(ctx, id, bookkeeper, domain.PermissionManageHolders), domain.ErrPermissionDenied)
+	assert.ErrorIs(t, service.Authorize(ctx, id, uuid.New(), domain.PermissionViewBalance), domain.ErrPermissionDenied)
+}
+
+func TestRemoveHolder(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	owner, partner := uuid.New(), uuid.New()
+	id := seedOwnedAccount(t, repo, owner, 0)
+	ctx := context.Background()
+
+	_, err := service.AddHolder(ctx, id, NewHolder{CustomerID: partner, Relationship: domain.RelationshipJointOwner})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, service.RemoveHolder(ctx, id, owner), domain.ErrPrimaryOwner)
+	require.NoError(t, service.RemoveHolder(ctx, id, partner))
+	assert.ErrorIs(t, service.RemoveHolder(ctx, id, partner), domain.ErrHolderNotFound)
+	assert.ErrorIs(t, service.Authorize(ctx, id, partner, domain.PermissionViewBalance), domain.ErrPermissionDenied)
+
+	holders, err := service.ListHolders(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, holders, 1)
+	assert.Equal(t, owner, holders[0].CustomerID)
+	assert.Equal(t, domain.RelationshipOwner, holders[0].RelationshipType)
+}
+
+func TestListAccounts_IncludesHeldAccounts(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	customer, spouse, employer := uuid.New(), uuid.New(), uuid.New()
+	ctx := context.Background()
+
+	own := seedOwnedAccount(t, repo, customer, 1_000)
+	joint := seedOwnedAccount(t, repo, spouse, 2_000)
+	company := seedOwnedAccount(t, repo, employer, 3_000)
+	seedOwnedAccount(t, repo, spouse, 4_000) // not shared
+
+	_, err := service.AddHolder(ctx, joint, NewHolder{CustomerID: customer, Relationship: domain.RelationshipJointOwner})
+	require.NoError(t, err)
+	_, err = service.AddHolder(ctx, company, NewHolder{CustomerID: customer, Relationship: domain.RelationshipAuthorizedUser, CanTransfer: true})
+	require.NoError(t, err)
+
+	accounts, err := service.ListAccounts(ctx, customer)
+	require.NoError(t, err)
+
+	balances := make(map[uuid.UUID]int64)
+	for _, acc := range accounts {
+		balances[acc.ID] = acc.Balance
+	}
+	assert.Equal(t, map[uuid.UUID]int64{own: 1_000, joint: 2_000, company: 0}, balances)
+}
@@ -0,0 +1,334 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// InterestSettings is what an employee can configure on an interest-bearing
+// account.
+type InterestSettings struct {
+	RateBps            int64
+	DayCountConvention domain.DayCountConvention
+	Tiers              []domain.InterestTier
+}
+
+// SetInterestSettings replaces the flat rate, day-count convention and tiers
+// of a savings account. Already accrued interest is left untouched.
+func (s *AccountService) SetInterestSettings(ctx context.Context, id uuid.UUID, settings InterestSettings) (*domain.Account, error) {
+	if settings.DayCountConvention == "" {
+		settings.DayCountConvention = domain.DayCountAct365
+	}
+	if !settings.DayCountConvention.Valid() {
+		return nil, fmt.Errorf("unsupported day count convention: %s", settings.DayCountConvention)
+	}
+	if settings.RateBps < 0 {
+		return nil, fmt.Errorf("interest rate cannot be negative")
+	}
+	for _, tier := range settings.Tiers {
+		if tier.FromBalance < 0 || tier.RateBps < 0 {
+			return nil, fmt.Errorf("interest tiers cannot be negative")
+		}
+	}
+
+	var account *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if account.AccountType != domain.AccountTypeSavings {
+			return fmt.Errorf("interest can only be configured on savings accounts")
+		}
+
+		account.InterestRateBps = settings.RateBps
+		account.DayCountConvention = settings.DayCountConvention
+		if err := repo.Update(ctx, account); err != nil {
+			return err
+		}
+
+		tiers := make([]domain.InterestTier, len(settings.Tiers))
+		for i, tier := range settings.Tiers {
+			tiers[i] = domain.InterestTier{AccountID: id, FromBalance: tier.FromBalance, RateBps: tier.RateBps}
+		}
+		return repo.ReplaceInterestTiers(ctx, id, tiers)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// AccrueInterest records one day of interest on every open savings account.
+// Accounts that already have an accrual for the day are skipped, so the run
+// can safely be repeated. It returns the number of accounts accrued.
+func (s *AccountService) AccrueInterest(ctx context.Context, day time.Time) (int, error) {
+	day = startOfDay(day)
+
+	accounts, err := s.repo.ListByType(ctx, domain.AccountTypeSavings)
+	if err != nil {
+		return 0, err
+	}
+
+	accrued := 0
+	var errs []error
+	for _, candidate := range accounts {
+		if candidate.Status == domain.AccountStatusClosed {
+			continue
+		}
+
+		// One failing account must not hold up the others
+		done, err := s.accrueAccount(ctx, candidate.ID, day)
+		if err != nil {
+			err = fmt.Errorf("accrue interest for %s: %w", candidate.ID, err)
+			log.Print(err)
+			errs = append(errs, err)
+			continue
+		}
+		if done {
+			accrued++
+		}
+	}
+
+	return accrued, errors.Join(errs...)
+}
+
+func (s *AccountService) accrueAccount(ctx context.Context, id uuid.UUID, day time.Time) (bool, error) {
+	accrued := false
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		account, err := repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		exists, err := repo.HasInterestAccrual(ctx, id, day)
+		if err != nil || exists {
+			return err
+		}
+
+		// Interest for a day is earned on the balance valued by the end of
+		// that day, so a payment value-dated earlier earns from its value date.
+		balance, err := repo.GetBalanceAsOf(ctx, id, account.Currency, day, domain.BalanceBasisValue)
+		if err != nil {
+			return err
+		}
+		amount, err := dailyInterest(ctx, repo, account, balance, day)
+		if err != nil {
+			return err
+		}
+
+		if err := repo.CreateInterestAccrual(ctx, &domain.InterestAccrual{
+			AccountID:   id,
+			AccrualDate: day,
+			Balance:     balance,
+			Amount:      amount,
+		}); err != nil {
+			return err
+		}
+
+		account.InterestAccrued += amount
+		if account.LastInterestCalculationDate == nil || day.After(*account.LastInterestCalculationDate) {
+			account.LastInterestCalculationDate = &day
+		}
+		accrued = true
+		return repo.Update(ctx, account)
+	})
+
+	return accrued, err
+}
+
+// reaccrueInterest recalculates the interest already accrued, but not yet
+// capitalised, from valueDate on after a back-dated entry changed the value
+// balances of those days, and carries the difference in the account's accrued
+// interest. Days already capitalised are not reopened. It must run inside
+// WithTx after the entry is written.
+func reaccrueInterest(ctx context.Context, repo domain.AccountRepository, account *domain.Account, valueDate time.Time) error {
+	if account.AccountType != domain.AccountTypeSavings {
+		return nil
+	}
+
+	accruals, err := repo.ListUncapitalisedAccruals(ctx, account.ID, valueDate, startOfDay(time.Now()).AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+
+	var delta int64
+	for _, accrual := range accruals {
+		balance, err := repo.GetBalanceAsOf(ctx, account.ID, account.Currency, accrual.AccrualDate, domain.BalanceBasisValue)
+		if err != nil {
+			return err
+		}
+		if balance == accrual.Balance {
+			continue
+		}
+		amount, err := dailyInterest(ctx, repo, account, balance, accrual.AccrualDate)
+		if err != nil {
+			return err
+		}
+
+		delta += amount - accrual.Amount
+		accrual.Balance = balance
+		accrual.Amount = amount
+		if err := repo.UpdateInterestAccrual(ctx, accrual); err != nil {
+			return err
+		}
+	}
+	if delta == 0 {
+		return nil
+	}
+
+	account.InterestAccrued += delta
+	return repo.Update(ctx, account)
+}
+
+// dailyInterest is what balance earns on the account for day under its tiers,
+// or its flat rate when it has none.
+func dailyInterest(ctx context.Context, repo domain.AccountRepository, account *domain.Account, balance int64, day time.Time) (int64, error) {
+	tiers, err := repo.GetInterestTiers(ctx, account.ID)
+	if err != nil {
+		return 0, err
+	}
+	if len(tiers) == 0 {
+		tiers = []domain.InterestTier{{FromBalance: 0, RateBps: account.InterestRateBps}}
+	}
+
+	convention := account.DayCountConvention
+	if !convention.Valid() {
+		convention = domain.DayCountAct365
+	}
+	return domain.DailyInterest(balance, tiers, convention, day), nil
+}
+
+// CapitaliseInterest credits every savings account with the interest it
+// accrued during the month containing period, posting one ledger entry with
+// reference INTEREST. Accruals are linked to the entry they were paid in, so
+// a repeated run for the same month posts nothing new. It returns the number
+// of accounts credited.
+func (s *AccountService) CapitaliseInterest(ctx context.Context, period time.Time) (int, error) {
+	from := startOfMonth(period)
+	to := from.AddDate(0, 1, 0)
+
+	accounts, err := s.repo.ListByType(ctx, domain.AccountTypeSavings)
+	if err != nil {
+		return 0, err
+	}
+
+	credited := 0
+	var errs []error
+	for _, candidate := range accounts {
+		if candidate.Status == domain.AccountStatusClosed {
+			continue
+		}
+
+		done, err := s.capitaliseAccount(ctx, candidate.ID, from, to, fmt.Sprintf("Interest %s", from.Format("2006-01")))
+		if err != nil {
+			err = fmt.Errorf("capitalise interest for %s: %w", candidate.ID, err)
+			log.Print(err)
+			errs = append(errs, err)
+			continue
+		}
+		if done {
+			credited++
+		}
+	}
+
+	return credited, errors.Join(errs...)
+}
+
+func (s *AccountService) capitaliseAccount(ctx context.Context, id uuid.UUID, from, to time.Time, description string) (bool, error) {
+	credited := false
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		account, err := repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		accruals, err := repo.ListUncapitalisedAccruals(ctx, id, from, to)
+		if err != nil {
+			return err
+		}
+
+		var total int64
+		ids := make([]uuid.UUID, len(accruals))
+		for i, accrual := range accruals {
+			total += accrual.Amount
+			ids[i] = accrual.ID
+		}
+		if total <= 0 {
+			return nil
+		}
+
+		balanceBefore := account.Balance
+		account.Balance += total
+		account.AvailableBalance += total
+		account.InterestAccrued -= total
+		if err := repo.Update(ctx, account); err != nil {
+			return err
+		}
+
+		entry := &domain.LedgerEntry{
+			ID:            uuid.New(),
+			AccountID:     id,
+			EntryType:     domain.EntryTypeCredit,
+			Amount:        total,
+			Currency:      account.Currency,
+			BalanceBefore: balanceBefore,
+			BalanceAfter:  account.Balance,
+			Description:   description,
+			Reference:     domain.ReferenceInterest,
+		}
+		if err := repo.CreateLedgerEntry(ctx, entry); err != nil {
+			return err
+		}
+
+		credited = true
+		return repo.MarkAccrualsCapitalised(ctx, ids, entry.ID)
+	})
+
+	return credited, err
+}
+
+// RunInterestScheduler accrues yesterday's interest every interval and, on
+// the first day of a month, capitalises the previous month. Both steps are
+// idempotent, so running more often than daily is harmless.
+func (s *AccountService) RunInterestScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			today := startOfDay(now)
+			if _, err := s.AccrueInterest(ctx, today.AddDate(0, 0, -1)); err != nil {
+				log.Printf("interest scheduler: accrual: %v", err)
+				continue
+			}
+			if today.Day() == 1 {
+				if _, err := s.CapitaliseInterest(ctx, today.AddDate(0, -1, 0)); err != nil {
+					log.Printf("interest scheduler: capitalisation: %v", err)
+				}
+			}
+		}
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func startOfMonth(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
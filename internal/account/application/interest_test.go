@@ -0,0 +1,105 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterest_RerunsDoNotPostTwice(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedSaver(t, repo)
+
+	may := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		ID: uuid.New(), AccountID: id, EntryType: domain.EntryTypeCredit, Amount: 1_000_000, Currency: "DKK",
+		BalanceAfter: 1_000_000, EntryDate: may.AddDate(0, 0, -1), PostedDate: may.AddDate(0, 0, -1), ValueDate: may.AddDate(0, 0, -1),
+	})
+
+	for _, day := range []time.Time{may.AddDate(0, 0, 9), may.AddDate(0, 0, 10)} {
+		accrued, err := service.AccrueInterest(ctx, day.Add(15*time.Hour))
+		require.NoError(t, err)
+		assert.Equal(t, 1, accrued)
+
+		accrued, err = service.AccrueInterest(ctx, day)
+		require.NoError(t, err)
+		assert.Zero(t, accrued, "the day is already accrued")
+	}
+	require.Len(t, repo.accruals, 2)
+	assert.Equal(t, int64(2000), repo.accounts[id].InterestAccrued)
+
+	credited, err := service.CapitaliseInterest(ctx, may.AddDate(0, 0, 20))
+	require.NoError(t, err)
+	assert.Equal(t, 1, credited)
+	credited, err = service.CapitaliseInterest(ctx, may)
+	require.NoError(t, err)
+	assert.Zero(t, credited, "the month is already capitalised")
+
+	var interest []domain.LedgerEntry
+	for _, entry := range repo.ledger {
+		if entry.Reference == domain.ReferenceInterest {
+			interest = append(interest, entry)
+		}
+	}
+	require.Len(t, interest, 1)
+	assert.Equal(t, int64(2000), interest[0].Amount)
+	assert.Equal(t, "Interest 2026-05", interest[0].Description)
+	for _, accrual := range repo.accruals {
+		require.NotNil(t, accrual.LedgerEntryID)
+		assert.Equal(t, interest[0].ID, *accrual.LedgerEntryID)
+	}
+
+	acc := repo.accounts[id]
+	assert.Equal(t, int64(2000), acc.Balance)
+	assert.Zero(t, acc.InterestAccrued)
+
+	// A capitalised day is not accrued again either
+	accrued, err := service.AccrueInterest(ctx, may.AddDate(0, 0, 9))
+	require.NoError(t, err)
+	assert.Zero(t, accrued)
+	assert.Len(t, repo.accruals, 2)
+}
+
+// vanishedSaver lists a savings account that can no longer be loaded, ahead
+// of the real ones.
+type vanishedSaver struct {
+	*memoryRepository
+	id uuid.UUID
+}
+
+func (r vanishedSaver) ListByType(ctx context.Context, accountType domain.AccountType) ([]*domain.Account, error) {
+	accounts, err := r.memoryRepository.ListByType(ctx, accountType)
+	vanished := &domain.Account{ID: r.id, AccountType: accountType, Status: domain.AccountStatusActive}
+	return append([]*domain.Account{vanished}, accounts...), err
+}
+
+func TestInterest_OneFailingAccountDoesNotStopTheRun(t *testing.T) {
+	repo := newMemoryRepository()
+	broken := vanishedSaver{memoryRepository: repo, id: uuid.New()}
+	service := NewAccountService(broken)
+	ctx := context.Background()
+	id := seedSaver(t, repo)
+
+	may := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		ID: uuid.New(), AccountID: id, EntryType: domain.EntryTypeCredit, Amount: 1_000_000, Currency: "DKK",
+		BalanceAfter: 1_000_000, EntryDate: may.AddDate(0, 0, -1), PostedDate: may.AddDate(0, 0, -1), ValueDate: may.AddDate(0, 0, -1),
+	})
+
+	accrued, err := service.AccrueInterest(ctx, may.AddDate(0, 0, 9))
+	assert.ErrorContains(t, err, broken.id.String())
+	assert.Equal(t, 1, accrued, "the healthy account is still accrued")
+
+	credited, err := service.CapitaliseInterest(ctx, may)
+	assert.ErrorContains(t, err, broken.id.String())
+	assert.Equal(t, 1, credited)
+	assert.Equal(t, int64(1000), repo.accounts[id].Balance)
+}
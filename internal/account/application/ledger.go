@@ -0,0 +1,196 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/account/render"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultLedgerPageSize = 100
+	maxLedgerPageSize     = 1000
+)
+
+// LedgerQuery selects entries from an account's ledger. Cursor is the
+// NextCursor of the previous page, or empty for the first page.
+type LedgerQuery struct {
+	From      *time.Time // Inclusive
+	To        *time.Time // Exclusive
+	EntryType *domain.LedgerEntryType
+	MinAmount *int64 // Size of the entry, whichever way it went
+	MaxAmount *int64
+	Reference string // Case-insensitive prefix
+	Currency  string
+	Cursor    string
+	Limit     int
+}
+
+// LedgerPage is one page of ledger lines, newest first. NextCursor is empty
+// on the last page.
+type LedgerPage struct {
+	Lines      []*domain.LedgerLine
+	NextCursor string
+}
+
+// QueryLedger returns a page of the account's ledger with running balances.
+func (s *AccountService) QueryLedger(ctx context.Context, accountID uuid.UUID, query LedgerQuery) (*LedgerPage, error) {
+	filter, err := s.ledgerFilter(ctx, accountID, query)
+	if err != nil {
+		return nil, err
+	}
+	if query.Cursor != "" {
+		filter.Before, err = decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultLedgerPageSize
+	}
+	limit = min(limit, maxLedgerPageSize)
+	return s.ledgerPage(ctx, accountID, filter, limit)
+}
+
+// ExportLedgerCSV writes every entry matching the query to w as CSV, newest
+// first, a page at a time so large ledgers are never held in memory. The
+// query's Cursor and Limit are ignored. Balance columns are left empty when
+// showBalances is false.
+func (s *AccountService) ExportLedgerCSV(ctx context.Context, w io.Writer, accountID uuid.UUID, query LedgerQuery, showBalances bool) error {
+	filter, err := s.ledgerFilter(ctx, accountID, query)
+	if err != nil {
+		return err
+	}
+
+	out, err := render.NewLedgerCSV(w, showBalances)
+	if err != nil {
+		return err
+	}
+	for {
+		page, err := s.ledgerPage(ctx, accountID, filter, maxLedgerPageSize)
+		if err != nil {
+			return err
+		}
+		if err := out.Write(page.Lines); err != nil {
+			return err
+		}
+		if page.NextCursor == "" {
+			return nil
+		}
+		last := page.Lines[len(page.Lines)-1]
+		filter.Before = &domain.Cursor{At: last.EntryDate, ID: last.ID}
+	}
+}
+
+// ledgerFilter checks the query and the account it is for.
+func (s *AccountService) ledgerFilter(ctx context.Context, accountID uuid.UUID, query LedgerQuery) (domain.LedgerFilter, error) {
+	filter := domain.LedgerFilter{
+		From:      query.From,
+		To:        query.To,
+		EntryType: query.EntryType,
+		MinAmount: query.MinAmount,
+		MaxAmount: query.MaxAmount,
+		Reference: query.Reference,
+	}
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		return filter, domain.ErrInvalidPeriod
+	}
+	if query.EntryType != nil && !query.EntryType.Valid() {
+		return filter, fmt.Errorf("%w: unknown entry type %q", domain.ErrInvalidLedgerFilter, *query.EntryType)
+	}
+	if (query.MinAmount != nil && *query.MinAmount < 0) || (query.MaxAmount != nil && *query.MaxAmount < 0) {
+		return filter, fmt.Errorf("%w: amount bounds cannot be negative", domain.ErrInvalidLedgerFilter)
+	}
+	if query.MinAmount != nil && query.MaxAmount != nil && *query.MinAmount > *query.MaxAmount {
+		return filter, fmt.Errorf("%w: minimum amount is above the maximum", domain.ErrInvalidLedgerFilter)
+	}
+	if query.Currency != "" {
+		code, err := supportedCurrency(query.Currency)
+		if err != nil {
+			return filter, err
+		}
+		filter.Currency = code
+	}
+
+	if _, err := s.repo.GetByID(ctx, accountID); err != nil {
+		return filter, err
+	}
+	return filter, nil
+}
+
+func (s *AccountService) ledgerPage(ctx context.Context, accountID uuid.UUID, filter domain.LedgerFilter, limit int) (*LedgerPage, error) {
+	// One extra row tells us whether there is another page
+	filter.Limit = limit + 1
+	lines, err := s.repo.QueryLedger(ctx, accountID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &LedgerPage{Lines: lines}
+	if len(lines) > limit {
+		page.Lines = lines[:limit]
+		last := page.Lines[limit-1]
+		page.NextCursor = encodeCursor(domain.Cursor{At: last.EntryDate, ID: last.ID})
+	}
+	return page, nil
+}
+
+// HistoricalBalance is an account's balance in one currency at a point in
+// time.
+type HistoricalBalance struct {
+	AccountID uuid.UUID           `json:"account_id"`
+	Currency  string              `json:"currency"`
+	At        time.Time           `json:"at"`
+	Basis     domain.BalanceBasis `json:"basis"`
+	Balance   int64               `json:"balance"`
+}
+
+// GetBalanceAsOf rebuilds the account's balance at the given instant from its
+// ledger. The booking basis counts the entries made by then; the value basis
+// counts those valued on or before that day, so it moves when an entry is
+// back-dated. An empty currency means the account's own.
+func (s *AccountService) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, at time.Time, basis domain.BalanceBasis, code string) (*HistoricalBalance, error) {
+	if basis == "" {
+		basis = domain.BalanceBasisBooking
+	}
+	if !basis.Valid() {
+		return nil, domain.ErrInvalidBalanceBasis
+	}
+
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if code == "" {
+		code = account.Currency
+	}
+	code, err = supportedCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.repo.GetBalanceAsOf(ctx, accountID, code, at, basis)
+	if err != nil {
+		return nil, err
+	}
+	return &HistoricalBalance{AccountID: accountID, Currency: code, At: at, Basis: basis, Balance: balance}, nil
+}
+
+// AdjustBalanceValueDated is AdjustBalance for a posting whose money counts
+// from an earlier day, such as a payment received over the weekend or a
+// back-dated correction. The value date may not be in the future or before
+// the account was opened. Interest already accrued on the account's own
+// currency since the value date is recalculated.
+func (s *AccountService) AdjustBalanceValueDated(ctx context.Context, id uuid.UUID, adjustment int64, code string, valueDate time.Time, reference, description string) (*domain.Account, error) {
+	if valueDate.IsZero() {
+		return nil, domain.ErrInvalidValueDate
+	}
+	return s.Post(ctx, Posting{AccountID: id, Amount: adjustment, Currency: code, Reference: reference, Description: description, ValueDate: valueDate})
+}
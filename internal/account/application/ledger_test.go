@@ -0,0 +1,371 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) QueryLedger(ctx context.Context, accountID uuid.UUID, filter domain.LedgerFilter) ([]*domain.LedgerLine, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var lines []*domain.LedgerLine
+	for _, entry := range r.ledger {
+		if entry.AccountID == accountID {
+			lines = append(lines, &domain.LedgerLine{LedgerEntry: entry})
+		}
+	}
+	before := func(a, b *domain.LedgerLine) bool {
+		if !a.EntryDate.Equal(b.EntryDate) {
+			return a.EntryDate.Before(b.EntryDate)
+		}
+		return a.ID.String() < b.ID.String()
+	}
+	sort.Slice(lines, func(i, j int) bool { return before(lines[i], lines[j]) })
+
+	running := make(map[string]int64)
+	for _, line := range lines {
+		running[line.Currency] += line.Amount
+		line.RunningBalance = running[line.Currency]
+	}
+
+	var matched []*domain.LedgerLine
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		amount := max(line.Amount, -line.Amount)
+		switch {
+		case filter.From != nil && line.EntryDate.Before(*filter.From),
+			filter.To != nil && !line.EntryDate.Before(*filter.To),
+			filter.EntryType != nil && line.EntryType != *filter.EntryType,
+			filter.MinAmount != nil && amount < *filter.MinAmount,
+			filter.MaxAmount != nil && amount > *filter.MaxAmount,
+			!strings.HasPrefix(strings.ToLower(line.Reference), strings.ToLower(filter.Reference)),
+			filter.Currency != "" && line.Currency != filter.Currency,
+			filter.Before != nil && !before(line, &domain.LedgerLine{LedgerEntry: domain.LedgerEntry{ID: filter.Before.ID, EntryDate: filter.Before.At}}):
+			continue
+		}
+		matched = append(matched, line)
+		if len(matched) == filter.Limit {
+			break
+		}
+	}
+	return matched, nil
+}
+
+// bookEntry appends a DKK ledger entry that records balanceAfter, which is
+// normally the previous balance plus amount.
+func bookEntry(repo *memoryRepository, accountID uuid.UUID, at time.Time, amount, balanceAfter int64, reference string) {
+	entryType := domain.EntryTypeCredit
+	if amount < 0 {
+		entryType = domain.EntryTypeDebit
+	}
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		ID:           uuid.New(),
+		AccountID:    accountID,
+		EntryType:    entryType,
+		Amount:       amount,
+		Currency:     "DKK",
+		BalanceAfter: balanceAfter,
+		Reference:    reference,
+		EntryDate:    at,
+	})
+}
+
+func TestQueryLedger_FiltersAndRunningBalance(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+	day := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	bookEntry(repo, id, day, 100_000, 100_000, "SALARY")
+	bookEntry(repo, id, day.AddDate(0, 0, 1), -2_500, 97_500, "CARD-1")
+	bookEntry(repo, id, day.AddDate(0, 0, 2), -40_000, 57_500, "RENT")
+	// Recorded balance drifted from what the ledger adds up to
+	bookEntry(repo, id, day.AddDate(0, 0, 3), -1_000, 55_000, "card-2")
+
+	page, err := service.QueryLedger(ctx, id, LedgerQuery{})
+	require.NoError(t, err)
+	require.Len(t, page.Lines, 4)
+	assert.Equal(t, "card-2", page.Lines[0].Reference, "newest first")
+	assert.Equal(t, int64(56_500), page.Lines[0].RunningBalance)
+	assert.Equal(t, int64(55_000), page.Lines[0].BalanceAfter)
+
+	debit := domain.EntryTypeDebit
+	minAmount, maxAmount := int64(1_000), int64(10_000)
+	page, err = service.QueryLedger(ctx, id, LedgerQuery{EntryType: &debit, MinAmount: &minAmount, MaxAmount: &maxAmount, Reference: "CARD"})
+	require.NoError(t, err)
+	require.Len(t, page.Lines, 2)
+	assert.Equal(t, int64(97_500), page.Lines[1].RunningBalance, "running balance counts entries filtered out")
+
+	from, to := day.AddDate(0, 0, 1), day.AddDate(0, 0, 3)
+	page, err = service.QueryLedger(ctx, id, LedgerQuery{From: &from, To: &to})
+	require.NoError(t, err)
+	require.Len(t, page.Lines, 2)
+	assert.Equal(t, "RENT", page.Lines[0].Reference)
+
+	_, err = service.QueryLedger(ctx, id, LedgerQuery{MinAmount: &maxAmount, MaxAmount: &minAmount})
+	assert.ErrorIs(t, err, domain.ErrInvalidLedgerFilter)
+	_, err = service.QueryLedger(ctx, id, LedgerQuery{From: &to, To: &from})
+	assert.ErrorIs(t, err, domain.ErrInvalidPeriod)
+}
+
+func TestQueryLedger_KeysetPaging(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+	at := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+
+	var balance int64
+	for i := 0; i < 7; i++ {
+		balance += 100
+		// Pairs of entries share a timestamp so the ID breaks the tie
+		bookEntry(repo, id, at.Add(time.Duration(i/2)*time.Minute), 100, balance, "")
+	}
+
+	seen := make(map[uuid.UUID]bool)
+	last := balance + 1
+	query := LedgerQuery{Limit: 3}
+	for {
+		page, err := service.QueryLedger(ctx, id, query)
+		require.NoError(t, err)
+		for _, line := range page.Lines {
+			assert.False(t, seen[line.ID])
+			seen[line.ID] = true
+			assert.Less(t, line.RunningBalance, last)
+			last = line.RunningBalance
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	assert.Len(t, seen, 7)
+}
+
+func TestExportLedgerCSV(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedAccount(t, repo, 0)
+	day := time.Date(2026, 4, 1, 9, 0, 0, 0, time.UTC)
+	bookEntry(repo, id, day, 100_000, 100_000, "SALARY")
+	bookEntry(repo, id, day.AddDate(0, 0, 1), -2_500, 97_500, "CARD")
+
+	var buf bytes.Buffer
+	require.NoError(t, service.ExportLedgerCSV(context.Background(), &buf, id, LedgerQuery{}, true))
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, rows, 3)
+	assert.True(t, strings.HasPrefix(rows[0], "entry_id,entry_date"))
+	assert.Contains(t, rows[1], ",debit,-25.00,DKK,0.00,975.00,975.00,CARD,")
+	assert.Contains(t, rows[2], ",credit,1000.00,DKK,0.00,1000.00,1000.00,SALARY,")
+
+	invalid := domain.LedgerEntryType("refund")
+	buf.Reset()
+	err := service.ExportLedgerCSV(context.Background(), &buf, id, LedgerQuery{EntryType: &invalid}, true)
+	assert.ErrorIs(t, err, domain.ErrInvalidLedgerFilter)
+	assert.Zero(t, buf.Len(), "nothing is written before the filter is checked")
+}
+
+func (r *memoryRepository) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, currency string, at time.Time, basis domain.BalanceBasis) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return balanceAsOf(r.ledger, accountID, currency, at, basis), nil
+}
+
+func (t *memoryTx) GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, currency string, at time.Time, basis domain.BalanceBasis) (int64, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	ledger := append(append([]domain.LedgerEntry(nil), t.parent.ledger...), t.ledger...)
+	return balanceAsOf(ledger, accountID, currency, at, basis), nil
+}
+
+func balanceAsOf(ledger []domain.LedgerEntry, accountID uuid.UUID, currency string, at time.Time, basis domain.BalanceBasis) int64 {
+	var balance int64
+	for _, entry := range ledger {
+		entry = stamped(entry)
+		if entry.AccountID != accountID || entry.Currency != currency {
+			continue
+		}
+		if basis == domain.BalanceBasisValue && entry.ValueDate.After(startOfDay(at)) {
+			continue
+		}
+		if basis == domain.BalanceBasisBooking && entry.EntryDate.After(at) {
+			continue
+		}
+		balance += entry.Amount
+	}
+	return balance
+}
+
+func (r *memoryRepository) ListByType(ctx context.Context, accountType domain.AccountType) ([]*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var accounts []*domain.Account
+	for _, acc := range r.accounts {
+		if acc.AccountType == accountType {
+			acc := acc
+			accounts = append(accounts, &acc)
+		}
+	}
+	return accounts, nil
+}
+
+func (t *memoryTx) GetInterestTiers(ctx context.Context, accountID uuid.UUID) ([]domain.InterestTier, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	var tiers []domain.InterestTier
+	for _, tier := range t.parent.tiers {
+		if tier.AccountID == accountID {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers, nil
+}
+
+func (t *memoryTx) HasInterestAccrual(ctx context.Context, accountID uuid.UUID, day time.Time) (bool, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, a := range t.parent.accruals {
+		if a.AccountID == accountID && a.AccrualDate.Equal(day) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *memoryTx) CreateInterestAccrual(ctx context.Context, accrual *domain.InterestAccrual) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	accrual.ID = uuid.New()
+	t.parent.accruals = append(t.parent.accruals, *accrual)
+	return nil
+}
+
+func (t *memoryTx) UpdateInterestAccrual(ctx context.Context, accrual *domain.InterestAccrual) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for i := range t.parent.accruals {
+		if t.parent.accruals[i].ID == accrual.ID {
+			t.parent.accruals[i] = *accrual
+		}
+	}
+	return nil
+}
+
+// seedSaver opens a DKK savings account ten days ago earning 36.5% a year,
+// which is 1000 a day on 1,000,000 under ACT/365.
+func seedSaver(t *testing.T, repo *memoryRepository) uuid.UUID {
+	t.Helper()
+	acc := &domain.Account{
+		AccountType:        domain.AccountTypeSavings,
+		Currency:           "DKK",
+		Status:             domain.AccountStatusActive,
+		InterestRateBps:    3650,
+		DayCountConvention: domain.DayCountAct365,
+		OpenedAt:           time.Now().AddDate(0, 0, -10),
+	}
+	require.NoError(t, repo.Create(context.Background(), acc))
+	return acc.ID
+}
+
+func TestGetBalanceAsOf_BookingAndValueBasis(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedAccount(t, repo, 1500)
+
+	friday := time.Date(2026, 5, 8, 0, 0, 0, 0, time.UTC)
+	monday := friday.AddDate(0, 0, 3)
+	bookEntry(repo, id, friday.Add(-24*time.Hour), 1000, 1000, "")
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		ID: uuid.New(), AccountID: id, EntryType: domain.EntryTypeCredit, Amount: 500, Currency: "DKK",
+		BalanceAfter: 1500, EntryDate: monday.Add(9 * time.Hour), PostedDate: monday, ValueDate: friday,
+	})
+
+	saturday := friday.AddDate(0, 0, 1).Add(12 * time.Hour)
+	booked, err := service.GetBalanceAsOf(context.Background(), id, saturday, domain.BalanceBasisBooking, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), booked.Balance)
+	assert.Equal(t, "DKK", booked.Currency)
+
+	valued, err := service.GetBalanceAsOf(context.Background(), id, saturday, domain.BalanceBasisValue, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1500), valued.Balance)
+
+	_, err = service.GetBalanceAsOf(context.Background(), id, saturday, "settlement", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidBalanceBasis)
+}
+
+func TestAccrueInterest_EarnsFromValueDate(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedSaver(t, repo)
+
+	today := startOfDay(time.Now())
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		ID: uuid.New(), AccountID: id, EntryType: domain.EntryTypeCredit, Amount: 1_000_000, Currency: "DKK",
+		BalanceAfter: 1_000_000, EntryDate: time.Now(), PostedDate: today, ValueDate: today.AddDate(0, 0, -2),
+	})
+
+	accrued, err := service.AccrueInterest(context.Background(), today.AddDate(0, 0, -1))
+	require.NoError(t, err)
+	assert.Equal(t, 1, accrued)
+	require.Len(t, repo.accruals, 1)
+	assert.Equal(t, int64(1_000_000), repo.accruals[0].Balance)
+	assert.Equal(t, int64(1000), repo.accruals[0].Amount)
+}
+
+func TestAdjustBalanceValueDated_ReaccruesInterest(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedSaver(t, repo)
+
+	today := startOfDay(time.Now())
+	for days := 4; days >= 1; days-- {
+		_, err := service.AccrueInterest(context.Background(), today.AddDate(0, 0, -days))
+		require.NoError(t, err)
+	}
+	require.Len(t, repo.accruals, 4)
+
+	account, err := service.AdjustBalanceValueDated(context.Background(), id, 1_000_000, "DKK", today.AddDate(0, 0, -3), domain.ReferenceCorrection, "Missed deposit")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000_000), account.Balance)
+
+	entry := repo.ledger[len(repo.ledger)-1]
+	assert.Equal(t, today.AddDate(0, 0, -3), entry.ValueDate)
+	assert.Equal(t, today, entry.PostedDate)
+
+	// The day before the value date is untouched; the three after it now
+	// earn on the corrected balance.
+	for _, accrual := range repo.accruals {
+		if accrual.AccrualDate.Equal(today.AddDate(0, 0, -4)) {
+			assert.Zero(t, accrual.Amount)
+		} else {
+			assert.Equal(t, int64(1000), accrual.Amount, accrual.AccrualDate)
+		}
+	}
+	assert.Equal(t, int64(3000), repo.accounts[id].InterestAccrued)
+}
+
+func TestAdjustBalanceValueDated_RejectsDatesOutsideTheAccountsLife(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedSaver(t, repo)
+
+	_, err := service.AdjustBalanceValueDated(context.Background(), id, 100, "DKK", time.Now().AddDate(0, 0, 1), "", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidValueDate)
+
+	_, err = service.AdjustBalanceValueDated(context.Background(), id, 100, "DKK", time.Now().AddDate(0, 0, -11), "", "")
+	assert.ErrorIs(t, err, domain.ErrInvalidValueDate)
+	assert.Empty(t, repo.ledger)
+}
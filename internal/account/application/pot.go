@@ -0,0 +1,139 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// NewPot describes a pot to create. TargetAmount and TargetDate are optional.
+type NewPot struct {
+	Name         string
+	TargetAmount int64
+	TargetDate   *time.Time
+}
+
+// CreatePot adds an empty pot to the account.
+func (s *AccountService) CreatePot(ctx context.Context, accountID uuid.UUID, req NewPot) (*domain.Pot, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || req.TargetAmount < 0 {
+		return nil, domain.ErrInvalidPot
+	}
+	if req.TargetDate != nil && !startOfDay(*req.TargetDate).After(startOfDay(time.Now())) {
+		return nil, domain.ErrInvalidPot
+	}
+
+	account, err := s.repo.GetByID(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	if account.Status == domain.AccountStatusClosed {
+		return nil, domain.ErrAccountClosed
+	}
+
+	pot := &domain.Pot{
+		AccountID:    accountID,
+		Name:         name,
+		TargetAmount: req.TargetAmount,
+	}
+	if req.TargetDate != nil {
+		day := startOfDay(*req.TargetDate)
+		pot.TargetDate = &day
+	}
+	if err := s.repo.CreatePot(ctx, pot); err != nil {
+		return nil, err
+	}
+	return pot, nil
+}
+
+// ListPots returns the account's pots with their progress as of now.
+func (s *AccountService) ListPots(ctx context.Context, accountID uuid.UUID, now time.Time) ([]domain.PotProgress, error) {
+	pots, err := s.repo.ListPots(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+	progress := make([]domain.PotProgress, len(pots))
+	for i, pot := range pots {
+		progress[i] = pot.Progress(now)
+	}
+	return progress, nil
+}
+
+// FundPot moves amount from the account's available balance into the pot.
+// Only money the account actually has can be set aside, not overdraft.
+func (s *AccountService) FundPot(ctx context.Context, accountID, potID uuid.UUID, amount int64) (*domain.PotProgress, error) {
+	return s.movePot(ctx, accountID, potID, amount)
+}
+
+// WithdrawFromPot moves amount from the pot back to the account's available
+// balance.
+func (s *AccountService) WithdrawFromPot(ctx context.Context, accountID, potID uuid.UUID, amount int64) (*domain.PotProgress, error) {
+	return s.movePot(ctx, accountID, potID, -amount)
+}
+
+// movePot moves money between an account and its pot; positive amounts go
+// into the pot. The booked balance does not change, so no ledger entry is
+// made on the account.
+func (s *AccountService) movePot(ctx context.Context, accountID, potID uuid.UUID, amount int64) (*domain.PotProgress, error) {
+	if amount == 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	var pot *domain.Pot
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		account, err := repo.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+		switch account.Status {
+		case domain.AccountStatusActive:
+		case domain.AccountStatusDormant:
+			return domain.ErrAccountDormant
+		default:
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotActive, account.Status)
+		}
+
+		pot, err = repo.GetPotForUpdate(ctx, potID)
+		if err != nil {
+			return err
+		}
+		if pot.AccountID != accountID {
+			return domain.ErrPotNotFound
+		}
+
+		if amount > 0 && (amount > account.AvailableBalance || !account.CanDebit(amount)) {
+			return domain.ErrInsufficientFunds
+		}
+		if amount < 0 && -amount > pot.Balance {
+			return domain.ErrInsufficientFunds
+		}
+
+		account.SavedAmount += amount
+		account.AvailableBalance -= amount
+		if err := repo.Update(ctx, account); err != nil {
+			return err
+		}
+		pot.Balance += amount
+		if err := repo.UpdatePot(ctx, pot); err != nil {
+			return err
+		}
+
+		return repo.CreatePotEntry(ctx, &domain.PotEntry{
+			PotID:        pot.ID,
+			AccountID:    accountID,
+			Amount:       amount,
+			BalanceAfter: pot.Balance,
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	progress := pot.Progress(time.Now())
+	return &progress, nil
+}
@@ -0,0 +1,136 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) CreatePot(ctx context.Context, pot *domain.Pot) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	pot.ID = uuid.New()
+	r.pots = append(r.pots, *pot)
+	return nil
+}
+
+func (r *memoryRepository) ListPots(ctx context.Context, accountID uuid.UUID) ([]*domain.Pot, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var pots []*domain.Pot
+	for i := range r.pots {
+		if r.pots[i].AccountID == accountID {
+			pot := r.pots[i]
+			pots = append(pots, &pot)
+		}
+	}
+	return pots, nil
+}
+
+func (t *memoryTx) GetPotForUpdate(ctx context.Context, id uuid.UUID) (*domain.Pot, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, pot := range t.parent.pots {
+		if pot.ID == id {
+			return &pot, nil
+		}
+	}
+	return nil, domain.ErrPotNotFound
+}
+
+func (t *memoryTx) UpdatePot(ctx context.Context, pot *domain.Pot) error {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for i := range t.parent.pots {
+		if t.parent.pots[i].ID == pot.ID {
+			t.parent.pots[i] = *pot
+		}
+	}
+	return nil
+}
+
+func (t *memoryTx) CreatePotEntry(ctx context.Context, entry *domain.PotEntry) error {
+	return nil
+}
+
+func TestPots_MoveMoneyWithinTheAccount(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 10_000)
+
+	target := time.Now().AddDate(0, 6, 0)
+	pot, err := service.CreatePot(ctx, id, NewPot{Name: " Holiday ", TargetAmount: 20_000, TargetDate: &target})
+	require.NoError(t, err)
+	assert.Equal(t, "Holiday", pot.Name)
+
+	progress, err := service.FundPot(ctx, id, pot.ID, 8_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(8_000), progress.Pot.Balance)
+	assert.Equal(t, int64(40), progress.Percent)
+	assert.Equal(t, int64(12_000), progress.Remaining)
+
+	acc := repo.accounts[id]
+	assert.Equal(t, int64(10_000), acc.Balance, "pots count towards the account")
+	assert.Equal(t, int64(2_000), acc.AvailableBalance)
+	assert.Equal(t, int64(8_000), acc.SavedAmount)
+	assert.Empty(t, repo.ledger, "no booked movement")
+
+	_, err = service.AdjustBalance(ctx, id, -2_001, "DKK", "TEST", "spend the pot")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	_, err = service.WithdrawFromPot(ctx, id, pot.ID, 8_001)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	progress, err = service.WithdrawFromPot(ctx, id, pot.ID, 3_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(5_000), progress.Pot.Balance)
+
+	acc = repo.accounts[id]
+	assert.Equal(t, int64(5_000), acc.AvailableBalance)
+	assert.Equal(t, int64(5_000), acc.SavedAmount)
+
+	pots, err := service.ListPots(ctx, id, time.Now())
+	require.NoError(t, err)
+	require.Len(t, pots, 1)
+	assert.Equal(t, int64(25), pots[0].Percent)
+}
+
+func TestPots_Refusals(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+	other := seedAccount(t, repo, 1_000)
+
+	_, err := service.CreatePot(ctx, id, NewPot{Name: "  "})
+	assert.ErrorIs(t, err, domain.ErrInvalidPot)
+	yesterday := time.Now().AddDate(0, 0, -1)
+	_, err = service.CreatePot(ctx, id, NewPot{Name: "Tax", TargetDate: &yesterday})
+	assert.ErrorIs(t, err, domain.ErrInvalidPot)
+
+	pot, err := service.CreatePot(ctx, id, NewPot{Name: "Emergency"})
+	require.NoError(t, err)
+
+	// Overdraft cannot be set aside
+	acc := repo.accounts[id]
+	acc.OverdraftLimit = 5_000
+	repo.accounts[id] = acc
+	_, err = service.FundPot(ctx, id, pot.ID, 1_001)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	_, err = service.FundPot(ctx, other, pot.ID, 100)
+	assert.ErrorIs(t, err, domain.ErrPotNotFound)
+
+	progress, err := service.FundPot(ctx, id, pot.ID, 1_000)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), progress.Percent, "no target")
+
+	_, err = service.CloseAccount(ctx, id, Closure{Reason: "x"})
+	assert.ErrorIs(t, err, domain.ErrClosureBlocked)
+}
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/currency"
+
+	"github.com/google/uuid"
+)
+
+// CreateProduct adds a product to the catalogue. New products are offered
+// straight away.
+func (s *AccountService) CreateProduct(ctx context.Context, product *domain.Product) (*domain.Product, error) {
+	if err := normaliseProduct(product); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.GetProduct(ctx, product.Code)
+	if err == nil {
+		return nil, domain.ErrProductExists
+	}
+	if !errors.Is(err, domain.ErrProductNotFound) {
+		return nil, err
+	}
+
+	product.Active = true
+	if err := s.repo.CreateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+// UpdateProduct replaces the terms of a product. Accounts already opened from
+// it keep the terms they were opened with; setting Active to false retires the
+// product so no new accounts or requests can use it.
+func (s *AccountService) UpdateProduct(ctx context.Context, code string, product *domain.Product) (*domain.Product, error) {
+	existing, err := s.repo.GetProduct(ctx, strings.ToUpper(code))
+	if err != nil {
+		return nil, err
+	}
+
+	product.Code = existing.Code
+	if err := normaliseProduct(product); err != nil {
+		return nil, err
+	}
+	product.CreatedAt = existing.CreatedAt
+	product.UpdatedAt = time.Now()
+	if err := s.repo.UpdateProduct(ctx, product); err != nil {
+		return nil, err
+	}
+	return product, nil
+}
+
+func (s *AccountService) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	return s.repo.GetProduct(ctx, strings.ToUpper(code))
+}
+
+// ListProducts returns the catalogue ordered by code, with retired products
+// only when asked for.
+func (s *AccountService) ListProducts(ctx context.Context, includeRetired bool) ([]*domain.Product, error) {
+	return s.repo.ListProducts(ctx, includeRetired)
+}
+
+// DefaultProduct returns the offered product with the lowest code of the
+// given type and currency, for callers that predate the catalogue.
+func (s *AccountService) DefaultProduct(ctx context.Context, accType domain.AccountType, code string) (*domain.Product, error) {
+	if code == "" {
+		code = currency.Default
+	}
+	code = currency.Normalize(code)
+
+	products, err := s.repo.ListProducts(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+	for _, product := range products {
+		if product.AccountType == accType && product.Currency == code {
+			return product, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: no %s product is offered in %s", domain.ErrProductNotFound, accType, code)
+}
+
+// offeredProduct returns the product new accounts and requests may be opened
+// from.
+func offeredProduct(ctx context.Context, repo domain.AccountRepository, code string) (*domain.Product, error) {
+	product, err := repo.GetProduct(ctx, strings.ToUpper(strings.TrimSpace(code)))
+	if err != nil {
+		return nil, err
+	}
+	if !product.Active {
+		return nil, fmt.Errorf("%w: %s", domain.ErrProductRetired, product.Code)
+	}
+	return product, nil
+}
+
+// checkEligibility runs the product's eligibility rules against the customer.
+func checkEligibility(ctx context.Context, repo domain.AccountRepository, product *domain.Product, customerID uuid.UUID) error {
+	if !product.RequiresKYC && product.MinAge == 0 {
+		return nil
+	}
+	customer, err := repo.GetCustomerProfile(ctx, customerID)
+	if err != nil {
+		return err
+	}
+	return product.CheckEligibility(customer, time.Now())
+}
+
+// normaliseProduct fills in defaults and validates the product.
+func normaliseProduct(product *domain.Product) error {
+	product.Code = strings.ToUpper(strings.TrimSpace(product.Code))
+	product.Name = strings.TrimSpace(product.Name)
+	if product.DayCountConvention == "" {
+		product.DayCountConvention = domain.DayCountAct365
+	}
+	if err := product.Validate(); err != nil {
+		return err
+	}
+	code, err := supportedCurrency(product.Currency)
+	if err != nil {
+		return err
+	}
+	product.Currency = code
+	return nil
+}
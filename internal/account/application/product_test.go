@@ -0,0 +1,200 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) CreateProduct(ctx context.Context, product *domain.Product) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.products[product.Code] = *product
+	return nil
+}
+
+func (r *memoryRepository) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	product, ok := r.products[code]
+	if !ok {
+		return nil, domain.ErrProductNotFound
+	}
+	return &product, nil
+}
+
+func (r *memoryRepository) ListProducts(ctx context.Context, includeRetired bool) ([]*domain.Product, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var products []*domain.Product
+	for _, product := range r.products {
+		if product.Active || includeRetired {
+			products = append(products, &product)
+		}
+	}
+	sort.Slice(products, func(i, j int) bool { return products[i].Code < products[j].Code })
+	return products, nil
+}
+
+func (r *memoryRepository) UpdateProduct(ctx context.Context, product *domain.Product) error {
+	return r.CreateProduct(ctx, product)
+}
+
+func (r *memoryRepository) GetCustomerProfile(ctx context.Context, customerID uuid.UUID) (*domain.CustomerProfile, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	profile, ok := r.customers[customerID]
+	if !ok {
+		return nil, domain.ErrCustomerNotFound
+	}
+	return &profile, nil
+}
+
+func (r *memoryRepository) ReplaceInterestTiers(ctx context.Context, accountID uuid.UUID, tiers []domain.InterestTier) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.tiers = append(r.tiers, tiers...)
+	return nil
+}
+
+func (t *memoryTx) GetProduct(ctx context.Context, code string) (*domain.Product, error) {
+	return t.parent.GetProduct(ctx, code)
+}
+
+func (t *memoryTx) GetCustomerProfile(ctx context.Context, customerID uuid.UUID) (*domain.CustomerProfile, error) {
+	return t.parent.GetCustomerProfile(ctx, customerID)
+}
+
+func (t *memoryTx) Create(ctx context.Context, account *domain.Account) error {
+	account.ID = uuid.New()
+	t.accounts[account.ID] = *account
+	return nil
+}
+
+func (t *memoryTx) SaveHolder(ctx context.Context, holder *domain.AccountHolder) error {
+	return t.parent.SaveHolder(ctx, holder)
+}
+
+func (t *memoryTx) ReplaceInterestTiers(ctx context.Context, accountID uuid.UUID, tiers []domain.InterestTier) error {
+	return t.parent.ReplaceInterestTiers(ctx, accountID, tiers)
+}
+
+func seedCustomer(repo *memoryRepository, age int, kyc string) uuid.UUID {
+	id := uuid.New()
+	repo.customers[id] = domain.CustomerProfile{
+		ID:          id,
+		DateOfBirth: time.Now().AddDate(-age, 0, -1),
+		KycStatus:   kyc,
+	}
+	return id
+}
+
+func bonusSaver() *domain.Product {
+	return &domain.Product{
+		Code:            "bonus-saver",
+		Name:            "Bonus Saver",
+		AccountType:     domain.AccountTypeSavings,
+		Currency:        "eur",
+		InterestRateBps: 100,
+		InterestTiers:   []domain.ProductTier{{FromBalance: 0, RateBps: 100}, {FromBalance: 5_000_000, RateBps: 250}},
+		MinAge:          18,
+		RequiresKYC:     true,
+	}
+}
+
+func TestCreateAccount_AppliesProductTerms(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+
+	product, err := service.CreateProduct(ctx, bonusSaver())
+	require.NoError(t, err)
+	assert.Equal(t, "BONUS-SAVER", product.Code)
+	assert.Equal(t, "EUR", product.Currency)
+	assert.Equal(t, domain.DayCountAct365, product.DayCountConvention)
+	assert.True(t, product.Active)
+
+	_, err = service.CreateProduct(ctx, bonusSaver())
+	assert.ErrorIs(t, err, domain.ErrProductExists)
+
+	customer := seedCustomer(repo, 30, domain.KycVerified)
+	account, err := service.CreateAccount(ctx, customer, "", "bonus-saver")
+	require.NoError(t, err)
+	assert.Equal(t, "Bonus Saver", account.AccountName)
+	assert.Equal(t, domain.AccountTypeSavings, account.AccountType)
+	assert.Equal(t, "EUR", account.Currency)
+	assert.Equal(t, "BONUS-SAVER", account.ProductCode)
+	assert.Equal(t, int64(100), account.InterestRateBps)
+	require.Len(t, repo.tiers, 2)
+	assert.Equal(t, account.ID, repo.tiers[1].AccountID)
+	assert.Equal(t, int64(250), repo.tiers[1].RateBps)
+
+	_, err = service.SetOverdraftLimit(ctx, account.ID, 10_000)
+	assert.ErrorIs(t, err, domain.ErrOverdraftNotAllowed)
+}
+
+func TestCreateAccount_ChecksEligibility(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	_, err := service.CreateProduct(ctx, bonusSaver())
+	require.NoError(t, err)
+
+	_, err = service.CreateAccount(ctx, seedCustomer(repo, 30, "pending"), "", "BONUS-SAVER")
+	assert.ErrorIs(t, err, domain.ErrNotEligible)
+	_, err = service.CreateAccount(ctx, seedCustomer(repo, 17, domain.KycVerified), "", "BONUS-SAVER")
+	assert.ErrorIs(t, err, domain.ErrNotEligible)
+	_, err = service.CreateAccount(ctx, uuid.New(), "", "BONUS-SAVER")
+	assert.ErrorIs(t, err, domain.ErrCustomerNotFound)
+	_, err = service.CreateAccount(ctx, seedCustomer(repo, 30, domain.KycVerified), "", "NO-SUCH")
+	assert.ErrorIs(t, err, domain.ErrProductNotFound)
+
+	retired := bonusSaver()
+	retired.Active = false
+	_, err = service.UpdateProduct(ctx, "bonus-saver", retired)
+	require.NoError(t, err)
+	_, err = service.CreateAccount(ctx, seedCustomer(repo, 30, domain.KycVerified), "", "BONUS-SAVER")
+	assert.ErrorIs(t, err, domain.ErrProductRetired)
+	assert.Empty(t, repo.accounts)
+}
+
+func TestApproveRequest_RunsEligibilityFirst(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	employee := uuid.New()
+	_, err := service.CreateProduct(ctx, bonusSaver())
+	require.NoError(t, err)
+
+	customer := seedCustomer(repo, 30, "in_review")
+	req, err := service.CreateRequest(ctx, customer, "bonus-saver", "For the holidays")
+	require.NoError(t, err)
+	assert.Equal(t, "BONUS-SAVER", req.ProductCode)
+	assert.Equal(t, domain.AccountTypeSavings, req.RequestedType)
+
+	_, err = service.UpdateRequestStatus(ctx, req.ID, domain.RequestStatusApproved, "", employee)
+	assert.ErrorIs(t, err, domain.ErrNotEligible)
+	assert.Equal(t, domain.RequestStatusPending, repo.requests[0].Status)
+	assert.Empty(t, repo.accounts)
+
+	profile := repo.customers[customer]
+	profile.KycStatus = domain.KycVerified
+	repo.customers[customer] = profile
+
+	approved, err := service.UpdateRequestStatus(ctx, req.ID, domain.RequestStatusApproved, "", employee)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestStatusApproved, approved.Status)
+	require.Len(t, repo.accounts, 1)
+	require.NotNil(t, approved.AccountID)
+	account := repo.accounts[*approved.AccountID]
+	assert.Equal(t, customer, account.CustomerID)
+	assert.Equal(t, "BONUS-SAVER", account.ProductCode)
+	assert.Equal(t, approved.AccountID, repo.requests[0].AccountID)
+}
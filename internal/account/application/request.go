@@ -0,0 +1,189 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/currency"
+
+	"github.com/google/uuid"
+)
+
+// DefaultRequestSLA is how long the bank aims to take to decide a request.
+const DefaultRequestSLA = 48 * time.Hour
+
+const (
+	defaultRequestPageSize = 50
+	maxRequestPageSize     = 200
+)
+
+// SetRequestSLA replaces the default time the bank aims to decide requests
+// in.
+func (s *AccountService) SetRequestSLA(sla time.Duration) error {
+	if sla <= 0 {
+		return fmt.Errorf("request SLA must be positive")
+	}
+	s.requestSLA = sla
+	return nil
+}
+
+// CreateRequest asks for an account of a catalogue product to be opened.
+// Eligibility is checked when an employee approves the request, since the
+// customer's KYC may be completed in the meantime.
+func (s *AccountService) CreateRequest(ctx context.Context, customerID uuid.UUID, productCode, reason string) (*domain.AccountRequest, error) {
+	product, err := offeredProduct(ctx, s.repo, productCode)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &domain.AccountRequest{
+		CustomerID:    customerID,
+		Kind:          domain.RequestKindOpenAccount,
+		RequestedType: product.AccountType,
+		ProductCode:   product.Code,
+		Status:        domain.RequestStatusPending,
+		Reason:        reason,
+	}
+
+	if err := s.repo.CreateRequest(ctx, req); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// RequestQuery selects a page of requests. Cursor is the NextCursor of the
+// previous page, or empty for the first page.
+type RequestQuery struct {
+	Status        *domain.RequestStatus
+	CustomerID    *uuid.UUID
+	RequestedType *domain.AccountType
+	Cursor        string
+	Limit         int
+}
+
+// RequestPage is one page of requests, oldest first, with their SLA aging.
+// NextCursor is empty on the last page.
+type RequestPage struct {
+	Requests   []domain.RequestAging
+	NextCursor string
+}
+
+// ListRequests returns a page of requests measured against the request SLA
+// as of now.
+func (s *AccountService) ListRequests(ctx context.Context, query RequestQuery, now time.Time) (*RequestPage, error) {
+	filter := domain.RequestFilter{
+		Status:        query.Status,
+		CustomerID:    query.CustomerID,
+		RequestedType: query.RequestedType,
+	}
+	if query.Cursor != "" {
+		after, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = after
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultRequestPageSize
+	}
+	limit = min(limit, maxRequestPageSize)
+	// One extra row tells us whether there is another page
+	filter.Limit = limit + 1
+
+	requests, err := s.repo.ListRequests(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &RequestPage{}
+	if len(requests) > limit {
+		requests = requests[:limit]
+		last := requests[limit-1]
+		page.NextCursor = encodeCursor(domain.Cursor{At: last.CreatedAt, ID: last.ID})
+	}
+	page.Requests = make([]domain.RequestAging, len(requests))
+	for i, req := range requests {
+		page.Requests[i] = req.Aging(s.requestSLA, now)
+	}
+	return page, nil
+}
+
+// UpdateRequestStatus approves or rejects a pending request. Approving carries
+// the request out in the same transaction that marks it approved, so a
+// request that cannot be carried out, such as a reactivation of an account
+// that is no longer dormant or an account the customer is not eligible for,
+// stays pending. Rejections need a reason the customer can see.
+func (s *AccountService) UpdateRequestStatus(ctx context.Context, id uuid.UUID, status domain.RequestStatus, rejectionReason string, processedBy uuid.UUID) (*domain.AccountRequest, error) {
+	if !status.Decided() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidRequestStatus, status)
+	}
+	rejectionReason = strings.TrimSpace(rejectionReason)
+	if status == domain.RequestStatusRejected && rejectionReason == "" {
+		return nil, domain.ErrRejectionReasonRequired
+	}
+
+	var req *domain.AccountRequest
+	var reactivated *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		req, err = repo.GetRequestByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if req.Status != domain.RequestStatusPending {
+			return fmt.Errorf("%w: %s", domain.ErrRequestProcessed, req.Status)
+		}
+
+		switch {
+		case status == domain.RequestStatusRejected:
+			req.RejectionReason = rejectionReason
+		case req.Kind == domain.RequestKindReactivate:
+			reactivated, err = reactivate(ctx, repo, *req.AccountID, processedBy)
+			if err != nil {
+				return err
+			}
+		default:
+			account, err := s.openRequestedAccount(ctx, repo, req)
+			if err != nil {
+				return err
+			}
+			req.AccountID = &account.ID
+		}
+
+		now := time.Now()
+		req.Status = status
+		req.ProcessedAt = &now
+		req.ProcessedBy = &processedBy
+		return repo.UpdateRequest(ctx, req)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if reactivated != nil {
+		s.notifyReactivated(ctx, reactivated)
+	}
+	return req, nil
+}
+
+// openRequestedAccount opens the account an approved request asks for.
+// Requests made before the catalogue carry only an account type and are
+// opened from the default product of that type.
+func (s *AccountService) openRequestedAccount(ctx context.Context, repo domain.AccountRepository, req *domain.AccountRequest) (*domain.Account, error) {
+	code := req.ProductCode
+	if code == "" {
+		product, err := s.DefaultProduct(ctx, req.RequestedType, currency.Default)
+		if err != nil {
+			return nil, err
+		}
+		code = product.Code
+	}
+
+	return s.openAccount(ctx, repo, req.CustomerID, "", code)
+}
@@ -0,0 +1,122 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListRequests(ctx context.Context, filter domain.RequestFilter) ([]*domain.AccountRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var requests []*domain.AccountRequest
+	for _, req := range r.requests {
+		switch {
+		case filter.Status != nil && req.Status != *filter.Status,
+			filter.CustomerID != nil && req.CustomerID != *filter.CustomerID,
+			filter.RequestedType != nil && req.RequestedType != *filter.RequestedType:
+			continue
+		}
+		if after := filter.After; after != nil {
+			if req.CreatedAt.Before(after.At) ||
+				req.CreatedAt.Equal(after.At) && req.ID.String() <= after.ID.String() {
+				continue
+			}
+		}
+		req := req
+		requests = append(requests, &req)
+		if len(requests) == filter.Limit {
+			break
+		}
+	}
+	return requests, nil
+}
+
+// seedRequest adds a pending request made at createdAt. Requests must be
+// seeded oldest first.
+func seedRequest(repo *memoryRepository, customerID uuid.UUID, accType domain.AccountType, createdAt time.Time) uuid.UUID {
+	req := domain.AccountRequest{
+		ID:            uuid.New(),
+		CustomerID:    customerID,
+		Kind:          domain.RequestKindOpenAccount,
+		RequestedType: accType,
+		Status:        domain.RequestStatusPending,
+		CreatedAt:     createdAt,
+	}
+	repo.requests = append(repo.requests, req)
+	return req.ID
+}
+
+func TestRejectRequest_NeedsReasonForCustomer(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	employee := uuid.New()
+	id := seedRequest(repo, uuid.New(), domain.AccountTypeSavings, time.Now())
+
+	_, err := service.UpdateRequestStatus(ctx, id, domain.RequestStatusPending, "", employee)
+	assert.ErrorIs(t, err, domain.ErrInvalidRequestStatus)
+	_, err = service.UpdateRequestStatus(ctx, id, domain.RequestStatusRejected, "  ", employee)
+	assert.ErrorIs(t, err, domain.ErrRejectionReasonRequired)
+	_, err = service.UpdateRequestStatus(ctx, uuid.New(), domain.RequestStatusRejected, "No", employee)
+	assert.ErrorIs(t, err, domain.ErrRequestNotFound)
+
+	rejected, err := service.UpdateRequestStatus(ctx, id, domain.RequestStatusRejected, "We could not verify your income", employee)
+	require.NoError(t, err)
+	assert.Equal(t, domain.RequestStatusRejected, rejected.Status)
+	assert.Equal(t, "We could not verify your income", repo.requests[0].RejectionReason)
+	assert.Equal(t, &employee, repo.requests[0].ProcessedBy)
+	assert.Nil(t, repo.requests[0].AccountID)
+	assert.Empty(t, repo.accounts)
+
+	_, err = service.UpdateRequestStatus(ctx, id, domain.RequestStatusApproved, "", employee)
+	assert.ErrorIs(t, err, domain.ErrRequestProcessed)
+}
+
+func TestListRequests_PagesAndFilters(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	now := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	alice, bob := uuid.New(), uuid.New()
+
+	oldest := seedRequest(repo, alice, domain.AccountTypeSavings, now.Add(-72*time.Hour))
+	seedRequest(repo, bob, domain.AccountTypeChecking, now.Add(-40*time.Hour))
+	seedRequest(repo, alice, domain.AccountTypeChecking, now.Add(-10*time.Hour))
+	newest := seedRequest(repo, alice, domain.AccountTypeSavings, now.Add(-time.Hour))
+
+	var seen []uuid.UUID
+	query := RequestQuery{Limit: 3}
+	for {
+		page, err := service.ListRequests(ctx, query, now)
+		require.NoError(t, err)
+		for _, aging := range page.Requests {
+			seen = append(seen, aging.Request.ID)
+		}
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	require.Len(t, seen, 4)
+	assert.Equal(t, oldest, seen[0])
+	assert.Equal(t, newest, seen[3])
+
+	savings := domain.AccountTypeSavings
+	page, err := service.ListRequests(ctx, RequestQuery{CustomerID: &alice, RequestedType: &savings}, now)
+	require.NoError(t, err)
+	require.Len(t, page.Requests, 2)
+	assert.Empty(t, page.NextCursor)
+	assert.Equal(t, domain.SLABreached, page.Requests[0].State)
+	assert.Equal(t, 72*time.Hour, page.Requests[0].Age)
+	assert.Equal(t, domain.SLAOnTrack, page.Requests[1].State)
+
+	_, err = service.ListRequests(ctx, RequestQuery{Cursor: "not-a-cursor"}, now)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
@@ -0,0 +1,292 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// DefaultReservationTTL is used when a caller does not ask for a specific hold
+// lifetime.
+const DefaultReservationTTL = 15 * time.Minute
+
+const expirySweepBatchSize = 100
+
+// ReserveFunds places a hold of amount on the account's balance in the given
+// currency for the transaction. The held amount stays in the balance but is
+// no longer available for other debits or reservations. A hold for a named
+// leg of the transaction is placed once; asking again returns it as it
+// stands, whatever its status.
+func (s *AccountService) ReserveFunds(ctx context.Context, accountID, transactionID uuid.UUID, leg string, amount int64, code string, ttl time.Duration) (*domain.FundReservation, error) {
+	if amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+	code, err := supportedCurrency(code)
+	if err != nil {
+		return nil, err
+	}
+	if ttl <= 0 {
+		ttl = DefaultReservationTTL
+	}
+
+	var reservation *domain.FundReservation
+	err = s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		account, err := repo.GetByIDForUpdate(ctx, accountID)
+		if err != nil {
+			return err
+		}
+
+		if leg != "" {
+			reservation, err = repo.GetReservationByLeg(ctx, transactionID, leg)
+			if err == nil || !errors.Is(err, domain.ErrReservationNotFound) {
+				return err
+			}
+		}
+
+		if account.Status == domain.AccountStatusDormant {
+			return domain.ErrAccountDormant
+		}
+		if account.Status != domain.AccountStatusActive {
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotActive, account.Status)
+		}
+		funds, err := lockFunds(ctx, repo, account, code)
+		if err != nil {
+			return err
+		}
+		if !funds.canDebit(amount) {
+			return domain.ErrInsufficientFunds
+		}
+
+		funds.move(0, amount)
+		if err := funds.save(ctx, repo); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		reservation = &domain.FundReservation{
+			AccountID:     accountID,
+			TransactionID: transactionID,
+			Amount:        amount,
+			Currency:      funds.currency(),
+			ReservedAt:    now,
+			ExpiresAt:     now.Add(ttl),
+			Status:        domain.ReservationStatusActive,
+			Leg:           leg,
+		}
+		return repo.CreateReservation(ctx, reservation)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// ReleaseReservation cancels an active hold and returns the amount to the
+// available balance. Releasing a hold that was already released or has
+// expired changes nothing; a captured hold cannot be released.
+func (s *AccountService) ReleaseReservation(ctx context.Context, id uuid.UUID, reason string) (*domain.FundReservation, error) {
+	var reservation *domain.FundReservation
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		reservation, err = repo.GetReservationByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if reservation.Status == domain.ReservationStatusReleased || reservation.Status == domain.ReservationStatusExpired {
+			return nil
+		}
+		return releaseReservation(ctx, repo, reservation, domain.ReservationStatusReleased, reason)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// CaptureReservation turns a hold into a real debit in the hold's currency.
+// amount may be lower than the held amount (a partial capture); the remainder
+// is released. An amount of zero captures the full hold. Capturing a hold that
+// was already captured changes nothing, so a lost response can be retried.
+// The returned account carries its sub-balances.
+func (s *AccountService) CaptureReservation(ctx context.Context, id uuid.UUID, amount int64, reference, description string) (*domain.FundReservation, *domain.Account, error) {
+	if amount < 0 {
+		return nil, nil, domain.ErrInvalidAmount
+	}
+
+	var (
+		reservation *domain.FundReservation
+		account     *domain.Account
+	)
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		reservation, err = repo.GetReservationByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if reservation.Status == domain.ReservationStatusConsumed {
+			account, err = repo.GetByIDForUpdate(ctx, reservation.AccountID)
+			return err
+		}
+		if reservation.Status != domain.ReservationStatusActive {
+			return fmt.Errorf("%w: %s", domain.ErrReservationNotActive, reservation.Status)
+		}
+		if time.Now().After(reservation.ExpiresAt) {
+			return domain.ErrReservationExpired
+		}
+		if amount == 0 {
+			amount = reservation.Amount
+		}
+		if amount > reservation.Amount {
+			return domain.ErrCaptureExceedsHold
+		}
+
+		account, err = repo.GetByIDForUpdate(ctx, reservation.AccountID)
+		if err != nil {
+			return err
+		}
+
+		funds, err := lockFunds(ctx, repo, account, reservation.Currency)
+		if err != nil {
+			return err
+		}
+
+		// Drop the whole hold, then debit the captured part. Any uncaptured
+		// remainder flows back into the available balance.
+		balanceBefore := funds.balance()
+		funds.move(-amount, -reservation.Amount)
+		if err := funds.save(ctx, repo); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		reservation.Status = domain.ReservationStatusConsumed
+		reservation.ReleasedAt = &now
+		reservation.ReleaseReason = "captured"
+		if err := repo.UpdateReservation(ctx, reservation); err != nil {
+			return err
+		}
+
+		return repo.CreateLedgerEntry(ctx, &domain.LedgerEntry{
+			AccountID:     account.ID,
+			TransactionID: &reservation.TransactionID,
+			Leg:           reservation.Leg,
+			EntryType:     domain.EntryTypeDebit,
+			Amount:        -amount,
+			Currency:      funds.currency(),
+			BalanceBefore: balanceBefore,
+			BalanceAfter:  funds.balance(),
+			Description:   description,
+			Reference:     reference,
+		})
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	account, err = s.withSubBalances(ctx, account)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reservation, account, nil
+}
+
+// ExpireReservations releases every active hold whose expiry is before now,
+// mirroring account.expire_fund_reservations(). It returns how many holds
+// were expired.
+func (s *AccountService) ExpireReservations(ctx context.Context, now time.Time) (int, error) {
+	expired := 0
+	for {
+		batch, err := s.repo.ListExpiredReservations(ctx, now, expirySweepBatchSize)
+		if err != nil {
+			return expired, err
+		}
+		if len(batch) == 0 {
+			return expired, nil
+		}
+
+		for _, candidate := range batch {
+			released := false
+			err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+				reservation, err := repo.GetReservationByIDForUpdate(ctx, candidate.ID)
+				if err != nil {
+					return err
+				}
+				// Captured or released since we listed it.
+				if reservation.Status != domain.ReservationStatusActive {
+					return nil
+				}
+				released = true
+				return releaseReservation(ctx, repo, reservation, domain.ReservationStatusExpired, "automatic_expiration")
+			})
+			if err != nil {
+				return expired, fmt.Errorf("expire reservation %s: %w", candidate.ID, err)
+			}
+			if released {
+				expired++
+			}
+		}
+
+		if len(batch) < expirySweepBatchSize {
+			return expired, nil
+		}
+	}
+}
+
+// RunReservationSweeper expires stale holds every interval until ctx is
+// cancelled.
+func (s *AccountService) RunReservationSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := s.ExpireReservations(ctx, now)
+			if err != nil {
+				log.Printf("reservation sweeper: %v", err)
+			}
+			if n > 0 {
+				log.Printf("reservation sweeper: expired %d holds", n)
+			}
+		}
+	}
+}
+
+// releaseReservation gives a locked, active reservation's amount back to its
+// account. It must run inside WithTx.
+func releaseReservation(ctx context.Context, repo domain.AccountRepository, reservation *domain.FundReservation, status domain.ReservationStatus, reason string) error {
+	if reservation.Status != domain.ReservationStatusActive {
+		return fmt.Errorf("%w: %s", domain.ErrReservationNotActive, reservation.Status)
+	}
+
+	account, err := repo.GetByIDForUpdate(ctx, reservation.AccountID)
+	if err != nil {
+		return err
+	}
+
+	funds, err := lockFunds(ctx, repo, account, reservation.Currency)
+	if err != nil {
+		return err
+	}
+	funds.move(0, -reservation.Amount)
+	if err := funds.save(ctx, repo); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	reservation.Status = status
+	reservation.ReleasedAt = &now
+	reservation.ReleaseReason = reason
+	return repo.UpdateReservation(ctx, reservation)
+}
@@ -0,0 +1,222 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*domain.FundReservation, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var expired []*domain.FundReservation
+	for _, res := range r.reservations {
+		if res.Status == domain.ReservationStatusActive && res.ExpiresAt.Before(now) && len(expired) < limit {
+			expired = append(expired, &res)
+		}
+	}
+	return expired, nil
+}
+
+// backdate moves a hold's expiry into the past.
+func (r *memoryRepository) backdate(id uuid.UUID) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.reservations {
+		if r.reservations[i].ID == id {
+			r.reservations[i].ExpiresAt = time.Now().Add(-time.Minute)
+		}
+	}
+}
+
+func TestReserveFunds_HoldsAvailableBalanceOnly(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+
+	hold, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 400, "DKK", time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReservationStatusActive, hold.Status)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), hold.ExpiresAt, time.Second)
+
+	acc := repo.accounts[id]
+	assert.Equal(t, int64(1_000), acc.Balance)
+	assert.Equal(t, int64(600), acc.AvailableBalance)
+	assert.Equal(t, int64(400), acc.ReservedAmount)
+	assert.Empty(t, repo.ledger, "a hold posts nothing")
+
+	_, err = service.ReserveFunds(ctx, id, uuid.New(), "hold", 601, "DKK", 0)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = service.AdjustBalance(ctx, id, -601, "DKK", "TEST", "spend the held money")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = service.ReserveFunds(ctx, id, uuid.New(), "hold", 0, "DKK", 0)
+	assert.ErrorIs(t, err, domain.ErrInvalidAmount)
+}
+
+func TestCaptureReservation_PostsOncePerLeg(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+	txID := uuid.New()
+
+	hold, err := service.ReserveFunds(ctx, id, txID, "debit", 400, "DKK", 0)
+	require.NoError(t, err)
+
+	_, _, err = service.CaptureReservation(ctx, hold.ID, 500, "TEST", "too much")
+	assert.ErrorIs(t, err, domain.ErrCaptureExceedsHold)
+
+	// A partial capture debits what was captured and frees the rest
+	for range 3 {
+		captured, acc, err := service.CaptureReservation(ctx, hold.ID, 300, "TEST", "capture")
+		require.NoError(t, err)
+		assert.Equal(t, domain.ReservationStatusConsumed, captured.Status)
+		assert.Equal(t, int64(700), acc.Balance)
+		assert.Equal(t, int64(700), acc.AvailableBalance)
+		assert.Equal(t, int64(0), acc.ReservedAmount)
+	}
+
+	require.Len(t, repo.ledger, 1)
+	entry := repo.ledger[0]
+	assert.Equal(t, txID, *entry.TransactionID)
+	assert.Equal(t, "debit", entry.Leg)
+	assert.Equal(t, int64(-300), entry.Amount)
+	assert.Equal(t, int64(1_000), entry.BalanceBefore)
+	assert.Equal(t, int64(700), entry.BalanceAfter)
+}
+
+func TestReservation_ReleaseAndExpiryRestoreAvailability(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+
+	released, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 300, "DKK", 0)
+	require.NoError(t, err)
+	lapsed, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 200, "DKK", 0)
+	require.NoError(t, err)
+	assert.Equal(t, int64(500), repo.accounts[id].AvailableBalance)
+
+	res, err := service.ReleaseReservation(ctx, released.ID, "cancelled")
+	require.NoError(t, err)
+	assert.Equal(t, domain.ReservationStatusReleased, res.Status)
+	assert.Equal(t, "cancelled", res.ReleaseReason)
+	assert.Equal(t, int64(800), repo.accounts[id].AvailableBalance)
+
+	n, err := service.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, n, "nothing has lapsed yet")
+
+	repo.backdate(lapsed.ID)
+	n, err = service.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+	n, err = service.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	acc := repo.accounts[id]
+	assert.Equal(t, int64(1_000), acc.Balance)
+	assert.Equal(t, int64(1_000), acc.AvailableBalance)
+	assert.Equal(t, int64(0), acc.ReservedAmount)
+	assert.Empty(t, repo.ledger)
+}
+
+func TestCaptureReservation_RefusesExpiredHolds(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+
+	hold, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 400, "DKK", 0)
+	require.NoError(t, err)
+
+	// Past its expiry but not yet swept
+	repo.backdate(hold.ID)
+	_, _, err = service.CaptureReservation(ctx, hold.ID, 0, "TEST", "late capture")
+	assert.ErrorIs(t, err, domain.ErrReservationExpired)
+
+	_, err = service.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	_, _, err = service.CaptureReservation(ctx, hold.ID, 0, "TEST", "late capture")
+	assert.ErrorIs(t, err, domain.ErrReservationNotActive)
+
+	assert.Equal(t, int64(1_000), repo.accounts[id].Balance)
+	assert.Empty(t, repo.ledger)
+}
+
+func TestReservation_SettledHoldsAreNotSettledAgain(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+
+	// A released hold is never captured, and releasing it again gives
+	// nothing back twice
+	released, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 300, "DKK", 0)
+	require.NoError(t, err)
+	for range 2 {
+		_, err = service.ReleaseReservation(ctx, released.ID, "cancelled")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1_000), repo.accounts[id].AvailableBalance)
+	}
+	_, _, err = service.CaptureReservation(ctx, released.ID, 0, "TEST", "capture")
+	assert.ErrorIs(t, err, domain.ErrReservationNotActive)
+
+	// A captured hold is never released, and capturing it again debits once
+	captured, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 300, "DKK", 0)
+	require.NoError(t, err)
+	for range 2 {
+		_, acc, err := service.CaptureReservation(ctx, captured.ID, 0, "TEST", "capture")
+		require.NoError(t, err)
+		assert.Equal(t, int64(700), acc.Balance)
+	}
+	_, err = service.ReleaseReservation(ctx, captured.ID, "cancelled")
+	assert.ErrorIs(t, err, domain.ErrReservationNotActive)
+
+	acc := repo.accounts[id]
+	assert.Equal(t, int64(700), acc.Balance)
+	assert.Equal(t, int64(700), acc.AvailableBalance)
+	assert.Len(t, repo.ledger, 1)
+}
+
+// staleListing hands out a listing of expired holds taken earlier, as when a
+// hold is settled between the sweeper listing it and getting to it.
+type staleListing struct {
+	*memoryRepository
+	listed []*domain.FundReservation
+}
+
+func (r *staleListing) ListExpiredReservations(context.Context, time.Time, int) ([]*domain.FundReservation, error) {
+	listed := r.listed
+	r.listed = nil
+	return listed, nil
+}
+
+func TestExpireReservations_CountsOnlyHoldsItExpired(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+
+	captured, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 300, "DKK", 0)
+	require.NoError(t, err)
+	lapsed, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 200, "DKK", 0)
+	require.NoError(t, err)
+	_, _, err = service.CaptureReservation(ctx, captured.ID, 0, "TEST", "capture")
+	require.NoError(t, err)
+	repo.backdate(lapsed.ID)
+
+	sweeper := NewAccountService(&staleListing{memoryRepository: repo, listed: []*domain.FundReservation{captured, lapsed}})
+	n, err := sweeper.ExpireReservations(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 1, n, "the captured hold was settled before the sweeper got to it")
+	assert.Equal(t, int64(700), repo.accounts[id].AvailableBalance)
+}
@@ -3,197 +3,350 @@ package application
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"strings"
 	"time"
 
 	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/blob"
+	"nordic-bank/internal/shared/notify"
+	"nordic-bank/internal/shared/vault"
+	transactionpb "nordic-bank/pkg/pb/transaction/v1"
 
 	"github.com/google/uuid"
 )
 
 type AccountService struct {
-	repo domain.AccountRepository
+	repo         domain.AccountRepository
+	documents    blob.Store
+	cardVault    vault.Vault
+	notifier     notify.Notifier
+	dormancy     DormancyPolicy
+	transactions transactionpb.TransactionServiceClient
+	requestSLA   time.Duration
 }
 
 func NewAccountService(repo domain.AccountRepository) *AccountService {
-	return &AccountService{repo: repo}
+	return &AccountService{
+		repo:       repo,
+		notifier:   notify.LogNotifier{},
+		dormancy:   DefaultDormancyPolicy,
+		requestSLA: DefaultRequestSLA,
+	}
 }
 
-func (s *AccountService) CreateAccount(ctx context.Context, customerID uuid.UUID, name string, accType domain.AccountType, currency string) (*domain.Account, error) {
+// CreateAccount opens an account for the customer from a catalogue product,
+// which sets its type, currency and interest terms. The customer must meet the
+// product's eligibility rules. An empty name defaults to the product's name.
+func (s *AccountService) CreateAccount(ctx context.Context, customerID uuid.UUID, name, productCode string) (*domain.Account, error) {
+	var account *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = s.openAccount(ctx, repo, customerID, name, productCode)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// openAccount opens an account from a product. It must run inside WithTx so
+// the account, its owner, interest tiers and opening entry commit together.
+func (s *AccountService) openAccount(ctx context.Context, repo domain.AccountRepository, customerID uuid.UUID, name, productCode string) (*domain.Account, error) {
+	product, err := offeredProduct(ctx, repo, productCode)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkEligibility(ctx, repo, product, customerID); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(name) == "" {
+		name = product.Name
+	}
+
+	accountNumber, err := s.newAccountNumber(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	account := &domain.Account{
 		CustomerID:       customerID,
-		AccountNumber:    generateAccountNumber(),
+		AccountNumber:    accountNumber,
 		AccountName:      name,
-		AccountType:      accType,
-		Currency:         currency,
+		AccountType:      product.AccountType,
+		Currency:         product.Currency,
+		ProductCode:      product.Code,
 		Balance:          0,
 		AvailableBalance: 0,
 		ReservedAmount:   0,
 		Status:           domain.AccountStatusActive,
 		OpenedAt:         time.Now(),
+
+		InterestRateBps:    product.InterestRateBps,
+		DayCountConvention: product.DayCountConvention,
 	}
 
-	if err := s.repo.Create(ctx, account); err != nil {
+	if err := repo.Create(ctx, account); err != nil {
 		return nil, err
 	}
+	if err := repo.SaveHolder(ctx, primaryOwner(account)); err != nil {
+		return nil, err
+	}
+	if len(product.InterestTiers) > 0 {
+		tiers := make([]domain.InterestTier, len(product.InterestTiers))
+		for i, tier := range product.InterestTiers {
+			tiers[i] = domain.InterestTier{AccountID: account.ID, FromBalance: tier.FromBalance, RateBps: tier.RateBps}
+		}
+		if err := repo.ReplaceInterestTiers(ctx, account.ID, tiers); err != nil {
+			return nil, err
+		}
+	}
 
 	// Create initial ledger entry
 	entry := &domain.LedgerEntry{
 		AccountID:     account.ID,
 		EntryType:     domain.EntryTypeCredit,
 		Amount:        0,
+		Currency:      account.Currency,
 		BalanceBefore: 0,
 		BalanceAfter:  0,
 		Description:   "Account opened",
 		Reference:     "OPEN",
 	}
-	_ = s.repo.CreateLedgerEntry(ctx, entry)
+	if err := repo.CreateLedgerEntry(ctx, entry); err != nil {
+		return nil, err
+	}
 
 	return account, nil
 }
 
+// GetAccount returns the account together with its balances in other
+// currencies.
 func (s *AccountService) GetAccount(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
-	return s.repo.GetByID(ctx, id)
+	account, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.withSubBalances(ctx, account)
 }
 
+// ListAccounts returns every account the customer holds. Balances are blanked
+// on accounts where the customer is an authorised user without the right to
+// see them.
 func (s *AccountService) ListAccounts(ctx context.Context, customerID uuid.UUID) ([]*domain.Account, error) {
-	return s.repo.ListByCustomerID(ctx, customerID)
-}
-
-func (s *AccountService) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.AccountStatus) (*domain.Account, error) {
-	account, err := s.repo.GetByID(ctx, id)
+	accounts, err := s.repo.ListByCustomerID(ctx, customerID)
 	if err != nil {
 		return nil, err
 	}
 
-	account.Status = status
-	if status == domain.AccountStatusClosed {
-		now := time.Now()
-		account.ClosedAt = &now
+	holdings, err := s.repo.ListHoldingsByCustomerID(ctx, customerID)
+	if err != nil {
+		return nil, err
+	}
+	hidden := make(map[uuid.UUID]bool)
+	for _, holding := range holdings {
+		if !holding.Allows(domain.PermissionViewBalance) {
+			hidden[holding.AccountID] = true
+		}
 	}
 
-	if err := s.repo.Update(ctx, account); err != nil {
-		return nil, err
+	for _, account := range accounts {
+		if account.CustomerID != customerID && hidden[account.ID] {
+			account.HideBalances()
+		}
 	}
 
-	return account, nil
+	return accounts, nil
 }
 
-func (s *AccountService) AdjustBalance(ctx context.Context, id uuid.UUID, adjustment int64, reference, description string) (*domain.Account, error) {
-	account, err := s.repo.GetByID(ctx, id)
+// AdjustBalance applies a signed adjustment to the account's balance in the
+// given currency and writes the matching ledger entry. The currency must be
+// the account's own or one it holds a sub-balance in. The account row is
+// locked for the duration of the transaction so concurrent adjustments
+// serialise instead of racing on the funds check, and the balance change and
+// ledger row commit or fail together. Dormant accounts still take credits but
+// refuse debits. The returned account carries its sub-balances.
+func (s *AccountService) AdjustBalance(ctx context.Context, id uuid.UUID, adjustment int64, code, reference, description string) (*domain.Account, error) {
+	return s.Post(ctx, Posting{AccountID: id, Amount: adjustment, Currency: code, Reference: reference, Description: description})
+}
+
+// Posting is a balance adjustment with the optional details AdjustBalance
+// leaves out.
+type Posting struct {
+	AccountID   uuid.UUID
+	Amount      int64 // Signed, minor units
+	Currency    string
+	Reference   string
+	Description string
+	ValueDate   time.Time // Zero values the entry on the day it is posted
+
+	// A posting for a leg of a transaction is made once: repeating it
+	// changes nothing, so a caller that lost the response can safely retry.
+	TransactionID uuid.UUID
+	Leg           string
+
+	// Force posts a debit past the funds check, for payments the bank is
+	// already bound to settle such as card clearing after the hold lapsed.
+	Force bool
+}
+
+// Post makes the posting as AdjustBalance does.
+func (s *AccountService) Post(ctx context.Context, p Posting) (*domain.Account, error) {
+	code, err := supportedCurrency(p.Currency)
 	if err != nil {
 		return nil, err
 	}
-
-	if account.Status != domain.AccountStatusActive {
-		return nil, fmt.Errorf("account is not active: %s", account.Status)
+	if (p.Leg == "") != (p.TransactionID == uuid.Nil) {
+		return nil, fmt.Errorf("a transaction leg needs both a transaction id and a leg name")
 	}
 
-	balanceBefore := account.Balance
-	account.Balance += adjustment
-	account.AvailableBalance += adjustment
+	var account *domain.Account
+	err = s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, p.AccountID)
+		if err != nil {
+			return err
+		}
 
-	if account.AvailableBalance < 0 {
-		return nil, fmt.Errorf("insufficient funds")
-	}
+		// The account lock serialises retries of the same leg
+		if p.Leg != "" {
+			posted, err := repo.HasLedgerLeg(ctx, p.TransactionID, p.Leg)
+			if err != nil || posted {
+				return err
+			}
+		}
 
-	if err := s.repo.Update(ctx, account); err != nil {
-		return nil, err
-	}
+		switch {
+		case account.Status == domain.AccountStatusDormant:
+			if p.Amount < 0 {
+				return domain.ErrAccountDormant
+			}
+		case account.Status != domain.AccountStatusActive:
+			return fmt.Errorf("%w: %s", domain.ErrAccountNotActive, account.Status)
+		}
 
-	entryType := domain.EntryTypeCredit
-	if adjustment < 0 {
-		entryType = domain.EntryTypeDebit
-	}
+		valueDate := p.ValueDate
+		if !valueDate.IsZero() {
+			valueDate = startOfDay(valueDate)
+			if valueDate.After(startOfDay(time.Now())) || valueDate.Before(startOfDay(account.OpenedAt)) {
+				return domain.ErrInvalidValueDate
+			}
+		}
 
-	entry := &domain.LedgerEntry{
-		AccountID:     account.ID,
-		EntryType:     entryType,
-		Amount:        adjustment,
-		BalanceBefore: balanceBefore,
-		BalanceAfter:  account.Balance,
-		Description:   description,
-		Reference:     reference,
-	}
-	_ = s.repo.CreateLedgerEntry(ctx, entry)
+		funds, err := lockFunds(ctx, repo, account, code)
+		if err != nil {
+			return err
+		}
+		if p.Amount < 0 && !p.Force && !funds.canDebit(-p.Amount) {
+			return domain.ErrInsufficientFunds
+		}
 
-	return account, nil
-}
+		balanceBefore := funds.balance()
+		funds.move(p.Amount, 0)
+		if err := funds.save(ctx, repo); err != nil {
+			return err
+		}
 
-func (s *AccountService) ToggleFavorite(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
-	account, err := s.repo.GetByID(ctx, id)
-	if err != nil {
-		return nil, err
-	}
+		entryType := domain.EntryTypeCredit
+		if p.Amount < 0 {
+			entryType = domain.EntryTypeDebit
+		}
 
-	account.IsFavorite = !account.IsFavorite
+		entry := &domain.LedgerEntry{
+			AccountID:     account.ID,
+			EntryType:     entryType,
+			Amount:        p.Amount,
+			Currency:      funds.currency(),
+			BalanceBefore: balanceBefore,
+			BalanceAfter:  funds.balance(),
+			Description:   p.Description,
+			Reference:     p.Reference,
+			ValueDate:     valueDate,
+			Leg:           p.Leg,
+		}
+		if p.TransactionID != uuid.Nil {
+			entry.TransactionID = &p.TransactionID
+		}
+		if err := repo.CreateLedgerEntry(ctx, entry); err != nil {
+			return err
+		}
 
-	if err := s.repo.Update(ctx, account); err != nil {
+		if valueDate.IsZero() || funds.sub != nil {
+			return nil
+		}
+		return reaccrueInterest(ctx, repo, account, valueDate)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return account, nil
+	return s.withSubBalances(ctx, account)
 }
 
-func generateAccountNumber() string {
-	// Simplified random account number for Denmark
-	// Format: DK + 2 check digits + 14 digits
-	rand.Seed(time.Now().UnixNano())
-	digits := ""
-	for i := 0; i < 14; i++ {
-		digits += fmt.Sprintf("%d", rand.Intn(10))
+// SetOverdraftLimit grants, changes or (with a limit of zero) revokes the
+// overdraft facility on an account whose product allows one; accounts opened
+// before the catalogue must be checking accounts. The limit cannot be lowered past
+// what the customer has already drawn.
+func (s *AccountService) SetOverdraftLimit(ctx context.Context, id uuid.UUID, limit int64) (*domain.Account, error) {
+	if limit < 0 {
+		return nil, domain.ErrInvalidAmount
 	}
-	return "DK99" + digits
-}
 
-func (s *AccountService) CreateRequest(ctx context.Context, customerID uuid.UUID, requestedType domain.AccountType, reason string) (*domain.AccountRequest, error) {
-	req := &domain.AccountRequest{
-		CustomerID:    customerID,
-		RequestedType: requestedType,
-		Status:        domain.RequestStatusPending,
-		Reason:        reason,
-	}
+	var account *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
 
-	if err := s.repo.CreateRequest(ctx, req); err != nil {
+		if limit > 0 {
+			eligible, err := overdraftEligible(ctx, repo, account)
+			if err != nil {
+				return err
+			}
+			if !eligible {
+				return domain.ErrOverdraftNotAllowed
+			}
+		}
+		if account.AvailableBalance < account.MinimumBalance-limit {
+			return domain.ErrOverdraftInUse
+		}
+
+		account.OverdraftLimit = limit
+		return repo.Update(ctx, account)
+	})
+	if err != nil {
 		return nil, err
 	}
 
-	return req, nil
+	return account, nil
 }
 
-func (s *AccountService) GetRequests(ctx context.Context, status *domain.RequestStatus) ([]*domain.AccountRequest, error) {
-	return s.repo.ListRequests(ctx, status)
+// overdraftEligible reports whether the account may be granted an overdraft.
+func overdraftEligible(ctx context.Context, repo domain.AccountRepository, account *domain.Account) (bool, error) {
+	if account.ProductCode == "" {
+		return account.AccountType == domain.AccountTypeChecking, nil
+	}
+	product, err := repo.GetProduct(ctx, account.ProductCode)
+	if err != nil {
+		return false, err
+	}
+	return product.OverdraftEligible, nil
 }
 
-func (s *AccountService) UpdateRequestStatus(ctx context.Context, id uuid.UUID, status domain.RequestStatus, processedBy uuid.UUID) (*domain.AccountRequest, error) {
-	req, err := s.repo.GetRequestByID(ctx, id)
+func (s *AccountService) ToggleFavorite(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	account, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	if req.Status != domain.RequestStatusPending {
-		return nil, fmt.Errorf("request is already processed: %s", req.Status)
-	}
-
-	req.Status = status
-	now := time.Now()
-	req.ProcessedAt = &now
-	req.ProcessedBy = &processedBy
+	account.IsFavorite = !account.IsFavorite
 
-	if err := s.repo.UpdateRequest(ctx, req); err != nil {
+	if err := s.repo.Update(ctx, account); err != nil {
 		return nil, err
 	}
 
-	// If approved, create the account automatically
-	if status == domain.RequestStatusApproved {
-		_, err := s.CreateAccount(ctx, req.CustomerID, string(req.RequestedType)+" Account", req.RequestedType, "DKK")
-		if err != nil {
-			// In a real system we might want to transactionally rollback the request update
-			// For now, we return error but the request is marked approved.
-			// Ideally we should use a transaction here.
-			return nil, fmt.Errorf("failed to create account for approved request: %v", err)
-		}
-	}
-
-	return req, nil
+	return account, nil
 }
@@ -0,0 +1,431 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryRepository is an in-memory AccountRepository that mimics the
+// transactional behaviour of the Postgres adapter: GetByIDForUpdate holds a
+// per-account lock until WithTx returns, and writes made inside WithTx are
+// only applied when fn succeeds. Methods not needed by the tests fall through
+// to the embedded nil interface and panic.
+type memoryRepository struct {
+	domain.AccountRepository
+
+	mu           sync.Mutex
+	accounts     map[uuid.UUID]domain.Account
+	ledger       []domain.LedgerEntry
+	statements   []domain.Statement
+	details      []domain.TransactionDetail
+	holders      []domain.AccountHolder
+	cards        map[uuid.UUID]domain.Card
+	requests     []domain.AccountRequest
+	accruals     []domain.InterestAccrual
+	scheduled    map[uuid.UUID]int64
+	history      []domain.StatusChange
+	subs         map[string]domain.SubBalance
+	reservations []domain.FundReservation
+	pots         []domain.Pot
+	products     map[string]domain.Product
+	customers    map[uuid.UUID]domain.CustomerProfile
+	tiers        []domain.InterestTier
+	rowLocks     map[uuid.UUID]*sync.Mutex
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{
+		accounts:  make(map[uuid.UUID]domain.Account),
+		cards:     make(map[uuid.UUID]domain.Card),
+		subs:      make(map[string]domain.SubBalance),
+		products:  make(map[string]domain.Product),
+		customers: make(map[uuid.UUID]domain.CustomerProfile),
+		rowLocks:  make(map[uuid.UUID]*sync.Mutex),
+	}
+}
+
+func (r *memoryRepository) rowLock(id uuid.UUID) *sync.Mutex {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.rowLocks[id]
+	if !ok {
+		l = &sync.Mutex{}
+		r.rowLocks[id] = l
+	}
+	return l
+}
+
+func (r *memoryRepository) WithTx(ctx context.Context, fn func(repo domain.AccountRepository) error) error {
+	tx := &memoryTx{parent: r, accounts: make(map[uuid.UUID]domain.Account), subs: make(map[string]domain.SubBalance)}
+	defer tx.unlock()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, acc := range tx.accounts {
+		r.accounts[id] = acc
+	}
+	for key, sub := range tx.subs {
+		r.subs[key] = sub
+	}
+	r.ledger = append(r.ledger, tx.ledger...)
+	r.history = append(r.history, tx.history...)
+	for _, req := range tx.requests {
+		for i := range r.requests {
+			if r.requests[i].ID == req.ID {
+				r.requests[i] = req
+			}
+		}
+	}
+	return nil
+}
+
+func (r *memoryRepository) Create(ctx context.Context, account *domain.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if account.ID == uuid.Nil {
+		account.ID = uuid.New()
+	}
+	r.accounts[account.ID] = *account
+	return nil
+}
+
+func (r *memoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	acc, ok := r.accounts[id]
+	if !ok {
+		return nil, errors.New("record not found")
+	}
+	return &acc, nil
+}
+
+func (r *memoryRepository) Update(ctx context.Context, account *domain.Account) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.accounts[account.ID] = *account
+	return nil
+}
+
+func (r *memoryRepository) CreateLedgerEntry(ctx context.Context, entry *domain.LedgerEntry) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ledger = append(r.ledger, stamped(*entry))
+	return nil
+}
+
+func (r *memoryRepository) ListSubBalances(ctx context.Context, accountID uuid.UUID) ([]domain.SubBalance, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var balances []domain.SubBalance
+	for _, sub := range r.subs {
+		if sub.AccountID == accountID {
+			balances = append(balances, sub)
+		}
+	}
+	sort.Slice(balances, func(i, j int) bool { return balances[i].Currency < balances[j].Currency })
+	return balances, nil
+}
+
+func subKey(accountID uuid.UUID, currency string) string {
+	return accountID.String() + "/" + currency
+}
+
+// stamped fills in the entry date the way the column default does.
+func stamped(entry domain.LedgerEntry) domain.LedgerEntry {
+	if entry.EntryDate.IsZero() {
+		entry.EntryDate = time.Now()
+	}
+	if entry.PostedDate.IsZero() {
+		entry.PostedDate = startOfDay(entry.EntryDate)
+	}
+	if entry.ValueDate.IsZero() {
+		entry.ValueDate = entry.PostedDate
+	}
+	return entry
+}
+
+type memoryTx struct {
+	domain.AccountRepository
+
+	parent   *memoryRepository
+	locked   []*sync.Mutex
+	accounts map[uuid.UUID]domain.Account
+	ledger   []domain.LedgerEntry
+	history  []domain.StatusChange
+	subs     map[string]domain.SubBalance
+	requests []domain.AccountRequest
+}
+
+func (t *memoryTx) unlock() {
+	for _, l := range t.locked {
+		l.Unlock()
+	}
+}
+
+func (t *memoryTx) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*domain.Account, error) {
+	l := t.parent.rowLock(id)
+	l.Lock()
+	t.locked = append(t.locked, l)
+	return t.parent.GetByID(ctx, id)
+}
+
+func (t *memoryTx) Update(ctx context.Context, account *domain.Account) error {
+	t.accounts[account.ID] = *account
+	return nil
+}
+
+func (t *memoryTx) CreateLedgerEntry(ctx context.Context, entry *domain.LedgerEntry) error {
+	t.ledger = append(t.ledger, stamped(*entry))
+	return nil
+}
+
+func (t *memoryTx) GetSubBalanceForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*domain.SubBalance, error) {
+	l := t.parent.rowLock(uuid.NewSHA1(accountID, []byte(currency)))
+	l.Lock()
+	t.locked = append(t.locked, l)
+
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	sub, ok := t.parent.subs[subKey(accountID, currency)]
+	if !ok {
+		return nil, domain.ErrCurrencyNotHeld
+	}
+	return &sub, nil
+}
+
+func (t *memoryTx) UpdateSubBalance(ctx context.Context, balance *domain.SubBalance) error {
+	t.subs[subKey(balance.AccountID, balance.Currency)] = *balance
+	return nil
+}
+
+func (t *memoryTx) CreateStatusChange(ctx context.Context, change *domain.StatusChange) error {
+	t.history = append(t.history, *change)
+	return nil
+}
+
+func seedAccount(t *testing.T, repo *memoryRepository, balance int64) uuid.UUID {
+	t.Helper()
+	acc := &domain.Account{
+		Currency:         "DKK",
+		Balance:          balance,
+		AvailableBalance: balance,
+		Status:           domain.AccountStatusActive,
+	}
+	require.NoError(t, repo.Create(context.Background(), acc))
+	return acc.ID
+}
+
+func TestAdjustBalance_ConcurrentDebits(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+
+	const (
+		workers      = 500
+		debit        = int64(100)
+		startBalance = int64(30_000) // enough for 300 of the 500 debits
+	)
+	id := seedAccount(t, repo, startBalance)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+		rejected  int
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := service.AdjustBalance(context.Background(), id, -debit, "DKK", "TEST", "parallel debit")
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case err == nil:
+				succeeded++
+			case errors.Is(err, domain.ErrInsufficientFunds):
+				rejected++
+			default:
+				t.Errorf("unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	acc, err := repo.GetByID(context.Background(), id)
+	require.NoError(t, err)
+
+	assert.Equal(t, 300, succeeded)
+	assert.Equal(t, workers-300, rejected)
+	assert.Equal(t, int64(0), acc.Balance)
+	assert.Equal(t, int64(0), acc.AvailableBalance)
+	assert.Len(t, repo.ledger, succeeded, "every successful debit must have exactly one ledger entry")
+
+	// The ledger must chain: each entry starts where the previous one ended.
+	balance := startBalance
+	for _, entry := range repo.ledger {
+		assert.Equal(t, balance, entry.BalanceBefore)
+		balance = entry.BalanceAfter
+	}
+	assert.Equal(t, acc.Balance, balance)
+}
+
+func TestAdjustBalance_RejectedDebitLeavesNoTrace(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	id := seedAccount(t, repo, 50)
+
+	_, err := service.AdjustBalance(context.Background(), id, -100, "DKK", "TEST", "too large")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	acc, err := repo.GetByID(context.Background(), id)
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), acc.Balance)
+	assert.Empty(t, repo.ledger)
+}
+
+func TestAdjustBalance_HonoursOverdraftAndMinimumBalance(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+
+	overdrawn := seedAccount(t, repo, 1_000)
+	acc, _ := repo.GetByID(ctx, overdrawn)
+	acc.AccountType = domain.AccountTypeChecking
+	require.NoError(t, repo.Update(ctx, acc))
+
+	_, err := service.SetOverdraftLimit(ctx, overdrawn, 5_000)
+	require.NoError(t, err)
+
+	acc, err = service.AdjustBalance(ctx, overdrawn, -6_000, "DKK", "TEST", "into overdraft")
+	require.NoError(t, err)
+	assert.Equal(t, int64(-5_000), acc.Balance)
+
+	_, err = service.AdjustBalance(ctx, overdrawn, -1, "DKK", "TEST", "past the limit")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	_, err = service.SetOverdraftLimit(ctx, overdrawn, 0)
+	assert.ErrorIs(t, err, domain.ErrOverdraftInUse)
+
+	floored := seedAccount(t, repo, 1_000)
+	acc, _ = repo.GetByID(ctx, floored)
+	acc.MinimumBalance = 400
+	require.NoError(t, repo.Update(ctx, acc))
+
+	_, err = service.AdjustBalance(ctx, floored, -700, "DKK", "TEST", "below minimum")
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+	_, err = service.AdjustBalance(ctx, floored, -600, "DKK", "TEST", "down to minimum")
+	assert.NoError(t, err)
+}
+
+func (t *memoryTx) HasLedgerLeg(ctx context.Context, transactionID uuid.UUID, leg string) (bool, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, entry := range append(append([]domain.LedgerEntry(nil), t.parent.ledger...), t.ledger...) {
+		if entry.TransactionID != nil && *entry.TransactionID == transactionID && entry.Leg == leg {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (t *memoryTx) GetReservationByLeg(ctx context.Context, transactionID uuid.UUID, leg string) (*domain.FundReservation, error) {
+	t.parent.mu.Lock()
+	defer t.parent.mu.Unlock()
+	for _, res := range t.parent.reservations {
+		if res.TransactionID == transactionID && res.Leg == leg {
+			return &res, nil
+		}
+	}
+	return nil, domain.ErrReservationNotFound
+}
+
+func TestPost_TransactionLegIsPostedOnce(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+	txID := uuid.New()
+
+	credit := Posting{AccountID: id, Amount: 500, Currency: "DKK", Reference: txID.String(), TransactionID: txID, Leg: "credit"}
+	for range 3 {
+		acc, err := service.Post(ctx, credit)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1_500), acc.Balance)
+	}
+	require.Len(t, repo.ledger, 1)
+	assert.Equal(t, txID, *repo.ledger[0].TransactionID)
+
+	// Another leg of the same transaction is a separate posting
+	credit.Leg, credit.Amount = "credit_reversal", -500
+	acc, err := service.Post(ctx, credit)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1_000), acc.Balance)
+
+	_, err = service.Post(ctx, Posting{AccountID: id, Amount: 1, Currency: "DKK", Leg: "credit"})
+	assert.Error(t, err)
+}
+
+func TestReservation_RetriesAreIdempotent(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+	txID := uuid.New()
+
+	hold, err := service.ReserveFunds(ctx, id, txID, "hold", 400, "DKK", 0)
+	require.NoError(t, err)
+	again, err := service.ReserveFunds(ctx, id, txID, "hold", 400, "DKK", 0)
+	require.NoError(t, err)
+	assert.Equal(t, hold.ID, again.ID)
+	assert.Equal(t, int64(600), repo.accounts[id].AvailableBalance)
+
+	for range 2 {
+		_, acc, err := service.CaptureReservation(ctx, hold.ID, 0, "TEST", "capture")
+		require.NoError(t, err)
+		assert.Equal(t, int64(600), acc.Balance)
+	}
+	_, err = service.ReleaseReservation(ctx, hold.ID, "too late")
+	assert.ErrorIs(t, err, domain.ErrReservationNotActive)
+
+	other, err := service.ReserveFunds(ctx, id, uuid.New(), "hold", 100, "DKK", 0)
+	require.NoError(t, err)
+	for range 2 {
+		_, err = service.ReleaseReservation(ctx, other.ID, "cancelled")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int64(600), repo.accounts[id].AvailableBalance)
+}
+
+func TestPost_ForcedDebitSkipsTheFundsCheck(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 1_000)
+	txID := uuid.New()
+
+	debit := Posting{AccountID: id, Amount: -3_000, Currency: "DKK", TransactionID: txID, Leg: "card_clearing"}
+	_, err := service.Post(ctx, debit)
+	assert.ErrorIs(t, err, domain.ErrInsufficientFunds)
+
+	debit.Force = true
+	for range 2 {
+		acc, err := service.Post(ctx, debit)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-2_000), acc.Balance)
+	}
+	assert.Len(t, repo.ledger, 1)
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// GenerateStatement summarises the ledger of an account between periodStart
+// and periodEnd (both inclusive dates) and stores it as a finalised
+// statement. The period must be over, and an account can only have one
+// statement per statement date.
+func (s *AccountService) GenerateStatement(ctx context.Context, accountID uuid.UUID, periodStart, periodEnd time.Time) (*domain.Statement, error) {
+	periodStart, periodEnd = startOfDay(periodStart), startOfDay(periodEnd)
+	if !periodEnd.After(periodStart) {
+		return nil, domain.ErrInvalidPeriod
+	}
+	if !periodEnd.Before(startOfDay(time.Now())) {
+		return nil, domain.ErrPeriodNotEnded
+	}
+
+	if _, err := s.repo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+
+	return s.issueStatement(ctx, accountID, periodStart, periodEnd, false)
+}
+
+// issueStatement summarises the ledger from periodStart to periodEnd (both
+// inclusive dates) and stores the finalised statement.
+func (s *AccountService) issueStatement(ctx context.Context, accountID uuid.UUID, periodStart, periodEnd time.Time, final bool) (*domain.Statement, error) {
+	exists, err := s.repo.HasStatement(ctx, accountID, periodEnd)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, domain.ErrStatementExists
+	}
+
+	from, to := periodStart, periodEnd.AddDate(0, 0, 1)
+
+	opening, err := s.repo.GetBalanceAt(ctx, accountID, from)
+	if err != nil {
+		return nil, err
+	}
+	closing, err := s.repo.GetBalanceAt(ctx, accountID, to)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := s.repo.ListLedgerEntries(ctx, accountID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	statement := &domain.Statement{
+		AccountID:       accountID,
+		StatementDate:   periodEnd,
+		PeriodStartDate: periodStart,
+		PeriodEndDate:   periodEnd,
+		OpeningBalance:  opening,
+		ClosingBalance:  closing,
+		IsFinal:         final,
+	}
+	for _, entry := range entries {
+		if entry.Amount == 0 {
+			continue
+		}
+		statement.TransactionCount++
+		switch {
+		case entry.Reference == domain.ReferenceInterest:
+			statement.InterestEarned += entry.Amount
+		case entry.Amount > 0:
+			statement.TotalCredits += entry.Amount
+		default:
+			statement.TotalDebits += -entry.Amount
+		}
+	}
+
+	if !statement.Balanced() {
+		return nil, fmt.Errorf("%w: account %s, %s to %s", domain.ErrStatementUnbalanced, accountID, periodStart.Format("2006-01-02"), periodEnd.Format("2006-01-02"))
+	}
+
+	now := time.Now()
+	statement.IsFinalized = true
+	statement.FinalizedAt = &now
+
+	if err := s.repo.CreateStatement(ctx, statement); err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+// GenerateMonthlyStatements issues statements for the calendar month
+// containing period to every account that was open during it. Accounts that
+// already have a statement for the month are skipped, as are closed accounts,
+// whose final statement covers everything since their last regular one. It
+// returns the number of statements created.
+func (s *AccountService) GenerateMonthlyStatements(ctx context.Context, period time.Time) (int, error) {
+	periodStart := startOfMonth(period)
+	periodEnd := periodStart.AddDate(0, 1, -1)
+
+	accounts, err := s.repo.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	created := 0
+	var errs []error
+	for _, account := range accounts {
+		if startOfDay(account.OpenedAt).After(periodEnd) {
+			continue
+		}
+		if account.Status == domain.AccountStatusClosed {
+			continue
+		}
+
+		// One failing account must not hold up the others
+		exists, err := s.repo.HasStatement(ctx, account.ID, periodEnd)
+		if err == nil && !exists {
+			_, err = s.GenerateStatement(ctx, account.ID, periodStart, periodEnd)
+			if err == nil {
+				created++
+			}
+		}
+		if err != nil {
+			err = fmt.Errorf("statement for %s: %w", account.ID, err)
+			log.Print(err)
+			errs = append(errs, err)
+		}
+	}
+
+	return created, errors.Join(errs...)
+}
+
+func (s *AccountService) ListStatements(ctx context.Context, accountID uuid.UUID) ([]*domain.Statement, error) {
+	return s.repo.ListStatements(ctx, accountID)
+}
+
+// GetStatement returns a statement, provided it belongs to the account.
+func (s *AccountService) GetStatement(ctx context.Context, accountID, statementID uuid.UUID) (*domain.Statement, error) {
+	statement, err := s.repo.GetStatementByID(ctx, statementID)
+	if err != nil {
+		return nil, err
+	}
+	if statement.AccountID != accountID {
+		return nil, domain.ErrStatementNotFound
+	}
+	return statement, nil
+}
+
+// RunStatementScheduler makes sure last month's statements exist, checking
+// every interval.
+func (s *AccountService) RunStatementScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			lastMonth := startOfMonth(now).AddDate(0, -1, 0)
+			n, err := s.GenerateMonthlyStatements(ctx, lastMonth)
+			if err != nil {
+				log.Printf("statement scheduler: %v", err)
+			}
+			if n > 0 {
+				log.Printf("statement scheduler: generated %d statements for %s", n, lastMonth.Format("2006-01"))
+			}
+		}
+	}
+}
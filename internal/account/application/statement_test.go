@@ -0,0 +1,128 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) GetBalanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var balance int64
+	for _, entry := range r.ledger {
+		if entry.AccountID == accountID && r.inOwnCurrency(entry) && entry.EntryDate.Before(at) {
+			balance += entry.Amount
+		}
+	}
+	return balance, nil
+}
+
+func (r *memoryRepository) ListLedgerEntries(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*domain.LedgerEntry, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var entries []*domain.LedgerEntry
+	for i := range r.ledger {
+		entry := r.ledger[i]
+		if entry.AccountID == accountID && r.inOwnCurrency(entry) && !entry.EntryDate.Before(from) && entry.EntryDate.Before(to) {
+			entries = append(entries, &entry)
+		}
+	}
+	return entries, nil
+}
+
+// inOwnCurrency reports whether the entry was booked on the account's own
+// balance rather than a sub-balance. The caller holds r.mu.
+func (r *memoryRepository) inOwnCurrency(entry domain.LedgerEntry) bool {
+	return entry.Currency == "" || entry.Currency == r.accounts[entry.AccountID].Currency
+}
+
+func (r *memoryRepository) HasStatement(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, st := range r.statements {
+		if st.AccountID == accountID && st.StatementDate.Equal(statementDate) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRepository) CreateStatement(ctx context.Context, statement *domain.Statement) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	statement.ID = uuid.New()
+	r.statements = append(r.statements, *statement)
+	return nil
+}
+
+func appendLedger(repo *memoryRepository, accountID uuid.UUID, at time.Time, amount int64, reference string) {
+	var balance int64
+	for _, entry := range repo.ledger {
+		if entry.AccountID == accountID {
+			balance = entry.BalanceAfter
+		}
+	}
+	repo.ledger = append(repo.ledger, domain.LedgerEntry{
+		AccountID:     accountID,
+		Amount:        amount,
+		BalanceBefore: balance,
+		BalanceAfter:  balance + amount,
+		Reference:     reference,
+		EntryDate:     at,
+	})
+}
+
+func TestGenerateStatement_SummarisesPeriod(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+
+	day := func(m time.Month, d int) time.Time { return time.Date(2024, m, d, 12, 0, 0, 0, time.UTC) }
+	appendLedger(repo, id, day(time.February, 20), 10_000, "DEPOSIT") // before the period
+	appendLedger(repo, id, day(time.March, 1), 5_000, "DEPOSIT")
+	appendLedger(repo, id, day(time.March, 15), -2_500, "TRANSFER")
+	appendLedger(repo, id, day(time.March, 31), 42, domain.ReferenceInterest)
+	appendLedger(repo, id, day(time.April, 1), -1_000, "TRANSFER") // after the period
+
+	start := time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC)
+
+	st, err := service.GenerateStatement(ctx, id, start, end)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10_000), st.OpeningBalance)
+	assert.Equal(t, int64(12_542), st.ClosingBalance)
+	assert.Equal(t, int64(5_000), st.TotalCredits)
+	assert.Equal(t, int64(2_500), st.TotalDebits)
+	assert.Equal(t, int64(42), st.InterestEarned)
+	assert.Equal(t, 3, st.TransactionCount)
+	assert.True(t, st.Balanced())
+	assert.True(t, st.IsFinalized)
+	assert.NotNil(t, st.FinalizedAt)
+
+	_, err = service.GenerateStatement(ctx, id, start, end)
+	assert.ErrorIs(t, err, domain.ErrStatementExists)
+}
+
+func TestGenerateStatement_RejectsOpenOrInvalidPeriods(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	id := seedAccount(t, repo, 0)
+
+	today := startOfDay(time.Now())
+
+	_, err := service.GenerateStatement(ctx, id, today.AddDate(0, 0, -10), today)
+	assert.ErrorIs(t, err, domain.ErrPeriodNotEnded)
+
+	_, err = service.GenerateStatement(ctx, id, today.AddDate(0, 0, -5), today.AddDate(0, 0, -10))
+	assert.ErrorIs(t, err, domain.ErrInvalidPeriod)
+}
@@ -0,0 +1,78 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+// UpdateStatus moves an account to another status on an employee's behalf.
+// Dormant accounts are reactivated through an approved request and accounts
+// are closed through CloseAccount; every other move must follow the
+// transition table and give a reason.
+func (s *AccountService) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.AccountStatus, reason string, changedBy uuid.UUID) (*domain.Account, error) {
+	if !status.Valid() {
+		return nil, fmt.Errorf("%w: %s", domain.ErrInvalidStatus, status)
+	}
+	if strings.TrimSpace(reason) == "" {
+		return nil, domain.ErrStatusReasonRequired
+	}
+
+	var account *domain.Account
+	err := s.repo.WithTx(ctx, func(repo domain.AccountRepository) error {
+		var err error
+		account, err = repo.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		switch {
+		case account.Status == domain.AccountStatusClosed:
+			return domain.ErrAccountClosed
+		case status == domain.AccountStatusClosed:
+			return domain.ErrClosureRequired
+		case account.Status == domain.AccountStatusDormant && status == domain.AccountStatusActive:
+			return domain.ErrReactivationRequired
+		}
+		return changeStatus(ctx, repo, account, status, reason, &changedBy)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// GetStatusHistory returns the account's status changes, oldest first.
+func (s *AccountService) GetStatusHistory(ctx context.Context, accountID uuid.UUID) ([]*domain.StatusChange, error) {
+	if _, err := s.repo.GetByID(ctx, accountID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListStatusChanges(ctx, accountID)
+}
+
+// changeStatus moves a locked account to another status, saves it along with
+// any other changes the caller made, and records the transition. changedBy is
+// nil for transitions made by scheduled jobs.
+func changeStatus(ctx context.Context, repo domain.AccountRepository, account *domain.Account, to domain.AccountStatus, reason string, changedBy *uuid.UUID) error {
+	from := account.Status
+	if !domain.CanTransition(from, to) {
+		return fmt.Errorf("%w: %s to %s", domain.ErrInvalidTransition, from, to)
+	}
+
+	account.Status = to
+	if err := repo.Update(ctx, account); err != nil {
+		return err
+	}
+
+	return repo.CreateStatusChange(ctx, &domain.StatusChange{
+		AccountID:  account.ID,
+		FromStatus: from,
+		ToStatus:   to,
+		Reason:     reason,
+		ChangedBy:  changedBy,
+	})
+}
@@ -0,0 +1,75 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListStatusChanges(ctx context.Context, accountID uuid.UUID) ([]*domain.StatusChange, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var changes []*domain.StatusChange
+	for i := range r.history {
+		if r.history[i].AccountID == accountID {
+			change := r.history[i]
+			changes = append(changes, &change)
+		}
+	}
+	return changes, nil
+}
+
+func TestUpdateStatus_RecordsHistory(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	employee := uuid.New()
+	id := seedAccount(t, repo, 1_000)
+
+	frozen, err := service.UpdateStatus(ctx, id, domain.AccountStatusFrozen, "Suspected fraud", employee)
+	require.NoError(t, err)
+	assert.Equal(t, domain.AccountStatusFrozen, frozen.Status)
+
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusActive, "Cleared by fraud team", employee)
+	require.NoError(t, err)
+
+	history, err := service.GetStatusHistory(ctx, id)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, domain.AccountStatusActive, history[0].FromStatus)
+	assert.Equal(t, domain.AccountStatusFrozen, history[0].ToStatus)
+	assert.Equal(t, "Suspected fraud", history[0].Reason)
+	assert.Equal(t, &employee, history[0].ChangedBy)
+	assert.Equal(t, domain.AccountStatusFrozen, history[1].FromStatus)
+	assert.Equal(t, domain.AccountStatusActive, history[1].ToStatus)
+}
+
+func TestUpdateStatus_Refusals(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewAccountService(repo)
+	ctx := context.Background()
+	employee := uuid.New()
+	id := seedAccount(t, repo, 1_000)
+
+	_, err := service.UpdateStatus(ctx, id, domain.AccountStatusFrozen, "  ", employee)
+	assert.ErrorIs(t, err, domain.ErrStatusReasonRequired)
+
+	_, err = service.UpdateStatus(ctx, id, "suspended", "Because", employee)
+	assert.ErrorIs(t, err, domain.ErrInvalidStatus)
+
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusActive, "Already active", employee)
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusFrozen, "Court order", employee)
+	require.NoError(t, err)
+	_, err = service.UpdateStatus(ctx, id, domain.AccountStatusDormant, "Looks idle", employee)
+	assert.ErrorIs(t, err, domain.ErrInvalidTransition)
+
+	assert.Equal(t, domain.AccountStatusFrozen, repo.accounts[id].Status)
+	assert.Len(t, repo.history, 1)
+}
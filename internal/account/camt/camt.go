@@ -0,0 +1,297 @@
+// Package camt writes ISO 20022 bank-to-customer cash management messages:
+// the end-of-day account statement camt.053.001.08 and the intraday account
+// report camt.052.001.08.
+package camt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	namespace053 = "urn:iso:std:iso:20022:tech:xsd:camt.053.001.08"
+	namespace052 = "urn:iso:std:iso:20022:tech:xsd:camt.052.001.08"
+)
+
+// Entry is a booked ledger entry, with its transaction when there is one.
+type Entry struct {
+	Ledger      *domain.LedgerEntry
+	Transaction *domain.TransactionDetail
+}
+
+// Report is the content of a statement or intraday report.
+type Report struct {
+	CreatedAt   time.Time
+	ServicerBIC string
+	Account     *domain.Account
+	From, To    time.Time // To is exclusive
+
+	OpeningBalance int64
+	ClosingBalance int64
+	Entries        []Entry
+}
+
+// WriteStatement writes r as a camt.053 end-of-day statement, with opening
+// and closing booked balances.
+func WriteStatement(w io.Writer, r Report) error {
+	doc := document053{
+		Xmlns: namespace053,
+		Statement: bankToCustomer{
+			GroupHeader: r.groupHeader("053"),
+			Statement:   r.account("CLBD"),
+		},
+	}
+	return write(w, doc)
+}
+
+// WriteIntradayReport writes r as a camt.052 intraday report. The closing
+// balance is reported as interim booked, since the day is not over.
+func WriteIntradayReport(w io.Writer, r Report) error {
+	doc := document052{
+		Xmlns: namespace052,
+		Report: bankToCustomerReport{
+			GroupHeader: r.groupHeader("052"),
+			Report:      r.account("ITBD"),
+		},
+	}
+	return write(w, doc)
+}
+
+func write(w io.Writer, doc interface{}) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func (r Report) groupHeader(kind string) groupHeader {
+	return groupHeader{
+		MessageID: max35(fmt.Sprintf("C%s%s%s", kind, r.CreatedAt.UTC().Format("20060102150405"), compactID(r.Account.ID)[:12])),
+		CreatedAt: dateTime(r.CreatedAt),
+	}
+}
+
+func (r Report) account(closingType string) accountStatement {
+	currency := r.Account.Currency
+	lastDay := r.To.Add(-time.Nanosecond)
+
+	var servicer *branchAndInstitution
+	if r.ServicerBIC != "" {
+		servicer = &branchAndInstitution{Institution: institution{BIC: r.ServicerBIC}}
+	}
+
+	st := accountStatement{
+		ID:        max35(fmt.Sprintf("%s-%s-%s", r.From.Format("20060102"), lastDay.Format("20060102"), compactID(r.Account.ID)[:12])),
+		CreatedAt: dateTime(r.CreatedAt),
+		Period:    &fromTo{From: dateTime(r.From), To: dateTime(lastDay)},
+		Account: cashAccount{
+			ID:       accountID(r.Account.AccountNumber),
+			Currency: currency,
+			Name:     maxText(r.Account.AccountName, 70),
+			Servicer: servicer,
+		},
+		Balances: []balance{
+			newBalance("OPBD", r.OpeningBalance, currency, r.From),
+			newBalance(closingType, r.ClosingBalance, currency, lastDay),
+		},
+	}
+
+	var credits, debits numberAndSum
+	for _, e := range r.Entries {
+		if e.Ledger.Amount == 0 {
+			continue
+		}
+		if e.Ledger.Amount > 0 {
+			credits.add(e.Ledger.Amount)
+		} else {
+			debits.add(-e.Ledger.Amount)
+		}
+		st.Entries = append(st.Entries, newEntry(e, currency))
+	}
+
+	net := credits.sum - debits.sum
+	st.Summary = &transactionsSummary{
+		Total: totalEntries{
+			Count: fmt.Sprint(credits.count + debits.count),
+			Sum:   decimal(credits.sum + debits.sum),
+			Net:   netEntry{Amount: decimal(abs(net)), Indicator: indicator(net)},
+		},
+		Credits: entriesSum{Count: fmt.Sprint(credits.count), Sum: decimal(credits.sum)},
+		Debits:  entriesSum{Count: fmt.Sprint(debits.count), Sum: decimal(debits.sum)},
+	}
+
+	return st
+}
+
+func newBalance(code string, amount int64, currency string, at time.Time) balance {
+	return balance{
+		Type:      balanceType{Code: code},
+		Amount:    money{Currency: currency, Value: decimal(abs(amount))},
+		Indicator: indicator(amount),
+		Date:      dateChoice{Date: at.Format("2006-01-02")},
+	}
+}
+
+func newEntry(e Entry, currency string) entry {
+	le := e.Ledger
+	ref := compactID(le.ID)
+	valueDate := le.ValueDate
+	if valueDate.IsZero() {
+		valueDate = le.EntryDate
+	}
+
+	ntry := entry{
+		Reference:      ref,
+		Amount:         money{Currency: currency, Value: decimal(abs(le.Amount))},
+		Indicator:      indicator(le.Amount),
+		Status:         entryStatus{Code: "BOOK"},
+		BookingDate:    dateTimeChoice{DateTime: dateTime(le.EntryDate)},
+		ValueDate:      dateChoice{Date: valueDate.Format("2006-01-02")},
+		ServicerRef:    ref,
+		BankTxCode:     bankTransactionCode(e),
+		AdditionalInfo: maxText(le.Description, 500),
+	}
+
+	details := transactionDetails{
+		Refs: references{ServicerRef: ref, EndToEndID: "NOTPROVIDED"},
+	}
+	if tx := e.Transaction; tx != nil {
+		details.Refs.TxID = compactID(tx.ID)
+		if tx.Reference != "" {
+			details.Refs.EndToEndID = max35(tx.Reference)
+		}
+		if tx.CounterpartyAccountNumber != "" || tx.CounterpartyName != "" {
+			var who *party
+			if name := maxText(tx.CounterpartyName, 140); name != "" {
+				who = &party{Party: partyIdentification{Name: name}}
+			}
+			var acct *plainAccount
+			if tx.CounterpartyAccountNumber != "" {
+				acct = &plainAccount{ID: accountID(tx.CounterpartyAccountNumber)}
+			}
+			// The counterparty paid us on a credit and was paid on a debit.
+			if le.Amount > 0 {
+				details.Parties = &relatedParties{Debtor: who, DebtorAccount: acct}
+			} else {
+				details.Parties = &relatedParties{Creditor: who, CreditorAccount: acct}
+			}
+		}
+		if tx.Description != "" {
+			details.Remittance = &remittance{Unstructured: maxText(tx.Description, 140)}
+		}
+	}
+	ntry.Details = &entryDetails{Transaction: details}
+
+	return ntry
+}
+
+// bankTransactionCode classifies an entry with the ISO bank transaction code
+// domain/family/sub-family.
+func bankTransactionCode(e Entry) *bankTxCode {
+	credit := e.Ledger.Amount > 0
+	domainCode, family, sub := "ACMT", "MDOP", "OTHR"
+	if credit {
+		family = "MCOP"
+	}
+
+	switch {
+	case e.Ledger.Reference == domain.ReferenceInterest:
+		sub = "INTR"
+	case e.Transaction != nil:
+		domainCode = "PMNT"
+		switch e.Transaction.Type {
+		case "deposit":
+			family, sub = "CNTR", "CDPT"
+		case "withdrawal":
+			family, sub = "CNTR", "CWDL"
+		case "payment":
+			family, sub = "CCRD", "POSD"
+		default:
+			family, sub = "ICDT", "DMCT"
+			if credit {
+				family = "RCDT"
+			}
+		}
+	}
+
+	return &bankTxCode{Domain: &txDomain{Code: domainCode, Family: txFamily{Code: family, SubFamily: sub}}}
+}
+
+var ibanPattern = regexp.MustCompile(`^[A-Z]{2}[0-9]{2}[a-zA-Z0-9]{1,30}$`)
+
+// accountID reports account numbers that look like IBANs as IBANs and
+// everything else as a proprietary identifier.
+func accountID(number string) accountIdentification {
+	if ibanPattern.MatchString(number) {
+		return accountIdentification{IBAN: number}
+	}
+	return accountIdentification{Other: &genericID{ID: maxText(number, 34)}}
+}
+
+func indicator(amount int64) string {
+	if amount < 0 {
+		return "DBIT"
+	}
+	return "CRDT"
+}
+
+// decimal formats minor units as a decimal amount with two fraction digits.
+func decimal(minor int64) string {
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	return fmt.Sprintf("%s%d.%02d", sign, minor/100, minor%100)
+}
+
+func abs(v int64) int64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func dateTime(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05-07:00")
+}
+
+func compactID(id uuid.UUID) string {
+	return strings.ReplaceAll(id.String(), "-", "")
+}
+
+func max35(s string) string {
+	return maxText(s, 35)
+}
+
+// maxText trims s to at most n characters, the limit of the MaxNText types.
+func maxText(s string, n int) string {
+	s = strings.TrimSpace(s)
+	if r := []rune(s); len(r) > n {
+		return string(r[:n])
+	}
+	return s
+}
+
+type numberAndSum struct {
+	count int
+	sum   int64
+}
+
+func (n *numberAndSum) add(amount int64) {
+	n.count++
+	n.sum += amount
+}
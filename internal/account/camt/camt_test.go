@@ -0,0 +1,130 @@
+package camt
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testReport() Report {
+	day := func(d, h int) time.Time { return time.Date(2024, time.March, d, h, 0, 0, 0, time.UTC) }
+	account := &domain.Account{
+		ID:            uuid.New(),
+		AccountNumber: "DK5000400440116243",
+		AccountName:   "Driftskonto",
+		Currency:      "DKK",
+	}
+	counterparty := &domain.TransactionDetail{
+		ID:                        uuid.New(),
+		Type:                      "transfer",
+		Reference:                 "INV-2024-0042",
+		Description:               "Faktura 42 <marts> & moms",
+		CounterpartyAccountNumber: "DK8930000000012345",
+		CounterpartyName:          "Hansen & Søn ApS",
+	}
+
+	return Report{
+		CreatedAt:      day(31, 18),
+		ServicerBIC:    "NBNKDKKK",
+		Account:        account,
+		From:           day(1, 0),
+		To:             day(1, 0).AddDate(0, 1, 0),
+		OpeningBalance: -5_000,
+		ClosingBalance: 120_150,
+		Entries: []Entry{
+			{Ledger: &domain.LedgerEntry{ID: uuid.New(), Amount: 0, Description: "Account opened", EntryDate: day(1, 9)}},
+			{Ledger: &domain.LedgerEntry{ID: uuid.New(), Amount: 150_000, Description: "Transfer", EntryDate: day(4, 10), ValueDate: day(2, 0)}, Transaction: counterparty},
+			{Ledger: &domain.LedgerEntry{ID: uuid.New(), Amount: -25_000, Description: "Withdrawal", EntryDate: day(12, 11)}, Transaction: &domain.TransactionDetail{ID: uuid.New(), Type: "withdrawal"}},
+			{Ledger: &domain.LedgerEntry{ID: uuid.New(), Amount: 150, Reference: domain.ReferenceInterest, EntryDate: day(31, 23)}},
+		},
+	}
+}
+
+// validate checks doc with xmllint against the official ISO 20022 schema in
+// testdata, as published in the Bank-to-Customer Cash Management 2018/2019
+// message set.
+func validate(t *testing.T, doc []byte, schema string) {
+	t.Helper()
+	xmllint, err := exec.LookPath("xmllint")
+	require.NoError(t, err, "xmllint is needed to validate against the schema (apt install libxml2-utils)")
+
+	file := filepath.Join(t.TempDir(), "doc.xml")
+	require.NoError(t, os.WriteFile(file, doc, 0o600))
+
+	out, err := exec.Command(xmllint, "--noout", "--schema", filepath.Join("testdata", schema), file).CombinedOutput()
+	require.NoError(t, err, "schema validation failed:\n%s\n%s", out, doc)
+}
+
+var betweenTags = regexp.MustCompile(`>\s+<`)
+
+// compact drops the indentation so assertions can span several elements.
+func compact(doc []byte) string {
+	return betweenTags.ReplaceAllString(string(doc), "><")
+}
+
+func TestWriteStatement_ValidCamt053(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteStatement(&buf, testReport()))
+	validate(t, buf.Bytes(), "camt.053.001.08.xsd")
+	doc := compact(buf.Bytes())
+
+	assert.Contains(t, doc, `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">`)
+	assert.Contains(t, doc, "<IBAN>DK5000400440116243</IBAN>")
+	assert.Contains(t, doc, "<FrDtTm>2024-03-01T00:00:00+00:00</FrDtTm>")
+	assert.Contains(t, doc, "<ToDtTm>2024-03-31T23:59:59+00:00</ToDtTm>")
+
+	// Opening balance is overdrawn, closing is in credit.
+	assert.Contains(t, doc, `<Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="DKK">50.00</Amt><CdtDbtInd>DBIT</CdtDbtInd>`)
+	assert.Contains(t, doc, "<Cd>CLBD</Cd>")
+	assert.Contains(t, doc, `<Amt Ccy="DKK">1201.50</Amt>`)
+
+	// The zero-amount opening entry is left out.
+	assert.Equal(t, 3, strings.Count(doc, "<Ntry>"))
+	assert.Contains(t, doc, "<NbOfNtries>3</NbOfNtries><Sum>1751.50</Sum>")
+	assert.Contains(t, doc, "<Amt>1251.50</Amt><CdtDbtInd>CRDT</CdtDbtInd>")
+
+	// A back-valued entry keeps its value date; others fall back to booking
+	assert.Contains(t, doc, "<BookgDt><DtTm>2024-03-04T10:00:00+00:00</DtTm></BookgDt><ValDt><Dt>2024-03-02</Dt></ValDt>")
+	assert.Contains(t, doc, "<BookgDt><DtTm>2024-03-12T11:00:00+00:00</DtTm></BookgDt><ValDt><Dt>2024-03-12</Dt></ValDt>")
+
+	assert.Contains(t, doc, "<Cd>RCDT</Cd><SubFmlyCd>DMCT</SubFmlyCd>")
+	assert.Contains(t, doc, "<Cd>CNTR</Cd><SubFmlyCd>CWDL</SubFmlyCd>")
+	assert.Contains(t, doc, "<Cd>MCOP</Cd><SubFmlyCd>INTR</SubFmlyCd>")
+	assert.Contains(t, doc, "<EndToEndId>INV-2024-0042</EndToEndId>")
+	assert.Contains(t, doc, "<Nm>Hansen &amp; Søn ApS</Nm>")
+	assert.Contains(t, doc, "<Ustrd>Faktura 42 &lt;marts&gt; &amp; moms</Ustrd>")
+}
+
+func TestWriteIntradayReport_ValidCamt052(t *testing.T) {
+	r := testReport()
+	r.From = time.Date(2024, time.March, 4, 0, 0, 0, 0, time.UTC)
+	r.To = time.Date(2024, time.March, 4, 14, 30, 0, 0, time.UTC)
+	r.Entries = r.Entries[1:2]
+	r.ServicerBIC = ""
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteIntradayReport(&buf, r))
+	validate(t, buf.Bytes(), "camt.052.001.08.xsd")
+	doc := compact(buf.Bytes())
+
+	assert.Contains(t, doc, "<BkToCstmrAcctRpt>")
+	assert.Contains(t, doc, "<Cd>ITBD</Cd>")
+	assert.NotContains(t, doc, "<Svcr>")
+	assert.Equal(t, 1, strings.Count(doc, "<Ntry>"))
+}
+
+func TestAccountID_FallsBackToProprietary(t *testing.T) {
+	assert.Equal(t, accountIdentification{IBAN: "DK5000400440116243"}, accountID("DK5000400440116243"))
+	assert.Equal(t, accountIdentification{Other: &genericID{ID: "4400-116243"}}, accountID("4400-116243"))
+}
@@ -0,0 +1,199 @@
+package camt
+
+import "encoding/xml"
+
+// The types below mirror the camt.05x.001.08 schemas. Field order matters:
+// the schemas are sequences, so elements must be declared in schema order.
+
+type document053 struct {
+	XMLName   xml.Name       `xml:"Document"`
+	Xmlns     string         `xml:"xmlns,attr"`
+	Statement bankToCustomer `xml:"BkToCstmrStmt"`
+}
+
+type bankToCustomer struct {
+	GroupHeader groupHeader      `xml:"GrpHdr"`
+	Statement   accountStatement `xml:"Stmt"`
+}
+
+type document052 struct {
+	XMLName xml.Name             `xml:"Document"`
+	Xmlns   string               `xml:"xmlns,attr"`
+	Report  bankToCustomerReport `xml:"BkToCstmrAcctRpt"`
+}
+
+type bankToCustomerReport struct {
+	GroupHeader groupHeader      `xml:"GrpHdr"`
+	Report      accountStatement `xml:"Rpt"`
+}
+
+// GroupHeader81
+type groupHeader struct {
+	MessageID string `xml:"MsgId"`
+	CreatedAt string `xml:"CreDtTm"`
+}
+
+// AccountStatement9 and AccountReport25, which share their leading elements.
+type accountStatement struct {
+	ID        string               `xml:"Id"`
+	CreatedAt string               `xml:"CreDtTm"`
+	Period    *fromTo              `xml:"FrToDt,omitempty"`
+	Account   cashAccount          `xml:"Acct"`
+	Balances  []balance            `xml:"Bal"`
+	Summary   *transactionsSummary `xml:"TxsSummry,omitempty"`
+	Entries   []entry              `xml:"Ntry"`
+}
+
+type fromTo struct {
+	From string `xml:"FrDtTm"`
+	To   string `xml:"ToDtTm"`
+}
+
+// CashAccount39
+type cashAccount struct {
+	ID       accountIdentification `xml:"Id"`
+	Currency string                `xml:"Ccy,omitempty"`
+	Name     string                `xml:"Nm,omitempty"`
+	Servicer *branchAndInstitution `xml:"Svcr,omitempty"`
+}
+
+// CashAccount38
+type plainAccount struct {
+	ID accountIdentification `xml:"Id"`
+}
+
+type accountIdentification struct {
+	IBAN  string     `xml:"IBAN,omitempty"`
+	Other *genericID `xml:"Othr,omitempty"`
+}
+
+type genericID struct {
+	ID string `xml:"Id"`
+}
+
+type branchAndInstitution struct {
+	Institution institution `xml:"FinInstnId"`
+}
+
+type institution struct {
+	BIC string `xml:"BICFI,omitempty"`
+}
+
+// CashBalance8
+type balance struct {
+	Type      balanceType `xml:"Tp"`
+	Amount    money       `xml:"Amt"`
+	Indicator string      `xml:"CdtDbtInd"`
+	Date      dateChoice  `xml:"Dt"`
+}
+
+type balanceType struct {
+	Code string `xml:"CdOrPrtry>Cd"`
+}
+
+type money struct {
+	Currency string `xml:"Ccy,attr"`
+	Value    string `xml:",chardata"`
+}
+
+type dateChoice struct {
+	Date string `xml:"Dt"`
+}
+
+type dateTimeChoice struct {
+	DateTime string `xml:"DtTm"`
+}
+
+// TotalTransactions6
+type transactionsSummary struct {
+	Total   totalEntries `xml:"TtlNtries"`
+	Credits entriesSum   `xml:"TtlCdtNtries"`
+	Debits  entriesSum   `xml:"TtlDbtNtries"`
+}
+
+type totalEntries struct {
+	Count string   `xml:"NbOfNtries"`
+	Sum   string   `xml:"Sum"`
+	Net   netEntry `xml:"TtlNetNtry"`
+}
+
+type netEntry struct {
+	Amount    string `xml:"Amt"`
+	Indicator string `xml:"CdtDbtInd"`
+}
+
+type entriesSum struct {
+	Count string `xml:"NbOfNtries"`
+	Sum   string `xml:"Sum"`
+}
+
+// ReportEntry10
+type entry struct {
+	Reference      string         `xml:"NtryRef"`
+	Amount         money          `xml:"Amt"`
+	Indicator      string         `xml:"CdtDbtInd"`
+	Status         entryStatus    `xml:"Sts"`
+	BookingDate    dateTimeChoice `xml:"BookgDt"`
+	ValueDate      dateChoice     `xml:"ValDt"`
+	ServicerRef    string         `xml:"AcctSvcrRef"`
+	BankTxCode     *bankTxCode    `xml:"BkTxCd"`
+	Details        *entryDetails  `xml:"NtryDtls,omitempty"`
+	AdditionalInfo string         `xml:"AddtlNtryInf,omitempty"`
+}
+
+type entryStatus struct {
+	Code string `xml:"Cd"`
+}
+
+type bankTxCode struct {
+	Domain *txDomain `xml:"Domn"`
+}
+
+type txDomain struct {
+	Code   string   `xml:"Cd"`
+	Family txFamily `xml:"Fmly"`
+}
+
+type txFamily struct {
+	Code      string `xml:"Cd"`
+	SubFamily string `xml:"SubFmlyCd"`
+}
+
+type entryDetails struct {
+	Transaction transactionDetails `xml:"TxDtls"`
+}
+
+// EntryTransaction10
+type transactionDetails struct {
+	Refs       references      `xml:"Refs"`
+	Parties    *relatedParties `xml:"RltdPties,omitempty"`
+	Remittance *remittance     `xml:"RmtInf,omitempty"`
+}
+
+// TransactionReferences6
+type references struct {
+	ServicerRef string `xml:"AcctSvcrRef,omitempty"`
+	EndToEndID  string `xml:"EndToEndId,omitempty"`
+	TxID        string `xml:"TxId,omitempty"`
+}
+
+// TransactionParties6
+type relatedParties struct {
+	Debtor          *party        `xml:"Dbtr,omitempty"`
+	DebtorAccount   *plainAccount `xml:"DbtrAcct,omitempty"`
+	Creditor        *party        `xml:"Cdtr,omitempty"`
+	CreditorAccount *plainAccount `xml:"CdtrAcct,omitempty"`
+}
+
+// Party40Choice
+type party struct {
+	Party partyIdentification `xml:"Pty"`
+}
+
+type partyIdentification struct {
+	Name string `xml:"Nm"`
+}
+
+type remittance struct {
+	Unstructured string `xml:"Ustrd"`
+}
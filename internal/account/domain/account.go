@@ -31,25 +31,89 @@ type Account struct {
 	AccountName   string      `gorm:"size:255"`
 	AccountType   AccountType `gorm:"type:account.account_type;not null"`
 	Currency      string      `gorm:"size:3;default:'DKK'"`
+	ProductCode   string      `gorm:"size:32;index"` // Empty for accounts opened before the catalogue
 
 	Balance          int64 `gorm:"not null;default:0"` // Minor units (e.g. øre)
 	AvailableBalance int64 `gorm:"not null;default:0"`
 	ReservedAmount   int64 `gorm:"not null;default:0"`
+	SavedAmount      int64 `gorm:"not null;default:0"` // Set aside in pots
+
+	// Limits
+	OverdraftLimit int64 `gorm:"not null;default:0"` // How far below zero the balance may go
+	MinimumBalance int64 `gorm:"not null;default:0"`
+
+	// Interest (for savings accounts)
+	InterestRateBps             int64              `gorm:"not null;default:0"` // Flat annual rate, used when no tiers are set
+	InterestAccrued             int64              `gorm:"not null;default:0"` // Accrued but not yet capitalised
+	LastInterestCalculationDate *time.Time         `gorm:"type:date"`
+	DayCountConvention          DayCountConvention `gorm:"size:10;default:'ACT/365'"`
 
 	Status AccountStatus `gorm:"type:account.account_status;default:'active'"`
 
+	// Dormancy
+	DormantSince     *time.Time `gorm:"type:date"`
+	DormancyNoticeAt *time.Time // When the customer was last warned the account is about to go dormant
+
 	IsFavorite bool `gorm:"default:false"`
 
 	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	OpenedAt  time.Time `gorm:"default:CURRENT_DATE"`
 	ClosedAt  *time.Time
+
+	ClosureReason string `gorm:"type:text"`
+
+	// SubBalances are the balances in other currencies. Only filled in by
+	// the service when asked for.
+	SubBalances []SubBalance `gorm:"-"`
 }
 
 func (Account) TableName() string {
 	return "account.accounts"
 }
 
+// DebitFloor is the lowest the available balance may drop to. The minimum
+// balance applies on its own; an overdraft facility extends the floor below it.
+func (a *Account) DebitFloor() int64 {
+	return a.MinimumBalance - a.OverdraftLimit
+}
+
+// CanDebit reports whether amount can be taken from the available balance
+// without breaching the minimum balance or overdraft limit.
+func (a *Account) CanDebit(amount int64) bool {
+	return a.AvailableBalance-amount >= a.DebitFloor()
+}
+
+// HideBalances blanks the balance figures, for holders allowed to use the
+// account but not to see what is on it.
+func (a *Account) HideBalances() {
+	a.Balance = 0
+	a.AvailableBalance = 0
+	a.ReservedAmount = 0
+	a.SavedAmount = 0
+	a.InterestAccrued = 0
+	a.SubBalances = nil
+}
+
+// ReferenceClosure marks the transfer that sweeps the remaining balance out
+// of an account being closed, and the zero-amount entry that closes it.
+const ReferenceClosure = "CLOSURE"
+
+// ReferenceReactivation marks the zero-amount ledger entry made when a dormant
+// account is reactivated, which restarts the inactivity clock.
+const ReferenceReactivation = "REACTIVATE"
+
+// ReferenceCorrection marks a back-dated entry posted by operations to put
+// right an earlier mistake.
+const ReferenceCorrection = "CORRECTION"
+
+// InactiveAccount is an active account together with the time of its last
+// ledger entry, as found by the dormancy scan.
+type InactiveAccount struct {
+	Account      *Account
+	LastActivity time.Time
+}
+
 type LedgerEntryType string
 
 const (
@@ -63,11 +127,15 @@ type LedgerEntry struct {
 	TransactionID *uuid.UUID      `gorm:"type:uuid"`
 	EntryType     LedgerEntryType `gorm:"not null"`
 	Amount        int64           `gorm:"not null"`
+	Currency      string          `gorm:"size:3"` // The balance the entry was booked on
 	BalanceBefore int64           `gorm:"not null"`
 	BalanceAfter  int64           `gorm:"not null"`
 	Description   string          `gorm:"type:text"`
 	Reference     string          `gorm:"size:100"`
 	EntryDate     time.Time       `gorm:"default:CURRENT_TIMESTAMP"`
+	ValueDate     time.Time       `gorm:"type:date"` // When the money counts from, e.g. for interest
+	PostedDate    time.Time       `gorm:"type:date"` // Business day the entry was booked on
+	Leg           string          `gorm:"size:30"`   // Step of the transaction it posts; unique per transaction
 }
 
 func (LedgerEntry) TableName() string {
@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SubBalance is money an account holds in a currency other than its own.
+// The account's own currency stays on Account. Sub-balances have no
+// overdraft and cannot go below zero.
+type SubBalance struct {
+	AccountID        uuid.UUID `gorm:"type:uuid;primaryKey"`
+	Currency         string    `gorm:"size:3;primaryKey"`
+	Balance          int64     `gorm:"not null;default:0"` // Minor units of Currency
+	AvailableBalance int64     `gorm:"not null;default:0"`
+	ReservedAmount   int64     `gorm:"not null;default:0"`
+	CreatedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt        time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (SubBalance) TableName() string {
+	return "account.account_sub_balances"
+}
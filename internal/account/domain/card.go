@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type CardStatus string
+
+const (
+	CardStatusInactive  CardStatus = "inactive" // Issued, waiting for the customer to activate it
+	CardStatusActive    CardStatus = "active"
+	CardStatusBlocked   CardStatus = "blocked"
+	CardStatusExpired   CardStatus = "expired"
+	CardStatusCancelled CardStatus = "cancelled"
+)
+
+type CardBrand string
+
+const (
+	CardBrandVisa       CardBrand = "visa"
+	CardBrandMastercard CardBrand = "mastercard"
+)
+
+// Card is a debit card on an account. The card number is never stored: the
+// token refers to it in the card vault and only the last four digits are kept
+// for display.
+type Card struct {
+	ID         uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID  uuid.UUID `gorm:"type:uuid;not null;index"`
+	CustomerID uuid.UUID `gorm:"type:uuid;not null;index"`
+
+	CardNumberToken string    `gorm:"size:255;not null;uniqueIndex" json:"-"`
+	CardLastFour    string    `gorm:"type:char(4);not null"`
+	CardType        string    `gorm:"size:20;default:'debit'"`
+	CardBrand       CardBrand `gorm:"size:20"`
+
+	ExpiryMonth int `gorm:"type:smallint;not null"`
+	ExpiryYear  int `gorm:"type:smallint;not null"`
+
+	// Limits, in minor units of the account currency
+	DailyLimit    int64 `gorm:"not null;default:0"`
+	MonthlyLimit  int64 `gorm:"not null;default:0"`
+	ATMDailyLimit int64 `gorm:"column:atm_daily_limit;not null;default:0"`
+
+	Status      CardStatus `gorm:"size:20;default:'inactive';index"`
+	BlockedAt   *time.Time
+	BlockReason string `gorm:"size:255"`
+
+	PinHash        string `gorm:"size:255" json:"-"`
+	PinAttempts    int    `gorm:"not null;default:0"`
+	PinLockedUntil *time.Time
+
+	ThreeDSecureEnabled bool `gorm:"column:three_d_secure_enabled;default:true"`
+
+	IssuedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	ActivatedAt *time.Time
+	CancelledAt *time.Time
+	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Card) TableName() string {
+	return "account.account_cards"
+}
+
+// Expired reports whether the card is past the end of its expiry month.
+func (c *Card) Expired(now time.Time) bool {
+	end := time.Date(c.ExpiryYear, time.Month(c.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	return !now.Before(end)
+}
+
+// PinLocked reports whether too many wrong PINs have locked the card.
+func (c *Card) PinLocked(now time.Time) bool {
+	return c.PinLockedUntil != nil && now.Before(*c.PinLockedUntil)
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCard_JSONHidesSecrets(t *testing.T) {
+	body, err := json.Marshal(Card{CardNumberToken: "tok_4111", CardLastFour: "1111", PinHash: "$2a$10$hash"})
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(body), "tok_4111")
+	assert.NotContains(t, string(body), "$2a$10$hash")
+	assert.Contains(t, string(body), `"CardLastFour":"1111"`)
+}
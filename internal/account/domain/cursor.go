@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is a position in a listing ordered by a timestamp and then by ID,
+// used for keyset pagination.
+type Cursor struct {
+	At time.Time
+	ID uuid.UUID
+}
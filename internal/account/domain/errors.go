@@ -0,0 +1,72 @@
+package domain
+
+import "errors"
+
+var (
+	ErrAccountNotFound         = errors.New("account not found")
+	ErrInvalidAccountNumber    = errors.New("invalid account number")
+	ErrInsufficientFunds       = errors.New("insufficient funds")
+	ErrAccountNotActive        = errors.New("account is not active")
+	ErrReservationNotActive    = errors.New("reservation is not active")
+	ErrReservationExpired      = errors.New("reservation has expired")
+	ErrReservationNotFound     = errors.New("reservation not found")
+	ErrCaptureExceedsHold      = errors.New("capture amount exceeds reserved amount")
+	ErrInvalidAmount           = errors.New("amount must be positive")
+	ErrOverdraftNotAllowed     = errors.New("the account's product does not allow an overdraft facility")
+	ErrOverdraftInUse          = errors.New("overdraft limit cannot be reduced below the amount currently drawn")
+	ErrInvalidPeriod           = errors.New("period end must be after period start")
+	ErrPeriodNotEnded          = errors.New("statement period has not ended yet")
+	ErrStatementExists         = errors.New("a statement already exists for this period")
+	ErrStatementUnbalanced     = errors.New("ledger does not reconcile for the statement period")
+	ErrStatementNotFound       = errors.New("statement not found for this account")
+	ErrStatementNotFinalized   = errors.New("only finalised statements can be rendered")
+	ErrUnsupportedDocument     = errors.New("unsupported statement document")
+	ErrDocumentStoreMissing    = errors.New("no document store is configured")
+	ErrHolderNotFound          = errors.New("customer is not a holder of this account")
+	ErrHolderExists            = errors.New("customer already holds this account")
+	ErrInvalidRelationship     = errors.New("holders can only be added as joint_owner or authorized_user")
+	ErrPrimaryOwner            = errors.New("the primary owner cannot be removed from the account")
+	ErrPermissionDenied        = errors.New("account holder lacks the required permission")
+	ErrCardNotFound            = errors.New("card not found")
+	ErrCardStatus              = errors.New("card is not in a state that allows this")
+	ErrCardExpired             = errors.New("card has expired")
+	ErrInvalidCardBrand        = errors.New("card brand must be visa or mastercard")
+	ErrInvalidCardLimit        = errors.New("card limits must not be negative and the ATM limit must fit within the daily limit")
+	ErrInvalidPIN              = errors.New("pin must be four digits")
+	ErrPINNotSet               = errors.New("no pin has been set for this card")
+	ErrIncorrectPIN            = errors.New("incorrect pin")
+	ErrPINLocked               = errors.New("card is locked after too many incorrect pins")
+	ErrCardVaultMissing        = errors.New("no card vault is configured")
+	ErrAccountDormant          = errors.New("account is dormant and must be reactivated before money can be taken out")
+	ErrAccountNotDormant       = errors.New("account is not dormant")
+	ErrReactivationRequired    = errors.New("dormant accounts are reactivated through an approved reactivation request")
+	ErrRequestPending          = errors.New("a request for this account is already pending")
+	ErrRequestProcessed        = errors.New("request is already processed")
+	ErrRequestNotFound         = errors.New("request not found")
+	ErrInvalidRequestStatus    = errors.New("requests can only be approved or rejected")
+	ErrRejectionReasonRequired = errors.New("a reason the customer can see is required to reject a request")
+	ErrInvalidLedgerFilter     = errors.New("invalid ledger filter")
+	ErrInvalidCursor           = errors.New("invalid page cursor")
+	ErrInvalidBalanceBasis     = errors.New("balance basis must be booking or value")
+	ErrInvalidValueDate        = errors.New("value date must fall between the account's opening and today")
+	ErrAccountClosed           = errors.New("account is closed and cannot be reopened")
+	ErrClosureRequired         = errors.New("accounts are closed through the closure workflow")
+	ErrClosureBlocked          = errors.New("account cannot be closed yet")
+	ErrSweepAccountRequired    = errors.New("a sweep account is required to pay out the remaining balance")
+	ErrInvalidSweepAccount     = errors.New("sweep account must be another active account in the same currency")
+	ErrTransferClientMissing   = errors.New("no transaction service is configured")
+	ErrInvalidStatus           = errors.New("unknown account status")
+	ErrInvalidTransition       = errors.New("account cannot move to this status from its current one")
+	ErrStatusReasonRequired    = errors.New("a reason is required to change the account status")
+	ErrUnsupportedCurrency     = errors.New("currency is not supported")
+	ErrCurrencyNotHeld         = errors.New("account holds no balance in this currency")
+	ErrPotNotFound             = errors.New("pot not found")
+	ErrInvalidPot              = errors.New("pots need a name, a target that is not negative and a target date in the future")
+	ErrProductNotFound         = errors.New("product not found")
+	ErrProductExists           = errors.New("a product with this code already exists")
+	ErrProductRetired          = errors.New("product is no longer offered")
+	ErrInvalidProduct          = errors.New("invalid product")
+	ErrNotEligible             = errors.New("customer is not eligible for this product")
+	ErrCustomerNotFound        = errors.New("customer not found")
+	ErrSubBalanceExists        = errors.New("account already holds a balance in this currency")
+)
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type HolderRelationship string
+
+const (
+	RelationshipOwner          HolderRelationship = "owner"
+	RelationshipJointOwner     HolderRelationship = "joint_owner"
+	RelationshipAuthorizedUser HolderRelationship = "authorized_user"
+)
+
+type HolderPermission string
+
+const (
+	PermissionWithdraw         HolderPermission = "withdraw"
+	PermissionTransfer         HolderPermission = "transfer"
+	PermissionViewBalance      HolderPermission = "view_balance"
+	PermissionViewTransactions HolderPermission = "view_transactions"
+	// PermissionManageHolders is not a flag of its own: only owners and joint
+	// owners may add or remove holders.
+	PermissionManageHolders HolderPermission = "manage_holders"
+)
+
+// AccountHolder links a customer to an account they did not necessarily open.
+// The customer on Account.CustomerID is the primary owner; further owners and
+// authorised users are added here. Removed holders keep their row with
+// RemovedAt set.
+type AccountHolder struct {
+	AccountID        uuid.UUID          `gorm:"type:uuid;primaryKey"`
+	CustomerID       uuid.UUID          `gorm:"type:uuid;primaryKey;index"`
+	RelationshipType HolderRelationship `gorm:"size:50;default:'owner'"`
+	PermissionLevel  string             `gorm:"size:20;default:'full'"` // full, view_only, limited
+
+	// Permissions. No gorm defaults: false must be written as false.
+	CanWithdraw         bool `gorm:"not null"`
+	CanTransfer         bool `gorm:"not null"`
+	CanViewBalance      bool `gorm:"not null"`
+	CanViewTransactions bool `gorm:"not null"`
+
+	AddedAt   time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+	AddedBy   *uuid.UUID `gorm:"type:uuid"`
+	RemovedAt *time.Time
+}
+
+func (AccountHolder) TableName() string {
+	return "account.account_holders"
+}
+
+// IsOwner reports whether the holder owns the account rather than merely
+// being authorised to use it.
+func (h *AccountHolder) IsOwner() bool {
+	return h.RelationshipType == RelationshipOwner || h.RelationshipType == RelationshipJointOwner
+}
+
+// Allows reports whether the holder has the given permission.
+func (h *AccountHolder) Allows(p HolderPermission) bool {
+	switch p {
+	case PermissionWithdraw:
+		return h.CanWithdraw
+	case PermissionTransfer:
+		return h.CanTransfer
+	case PermissionViewBalance:
+		return h.CanViewBalance
+	case PermissionViewTransactions:
+		return h.CanViewTransactions
+	case PermissionManageHolders:
+		return h.IsOwner()
+	}
+	return false
+}
+
+// GrantAll gives the holder every permission, as owners always have.
+func (h *AccountHolder) GrantAll() {
+	h.CanWithdraw = true
+	h.CanTransfer = true
+	h.CanViewBalance = true
+	h.CanViewTransactions = true
+	h.PermissionLevel = "full"
+}
+
+// SetPermissionLevel derives the coarse permission level stored next to the
+// individual flags.
+func (h *AccountHolder) SetPermissionLevel() {
+	switch {
+	case h.CanWithdraw && h.CanTransfer && h.CanViewBalance && h.CanViewTransactions:
+		h.PermissionLevel = "full"
+	case !h.CanWithdraw && !h.CanTransfer:
+		h.PermissionLevel = "view_only"
+	default:
+		h.PermissionLevel = "limited"
+	}
+}
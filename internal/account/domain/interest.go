@@ -0,0 +1,116 @@
+package domain
+
+import (
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ReferenceInterest marks the ledger entry that capitalises accrued interest.
+const ReferenceInterest = "INTEREST"
+
+type DayCountConvention string
+
+const (
+	// DayCountAct365 accrues 1/365 of the annual rate for every calendar day,
+	// leap years included (ACT/365 Fixed).
+	DayCountAct365 DayCountConvention = "ACT/365"
+	// DayCount30360 treats every month as 30 days and the year as 360
+	// (30E/360): a 31-day month still earns 30 days and the last day of
+	// February makes up the missing ones.
+	DayCount30360 DayCountConvention = "30/360"
+)
+
+func (c DayCountConvention) Valid() bool {
+	return c == DayCountAct365 || c == DayCount30360
+}
+
+// dayFraction returns the share of a year, as num/den, that interest for the
+// given day represents.
+func (c DayCountConvention) dayFraction(day time.Time) (num, den int64) {
+	if c == DayCount30360 {
+		return days360(day, day.AddDate(0, 0, 1)), 360
+	}
+	return 1, 365
+}
+
+// days360 counts days between two dates under the 30E/360 convention.
+func days360(from, to time.Time) int64 {
+	d1, d2 := from.Day(), to.Day()
+	if d1 == 31 {
+		d1 = 30
+	}
+	if d2 == 31 {
+		d2 = 30
+	}
+	return int64(360*(to.Year()-from.Year()) + 30*(int(to.Month())-int(from.Month())) + (d2 - d1))
+}
+
+// InterestTier is one balance band of a tiered rate. The band starts at
+// FromBalance and runs up to the next tier's FromBalance; the portion of the
+// balance inside the band earns RateBps.
+type InterestTier struct {
+	ID          uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID   uuid.UUID `gorm:"type:uuid;not null;index"`
+	FromBalance int64     `gorm:"not null"` // Minor units
+	RateBps     int64     `gorm:"not null"` // Annual rate in basis points (250 = 2.50%)
+}
+
+func (InterestTier) TableName() string {
+	return "account.interest_tiers"
+}
+
+// InterestAccrual records one day's interest for an account. There is at most
+// one row per account and day, which is what makes accrual runs repeatable.
+// LedgerEntryID is set once the amount has been capitalised.
+type InterestAccrual struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID     uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_interest_accrual_day"`
+	AccrualDate   time.Time  `gorm:"type:date;not null;uniqueIndex:idx_interest_accrual_day"`
+	Balance       int64      `gorm:"not null"` // Balance the interest was calculated on
+	Amount        int64      `gorm:"not null"` // Minor units
+	LedgerEntryID *uuid.UUID `gorm:"type:uuid;index"`
+	CreatedAt     time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (InterestAccrual) TableName() string {
+	return "account.interest_accruals"
+}
+
+// DailyInterest returns one day's interest on balance in minor units, rounded
+// half up. Negative balances earn nothing. tiers need not be sorted; the
+// lowest band is assumed to start at zero.
+func DailyInterest(balance int64, tiers []InterestTier, convention DayCountConvention, day time.Time) int64 {
+	if balance <= 0 || len(tiers) == 0 {
+		return 0
+	}
+
+	sorted := make([]InterestTier, len(tiers))
+	copy(sorted, tiers)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].FromBalance < sorted[j].FromBalance })
+
+	// Sum of (portion in band * rate in bps) over all bands.
+	weighted := new(big.Int)
+	for i, tier := range sorted {
+		lower := tier.FromBalance
+		if lower >= balance {
+			break
+		}
+		upper := balance
+		if i+1 < len(sorted) && sorted[i+1].FromBalance < upper {
+			upper = sorted[i+1].FromBalance
+		}
+		portion := big.NewInt(upper - lower)
+		weighted.Add(weighted, portion.Mul(portion, big.NewInt(tier.RateBps)))
+	}
+
+	num, den := convention.dayFraction(day)
+	numerator := weighted.Mul(weighted, big.NewInt(num))
+	denominator := big.NewInt(10_000 * den)
+
+	// Round half up: (n + d/2) / d
+	numerator.Add(numerator, new(big.Int).Quo(denominator, big.NewInt(2)))
+	return numerator.Quo(numerator, denominator).Int64()
+}
@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func TestDailyInterest_Act365(t *testing.T) {
+	tiers := []InterestTier{{FromBalance: 0, RateBps: 365}} // 3.65%
+
+	// 100,000.00 DKK at 3.65% for one day is exactly 10.00 DKK.
+	assert.Equal(t, int64(1_000), DailyInterest(10_000_000, tiers, DayCountAct365, date(2026, 3, 15)))
+	// Leap years still divide by 365.
+	assert.Equal(t, int64(1_000), DailyInterest(10_000_000, tiers, DayCountAct365, date(2028, 2, 29)))
+}
+
+func TestDailyInterest_30360(t *testing.T) {
+	tiers := []InterestTier{{FromBalance: 0, RateBps: 360}} // 3.60%
+	balance := int64(10_000_000)
+
+	assert.Equal(t, int64(1_000), DailyInterest(balance, tiers, DayCount30360, date(2026, 1, 15)))
+	assert.Equal(t, int64(0), DailyInterest(balance, tiers, DayCount30360, date(2026, 1, 30)), "30th to 31st is zero days")
+	assert.Equal(t, int64(3_000), DailyInterest(balance, tiers, DayCount30360, date(2026, 2, 28)), "end of February fills the month to 30 days")
+
+	// Every month adds up to 30 days of interest.
+	for m := time.January; m <= time.December; m++ {
+		var month int64
+		for d := date(2026, m, 1); d.Month() == m; d = d.AddDate(0, 0, 1) {
+			month += DailyInterest(balance, tiers, DayCount30360, d)
+		}
+		assert.Equal(t, int64(30_000), month, m.String())
+	}
+}
+
+func TestDailyInterest_Tiers(t *testing.T) {
+	tiers := []InterestTier{
+		{FromBalance: 5_000_000, RateBps: 730}, // 7.30% above 50,000.00
+		{FromBalance: 0, RateBps: 365},         // 3.65% up to 50,000.00
+	}
+
+	// 50,000 at 3.65% (5.00) + 50,000 at 7.30% (10.00)
+	assert.Equal(t, int64(1_500), DailyInterest(10_000_000, tiers, DayCountAct365, date(2026, 6, 1)))
+	// Entirely inside the first band
+	assert.Equal(t, int64(500), DailyInterest(5_000_000, tiers, DayCountAct365, date(2026, 6, 1)))
+}
+
+func TestDailyInterest_NothingOnNegativeBalance(t *testing.T) {
+	tiers := []InterestTier{{FromBalance: 0, RateBps: 500}}
+	assert.Equal(t, int64(0), DailyInterest(-10_000, tiers, DayCountAct365, date(2026, 6, 1)))
+}
+
+func TestDailyInterest_RoundsHalfUp(t *testing.T) {
+	tiers := []InterestTier{{FromBalance: 0, RateBps: 10_000}} // 100%
+	// 182.5 / 365 = 0.5 → 1
+	assert.Equal(t, int64(1), DailyInterest(183, tiers, DayCountAct365, date(2026, 6, 1)))
+	assert.Equal(t, int64(0), DailyInterest(182, tiers, DayCountAct365, date(2026, 6, 1)))
+}
@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// LedgerFilter narrows a listing of an account's ledger. Entries are listed
+// newest first; Before continues a listing from the entry a previous page
+// ended on. Amount bounds apply to the size of the entry, whichever way it
+// went, and Reference matches as a case-insensitive prefix.
+type LedgerFilter struct {
+	From      *time.Time // Inclusive
+	To        *time.Time // Exclusive
+	EntryType *LedgerEntryType
+	MinAmount *int64
+	MaxAmount *int64
+	Reference string
+	Currency  string
+	Before    *Cursor
+	Limit     int
+}
+
+// LedgerLine is a ledger entry with the running balance of its currency,
+// summed from the account's ledger up to and including the entry. It matches
+// BalanceAfter unless a balance was changed without a ledger entry.
+type LedgerLine struct {
+	LedgerEntry    `gorm:"embedded"`
+	RunningBalance int64
+}
+
+// HideBalances blanks the balances on the line for holders who may see
+// transactions but not balances.
+func (l *LedgerLine) HideBalances() {
+	l.BalanceBefore = 0
+	l.BalanceAfter = 0
+	l.RunningBalance = 0
+}
+
+// Valid reports whether t is a known ledger entry type.
+func (t LedgerEntryType) Valid() bool {
+	return t == EntryTypeDebit || t == EntryTypeCredit
+}
+
+// BalanceBasis chooses which date of a ledger entry decides whether it counts
+// towards a historical balance.
+type BalanceBasis string
+
+const (
+	BalanceBasisBooking BalanceBasis = "booking" // When the entry was made
+	BalanceBasisValue   BalanceBasis = "value"   // From its value date
+)
+
+// Valid reports whether b is a known balance basis.
+func (b BalanceBasis) Valid() bool {
+	return b == BalanceBasisBooking || b == BalanceBasisValue
+}
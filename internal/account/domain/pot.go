@@ -0,0 +1,74 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Pot is money a customer has set aside inside an account, such as for a
+// holiday or the tax bill. Pot balances stay part of the account's balance
+// but are counted in Account.SavedAmount and excluded from the available
+// balance. A pot has no account number of its own.
+type Pot struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID    uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Name         string     `gorm:"size:100;not null"`
+	Balance      int64      `gorm:"not null;default:0"` // Minor units of the account's currency
+	TargetAmount int64      `gorm:"not null;default:0"` // Zero when the pot has no target
+	TargetDate   *time.Time `gorm:"type:date"`
+	CreatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt    time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Pot) TableName() string {
+	return "account.account_pots"
+}
+
+// PotEntry records a move between an account and one of its pots. Positive
+// amounts went into the pot.
+type PotEntry struct {
+	ID           uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	PotID        uuid.UUID `gorm:"type:uuid;not null;index"`
+	AccountID    uuid.UUID `gorm:"type:uuid;not null"`
+	Amount       int64     `gorm:"not null"`
+	BalanceAfter int64     `gorm:"not null"` // Of the pot
+	CreatedAt    time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (PotEntry) TableName() string {
+	return "account.pot_entries"
+}
+
+// PotProgress is how far a pot is from its target.
+type PotProgress struct {
+	Pot       *Pot
+	Percent   int64 // Of the target reached, capped at 100
+	Remaining int64 // Still to save
+	// MonthlySaving is what has to go in every month, this one included, to
+	// reach the target by its date. Zero without a target date.
+	MonthlySaving int64
+}
+
+// Progress reports the pot's progress towards its target as of now.
+func (p *Pot) Progress(now time.Time) PotProgress {
+	progress := PotProgress{Pot: p}
+	if p.TargetAmount <= 0 {
+		return progress
+	}
+
+	progress.Percent = min(100, p.Balance*100/p.TargetAmount)
+	progress.Remaining = max(0, p.TargetAmount-p.Balance)
+	if p.TargetDate != nil && progress.Remaining > 0 {
+		months := int64(monthsUntil(now, *p.TargetDate))
+		progress.MonthlySaving = (progress.Remaining + months - 1) / months
+	}
+	return progress
+}
+
+// monthsUntil counts the calendar months from now's month up to and
+// including the target's, at least one.
+func monthsUntil(now, target time.Time) int {
+	months := (target.Year()-now.Year())*12 + int(target.Month()-now.Month()) + 1
+	return max(1, months)
+}
@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPotProgress(t *testing.T) {
+	now := date(2026, 3, 15)
+
+	none := Pot{Balance: 5_000}
+	assert.Equal(t, PotProgress{Pot: &none}, none.Progress(now))
+
+	target := date(2026, 6, 1)
+	holiday := Pot{Balance: 2_500, TargetAmount: 10_000, TargetDate: &target}
+	p := holiday.Progress(now)
+	assert.Equal(t, int64(25), p.Percent)
+	assert.Equal(t, int64(7_500), p.Remaining)
+	assert.Equal(t, int64(1_875), p.MonthlySaving, "March to June is four months")
+
+	overdue := date(2026, 1, 1)
+	holiday.TargetDate = &overdue
+	assert.Equal(t, int64(7_500), holiday.Progress(now).MonthlySaving, "past targets need the rest now")
+
+	holiday.Balance = 12_000
+	p = holiday.Progress(now)
+	assert.Equal(t, int64(100), p.Percent)
+	assert.Equal(t, int64(0), p.Remaining)
+	assert.Equal(t, int64(0), p.MonthlySaving)
+}
@@ -0,0 +1,112 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ProductTier is one balance band of a product's interest rate, copied onto
+// accounts opened from the product as InterestTier rows.
+type ProductTier struct {
+	FromBalance int64 `json:"from_balance"`
+	RateBps     int64 `json:"rate_bps"`
+}
+
+// Product is an entry in the account catalogue. Every new account is opened
+// from a product, which decides its type, currency and interest terms and who
+// may have one. Retired products stay in the catalogue for the accounts
+// already opened from them.
+type Product struct {
+	Code        string      `gorm:"primaryKey;size:32"`
+	Name        string      `gorm:"size:255;not null"`
+	AccountType AccountType `gorm:"type:account.account_type;not null"`
+	Currency    string      `gorm:"size:3;not null"`
+
+	// Interest
+	InterestRateBps    int64              `gorm:"not null;default:0"`
+	InterestTiers      []ProductTier      `gorm:"serializer:json"`
+	DayCountConvention DayCountConvention `gorm:"size:10;default:'ACT/365'"`
+
+	MonthlyFee        int64 `gorm:"not null;default:0"` // Minor units of Currency
+	OverdraftEligible bool  `gorm:"not null"`
+
+	// Eligibility
+	MinAge      int  `gorm:"not null;default:0"`
+	RequiresKYC bool `gorm:"not null"`
+
+	Active    bool      `gorm:"not null"`
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Product) TableName() string {
+	return "account.products"
+}
+
+// Valid reports whether t is a known account type.
+func (t AccountType) Valid() bool {
+	switch t {
+	case AccountTypeChecking, AccountTypeSavings, AccountTypeInvestment, AccountTypeLoan:
+		return true
+	}
+	return false
+}
+
+// Validate checks the product's terms. The currency is checked by the
+// caller, which knows the currencies the bank keeps balances in.
+func (p *Product) Validate() error {
+	switch {
+	case p.Code == "" || len(p.Code) > 32 || strings.ToUpper(p.Code) != p.Code || strings.ContainsAny(p.Code, " \t/"):
+		return fmt.Errorf("%w: code must be 1-32 upper-case characters without spaces or slashes", ErrInvalidProduct)
+	case strings.TrimSpace(p.Name) == "":
+		return fmt.Errorf("%w: name is required", ErrInvalidProduct)
+	case !p.AccountType.Valid():
+		return fmt.Errorf("%w: unknown account type %q", ErrInvalidProduct, p.AccountType)
+	case !p.DayCountConvention.Valid():
+		return fmt.Errorf("%w: unsupported day count convention %q", ErrInvalidProduct, p.DayCountConvention)
+	case p.InterestRateBps < 0 || p.MonthlyFee < 0 || p.MinAge < 0:
+		return fmt.Errorf("%w: rate, fee and minimum age cannot be negative", ErrInvalidProduct)
+	}
+	for _, tier := range p.InterestTiers {
+		if tier.FromBalance < 0 || tier.RateBps < 0 {
+			return fmt.Errorf("%w: interest tiers cannot be negative", ErrInvalidProduct)
+		}
+	}
+	return nil
+}
+
+// KycVerified is the KYC status a customer needs for products that require
+// KYC.
+const KycVerified = "verified"
+
+// CustomerProfile is what the account service knows about a customer when
+// checking product eligibility.
+type CustomerProfile struct {
+	ID          uuid.UUID
+	DateOfBirth time.Time
+	KycStatus   string
+}
+
+// CheckEligibility reports why the customer may not open an account of the
+// product, or nil when they may.
+func (p *Product) CheckEligibility(customer *CustomerProfile, now time.Time) error {
+	if p.RequiresKYC && customer.KycStatus != KycVerified {
+		return fmt.Errorf("%w: customer KYC must be verified, it is %s", ErrNotEligible, customer.KycStatus)
+	}
+	if age := ageOn(customer.DateOfBirth, now); age < p.MinAge {
+		return fmt.Errorf("%w: customer must be at least %d, they are %d", ErrNotEligible, p.MinAge, age)
+	}
+	return nil
+}
+
+// ageOn returns the age in whole years of someone born on birth.
+func ageOn(birth, now time.Time) int {
+	age := now.Year() - birth.Year()
+	if now.Month() < birth.Month() || (now.Month() == birth.Month() && now.Day() < birth.Day()) {
+		age--
+	}
+	return age
+}
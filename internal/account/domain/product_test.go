@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProductEligibility(t *testing.T) {
+	youth := Product{MinAge: 18, RequiresKYC: true}
+	now := date(2026, 5, 10)
+
+	verified := &CustomerProfile{DateOfBirth: date(2008, 5, 10), KycStatus: KycVerified}
+	assert.NoError(t, youth.CheckEligibility(verified, now), "turns 18 today")
+
+	young := &CustomerProfile{DateOfBirth: date(2008, 5, 11), KycStatus: KycVerified}
+	assert.ErrorIs(t, youth.CheckEligibility(young, now), ErrNotEligible)
+
+	pending := &CustomerProfile{DateOfBirth: date(1990, 1, 1), KycStatus: "pending"}
+	err := youth.CheckEligibility(pending, now)
+	assert.ErrorIs(t, err, ErrNotEligible)
+	assert.Contains(t, err.Error(), "KYC must be verified")
+
+	open := Product{}
+	assert.NoError(t, open.CheckEligibility(pending, now))
+}
@@ -2,23 +2,150 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type AccountRepository interface {
+	// WithTx runs fn inside a single database transaction. The repository
+	// handed to fn is bound to that transaction; returning an error from fn
+	// rolls everything back.
+	WithTx(ctx context.Context, fn func(repo AccountRepository) error) error
+
 	Create(ctx context.Context, account *Account) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Account, error)
+	// GetByIDForUpdate loads the account and locks its row until the
+	// surrounding transaction ends. Only meaningful inside WithTx.
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*Account, error)
 	GetByAccountNumber(ctx context.Context, number string) (*Account, error)
+	// ListByCustomerID returns the accounts the customer opened or currently
+	// holds as a joint owner or authorised user.
 	ListByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*Account, error)
+	ListByType(ctx context.Context, accountType AccountType) ([]*Account, error)
+	List(ctx context.Context) ([]*Account, error)
 	Update(ctx context.Context, account *Account) error
+	// ListInactiveAccounts returns active accounts whose last ledger entry
+	// was made before the given instant.
+	ListInactiveAccounts(ctx context.Context, before time.Time) ([]*InactiveAccount, error)
+
+	// Sub-balances
+	CreateSubBalance(ctx context.Context, balance *SubBalance) error
+	// GetSubBalanceForUpdate loads and locks the sub-balance, or returns
+	// ErrCurrencyNotHeld. Only meaningful inside WithTx.
+	GetSubBalanceForUpdate(ctx context.Context, accountID uuid.UUID, currency string) (*SubBalance, error)
+	UpdateSubBalance(ctx context.Context, balance *SubBalance) error
+	ListSubBalances(ctx context.Context, accountID uuid.UUID) ([]SubBalance, error)
+
+	// Pots
+	CreatePot(ctx context.Context, pot *Pot) error
+	// GetPotForUpdate loads and locks the pot, or returns ErrPotNotFound.
+	// Only meaningful inside WithTx.
+	GetPotForUpdate(ctx context.Context, id uuid.UUID) (*Pot, error)
+	UpdatePot(ctx context.Context, pot *Pot) error
+	ListPots(ctx context.Context, accountID uuid.UUID) ([]*Pot, error)
+	CreatePotEntry(ctx context.Context, entry *PotEntry) error
+
+	// QueryLedger returns the account's ledger entries that match the
+	// filter, newest first, with their running balances.
+	QueryLedger(ctx context.Context, accountID uuid.UUID, filter LedgerFilter) ([]*LedgerLine, error)
+
+	// Products
+	CreateProduct(ctx context.Context, product *Product) error
+	// GetProduct returns the product, or ErrProductNotFound.
+	GetProduct(ctx context.Context, code string) (*Product, error)
+	ListProducts(ctx context.Context, includeRetired bool) ([]*Product, error)
+	UpdateProduct(ctx context.Context, product *Product) error
+	// GetCustomerProfile returns the customer's date of birth and KYC
+	// status, or ErrCustomerNotFound.
+	GetCustomerProfile(ctx context.Context, customerID uuid.UUID) (*CustomerProfile, error)
+
+	// Status history
+	CreateStatusChange(ctx context.Context, change *StatusChange) error
+	// ListStatusChanges returns the account's status history, oldest first.
+	ListStatusChanges(ctx context.Context, accountID uuid.UUID) ([]*StatusChange, error)
+
+	// Holders
+	// SaveHolder inserts the holder, or re-instates a removed one with the
+	// given relationship and permissions.
+	SaveHolder(ctx context.Context, holder *AccountHolder) error
+	// GetHolder returns the active holder row, or ErrHolderNotFound.
+	GetHolder(ctx context.Context, accountID, customerID uuid.UUID) (*AccountHolder, error)
+	ListHolders(ctx context.Context, accountID uuid.UUID) ([]*AccountHolder, error)
+	ListHoldingsByCustomerID(ctx context.Context, customerID uuid.UUID) ([]*AccountHolder, error)
+	RemoveHolder(ctx context.Context, accountID, customerID uuid.UUID, at time.Time) error
+
+	// Cards
+	CreateCard(ctx context.Context, card *Card) error
+	// GetCardByID returns the card, or ErrCardNotFound.
+	GetCardByID(ctx context.Context, id uuid.UUID) (*Card, error)
+	// GetCardByIDForUpdate loads the card and locks its row until the
+	// surrounding transaction ends. Only meaningful inside WithTx.
+	GetCardByIDForUpdate(ctx context.Context, id uuid.UUID) (*Card, error)
+	// GetCardByToken returns the card with the given vault token, or
+	// ErrCardNotFound.
+	GetCardByToken(ctx context.Context, token string) (*Card, error)
+	ListCardsByAccountID(ctx context.Context, accountID uuid.UUID) ([]*Card, error)
+	// CountScheduledTransactions counts the active standing orders and
+	// future-dated payments to or from the account.
+	CountScheduledTransactions(ctx context.Context, accountID uuid.UUID) (int64, error)
+	UpdateCard(ctx context.Context, card *Card) error
 
 	// Ledger
 	CreateLedgerEntry(ctx context.Context, entry *LedgerEntry) error
+	// HasLedgerLeg reports whether the leg of the transaction is already
+	// posted.
+	HasLedgerLeg(ctx context.Context, transactionID uuid.UUID, leg string) (bool, error)
+	// GetBalanceAt returns the booked balance in the account's own currency
+	// as of the given instant, i.e. the sum of such ledger entries made
+	// before it.
+	GetBalanceAt(ctx context.Context, accountID uuid.UUID, at time.Time) (int64, error)
+	// GetBalanceAsOf sums the account's entries in currency up to and
+	// including at: on the booking basis those made by then, on the value
+	// basis those whose value date is on or before at's day.
+	GetBalanceAsOf(ctx context.Context, accountID uuid.UUID, currency string, at time.Time, basis BalanceBasis) (int64, error)
+	// ListLedgerEntries returns entries in the account's own currency made in
+	// [from, to), oldest first.
+	ListLedgerEntries(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*LedgerEntry, error)
+	// ListTransactionDetails looks up the transactions behind ledger entries
+	// of accountID. Unknown IDs are skipped.
+	ListTransactionDetails(ctx context.Context, accountID uuid.UUID, ids []uuid.UUID) ([]*TransactionDetail, error)
+
+	// Interest
+	GetInterestTiers(ctx context.Context, accountID uuid.UUID) ([]InterestTier, error)
+	ReplaceInterestTiers(ctx context.Context, accountID uuid.UUID, tiers []InterestTier) error
+	HasInterestAccrual(ctx context.Context, accountID uuid.UUID, day time.Time) (bool, error)
+	CreateInterestAccrual(ctx context.Context, accrual *InterestAccrual) error
+	ListUncapitalisedAccruals(ctx context.Context, accountID uuid.UUID, from, to time.Time) ([]*InterestAccrual, error)
+	MarkAccrualsCapitalised(ctx context.Context, ids []uuid.UUID, ledgerEntryID uuid.UUID) error
+	UpdateInterestAccrual(ctx context.Context, accrual *InterestAccrual) error
+
+	// Reservations
+	CreateReservation(ctx context.Context, res *FundReservation) error
+	GetReservationByIDForUpdate(ctx context.Context, id uuid.UUID) (*FundReservation, error)
+	// GetReservationByLeg returns the hold placed for the leg of the
+	// transaction, or ErrReservationNotFound.
+	GetReservationByLeg(ctx context.Context, transactionID uuid.UUID, leg string) (*FundReservation, error)
+	UpdateReservation(ctx context.Context, res *FundReservation) error
+	ListExpiredReservations(ctx context.Context, now time.Time, limit int) ([]*FundReservation, error)
+
+	// Statements
+	CreateStatement(ctx context.Context, statement *Statement) error
+	GetStatementByID(ctx context.Context, id uuid.UUID) (*Statement, error)
+	HasStatement(ctx context.Context, accountID uuid.UUID, statementDate time.Time) (bool, error)
+	// GetLatestStatement returns the statement with the latest period, or
+	// ErrStatementNotFound when the account has none.
+	GetLatestStatement(ctx context.Context, accountID uuid.UUID) (*Statement, error)
+	ListStatements(ctx context.Context, accountID uuid.UUID) ([]*Statement, error)
+	ListStatementsWithoutDocument(ctx context.Context, limit int) ([]*Statement, error)
+	SetStatementDocument(ctx context.Context, id uuid.UUID, url string, generatedAt time.Time) error
 
 	// Requests
 	CreateRequest(ctx context.Context, req *AccountRequest) error
-	GetRequestByID(ctx context.Context, id uuid.UUID) (*AccountRequest, error)
-	ListRequests(ctx context.Context, status *RequestStatus) ([]*AccountRequest, error)
+	// GetRequestByIDForUpdate locks the request row until the transaction
+	// ends, or returns ErrRequestNotFound. It must run inside WithTx.
+	GetRequestByIDForUpdate(ctx context.Context, id uuid.UUID) (*AccountRequest, error)
+	ListRequests(ctx context.Context, filter RequestFilter) ([]*AccountRequest, error)
 	UpdateRequest(ctx context.Context, req *AccountRequest) error
+	HasPendingRequest(ctx context.Context, accountID uuid.UUID, kind RequestKind) (bool, error)
 }
@@ -14,13 +14,26 @@ const (
 	RequestStatusRejected RequestStatus = "rejected"
 )
 
+// RequestKind says what approving a request does.
+type RequestKind string
+
+const (
+	RequestKindOpenAccount RequestKind = "open_account" // Opens a new account of RequestedType
+	RequestKindReactivate  RequestKind = "reactivate"   // Reactivates the dormant AccountID
+)
+
 type AccountRequest struct {
 	ID            uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
 	CustomerID    uuid.UUID     `gorm:"type:uuid;not null;index"`
+	Kind          RequestKind   `gorm:"size:20;not null;default:'open_account'"`
+	AccountID     *uuid.UUID    `gorm:"type:uuid;index"` // The account to reactivate, or the one opened on approval
 	RequestedType AccountType   `gorm:"type:account.account_type;not null"`
+	ProductCode   string        `gorm:"size:32"` // The product to open
 	Status        RequestStatus `gorm:"type:varchar(20);default:'pending'"`
 	Reason        string        `gorm:"type:text"` // Optional note from customer
 
+	RejectionReason string `gorm:"type:text"` // Shown to the customer
+
 	CreatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt   time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	ProcessedAt *time.Time
@@ -30,3 +43,62 @@ type AccountRequest struct {
 func (AccountRequest) TableName() string {
 	return "account.account_requests"
 }
+
+// Decided reports whether status is one an employee can give a request.
+func (s RequestStatus) Decided() bool {
+	return s == RequestStatusApproved || s == RequestStatusRejected
+}
+
+// RequestFilter narrows a listing of requests. Requests are listed oldest
+// first; After continues a listing from the request a previous page ended on.
+type RequestFilter struct {
+	Status        *RequestStatus
+	CustomerID    *uuid.UUID
+	RequestedType *AccountType
+	After         *Cursor
+	Limit         int
+}
+
+// SLAState says how a request stands against the time the bank aims to take
+// to decide it.
+type SLAState string
+
+const (
+	SLAOnTrack  SLAState = "on_track" // Pending with more than a quarter of the SLA left
+	SLAAtRisk   SLAState = "at_risk"  // Pending and due within a quarter of the SLA
+	SLABreached SLAState = "breached" // Pending past its due time, or decided late
+	SLAMet      SLAState = "met"      // Decided in time
+)
+
+// RequestAging is a request together with how long it has waited.
+type RequestAging struct {
+	Request *AccountRequest
+	Age     time.Duration // Until now, or until it was decided
+	DueAt   time.Time
+	State   SLAState
+}
+
+// Aging measures the request against sla as of now.
+func (r *AccountRequest) Aging(sla time.Duration, now time.Time) RequestAging {
+	aging := RequestAging{Request: r, DueAt: r.CreatedAt.Add(sla)}
+
+	if r.ProcessedAt != nil {
+		aging.Age = r.ProcessedAt.Sub(r.CreatedAt)
+		aging.State = SLAMet
+		if r.ProcessedAt.After(aging.DueAt) {
+			aging.State = SLABreached
+		}
+		return aging
+	}
+
+	aging.Age = now.Sub(r.CreatedAt)
+	switch left := aging.DueAt.Sub(now); {
+	case left < 0:
+		aging.State = SLABreached
+	case left <= sla/4:
+		aging.State = SLAAtRisk
+	default:
+		aging.State = SLAOnTrack
+	}
+	return aging
+}
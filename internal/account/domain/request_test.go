@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestAging(t *testing.T) {
+	created := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	sla := 48 * time.Hour
+	req := AccountRequest{CreatedAt: created}
+
+	aging := req.Aging(sla, created.Add(24*time.Hour))
+	assert.Equal(t, SLAOnTrack, aging.State)
+	assert.Equal(t, created.Add(sla), aging.DueAt)
+	assert.Equal(t, 24*time.Hour, aging.Age)
+
+	assert.Equal(t, SLAAtRisk, req.Aging(sla, created.Add(36*time.Hour)).State)
+	assert.Equal(t, SLABreached, req.Aging(sla, created.Add(49*time.Hour)).State)
+
+	processed := created.Add(50 * time.Hour)
+	req.ProcessedAt = &processed
+	aging = req.Aging(sla, created.Add(100*time.Hour))
+	assert.Equal(t, SLABreached, aging.State)
+	assert.Equal(t, 50*time.Hour, aging.Age, "age stops when the request is decided")
+
+	processed = created.Add(time.Hour)
+	assert.Equal(t, SLAMet, req.Aging(sla, created.Add(100*time.Hour)).State)
+}
@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type ReservationStatus string
+
+const (
+	ReservationStatusActive   ReservationStatus = "active"
+	ReservationStatusReleased ReservationStatus = "released"
+	ReservationStatusConsumed ReservationStatus = "consumed"
+	ReservationStatusExpired  ReservationStatus = "expired"
+)
+
+// FundReservation is a hold on part of an account's balance. While active the
+// amount is counted in Account.ReservedAmount and excluded from
+// Account.AvailableBalance.
+type FundReservation struct {
+	ID            uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID     uuid.UUID         `gorm:"type:uuid;not null;index"`
+	TransactionID uuid.UUID         `gorm:"type:uuid;not null;index"`
+	Amount        int64             `gorm:"not null"` // Minor units
+	Currency      string            `gorm:"size:3"`   // The balance the hold is on
+	ReservedAt    time.Time         `gorm:"default:CURRENT_TIMESTAMP"`
+	ExpiresAt     time.Time         `gorm:"not null;index"`
+	Status        ReservationStatus `gorm:"size:20;default:'active';index"`
+	ReleasedAt    *time.Time
+	ReleaseReason string `gorm:"size:100"`
+	Leg           string `gorm:"size:30"` // Unique per transaction when set, so a retried hold is placed once
+}
+
+func (FundReservation) TableName() string {
+	return "account.fund_reservations"
+}
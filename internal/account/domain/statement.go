@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Statement summarises an account's ledger over a period. Statements are
+// written once, already finalised, and never recalculated: the only change
+// the repository allows is attaching the rendered document.
+type Statement struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID       uuid.UUID `gorm:"type:uuid;not null;uniqueIndex:idx_statement_account_date"`
+	StatementDate   time.Time `gorm:"type:date;not null;uniqueIndex:idx_statement_account_date"`
+	PeriodStartDate time.Time `gorm:"type:date;not null"`
+	PeriodEndDate   time.Time `gorm:"type:date;not null;check:valid_period,period_end_date >= period_start_date"` // Inclusive
+
+	OpeningBalance int64 `gorm:"not null"` // Minor units
+	ClosingBalance int64 `gorm:"not null;check:valid_balance,closing_balance = opening_balance + total_credits - total_debits + interest_earned"`
+
+	TotalCredits     int64 `gorm:"not null;default:0"`
+	TotalDebits      int64 `gorm:"not null;default:0"`
+	TransactionCount int   `gorm:"not null;default:0"`
+	InterestEarned   int64 `gorm:"not null;default:0"`
+
+	DocumentURL         string `gorm:"type:text"`
+	DocumentGeneratedAt *time.Time
+
+	IsFinalized bool `gorm:"default:false"`
+	FinalizedAt *time.Time
+	IsFinal     bool `gorm:"default:false"` // The last statement, issued when the account was closed
+
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (Statement) TableName() string {
+	return "account.account_statements"
+}
+
+// Balanced reports whether the statement satisfies the valid_balance check:
+// closing = opening + credits - debits + interest.
+func (s *Statement) Balanced() bool {
+	return s.ClosingBalance == s.OpeningBalance+s.TotalCredits-s.TotalDebits+s.InterestEarned
+}
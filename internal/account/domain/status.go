@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// statusTransitions lists the statuses an account may move to from each
+// status. Closed is terminal.
+var statusTransitions = map[AccountStatus][]AccountStatus{
+	AccountStatusActive:  {AccountStatusFrozen, AccountStatusDormant, AccountStatusClosed},
+	AccountStatusFrozen:  {AccountStatusActive, AccountStatusClosed},
+	AccountStatusDormant: {AccountStatusActive, AccountStatusClosed},
+}
+
+// Valid reports whether s is a known account status.
+func (s AccountStatus) Valid() bool {
+	switch s {
+	case AccountStatusActive, AccountStatusFrozen, AccountStatusDormant, AccountStatusClosed:
+		return true
+	}
+	return false
+}
+
+// CanTransition reports whether an account may move from one status to
+// another.
+func CanTransition(from, to AccountStatus) bool {
+	for _, next := range statusTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+// StatusChange records one status transition of an account. ChangedBy is the
+// employee or customer behind the change, and nil when a scheduled job made it.
+type StatusChange struct {
+	ID         uuid.UUID     `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AccountID  uuid.UUID     `gorm:"type:uuid;not null;index"`
+	FromStatus AccountStatus `gorm:"size:20;not null"`
+	ToStatus   AccountStatus `gorm:"size:20;not null"`
+	Reason     string        `gorm:"type:text;not null"`
+	ChangedBy  *uuid.UUID    `gorm:"type:uuid"`
+	ChangedAt  time.Time     `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (StatusChange) TableName() string {
+	return "account.account_status_history"
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCanTransition(t *testing.T) {
+	allowed := [][2]AccountStatus{
+		{AccountStatusActive, AccountStatusFrozen},
+		{AccountStatusFrozen, AccountStatusActive},
+		{AccountStatusActive, AccountStatusDormant},
+		{AccountStatusDormant, AccountStatusActive},
+		{AccountStatusActive, AccountStatusClosed},
+		{AccountStatusFrozen, AccountStatusClosed},
+		{AccountStatusDormant, AccountStatusClosed},
+	}
+	for _, tr := range allowed {
+		assert.True(t, CanTransition(tr[0], tr[1]), "%s to %s", tr[0], tr[1])
+	}
+
+	refused := [][2]AccountStatus{
+		{AccountStatusFrozen, AccountStatusDormant},
+		{AccountStatusDormant, AccountStatusFrozen},
+		{AccountStatusActive, AccountStatusActive},
+		{AccountStatusClosed, AccountStatusActive},
+		{AccountStatusClosed, AccountStatusFrozen},
+		{AccountStatusActive, "suspended"},
+	}
+	for _, tr := range refused {
+		assert.False(t, CanTransition(tr[0], tr[1]), "%s to %s", tr[0], tr[1])
+	}
+}
@@ -0,0 +1,16 @@
+package domain
+
+import "github.com/google/uuid"
+
+// TransactionDetail is the part of a transaction.transactions row that
+// describes a ledger entry to the customer, resolved from the account's side:
+// the counterparty is the other account of the transfer, if any.
+type TransactionDetail struct {
+	ID          uuid.UUID
+	Type        string
+	Reference   string
+	Description string
+
+	CounterpartyAccountNumber string
+	CounterpartyName          string
+}
@@ -0,0 +1,245 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+	pb "nordic-bank/pkg/pb/account/v1"
+	commonpb "nordic-bank/pkg/pb/common/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *AccountServiceServer) IssueCard(ctx context.Context, req *pb.IssueCardRequest) (*pb.IssueCardResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := uuid.Parse(req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	issued, err := s.service.IssueCard(ctx, accountID, customerID, domain.CardBrand(req.CardBrand))
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.mapCardToPb(ctx, issued.Card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.IssueCardResponse{
+		Card: card,
+		Pan:  issued.PAN,
+	}, nil
+}
+
+func (s *AccountServiceServer) GetCard(ctx context.Context, req *pb.GetCardRequest) (*pb.GetCardResponse, error) {
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.service.GetCard(ctx, cardID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.mapCardToPb(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCardResponse{Card: res}, nil
+}
+
+func (s *AccountServiceServer) GetCardByToken(ctx context.Context, req *pb.GetCardByTokenRequest) (*pb.GetCardByTokenResponse, error) {
+	card, err := s.service.GetCardByToken(ctx, req.CardNumberToken)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.mapCardToPb(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetCardByTokenResponse{Card: res}, nil
+}
+
+func (s *AccountServiceServer) ListCards(ctx context.Context, req *pb.ListCardsRequest) (*pb.ListCardsResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	cards, err := s.service.ListCards(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	pbCards := make([]*pb.Card, len(cards))
+	for i, card := range cards {
+		pbCards[i] = mapCardToPb(card, account.Currency)
+	}
+
+	return &pb.ListCardsResponse{
+		Cards: pbCards,
+	}, nil
+}
+
+func (s *AccountServiceServer) UpdateCardStatus(ctx context.Context, req *pb.UpdateCardStatusRequest) (*pb.UpdateCardStatusResponse, error) {
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, err
+	}
+
+	var card *domain.Card
+	switch req.Action {
+	case "activate":
+		card, err = s.service.ActivateCard(ctx, cardID)
+	case "block":
+		card, err = s.service.BlockCard(ctx, cardID, req.Reason)
+	case "unblock":
+		card, err = s.service.UnblockCard(ctx, cardID)
+	case "cancel":
+		card, err = s.service.CancelCard(ctx, cardID, req.Reason)
+	default:
+		return nil, fmt.Errorf("unknown card action %q", req.Action)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.mapCardToPb(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.UpdateCardStatusResponse{Card: res}, nil
+}
+
+func (s *AccountServiceServer) SetCardPin(ctx context.Context, req *pb.SetCardPinRequest) (*pb.SetCardPinResponse, error) {
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.service.SetCardPIN(ctx, cardID, req.Pin)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.mapCardToPb(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetCardPinResponse{Card: res}, nil
+}
+
+// VerifyCardPin answers a wrong or locked PIN with verified=false rather than
+// an error, so terminals can show the remaining attempts.
+func (s *AccountServiceServer) VerifyCardPin(ctx context.Context, req *pb.VerifyCardPinRequest) (*pb.VerifyCardPinResponse, error) {
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.service.VerifyCardPIN(ctx, cardID, req.Pin)
+	if err != nil && !errors.Is(err, domain.ErrIncorrectPIN) && !errors.Is(err, domain.ErrPINLocked) {
+		return nil, err
+	}
+
+	card, cardErr := s.service.GetCard(ctx, cardID)
+	if cardErr != nil {
+		return nil, cardErr
+	}
+
+	res := &pb.VerifyCardPinResponse{
+		Verified: err == nil,
+		Attempts: int32(card.PinAttempts),
+	}
+	if card.PinLockedUntil != nil {
+		res.LockedUntil = timestamppb.New(*card.PinLockedUntil)
+	}
+	return res, nil
+}
+
+func (s *AccountServiceServer) SetCardLimits(ctx context.Context, req *pb.SetCardLimitsRequest) (*pb.SetCardLimitsResponse, error) {
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, err
+	}
+
+	card, err := s.service.SetCardLimits(ctx, cardID, application.CardLimits{
+		Daily:    req.DailyLimit,
+		Monthly:  req.MonthlyLimit,
+		ATMDaily: req.AtmDailyLimit,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.mapCardToPb(ctx, card)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.SetCardLimitsResponse{Card: res}, nil
+}
+
+// mapCardToPb looks up the account currency the card limits are in.
+func (s *AccountServiceServer) mapCardToPb(ctx context.Context, card *domain.Card) (*pb.Card, error) {
+	account, err := s.service.GetAccount(ctx, card.AccountID)
+	if err != nil {
+		return nil, err
+	}
+	return mapCardToPb(card, account.Currency), nil
+}
+
+func mapCardToPb(c *domain.Card, currency string) *pb.Card {
+	money := func(amount int64) *commonpb.Money {
+		return &commonpb.Money{Amount: amount, Currency: currency}
+	}
+
+	res := &pb.Card{
+		Id:              c.ID.String(),
+		AccountId:       c.AccountID.String(),
+		CustomerId:      c.CustomerID.String(),
+		CardNumberToken: c.CardNumberToken,
+		LastFour:        c.CardLastFour,
+		CardType:        c.CardType,
+		CardBrand:       string(c.CardBrand),
+		ExpiryMonth:     int32(c.ExpiryMonth),
+		ExpiryYear:      int32(c.ExpiryYear),
+		DailyLimit:      money(c.DailyLimit),
+		MonthlyLimit:    money(c.MonthlyLimit),
+		AtmDailyLimit:   money(c.ATMDailyLimit),
+		Status:          string(c.Status),
+		BlockReason:     c.BlockReason,
+		PinSet:          c.PinHash != "",
+		IssuedAt:        timestamppb.New(c.IssuedAt),
+	}
+	if c.PinLockedUntil != nil {
+		res.PinLockedUntil = timestamppb.New(*c.PinLockedUntil)
+	}
+	if c.ActivatedAt != nil {
+		res.ActivatedAt = timestamppb.New(*c.ActivatedAt)
+	}
+	if c.CancelledAt != nil {
+		res.CancelledAt = timestamppb.New(*c.CancelledAt)
+	}
+	return res
+}
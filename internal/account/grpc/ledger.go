@@ -0,0 +1,133 @@
+package grpc
+
+import (
+	"context"
+	"time"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+	pb "nordic-bank/pkg/pb/account/v1"
+	commonpb "nordic-bank/pkg/pb/common/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (s *AccountServiceServer) ListLedgerEntries(ctx context.Context, req *pb.ListLedgerEntriesRequest) (*pb.ListLedgerEntriesResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	query := ledgerQuery(req.Filter)
+	query.Cursor = req.Cursor
+	query.Limit = int(req.Limit)
+
+	page, err := s.service.QueryLedger(ctx, accountID, query)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]*pb.LedgerEntry, len(page.Lines))
+	for i, line := range page.Lines {
+		entries[i] = mapLedgerLineToPb(line)
+	}
+	return &pb.ListLedgerEntriesResponse{
+		Entries:    entries,
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+func (s *AccountServiceServer) GetBalanceAsOf(ctx context.Context, req *pb.GetBalanceAsOfRequest) (*pb.GetBalanceAsOfResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	at := time.Now()
+	if req.At != nil {
+		at = req.At.AsTime()
+	}
+
+	balance, err := s.service.GetBalanceAsOf(ctx, accountID, at, domain.BalanceBasis(req.Basis), req.Currency)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetBalanceAsOfResponse{
+		Balance: &commonpb.Money{Amount: balance.Balance, Currency: balance.Currency},
+		At:      timestamppb.New(balance.At),
+		Basis:   string(balance.Basis),
+	}, nil
+}
+
+// ExportLedgerCsv streams the CSV in the chunks the exporter flushes.
+func (s *AccountServiceServer) ExportLedgerCsv(req *pb.ExportLedgerCsvRequest, stream pb.AccountService_ExportLedgerCsvServer) error {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return err
+	}
+
+	return s.service.ExportLedgerCSV(stream.Context(), chunkWriter{stream}, accountID, ledgerQuery(req.Filter), true)
+}
+
+// chunkWriter sends every write as one chunk of the stream.
+type chunkWriter struct {
+	stream pb.AccountService_ExportLedgerCsvServer
+}
+
+func (w chunkWriter) Write(p []byte) (int, error) {
+	data := make([]byte, len(p))
+	copy(data, p)
+	if err := w.stream.Send(&pb.ExportLedgerCsvChunk{Data: data}); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+func ledgerQuery(filter *pb.LedgerFilter) application.LedgerQuery {
+	var query application.LedgerQuery
+	if filter == nil {
+		return query
+	}
+	if filter.From != nil {
+		from := filter.From.AsTime()
+		query.From = &from
+	}
+	if filter.To != nil {
+		to := filter.To.AsTime()
+		query.To = &to
+	}
+	if filter.EntryType != "" {
+		entryType := domain.LedgerEntryType(filter.EntryType)
+		query.EntryType = &entryType
+	}
+	query.MinAmount = filter.MinAmount
+	query.MaxAmount = filter.MaxAmount
+	query.Reference = filter.Reference
+	query.Currency = filter.Currency
+	return query
+}
+
+func mapLedgerLineToPb(line *domain.LedgerLine) *pb.LedgerEntry {
+	money := func(amount int64) *commonpb.Money {
+		return &commonpb.Money{Amount: amount, Currency: line.Currency}
+	}
+
+	res := &pb.LedgerEntry{
+		Id:             line.ID.String(),
+		AccountId:      line.AccountID.String(),
+		EntryType:      string(line.EntryType),
+		Amount:         money(line.Amount),
+		BalanceBefore:  money(line.BalanceBefore),
+		BalanceAfter:   money(line.BalanceAfter),
+		RunningBalance: money(line.RunningBalance),
+		Description:    line.Description,
+		Reference:      line.Reference,
+		EntryDate:      timestamppb.New(line.EntryDate),
+		ValueDate:      line.ValueDate.Format(dateLayout),
+		PostedDate:     line.PostedDate.Format(dateLayout),
+	}
+	if line.TransactionID != nil {
+		res.TransactionId = line.TransactionID.String()
+	}
+	return res
+}
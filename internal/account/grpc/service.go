@@ -2,14 +2,21 @@ package grpc
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"nordic-bank/internal/account/application"
 	"nordic-bank/internal/account/domain"
+	sharedauth "nordic-bank/internal/shared/auth"
+	"nordic-bank/internal/shared/currency"
 	pb "nordic-bank/pkg/pb/account/v1"
 	commonpb "nordic-bank/pkg/pb/common/v1"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"gorm.io/gorm"
 )
 
 type AccountServiceServer struct {
@@ -27,7 +34,16 @@ func (s *AccountServiceServer) CreateAccount(ctx context.Context, req *pb.Create
 		return nil, err
 	}
 
-	account, err := s.service.CreateAccount(ctx, customerID, req.AccountName, domain.AccountType(req.AccountType), req.Currency)
+	code := req.ProductCode
+	if code == "" {
+		product, err := s.service.DefaultProduct(ctx, domain.AccountType(req.AccountType), req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		code = product.Code
+	}
+
+	account, err := s.service.CreateAccount(ctx, customerID, req.AccountName, code)
 	if err != nil {
 		return nil, err
 	}
@@ -53,6 +69,17 @@ func (s *AccountServiceServer) GetAccount(ctx context.Context, req *pb.GetAccoun
 	}, nil
 }
 
+func (s *AccountServiceServer) GetAccountByNumber(ctx context.Context, req *pb.GetAccountByNumberRequest) (*pb.GetAccountByNumberResponse, error) {
+	account, err := s.service.GetAccountByNumber(ctx, req.AccountNumber)
+	if err != nil {
+		return nil, statusError(err)
+	}
+
+	return &pb.GetAccountByNumberResponse{
+		Account: mapAccountToPb(account),
+	}, nil
+}
+
 func (s *AccountServiceServer) ListAccounts(ctx context.Context, req *pb.ListAccountsRequest) (*pb.ListAccountsResponse, error) {
 	customerID, err := uuid.Parse(req.CustomerId)
 	if err != nil {
@@ -77,12 +104,22 @@ func (s *AccountServiceServer) ListAccounts(ctx context.Context, req *pb.ListAcc
 func (s *AccountServiceServer) UpdateAccountStatus(ctx context.Context, req *pb.UpdateAccountStatusRequest) (*pb.UpdateAccountStatusResponse, error) {
 	accountID, err := uuid.Parse(req.AccountId)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, "invalid account_id")
 	}
 
-	account, err := s.service.UpdateStatus(ctx, accountID, domain.AccountStatus(req.Status))
+	// Status history names a person, so only an employee's token will do
+	claims, ok := sharedauth.ClaimsFrom(ctx)
+	if !ok || claims.Role != "employee" {
+		return nil, status.Error(codes.PermissionDenied, "only an employee can change an account status")
+	}
+	changedBy, err := uuid.Parse(claims.UserID)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.Unauthenticated, "token does not identify a user")
+	}
+
+	account, err := s.service.UpdateStatus(ctx, accountID, domain.AccountStatus(req.Status), req.Reason, changedBy)
+	if err != nil {
+		return nil, statusError(err)
 	}
 
 	return &pb.UpdateAccountStatusResponse{
@@ -90,26 +127,262 @@ func (s *AccountServiceServer) UpdateAccountStatus(ctx context.Context, req *pb.
 	}, nil
 }
 
+func (s *AccountServiceServer) CheckHolderPermission(ctx context.Context, req *pb.CheckHolderPermissionRequest) (*pb.CheckHolderPermissionResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := uuid.Parse(req.CustomerId)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.service.Authorize(ctx, accountID, customerID, domain.HolderPermission(req.Permission))
+	if errors.Is(err, domain.ErrPermissionDenied) {
+		return &pb.CheckHolderPermissionResponse{Allowed: false}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.CheckHolderPermissionResponse{Allowed: true}, nil
+}
+
 func (s *AccountServiceServer) AdjustBalance(ctx context.Context, req *pb.AdjustBalanceRequest) (*pb.AdjustBalanceResponse, error) {
 	accountID, err := uuid.Parse(req.AccountId)
 	if err != nil {
 		return nil, err
 	}
 
-	account, err := s.service.AdjustBalance(ctx, accountID, req.AmountAdjustment, req.Reference, req.Description)
+	posting := application.Posting{
+		AccountID:   accountID,
+		Amount:      req.AmountAdjustment,
+		Currency:    req.Currency,
+		Reference:   req.Reference,
+		Description: req.Description,
+		Leg:         req.Leg,
+		Force:       req.Force,
+	}
+	if req.ValueDate != "" {
+		if posting.ValueDate, err = time.Parse(dateLayout, req.ValueDate); err != nil {
+			return nil, err
+		}
+	}
+	if req.TransactionId != "" {
+		if posting.TransactionID, err = uuid.Parse(req.TransactionId); err != nil {
+			return nil, err
+		}
+	}
+
+	account, err := s.service.Post(ctx, posting)
 	if err != nil {
 		return nil, err
 	}
 
 	return &pb.AdjustBalanceResponse{
-		NewBalance: &commonpb.Money{
-			Amount:   account.Balance,
-			Currency: account.Currency,
-		},
+		NewBalance: balanceIn(account, req.Currency),
+	}, nil
+}
+
+func (s *AccountServiceServer) ReserveFunds(ctx context.Context, req *pb.ReserveFundsRequest) (*pb.ReserveFundsResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	transactionID, err := uuid.Parse(req.TransactionId)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := time.Duration(req.TtlSeconds) * time.Second
+	reservation, err := s.service.ReserveFunds(ctx, accountID, transactionID, req.Leg, req.Amount, req.Currency, ttl)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.ReserveFundsResponse{
+		Reservation: mapReservationToPb(reservation),
+	}, nil
+}
+
+func (s *AccountServiceServer) ReleaseReservation(ctx context.Context, req *pb.ReleaseReservationRequest) (*pb.ReleaseReservationResponse, error) {
+	id, err := uuid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := s.service.ReleaseReservation(ctx, id, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.Currency == "" {
+		account, err := s.service.GetAccount(ctx, reservation.AccountID)
+		if err != nil {
+			return nil, err
+		}
+		reservation.Currency = account.Currency
+	}
+
+	return &pb.ReleaseReservationResponse{
+		Reservation: mapReservationToPb(reservation),
+	}, nil
+}
+
+func (s *AccountServiceServer) CaptureReservation(ctx context.Context, req *pb.CaptureReservationRequest) (*pb.CaptureReservationResponse, error) {
+	id, err := uuid.Parse(req.ReservationId)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, account, err := s.service.CaptureReservation(ctx, id, req.Amount, req.Reference, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.Currency == "" {
+		reservation.Currency = account.Currency
+	}
+
+	return &pb.CaptureReservationResponse{
+		Reservation: mapReservationToPb(reservation),
+		NewBalance:  balanceIn(account, reservation.Currency),
+	}, nil
+}
+
+func (s *AccountServiceServer) ListStatements(ctx context.Context, req *pb.ListStatementsRequest) (*pb.ListStatementsResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statements, err := s.service.ListStatements(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	pbStatements := make([]*pb.Statement, len(statements))
+	for i, st := range statements {
+		pbStatements[i] = mapStatementToPb(st, account.Currency)
+	}
+
+	return &pb.ListStatementsResponse{
+		Statements: pbStatements,
 	}, nil
 }
 
+func (s *AccountServiceServer) GetStatement(ctx context.Context, req *pb.GetStatementRequest) (*pb.GetStatementResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	statementID, err := uuid.Parse(req.StatementId)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := s.service.GetStatement(ctx, accountID, statementID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetStatementResponse{
+		Statement: mapStatementToPb(statement, account.Currency),
+	}, nil
+}
+
+func (s *AccountServiceServer) GenerateStatement(ctx context.Context, req *pb.GenerateStatementRequest) (*pb.GenerateStatementResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, err
+	}
+	start, err := time.Parse(dateLayout, req.PeriodStartDate)
+	if err != nil {
+		return nil, err
+	}
+	end, err := time.Parse(dateLayout, req.PeriodEndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	account, err := s.service.GetAccount(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := s.service.GenerateStatement(ctx, accountID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GenerateStatementResponse{
+		Statement: mapStatementToPb(statement, account.Currency),
+	}, nil
+}
+
+const dateLayout = "2006-01-02"
+
+func mapStatementToPb(st *domain.Statement, currency string) *pb.Statement {
+	money := func(amount int64) *commonpb.Money {
+		return &commonpb.Money{Amount: amount, Currency: currency}
+	}
+
+	res := &pb.Statement{
+		Id:               st.ID.String(),
+		AccountId:        st.AccountID.String(),
+		StatementDate:    st.StatementDate.Format(dateLayout),
+		PeriodStartDate:  st.PeriodStartDate.Format(dateLayout),
+		PeriodEndDate:    st.PeriodEndDate.Format(dateLayout),
+		OpeningBalance:   money(st.OpeningBalance),
+		ClosingBalance:   money(st.ClosingBalance),
+		TotalCredits:     money(st.TotalCredits),
+		TotalDebits:      money(st.TotalDebits),
+		TransactionCount: int32(st.TransactionCount),
+		InterestEarned:   money(st.InterestEarned),
+		DocumentUrl:      st.DocumentURL,
+		IsFinalized:      st.IsFinalized,
+	}
+	if st.FinalizedAt != nil {
+		res.FinalizedAt = timestamppb.New(*st.FinalizedAt)
+	}
+	return res
+}
+
+func mapReservationToPb(r *domain.FundReservation) *pb.FundReservation {
+	res := &pb.FundReservation{
+		Id:            r.ID.String(),
+		AccountId:     r.AccountID.String(),
+		TransactionId: r.TransactionID.String(),
+		Amount: &commonpb.Money{
+			Amount:   r.Amount,
+			Currency: r.Currency,
+		},
+		Status:        string(r.Status),
+		ReservedAt:    timestamppb.New(r.ReservedAt),
+		ExpiresAt:     timestamppb.New(r.ExpiresAt),
+		ReleaseReason: r.ReleaseReason,
+	}
+	if r.ReleasedAt != nil {
+		res.ReleasedAt = timestamppb.New(*r.ReleasedAt)
+	}
+	return res
+}
+
 func mapAccountToPb(a *domain.Account) *pb.Account {
+	subBalances := make([]*commonpb.Money, len(a.SubBalances))
+	for i, b := range a.SubBalances {
+		subBalances[i] = &commonpb.Money{Amount: b.Balance, Currency: b.Currency}
+	}
 	return &pb.Account{
 		Id:            a.ID.String(),
 		CustomerId:    a.CustomerID.String(),
@@ -125,8 +398,47 @@ func mapAccountToPb(a *domain.Account) *pb.Account {
 			Amount:   a.AvailableBalance,
 			Currency: a.Currency,
 		},
-		Status:    string(a.Status),
-		CreatedAt: timestamppb.New(a.CreatedAt),
-		UpdatedAt: timestamppb.New(a.UpdatedAt),
+		OverdraftLimit: &commonpb.Money{
+			Amount:   a.OverdraftLimit,
+			Currency: a.Currency,
+		},
+		MinimumBalance: &commonpb.Money{
+			Amount:   a.MinimumBalance,
+			Currency: a.Currency,
+		},
+		Status:      string(a.Status),
+		CreatedAt:   timestamppb.New(a.CreatedAt),
+		UpdatedAt:   timestamppb.New(a.UpdatedAt),
+		SubBalances: subBalances,
+		ProductCode: a.ProductCode,
+	}
+}
+
+// balanceIn returns the account's booked balance in the given currency, which
+// is its own currency when empty.
+func balanceIn(a *domain.Account, code string) *commonpb.Money {
+	code = currency.Normalize(code)
+	for _, b := range a.SubBalances {
+		if b.Currency == code {
+			return &commonpb.Money{Amount: b.Balance, Currency: b.Currency}
+		}
+	}
+	return &commonpb.Money{Amount: a.Balance, Currency: a.Currency}
+}
+
+// statusError maps an account lookup or status change failure to a gRPC
+// status.
+func statusError(err error) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound), errors.Is(err, domain.ErrAccountNotFound):
+		return status.Error(codes.NotFound, "account not found")
+	case errors.Is(err, domain.ErrInvalidAccountNumber), errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrStatusReasonRequired):
+		return status.Error(codes.InvalidArgument, err.Error())
+	case errors.Is(err, domain.ErrInvalidTransition), errors.Is(err, domain.ErrAccountClosed),
+		errors.Is(err, domain.ErrClosureRequired), errors.Is(err, domain.ErrReactivationRequired):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	default:
+		return err
 	}
 }
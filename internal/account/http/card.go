@@ -0,0 +1,259 @@
+package http
+
+import (
+	"net/http"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// cardFromPath parses the card ID and checks that a customer caller may use
+// the account the card draws on and, when cardholderOnly is set, that the
+// card was issued to them. Employees may use any card. It writes the error
+// response itself.
+func (h *Handler) cardFromPath(c *gin.Context, cardholderOnly bool) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.Param("cardId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid card id"})
+		return uuid.Nil, false
+	}
+	if c.GetString("role") == "employee" {
+		return id, true
+	}
+
+	card, err := h.service.GetCard(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return uuid.Nil, false
+	}
+	if cardholderOnly && card.CustomerID.String() != c.GetString("customerID") {
+		respondError(c, domain.ErrPermissionDenied)
+		return uuid.Nil, false
+	}
+	if !h.authorize(c, card.AccountID, domain.PermissionWithdraw) {
+		return uuid.Nil, false
+	}
+
+	return id, true
+}
+
+func (h *Handler) issueCard(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		CustomerID string `json:"customer_id" binding:"required"`
+		CardBrand  string `json:"card_brand" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionWithdraw) {
+		return
+	}
+
+	card, err := h.service.IssueCard(c.Request.Context(), id, customerID, domain.CardBrand(req.CardBrand))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, card)
+}
+
+func (h *Handler) listCards(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionWithdraw) {
+		return
+	}
+
+	cards, err := h.service.ListCards(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, cards)
+}
+
+func (h *Handler) getCard(c *gin.Context) {
+	id, ok := h.cardFromPath(c, false)
+	if !ok {
+		return
+	}
+
+	card, err := h.service.GetCard(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *Handler) activateCard(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	card, err := h.service.ActivateCard(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+type cardReasonRequest struct {
+	Reason string `json:"reason"`
+}
+
+func (h *Handler) blockCard(c *gin.Context) {
+	id, ok := h.cardFromPath(c, false)
+	if !ok {
+		return
+	}
+
+	var req cardReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := h.service.BlockCard(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *Handler) unblockCard(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	card, err := h.service.UnblockCard(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *Handler) cancelCard(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	var req cardReasonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := h.service.CancelCard(c.Request.Context(), id, req.Reason)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+type cardPINRequest struct {
+	PIN string `json:"pin" binding:"required"`
+}
+
+func (h *Handler) setCardPIN(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	var req cardPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := h.service.SetCardPIN(c.Request.Context(), id, req.PIN)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
+
+func (h *Handler) verifyCardPIN(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	var req cardPINRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := h.service.VerifyCardPIN(c.Request.Context(), id, req.PIN); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"verified": true})
+}
+
+func (h *Handler) setCardLimits(c *gin.Context) {
+	id, ok := h.cardFromPath(c, true)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		DailyLimit    int64 `json:"daily_limit" binding:"gte=0"`
+		MonthlyLimit  int64 `json:"monthly_limit" binding:"gte=0"`
+		ATMDailyLimit int64 `json:"atm_daily_limit" binding:"gte=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	card, err := h.service.SetCardLimits(c.Request.Context(), id, application.CardLimits{
+		Daily:    req.DailyLimit,
+		Monthly:  req.MonthlyLimit,
+		ATMDaily: req.ATMDailyLimit,
+	})
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, card)
+}
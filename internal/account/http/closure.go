@@ -0,0 +1,57 @@
+package http
+
+import (
+	"net/http"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type closeAccountRequest struct {
+	SweepAccountID string `json:"sweep_account_id"` // Required unless the account is empty
+	Reason         string `json:"reason" binding:"required"`
+}
+
+func (h *Handler) closeAccount(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req closeAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	closure := application.Closure{Reason: req.Reason}
+	if req.SweepAccountID != "" {
+		closure.SweepAccountID, err = uuid.Parse(req.SweepAccountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid sweep_account_id"})
+			return
+		}
+	}
+	if userID, err := uuid.Parse(c.GetString("userID")); err == nil {
+		closure.ClosedBy = userID
+	}
+	if c.GetString("role") == "customer" {
+		closure.RequestedBy, err = uuid.Parse(c.GetString("customerID"))
+		if err != nil {
+			respondError(c, domain.ErrPermissionDenied)
+			return
+		}
+	}
+
+	closed, err := h.service.CloseAccount(c.Request.Context(), id, closure)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, closed)
+}
@@ -0,0 +1,45 @@
+package http
+
+import (
+	"net/http"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// openSubBalance lets an account hold money in another currency. Customers
+// can only do this for accounts they own.
+func (h *Handler) openSubBalance(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		Currency string `json:"currency" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var customerID uuid.UUID
+	if c.GetString("role") == "customer" {
+		customerID, err = uuid.Parse(c.GetString("customerID"))
+		if err != nil {
+			respondError(c, domain.ErrPermissionDenied)
+			return
+		}
+	}
+
+	balance, err := h.service.OpenSubBalance(c.Request.Context(), id, customerID, req.Currency)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, balance)
+}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"net/http"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// requestReactivation files a request to reactivate a dormant account for an
+// employee to approve. Customers ask on their own behalf; employees ask on
+// behalf of the primary owner.
+func (h *Handler) requestReactivation(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var customerID uuid.UUID
+	if c.GetString("role") == "customer" {
+		customerID, err = uuid.Parse(c.GetString("customerID"))
+		if err != nil {
+			respondError(c, domain.ErrPermissionDenied)
+			return
+		}
+	} else {
+		account, err := h.service.GetAccount(c.Request.Context(), id)
+		if err != nil {
+			respondError(c, err)
+			return
+		}
+		customerID = account.CustomerID
+	}
+
+	request, err := h.service.RequestReactivation(c.Request.Context(), id, customerID, req.Reason)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, request)
+}
+
+func (h *Handler) runDormancyScan(c *gin.Context) {
+	run, err := h.service.DetectDormancy(c.Request.Context(), time.Now())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, run)
+}
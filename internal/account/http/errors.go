@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+)
+
+// respondError maps service errors onto HTTP status codes. Anything it does not
+// recognise is reported as an internal error.
+func respondError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound),
+		errors.Is(err, domain.ErrAccountNotFound),
+		errors.Is(err, domain.ErrStatementNotFound),
+		errors.Is(err, domain.ErrHolderNotFound),
+		errors.Is(err, domain.ErrCardNotFound),
+		errors.Is(err, domain.ErrPotNotFound),
+		errors.Is(err, domain.ErrProductNotFound),
+		errors.Is(err, domain.ErrCustomerNotFound),
+		errors.Is(err, domain.ErrRequestNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAccountNumber),
+		errors.Is(err, domain.ErrOverdraftNotAllowed),
+		errors.Is(err, domain.ErrInvalidPeriod),
+		errors.Is(err, domain.ErrPeriodNotEnded),
+		errors.Is(err, domain.ErrUnsupportedDocument),
+		errors.Is(err, domain.ErrInvalidRelationship),
+		errors.Is(err, domain.ErrInvalidCardBrand),
+		errors.Is(err, domain.ErrInvalidCardLimit),
+		errors.Is(err, domain.ErrInvalidPIN),
+		errors.Is(err, domain.ErrSweepAccountRequired),
+		errors.Is(err, domain.ErrInvalidSweepAccount),
+		errors.Is(err, domain.ErrInvalidStatus),
+		errors.Is(err, domain.ErrStatusReasonRequired),
+		errors.Is(err, domain.ErrUnsupportedCurrency),
+		errors.Is(err, domain.ErrInvalidPot),
+		errors.Is(err, domain.ErrInvalidProduct),
+		errors.Is(err, domain.ErrInvalidRequestStatus),
+		errors.Is(err, domain.ErrRejectionReasonRequired),
+		errors.Is(err, domain.ErrInvalidCursor),
+		errors.Is(err, domain.ErrInvalidLedgerFilter),
+		errors.Is(err, domain.ErrInvalidBalanceBasis),
+		errors.Is(err, domain.ErrInvalidValueDate):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrPermissionDenied),
+		errors.Is(err, domain.ErrIncorrectPIN):
+		status = http.StatusForbidden
+	case errors.Is(err, domain.ErrPINLocked):
+		status = http.StatusLocked
+	case errors.Is(err, domain.ErrInsufficientFunds),
+		errors.Is(err, domain.ErrAccountNotActive),
+		errors.Is(err, domain.ErrOverdraftInUse),
+		errors.Is(err, domain.ErrStatementExists),
+		errors.Is(err, domain.ErrStatementNotFinalized),
+		errors.Is(err, domain.ErrHolderExists),
+		errors.Is(err, domain.ErrPrimaryOwner),
+		errors.Is(err, domain.ErrCardStatus),
+		errors.Is(err, domain.ErrCardExpired),
+		errors.Is(err, domain.ErrPINNotSet),
+		errors.Is(err, domain.ErrAccountDormant),
+		errors.Is(err, domain.ErrAccountNotDormant),
+		errors.Is(err, domain.ErrReactivationRequired),
+		errors.Is(err, domain.ErrRequestPending),
+		errors.Is(err, domain.ErrRequestProcessed),
+		errors.Is(err, domain.ErrAccountClosed),
+		errors.Is(err, domain.ErrClosureRequired),
+		errors.Is(err, domain.ErrClosureBlocked),
+		errors.Is(err, domain.ErrInvalidTransition),
+		errors.Is(err, domain.ErrCurrencyNotHeld),
+		errors.Is(err, domain.ErrSubBalanceExists),
+		errors.Is(err, domain.ErrProductExists),
+		errors.Is(err, domain.ErrProductRetired),
+		errors.Is(err, domain.ErrNotEligible):
+		status = http.StatusConflict
+	case errors.Is(err, domain.ErrDocumentStoreMissing),
+		errors.Is(err, domain.ErrCardVaultMissing),
+		errors.Is(err, domain.ErrTransferClientMissing):
+		status = http.StatusServiceUnavailable
+	}
+
+	c.JSON(status, gin.H{"error": err.Error()})
+}
@@ -2,45 +2,125 @@ package http
 
 import (
 	"net/http"
+	"strconv"
+	"time"
 
 	"nordic-bank/internal/account/application"
 	"nordic-bank/internal/account/domain"
+	sharedauth "nordic-bank/internal/shared/auth"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type Handler struct {
-	service *application.AccountService
+	service   *application.AccountService
+	jwtSecret []byte
 }
 
-func NewHandler(service *application.AccountService) *Handler {
-	return &Handler{service: service}
+func NewHandler(service *application.AccountService, jwtSecret string) *Handler {
+	return &Handler{
+		service:   service,
+		jwtSecret: []byte(jwtSecret),
+	}
 }
 
 func (h *Handler) RegisterRoutes(router *gin.Engine) {
-	acc := router.Group("/api/v1/accounts")
+	// Customers are held to their account holder permissions. Internal
+	// callers use the gRPC API.
+	acc := router.Group("/api/v1/accounts", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("customer", "employee"))
 	{
-		acc.POST("", h.createAccount)
 		acc.GET("", h.listAccounts)
+		acc.GET("/by-number/:number", h.getAccountByNumber)
 		acc.GET("/:id", h.getAccount)
-		acc.PATCH("/:id/status", h.updateStatus)
 		acc.PUT("/:id/favorite", h.toggleFavorite)
+		acc.GET("/:id/statements", h.listStatements)
+		acc.GET("/:id/statements/camt053", h.exportCamt053)
+		acc.GET("/:id/statements/camt052", h.exportCamt052)
+		acc.GET("/:id/statements/:statementId", h.getStatement)
+		acc.GET("/:id/statements/:statementId/document", h.downloadStatement)
+
+		acc.GET("/:id/ledger", h.listLedger)
+		acc.GET("/:id/ledger/export", h.exportLedger)
+		acc.GET("/:id/balance-as-of", h.getBalanceAsOf)
+
+		acc.GET("/:id/cards", h.listCards)
+		acc.POST("/:id/cards", h.issueCard)
+
+		acc.GET("/:id/pots", h.listPots)
+		acc.POST("/:id/pots", h.createPot)
+		acc.POST("/:id/pots/:potId/deposits", h.fundPot)
+		acc.POST("/:id/pots/:potId/withdrawals", h.withdrawFromPot)
+
+		acc.GET("/:id/holders", h.listHolders)
+		acc.POST("/:id/holders", h.addHolder)
+		acc.DELETE("/:id/holders/:customerId", h.removeHolder)
+		acc.POST("/:id/reactivation", h.requestReactivation)
+		acc.POST("/:id/closure", h.closeAccount)
+		acc.POST("/:id/sub-balances", h.openSubBalance)
+
+		// Customers open accounts through an approved request. Credit
+		// facilities are granted by employees only.
+		employee := acc.Group("", sharedauth.RoleMiddleware("employee"))
+		employee.POST("", h.createAccount)
+		employee.PUT("/:id/overdraft", h.setOverdraft)
+		employee.DELETE("/:id/overdraft", h.revokeOverdraft)
+		employee.PUT("/:id/interest", h.setInterest)
+		employee.POST("/:id/statements", h.generateStatement)
+		employee.PATCH("/:id/status", h.updateStatus)
+		employee.POST("/:id/corrections", h.postCorrection)
+		employee.GET("/:id/status-history", h.getStatusHistory)
+	}
+
+	// Any holder who may withdraw can see or block a card; only the
+	// cardholder or an employee can change it
+	cards := router.Group("/api/v1/cards", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("customer", "employee"))
+	{
+		cards.GET("/:cardId", h.getCard)
+		cards.POST("/:cardId/activate", h.activateCard)
+		cards.POST("/:cardId/block", h.blockCard)
+		cards.POST("/:cardId/unblock", h.unblockCard)
+		cards.POST("/:cardId/cancel", h.cancelCard)
+		cards.PUT("/:cardId/pin", h.setCardPIN)
+		cards.POST("/:cardId/pin/verify", h.verifyCardPIN)
+		cards.PUT("/:cardId/limits", h.setCardLimits)
+	}
+
+	interest := router.Group("/api/v1/interest", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"))
+	{
+		interest.POST("/accruals", h.runInterestAccrual)
+		interest.POST("/capitalisations", h.runInterestCapitalisation)
+	}
+
+	dormancy := router.Group("/api/v1/dormancy", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"))
+	{
+		dormancy.POST("/runs", h.runDormancyScan)
+	}
+
+	// Anyone may browse the catalogue; only employees manage it
+	products := router.Group("/api/v1/products", sharedauth.OptionalAuthMiddleware(h.jwtSecret))
+	{
+		products.GET("", h.listProducts)
+		products.GET("/:code", h.getProduct)
+
+		admin := products.Group("", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"))
+		admin.POST("", h.createProduct)
+		admin.PUT("/:code", h.updateProduct)
 	}
 
-	req := router.Group("/api/v1/requests")
+	// Customers only file and see their own requests; employees decide them
+	req := router.Group("/api/v1/requests", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("customer", "employee"))
 	{
 		req.POST("", h.createRequest)
 		req.GET("", h.listRequests)
-		req.PUT("/:id/status", h.updateRequestStatus)
+		req.PUT("/:id/status", sharedauth.RoleMiddleware("employee"), h.updateRequestStatus)
 	}
 }
 
 type createAccountRequest struct {
 	CustomerID  string `json:"customer_id" binding:"required"`
-	AccountName string `json:"account_name" binding:"required"`
-	AccountType string `json:"account_type" binding:"required"`
-	Currency    string `json:"currency" binding:"required"`
+	AccountName string `json:"account_name"` // Defaults to the product's name
+	ProductCode string `json:"product_code" binding:"required"`
 }
 
 func (h *Handler) createAccount(c *gin.Context) {
@@ -56,9 +136,9 @@ func (h *Handler) createAccount(c *gin.Context) {
 		return
 	}
 
-	account, err := h.service.CreateAccount(c.Request.Context(), customerID, req.AccountName, domain.AccountType(req.AccountType), req.Currency)
+	account, err := h.service.CreateAccount(c.Request.Context(), customerID, req.AccountName, req.ProductCode)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -77,6 +157,10 @@ func (h *Handler) listAccounts(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
 		return
 	}
+	if c.GetString("role") == "customer" && c.GetString("customerID") != customerID.String() {
+		respondError(c, domain.ErrPermissionDenied)
+		return
+	}
 
 	accounts, err := h.service.ListAccounts(c.Request.Context(), customerID)
 	if err != nil {
@@ -95,6 +179,10 @@ func (h *Handler) getAccount(c *gin.Context) {
 		return
 	}
 
+	if !h.authorize(c, id, domain.PermissionViewBalance) {
+		return
+	}
+
 	account, err := h.service.GetAccount(c.Request.Context(), id)
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "account not found"})
@@ -104,36 +192,31 @@ func (h *Handler) getAccount(c *gin.Context) {
 	c.JSON(http.StatusOK, account)
 }
 
-func (h *Handler) updateStatus(c *gin.Context) {
-	idStr := c.Param("id")
-	id, err := uuid.Parse(idStr)
+// getAccountByNumber looks up an account by IBAN. Customers use it to find a
+// payee, so they only get what a payment needs, not the balances or owner.
+func (h *Handler) getAccountByNumber(c *gin.Context) {
+	account, err := h.service.GetAccountByNumber(c.Request.Context(), c.Param("number"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		respondError(c, err)
 		return
 	}
 
-	var req struct {
-		Status string `json:"status" binding:"required"`
-	}
-	if err := c.ShouldBindJSON(&req); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	if c.GetString("role") != "employee" {
+		c.JSON(http.StatusOK, gin.H{
+			"ID":          account.ID,
+			"AccountName": account.AccountName,
+			"Currency":    account.Currency,
+		})
 		return
 	}
-
-	account, err := h.service.UpdateStatus(c.Request.Context(), id, domain.AccountStatus(req.Status))
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
 	c.JSON(http.StatusOK, account)
 }
 
 func (h *Handler) createRequest(c *gin.Context) {
 	var req struct {
-		CustomerID    string `json:"customer_id" binding:"required"`
-		RequestedType string `json:"requested_type" binding:"required"`
-		Reason        string `json:"reason"`
+		CustomerID  string `json:"customer_id" binding:"required"`
+		ProductCode string `json:"product_code" binding:"required"`
+		Reason      string `json:"reason"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -146,36 +229,88 @@ func (h *Handler) createRequest(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
 		return
 	}
+	if c.GetString("role") == "customer" && c.GetString("customerID") != customerID.String() {
+		respondError(c, domain.ErrPermissionDenied)
+		return
+	}
 
 	request, err := h.service.CreateRequest(
 		c.Request.Context(),
 		customerID,
-		domain.AccountType(req.RequestedType),
+		req.ProductCode,
 		req.Reason,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, request)
 }
 
+// requestView is a request with how it stands against the request SLA.
+type requestView struct {
+	*domain.AccountRequest
+	AgeHours float64         `json:"age_hours"`
+	DueAt    time.Time       `json:"due_at"`
+	SLAState domain.SLAState `json:"sla_state"`
+}
+
+// listRequests returns a page of requests, oldest first, filtered by status,
+// customer_id and requested_type. Pass the returned next_cursor as cursor to
+// get the next page.
 func (h *Handler) listRequests(c *gin.Context) {
-	statusStr := c.Query("status")
-	var status *domain.RequestStatus
-	if statusStr != "" {
-		s := domain.RequestStatus(statusStr)
-		status = &s
+	var query application.RequestQuery
+	if v := c.Query("status"); v != "" {
+		status := domain.RequestStatus(v)
+		query.Status = &status
+	}
+	if v := c.Query("requested_type"); v != "" {
+		accType := domain.AccountType(v)
+		query.RequestedType = &accType
+	}
+	if v := c.Query("customer_id"); v != "" {
+		customerID, err := uuid.Parse(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
+			return
+		}
+		query.CustomerID = &customerID
 	}
+	if c.GetString("role") == "customer" {
+		customerID, err := uuid.Parse(c.GetString("customerID"))
+		if err != nil {
+			respondError(c, domain.ErrPermissionDenied)
+			return
+		}
+		query.CustomerID = &customerID
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+		query.Limit = limit
+	}
+	query.Cursor = c.Query("cursor")
 
-	requests, err := h.service.GetRequests(c.Request.Context(), status)
+	page, err := h.service.ListRequests(c.Request.Context(), query, time.Now())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
-	c.JSON(http.StatusOK, requests)
+	views := make([]requestView, len(page.Requests))
+	for i, aging := range page.Requests {
+		views[i] = requestView{
+			AccountRequest: aging.Request,
+			AgeHours:       aging.Age.Hours(),
+			DueAt:          aging.DueAt,
+			SLAState:       aging.State,
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"requests": views, "next_cursor": page.NextCursor})
 }
 
 func (h *Handler) updateRequestStatus(c *gin.Context) {
@@ -187,8 +322,8 @@ func (h *Handler) updateRequestStatus(c *gin.Context) {
 	}
 
 	var req struct {
-		Status      string `json:"status" binding:"required"`
-		ProcessedBy string `json:"processed_by" binding:"required"`
+		Status          string `json:"status" binding:"required"`
+		RejectionReason string `json:"rejection_reason"` // Required to reject; shown to the customer
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -196,9 +331,9 @@ func (h *Handler) updateRequestStatus(c *gin.Context) {
 		return
 	}
 
-	processedBy, err := uuid.Parse(req.ProcessedBy)
+	processedBy, err := uuid.Parse(c.GetString("userID"))
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid processed_by id"})
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "token does not identify a user"})
 		return
 	}
 
@@ -206,10 +341,11 @@ func (h *Handler) updateRequestStatus(c *gin.Context) {
 		c.Request.Context(),
 		id,
 		domain.RequestStatus(req.Status),
+		req.RejectionReason,
 		processedBy,
 	)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		respondError(c, err)
 		return
 	}
 
@@ -232,3 +368,135 @@ func (h *Handler) toggleFavorite(c *gin.Context) {
 
 	c.JSON(http.StatusOK, account)
 }
+
+func (h *Handler) setOverdraft(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		OverdraftLimit int64 `json:"overdraft_limit" binding:"required,gt=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	account, err := h.service.SetOverdraftLimit(c.Request.Context(), id, req.OverdraftLimit)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *Handler) revokeOverdraft(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	account, err := h.service.SetOverdraftLimit(c.Request.Context(), id, 0)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+type interestTierRequest struct {
+	FromBalance int64 `json:"from_balance" binding:"gte=0"`
+	RateBps     int64 `json:"rate_bps" binding:"gte=0"`
+}
+
+type setInterestRequest struct {
+	RateBps            int64                 `json:"rate_bps" binding:"gte=0"`
+	DayCountConvention string                `json:"day_count_convention"`
+	Tiers              []interestTierRequest `json:"tiers"`
+}
+
+func (h *Handler) setInterest(c *gin.Context) {
+	idStr := c.Param("id")
+	id, err := uuid.Parse(idStr)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req setInterestRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	settings := application.InterestSettings{
+		RateBps:            req.RateBps,
+		DayCountConvention: domain.DayCountConvention(req.DayCountConvention),
+	}
+	for _, tier := range req.Tiers {
+		settings.Tiers = append(settings.Tiers, domain.InterestTier{FromBalance: tier.FromBalance, RateBps: tier.RateBps})
+	}
+
+	account, err := h.service.SetInterestSettings(c.Request.Context(), id, settings)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, account)
+}
+
+func (h *Handler) runInterestAccrual(c *gin.Context) {
+	var req struct {
+		Date string `json:"date" binding:"required"` // YYYY-MM-DD
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	day, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid date, use YYYY-MM-DD"})
+		return
+	}
+
+	accrued, err := h.service.AccrueInterest(c.Request.Context(), day)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"date": req.Date, "accounts_accrued": accrued})
+}
+
+func (h *Handler) runInterestCapitalisation(c *gin.Context) {
+	var req struct {
+		Period string `json:"period" binding:"required"` // YYYY-MM
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	period, err := time.Parse("2006-01", req.Period)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period, use YYYY-MM"})
+		return
+	}
+
+	credited, err := h.service.CapitaliseInterest(c.Request.Context(), period)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"period": req.Period, "accounts_credited": credited})
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"net/http"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// authorize enforces the account holder permissions when the caller is a
+// customer. Employees are not restricted and any other caller is refused. It
+// writes the error response itself and returns false when the request must
+// stop.
+func (h *Handler) authorize(c *gin.Context, accountID uuid.UUID, permission domain.HolderPermission) bool {
+	switch c.GetString("role") {
+	case "employee":
+		return true
+	case "customer":
+	default:
+		respondError(c, domain.ErrPermissionDenied)
+		return false
+	}
+
+	customerID, err := uuid.Parse(c.GetString("customerID"))
+	if err != nil {
+		respondError(c, domain.ErrPermissionDenied)
+		return false
+	}
+
+	if err := h.service.Authorize(c.Request.Context(), accountID, customerID, permission); err != nil {
+		respondError(c, err)
+		return false
+	}
+
+	return true
+}
+
+func (h *Handler) listHolders(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionManageHolders) {
+		return
+	}
+
+	holders, err := h.service.ListHolders(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, holders)
+}
+
+type addHolderRequest struct {
+	CustomerID       string `json:"customer_id" binding:"required"`
+	RelationshipType string `json:"relationship_type" binding:"required"`
+	// Permissions for authorised users; omitted flags default to granted.
+	CanWithdraw         *bool `json:"can_withdraw"`
+	CanTransfer         *bool `json:"can_transfer"`
+	CanViewBalance      *bool `json:"can_view_balance"`
+	CanViewTransactions *bool `json:"can_view_transactions"`
+}
+
+func (h *Handler) addHolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req addHolderRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	customerID, err := uuid.Parse(req.CustomerID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer_id"})
+		return
+	}
+
+	if !h.authorize(c, id, domain.PermissionManageHolders) {
+		return
+	}
+
+	holder := application.NewHolder{
+		CustomerID:          customerID,
+		Relationship:        domain.HolderRelationship(req.RelationshipType),
+		CanWithdraw:         flag(req.CanWithdraw),
+		CanTransfer:         flag(req.CanTransfer),
+		CanViewBalance:      flag(req.CanViewBalance),
+		CanViewTransactions: flag(req.CanViewTransactions),
+	}
+	if userID, err := uuid.Parse(c.GetString("userID")); err == nil {
+		holder.AddedBy = &userID
+	}
+
+	added, err := h.service.AddHolder(c.Request.Context(), id, holder)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, added)
+}
+
+func (h *Handler) removeHolder(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	customerID, err := uuid.Parse(c.Param("customerId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid customer id"})
+		return
+	}
+
+	if !h.authorize(c, id, domain.PermissionManageHolders) {
+		return
+	}
+
+	if err := h.service.RemoveHolder(c.Request.Context(), id, customerID); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// flag reads an optional permission flag, which is granted unless it is
+// explicitly set to false.
+func flag(b *bool) bool {
+	return b == nil || *b
+}
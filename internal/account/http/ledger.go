@@ -0,0 +1,229 @@
+package http
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// listLedger returns a page of the account's ledger, newest first, with
+// running balances. Pass the returned next_cursor as cursor to get the next
+// page.
+func (h *Handler) listLedger(c *gin.Context) {
+	id, query, ok := h.ledgerRequest(c)
+	if !ok {
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+		query.Limit = limit
+	}
+	query.Cursor = c.Query("cursor")
+
+	page, err := h.service.QueryLedger(c.Request.Context(), id, query)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	if !h.canViewBalance(c, id) {
+		for _, line := range page.Lines {
+			line.HideBalances()
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"entries": page.Lines, "next_cursor": page.NextCursor})
+}
+
+// exportLedger streams every ledger entry matching the filters as CSV.
+func (h *Handler) exportLedger(c *gin.Context) {
+	id, query, ok := h.ledgerRequest(c)
+	if !ok {
+		return
+	}
+
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"ledger-%s.csv\"", id))
+	err := h.service.ExportLedgerCSV(c.Request.Context(), c.Writer, id, query, h.canViewBalance(c, id))
+	if err == nil {
+		return
+	}
+	if c.Writer.Written() {
+		// Too late for an error status; the client sees a truncated file
+		log.Printf("ledger export for %s failed part way: %v", id, err)
+		return
+	}
+	c.Writer.Header().Del("Content-Type")
+	c.Writer.Header().Del("Content-Disposition")
+	respondError(c, err)
+}
+
+// ledgerRequest reads the account and the filters shared by the ledger
+// listing and export. Dates are YYYY-MM-DD or RFC 3339; a plain date in to
+// includes that whole day. Amounts are in minor units.
+func (h *Handler) ledgerRequest(c *gin.Context) (id uuid.UUID, query application.LedgerQuery, ok bool) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return id, query, false
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return id, query, false
+	}
+
+	if v := c.Query("from"); v != "" {
+		from, _, err := parseLedgerTime(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from, use YYYY-MM-DD or RFC 3339"})
+			return id, query, false
+		}
+		query.From = &from
+	}
+	if v := c.Query("to"); v != "" {
+		to, dateOnly, err := parseLedgerTime(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to, use YYYY-MM-DD or RFC 3339"})
+			return id, query, false
+		}
+		if dateOnly {
+			to = to.AddDate(0, 0, 1)
+		}
+		query.To = &to
+	}
+	if v := c.Query("entry_type"); v != "" {
+		entryType := domain.LedgerEntryType(v)
+		query.EntryType = &entryType
+	}
+	if query.MinAmount, ok = amountParam(c, "min_amount"); !ok {
+		return id, query, false
+	}
+	if query.MaxAmount, ok = amountParam(c, "max_amount"); !ok {
+		return id, query, false
+	}
+	query.Reference = c.Query("reference")
+	query.Currency = c.Query("currency")
+
+	return id, query, true
+}
+
+// getBalanceAsOf returns the account's balance at the given time, on the
+// booking basis by default or on the value basis with basis=value. A plain
+// date in at means the end of that day; no at means now.
+func (h *Handler) getBalanceAsOf(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewBalance) {
+		return
+	}
+
+	at := time.Now()
+	if v := c.Query("at"); v != "" {
+		var dateOnly bool
+		at, dateOnly, err = parseLedgerTime(v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid at, use YYYY-MM-DD or RFC 3339"})
+			return
+		}
+		if dateOnly {
+			at = at.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		}
+	}
+
+	balance, err := h.service.GetBalanceAsOf(c.Request.Context(), id, at, domain.BalanceBasis(c.Query("basis")), c.Query("currency"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, balance)
+}
+
+// postCorrection books a back-dated adjustment for operations. The value date
+// decides from when the money counts, including for interest.
+func (h *Handler) postCorrection(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		Amount      int64  `json:"amount" binding:"required"` // Signed, minor units
+		Currency    string `json:"currency" binding:"required"`
+		ValueDate   string `json:"value_date" binding:"required"`
+		Reference   string `json:"reference"`
+		Description string `json:"description" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	valueDate, err := time.Parse("2006-01-02", req.ValueDate)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid value_date, use YYYY-MM-DD"})
+		return
+	}
+	if req.Reference == "" {
+		req.Reference = domain.ReferenceCorrection
+	}
+
+	account, err := h.service.AdjustBalanceValueDated(c.Request.Context(), id, req.Amount, req.Currency, valueDate, req.Reference, req.Description)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, account)
+}
+
+// amountParam reads an optional amount in minor units from the query string.
+func amountParam(c *gin.Context, name string) (*int64, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	amount, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", use minor units"})
+		return nil, false
+	}
+	return &amount, true
+}
+
+func parseLedgerTime(v string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		return t, true, nil
+	}
+	t, err = time.Parse(time.RFC3339, v)
+	return t, false, err
+}
+
+// canViewBalance reports whether the caller may see balances on the account.
+// Authorised users without the view_balance permission may still see
+// transactions.
+func (h *Handler) canViewBalance(c *gin.Context, accountID uuid.UUID) bool {
+	switch c.GetString("role") {
+	case "employee":
+		return true
+	case "customer":
+	default:
+		return false
+	}
+	customerID, err := uuid.Parse(c.GetString("customerID"))
+	if err != nil {
+		return false
+	}
+	return h.service.Authorize(c.Request.Context(), accountID, customerID, domain.PermissionViewBalance) == nil
+}
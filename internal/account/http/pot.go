@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+type createPotRequest struct {
+	Name         string `json:"name" binding:"required"`
+	TargetAmount int64  `json:"target_amount"`
+	TargetDate   string `json:"target_date"` // YYYY-MM-DD, optional
+}
+
+func (h *Handler) createPot(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionTransfer) {
+		return
+	}
+
+	var req createPotRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	pot := application.NewPot{Name: req.Name, TargetAmount: req.TargetAmount}
+	if req.TargetDate != "" {
+		day, err := time.Parse("2006-01-02", req.TargetDate)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "target_date must be YYYY-MM-DD"})
+			return
+		}
+		pot.TargetDate = &day
+	}
+
+	created, err := h.service.CreatePot(c.Request.Context(), id, pot)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, created)
+}
+
+func (h *Handler) listPots(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewBalance) {
+		return
+	}
+
+	pots, err := h.service.ListPots(c.Request.Context(), id, time.Now())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, pots)
+}
+
+func (h *Handler) fundPot(c *gin.Context) {
+	h.movePot(c, h.service.FundPot)
+}
+
+func (h *Handler) withdrawFromPot(c *gin.Context) {
+	h.movePot(c, h.service.WithdrawFromPot)
+}
+
+func (h *Handler) movePot(c *gin.Context, move func(ctx context.Context, accountID, potID uuid.UUID, amount int64) (*domain.PotProgress, error)) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	potID, err := uuid.Parse(c.Param("potId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid pot id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionTransfer) {
+		return
+	}
+
+	var req struct {
+		Amount int64 `json:"amount" binding:"required,gt=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	progress, err := move(c.Request.Context(), id, potID, req.Amount)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, progress)
+}
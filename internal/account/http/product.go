@@ -0,0 +1,103 @@
+package http
+
+import (
+	"net/http"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+type productRequest struct {
+	Code               string               `json:"code"`
+	Name               string               `json:"name" binding:"required"`
+	AccountType        string               `json:"account_type" binding:"required"`
+	Currency           string               `json:"currency" binding:"required"`
+	InterestRateBps    int64                `json:"interest_rate_bps"`
+	InterestTiers      []domain.ProductTier `json:"interest_tiers"`
+	DayCountConvention string               `json:"day_count_convention"`
+	MonthlyFee         int64                `json:"monthly_fee"`
+	OverdraftEligible  bool                 `json:"overdraft_eligible"`
+	MinAge             int                  `json:"min_age"`
+	RequiresKYC        bool                 `json:"requires_kyc"`
+	Active             *bool                `json:"active"`
+}
+
+func (r productRequest) product() *domain.Product {
+	product := &domain.Product{
+		Code:               r.Code,
+		Name:               r.Name,
+		AccountType:        domain.AccountType(r.AccountType),
+		Currency:           r.Currency,
+		InterestRateBps:    r.InterestRateBps,
+		InterestTiers:      r.InterestTiers,
+		DayCountConvention: domain.DayCountConvention(r.DayCountConvention),
+		MonthlyFee:         r.MonthlyFee,
+		OverdraftEligible:  r.OverdraftEligible,
+		MinAge:             r.MinAge,
+		RequiresKYC:        r.RequiresKYC,
+		Active:             true,
+	}
+	if r.Active != nil {
+		product.Active = *r.Active
+	}
+	return product
+}
+
+// listProducts returns the products on offer; employees can ask for retired
+// ones too with ?all=true.
+func (h *Handler) listProducts(c *gin.Context) {
+	includeRetired := c.Query("all") == "true" && c.GetString("role") == "employee"
+
+	products, err := h.service.ListProducts(c.Request.Context(), includeRetired)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, products)
+}
+
+func (h *Handler) getProduct(c *gin.Context) {
+	product, err := h.service.GetProduct(c.Request.Context(), c.Param("code"))
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
+
+func (h *Handler) createProduct(c *gin.Context) {
+	var req productRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.service.CreateProduct(c.Request.Context(), req.product())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, product)
+}
+
+// updateProduct replaces a product's terms. Sending "active": false retires
+// it.
+func (h *Handler) updateProduct(c *gin.Context) {
+	var req productRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	product, err := h.service.UpdateProduct(c.Request.Context(), c.Param("code"), req.product())
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, product)
+}
@@ -0,0 +1,194 @@
+package http
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"nordic-bank/internal/account/application"
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/account/render"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func (h *Handler) listStatements(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return
+	}
+
+	statements, err := h.service.ListStatements(c.Request.Context(), id)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statements)
+}
+
+func (h *Handler) getStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return
+	}
+	statementID, err := uuid.Parse(c.Param("statementId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	statement, err := h.service.GetStatement(c.Request.Context(), id, statementID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statement)
+}
+
+func (h *Handler) generateStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	var req struct {
+		PeriodStart string `json:"period_start" binding:"required"` // YYYY-MM-DD
+		PeriodEnd   string `json:"period_end" binding:"required"`   // YYYY-MM-DD, inclusive
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, err := time.Parse("2006-01-02", req.PeriodStart)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period_start, use YYYY-MM-DD"})
+		return
+	}
+	end, err := time.Parse("2006-01-02", req.PeriodEnd)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid period_end, use YYYY-MM-DD"})
+		return
+	}
+
+	statement, err := h.service.GenerateStatement(c.Request.Context(), id, start, end)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, statement)
+}
+
+// downloadStatement serves a rendered statement. The format (pdf or csv) and
+// language (da or en) are picked with query parameters and default to a
+// Danish PDF.
+func (h *Handler) downloadStatement(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return
+	}
+	statementID, err := uuid.Parse(c.Param("statementId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid statement id"})
+		return
+	}
+
+	format := application.DocumentFormat(c.DefaultQuery("format", string(application.DocumentFormatPDF)))
+	lang := render.Language(c.DefaultQuery("lang", string(render.Danish)))
+
+	ctx := c.Request.Context()
+	document, err := h.service.OpenStatementDocument(ctx, id, statementID, format, lang)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+	defer document.Close()
+
+	statement, err := h.service.GetStatement(ctx, id, statementID)
+	if err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", application.StatementDocumentName(statement, format, lang)))
+	c.Header("Content-Type", format.ContentType())
+	c.Status(http.StatusOK)
+	if _, err := io.Copy(c.Writer, document); err != nil {
+		c.Error(err)
+	}
+}
+
+const camtContentType = "application/xml; charset=utf-8"
+
+// exportCamt053 returns an ISO 20022 camt.053 statement for the dates given by
+// the from and to query parameters (YYYY-MM-DD, both inclusive).
+func (h *Handler) exportCamt053(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", c.Query("from"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from, use YYYY-MM-DD"})
+		return
+	}
+	to, err := time.Parse("2006-01-02", c.Query("to"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to, use YYYY-MM-DD"})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.service.ExportCamt053(c.Request.Context(), &buf, id, from, to); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"camt053-%s-%s.xml\"", from.Format("20060102"), to.Format("20060102")))
+	c.Data(http.StatusOK, camtContentType, buf.Bytes())
+}
+
+// exportCamt052 returns an ISO 20022 camt.052 intraday report for today.
+func (h *Handler) exportCamt052(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+	if !h.authorize(c, id, domain.PermissionViewTransactions) {
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := h.service.ExportCamt052(c.Request.Context(), &buf, id); err != nil {
+		respondError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"camt052-%s.xml\"", time.Now().Format("20060102")))
+	c.Data(http.StatusOK, camtContentType, buf.Bytes())
+}
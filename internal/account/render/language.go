@@ -0,0 +1,150 @@
+// Package render turns finalised account statements into customer-facing
+// documents: a branded PDF and a CSV of the period's ledger entries.
+package render
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+type Language string
+
+const (
+	Danish  Language = "da"
+	English Language = "en"
+)
+
+// Languages lists every language a statement is rendered in.
+var Languages = []Language{Danish, English}
+
+func (l Language) Valid() bool {
+	return l == Danish || l == English
+}
+
+type labels struct {
+	Title          string
+	FinalTitle     string
+	Account        string
+	AccountNumber  string
+	Currency       string
+	Period         string
+	StatementDate  string
+	Summary        string
+	OpeningBalance string
+	Credits        string
+	Debits         string
+	Interest       string
+	ClosingBalance string
+	Transactions   string
+	Date           string
+	Description    string
+	Reference      string
+	Amount         string
+	Balance        string
+	NoEntries      string
+	Page           string
+	Footer         string
+}
+
+var translations = map[Language]labels{
+	Danish: {
+		Title:          "Kontoudskrift",
+		FinalTitle:     "Slutopgørelse",
+		Account:        "Konto",
+		AccountNumber:  "Kontonummer",
+		Currency:       "Valuta",
+		Period:         "Periode",
+		StatementDate:  "Udskriftsdato",
+		Summary:        "Oversigt",
+		OpeningBalance: "Primosaldo",
+		Credits:        "Indbetalinger",
+		Debits:         "Udbetalinger",
+		Interest:       "Renter",
+		ClosingBalance: "Ultimosaldo",
+		Transactions:   "Posteringer",
+		Date:           "Dato",
+		Description:    "Tekst",
+		Reference:      "Reference",
+		Amount:         "Beløb",
+		Balance:        "Saldo",
+		NoEntries:      "Ingen posteringer i perioden.",
+		Page:           "Side %d af %d",
+		Footer:         "Nordic Bank A/S · Kontoudskriften er dannet automatisk og er endelig.",
+	},
+	English: {
+		Title:          "Account statement",
+		FinalTitle:     "Final statement",
+		Account:        "Account",
+		AccountNumber:  "Account number",
+		Currency:       "Currency",
+		Period:         "Period",
+		StatementDate:  "Statement date",
+		Summary:        "Summary",
+		OpeningBalance: "Opening balance",
+		Credits:        "Money in",
+		Debits:         "Money out",
+		Interest:       "Interest",
+		ClosingBalance: "Closing balance",
+		Transactions:   "Transactions",
+		Date:           "Date",
+		Description:    "Description",
+		Reference:      "Reference",
+		Amount:         "Amount",
+		Balance:        "Balance",
+		NoEntries:      "No transactions in this period.",
+		Page:           "Page %d of %d",
+		Footer:         "Nordic Bank A/S · This statement was generated automatically and is final.",
+	},
+}
+
+func (l Language) labels() labels {
+	if t, ok := translations[l]; ok {
+		return t
+	}
+	return translations[English]
+}
+
+func (l Language) date(t time.Time) string {
+	if l == Danish {
+		return t.Format("02-01-2006")
+	}
+	return t.Format("2 Jan 2006")
+}
+
+// amount formats minor units with two decimals and the language's separators,
+// e.g. -1.234,56 in Danish and -1,234.56 in English.
+func (l Language) amount(minor int64) string {
+	if l == Danish {
+		return formatMinor(minor, ".", ",")
+	}
+	return formatMinor(minor, ",", ".")
+}
+
+// plainAmount is amount without thousands separators, which is what
+// spreadsheets expect when importing a CSV.
+func (l Language) plainAmount(minor int64) string {
+	if l == Danish {
+		return formatMinor(minor, "", ",")
+	}
+	return formatMinor(minor, "", ".")
+}
+
+func formatMinor(minor int64, thousands, decimal string) string {
+	sign := ""
+	if minor < 0 {
+		sign = "-"
+		minor = -minor
+	}
+
+	whole := fmt.Sprintf("%d", minor/100)
+	var b strings.Builder
+	for i, r := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			b.WriteString(thousands)
+		}
+		b.WriteRune(r)
+	}
+
+	return fmt.Sprintf("%s%s%s%02d", sign, b.String(), decimal, minor%100)
+}
@@ -0,0 +1,64 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+)
+
+// LedgerCSV streams ledger lines as CSV for support staff explaining a
+// balance. Amounts use a decimal point whatever the customer's language, and
+// each row shows both the recorded balance and the running balance so an
+// entry where they part ways stands out.
+type LedgerCSV struct {
+	cw           *csv.Writer
+	showBalances bool
+}
+
+// NewLedgerCSV writes the header row. Balance columns are left empty when
+// showBalances is false.
+func NewLedgerCSV(w io.Writer, showBalances bool) (*LedgerCSV, error) {
+	l := &LedgerCSV{cw: csv.NewWriter(w), showBalances: showBalances}
+	err := l.cw.Write([]string{
+		"entry_id", "entry_date", "entry_type", "amount", "currency",
+		"balance_before", "balance_after", "running_balance",
+		"reference", "description", "transaction_id",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// Write writes one row per line and flushes them to the underlying writer.
+func (l *LedgerCSV) Write(lines []*domain.LedgerLine) error {
+	for _, line := range lines {
+		var before, after, running, txID string
+		if l.showBalances {
+			before = English.plainAmount(line.BalanceBefore)
+			after = English.plainAmount(line.BalanceAfter)
+			running = English.plainAmount(line.RunningBalance)
+		}
+		if line.TransactionID != nil {
+			txID = line.TransactionID.String()
+		}
+		err := l.cw.Write([]string{
+			line.ID.String(),
+			line.EntryDate.UTC().Format(time.RFC3339),
+			string(line.EntryType),
+			English.plainAmount(line.Amount),
+			line.Currency,
+			before, after, running,
+			line.Reference,
+			line.Description,
+			txID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	l.cw.Flush()
+	return l.cw.Error()
+}
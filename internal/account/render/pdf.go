@@ -0,0 +1,169 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"nordic-bank/internal/account/domain"
+	"nordic-bank/internal/shared/pdf"
+)
+
+// House colours, matching the customer web app.
+var (
+	brandNavy  = pdf.RGB(0x0f, 0x17, 0x2a)
+	brandBlue  = pdf.RGB(0x25, 0x63, 0xeb)
+	mutedText  = pdf.RGB(0x4b, 0x55, 0x63)
+	rowShading = pdf.RGB(0xf1, 0xf5, 0xf9)
+	white      = pdf.RGB(0xff, 0xff, 0xff)
+)
+
+const (
+	marginLeft   = 40.0
+	marginRight  = pdf.PageWidth - 40
+	headerHeight = 80.0
+	footerTop    = 60.0
+	rowHeight    = 16.0
+
+	colDate        = marginLeft
+	colDescription = marginLeft + 70
+	colReference   = marginLeft + 300
+	colAmount      = marginRight - 90
+	colBalance     = marginRight
+)
+
+var (
+	textStyle  = pdf.Style{Font: pdf.Helvetica, Size: 9, Color: pdf.Black}
+	labelStyle = pdf.Style{Font: pdf.Helvetica, Size: 9, Color: mutedText}
+	boldStyle  = pdf.Style{Font: pdf.HelveticaBold, Size: 9, Color: pdf.Black}
+	headStyle  = pdf.Style{Font: pdf.HelveticaBold, Size: 12, Color: brandNavy}
+)
+
+// WritePDF renders the statement as an A4 PDF: a branded header, the account
+// details and period summary, then the transactions, continuing onto as many
+// pages as needed.
+func WritePDF(w io.Writer, st Statement, lang Language) error {
+	t := lang.labels()
+	account, statement := st.Account, st.Statement
+	if statement.IsFinal {
+		t.Title = t.FinalTitle
+	}
+
+	doc := pdf.New(fmt.Sprintf("%s %s %s", t.Title, account.AccountNumber, statement.StatementDate.Format("2006-01")))
+
+	page := newPage(doc, t)
+	y := pdf.PageHeight - headerHeight - 40
+
+	details := [][2]string{
+		{t.Account, account.AccountName},
+		{t.AccountNumber, account.AccountNumber},
+		{t.Currency, account.Currency},
+		{t.Period, lang.date(statement.PeriodStartDate) + " – " + lang.date(statement.PeriodEndDate)},
+		{t.StatementDate, lang.date(statement.StatementDate)},
+	}
+	for _, d := range details {
+		page.Text(marginLeft, y, labelStyle, d[0])
+		page.Text(marginLeft+110, y, textStyle, d[1])
+		y -= 14
+	}
+
+	y -= 20
+	page.Text(marginLeft, y, headStyle, t.Summary)
+	y -= 8
+	page.Line(marginLeft, y, marginRight, y, 0.5, brandNavy)
+	y -= 16
+
+	summary := []struct {
+		label  string
+		amount int64
+	}{
+		{t.OpeningBalance, statement.OpeningBalance},
+		{t.Credits, statement.TotalCredits},
+		{t.Debits, -statement.TotalDebits},
+		{t.Interest, statement.InterestEarned},
+	}
+	for _, row := range summary {
+		page.Text(marginLeft, y, textStyle, row.label)
+		page.TextRight(marginRight, y, textStyle, lang.amount(row.amount))
+		y -= 14
+	}
+	page.Line(marginLeft, y+10, marginRight, y+10, 0.5, mutedText)
+	page.Text(marginLeft, y-2, boldStyle, t.ClosingBalance)
+	page.TextRight(marginRight, y-2, boldStyle, lang.amount(statement.ClosingBalance))
+	y -= 40
+
+	page.Text(marginLeft, y, headStyle, t.Transactions)
+	y -= 22
+
+	lines := st.lines()
+	if len(lines) == 0 {
+		page.Text(marginLeft, y, labelStyle, t.NoEntries)
+	} else {
+		y = tableHeader(page, y, t)
+		for i, entry := range lines {
+			if y < footerTop+rowHeight {
+				page = newPage(doc, t)
+				y = tableHeader(page, pdf.PageHeight-headerHeight-30, t)
+			}
+			if i%2 == 1 {
+				page.Rect(marginLeft-4, y-4, marginRight-marginLeft+8, rowHeight, rowShading)
+			}
+			tableRow(page, y, lang, entry)
+			y -= rowHeight
+		}
+	}
+
+	addFooters(doc, t)
+
+	_, err := doc.WriteTo(w)
+	return err
+}
+
+func newPage(doc *pdf.Document, t labels) *pdf.Page {
+	page := doc.AddPage()
+
+	page.Rect(0, pdf.PageHeight-headerHeight, pdf.PageWidth, headerHeight, brandNavy)
+	page.Rect(0, pdf.PageHeight-headerHeight-4, pdf.PageWidth, 4, brandBlue)
+	page.Text(marginLeft, pdf.PageHeight-50, pdf.Style{Font: pdf.HelveticaBold, Size: 22, Color: white}, "Nordic Bank")
+	page.TextRight(marginRight, pdf.PageHeight-48, pdf.Style{Font: pdf.Helvetica, Size: 13, Color: white}, t.Title)
+
+	return page
+}
+
+func addFooters(doc *pdf.Document, t labels) {
+	docPages := doc.Pages()
+	for i, page := range docPages {
+		page.Line(marginLeft, footerTop-20, marginRight, footerTop-20, 0.5, mutedText)
+		page.Text(marginLeft, footerTop-34, labelStyle, t.Footer)
+		page.TextRight(marginRight, footerTop-34, labelStyle, fmt.Sprintf(t.Page, i+1, len(docPages)))
+	}
+}
+
+func tableHeader(page *pdf.Page, y float64, t labels) float64 {
+	page.Text(colDate, y, boldStyle, t.Date)
+	page.Text(colDescription, y, boldStyle, t.Description)
+	page.Text(colReference, y, boldStyle, t.Reference)
+	page.TextRight(colAmount, y, boldStyle, t.Amount)
+	page.TextRight(colBalance, y, boldStyle, t.Balance)
+	page.Line(marginLeft, y-5, marginRight, y-5, 0.5, brandNavy)
+	return y - rowHeight - 2
+}
+
+func tableRow(page *pdf.Page, y float64, lang Language, entry *domain.LedgerEntry) {
+	page.Text(colDate, y, textStyle, lang.date(entry.EntryDate))
+	page.Text(colDescription, y, textStyle, truncate(entry.Description, colReference-colDescription-10, textStyle.Size))
+	page.Text(colReference, y, textStyle, truncate(entry.Reference, colAmount-colReference-80, textStyle.Size))
+	page.TextRight(colAmount, y, textStyle, lang.amount(entry.Amount))
+	page.TextRight(colBalance, y, textStyle, lang.amount(entry.BalanceAfter))
+}
+
+// truncate shortens s with an ellipsis so it fits in width points.
+func truncate(s string, width, size float64) string {
+	if pdf.TextWidth(s, size) <= width {
+		return s
+	}
+	r := []rune(s)
+	for len(r) > 0 && pdf.TextWidth(string(r)+"...", size) > width {
+		r = r[:len(r)-1]
+	}
+	return string(r) + "..."
+}
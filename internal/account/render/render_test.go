@@ -0,0 +1,103 @@
+package render
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/account/domain"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAmountFormatting(t *testing.T) {
+	assert.Equal(t, "1.234.567,89", Danish.amount(123_456_789))
+	assert.Equal(t, "1,234,567.89", English.amount(123_456_789))
+	assert.Equal(t, "-0,05", Danish.amount(-5))
+	assert.Equal(t, "999.00", English.amount(99_900))
+	assert.Equal(t, "-1234567,89", Danish.plainAmount(-123_456_789))
+}
+
+func testStatement(entries int) Statement {
+	st := Statement{
+		Account: &domain.Account{AccountNumber: "DK5000400440116243", AccountName: "Lønkonto", Currency: "DKK"},
+		Statement: &domain.Statement{
+			StatementDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+			PeriodStartDate: time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC),
+			PeriodEndDate:   time.Date(2024, time.March, 31, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	var balance int64
+	for i := 0; i < entries; i++ {
+		st.Entries = append(st.Entries, &domain.LedgerEntry{
+			Amount:       1_000,
+			BalanceAfter: balance + 1_000,
+			Description:  "Overførsel; \"husleje\"",
+			Reference:    "TRANSFER",
+			EntryDate:    time.Date(2024, time.March, 1+i%28, 9, 0, 0, 0, time.UTC),
+		})
+		balance += 1_000
+	}
+	st.Statement.ClosingBalance = balance
+	st.Statement.TotalCredits = balance
+	return st
+}
+
+func TestWriteCSV_QuotesAndLocalises(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, testStatement(1), Danish))
+
+	lines := strings.Split(strings.TrimPrefix(buf.String(), "\ufeff"), "\n")
+	assert.Equal(t, "Dato;Tekst;Reference;Beløb;Saldo;Valuta", lines[0])
+	assert.Equal(t, `2024-03-01;"Overførsel; ""husleje""";TRANSFER;10,00;10,00;DKK`, lines[1])
+
+	buf.Reset()
+	require.NoError(t, WriteCSV(&buf, testStatement(1), English))
+	assert.Contains(t, buf.String(), "2024-03-01,\"Overførsel; \"\"husleje\"\"\",TRANSFER,10.00,10.00,DKK")
+}
+
+func TestWritePDF_PaginatesLongStatements(t *testing.T) {
+	var short, long bytes.Buffer
+	require.NoError(t, WritePDF(&short, testStatement(0), Danish))
+	require.NoError(t, WritePDF(&long, testStatement(120), English))
+
+	assert.True(t, strings.HasPrefix(short.String(), "%PDF-1.4"))
+	assert.Contains(t, short.String(), "/Count 1 ")
+	assert.Contains(t, short.String(), "(Ingen posteringer i perioden.)")
+
+	assert.NotContains(t, long.String(), "/Count 1 ")
+	assert.Contains(t, long.String(), "(Page 1 of ")
+	assert.True(t, strings.HasSuffix(long.String(), "%%EOF\n"))
+}
+
+func TestLedgerCSV_HidesBalancesWhenAsked(t *testing.T) {
+	line := &domain.LedgerLine{
+		LedgerEntry: domain.LedgerEntry{
+			EntryType:     domain.EntryTypeDebit,
+			Amount:        -12_345,
+			Currency:      "DKK",
+			BalanceBefore: 50_000,
+			BalanceAfter:  37_655,
+			Reference:     "CARD",
+			Description:   "Café, Nørrebro",
+			EntryDate:     time.Date(2026, time.May, 2, 14, 30, 0, 0, time.UTC),
+		},
+		RunningBalance: 37_655,
+	}
+
+	var buf bytes.Buffer
+	csv, err := NewLedgerCSV(&buf, true)
+	require.NoError(t, err)
+	require.NoError(t, csv.Write([]*domain.LedgerLine{line}))
+	rows := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, rows, 2)
+	assert.Contains(t, rows[1], `,2026-05-02T14:30:00Z,debit,-123.45,DKK,500.00,376.55,376.55,CARD,"Café, Nørrebro",`)
+
+	buf.Reset()
+	csv, err = NewLedgerCSV(&buf, false)
+	require.NoError(t, err)
+	require.NoError(t, csv.Write([]*domain.LedgerLine{line}))
+	assert.Contains(t, buf.String(), `,-123.45,DKK,,,,CARD,`)
+}
@@ -0,0 +1,58 @@
+package render
+
+import (
+	"encoding/csv"
+	"io"
+
+	"nordic-bank/internal/account/domain"
+)
+
+// Statement is everything that goes into a statement document.
+type Statement struct {
+	Account   *domain.Account
+	Statement *domain.Statement
+	Entries   []*domain.LedgerEntry // Ledger entries for the period, oldest first
+}
+
+// lines returns the entries that are shown as transactions. Zero-amount
+// entries, such as the one written when an account is opened, are left out
+// so the list matches the statement's transaction count.
+func (s Statement) lines() []*domain.LedgerEntry {
+	lines := make([]*domain.LedgerEntry, 0, len(s.Entries))
+	for _, entry := range s.Entries {
+		if entry.Amount != 0 {
+			lines = append(lines, entry)
+		}
+	}
+	return lines
+}
+
+// WriteCSV writes one row per transaction. Danish files use semicolons and
+// decimal commas so they open correctly in a Danish spreadsheet.
+func WriteCSV(w io.Writer, st Statement, lang Language) error {
+	t := lang.labels()
+
+	// A byte order mark makes spreadsheets read the file as UTF-8.
+	if _, err := io.WriteString(w, "\ufeff"); err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if lang == Danish {
+		cw.Comma = ';'
+	}
+
+	rows := [][]string{{t.Date, t.Description, t.Reference, t.Amount, t.Balance, t.Currency}}
+	for _, entry := range st.lines() {
+		rows = append(rows, []string{
+			entry.EntryDate.Format("2006-01-02"),
+			entry.Description,
+			entry.Reference,
+			lang.plainAmount(entry.Amount),
+			lang.plainAmount(entry.BalanceAfter),
+			st.Account.Currency,
+		})
+	}
+
+	return cw.WriteAll(rows)
+}
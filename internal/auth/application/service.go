@@ -163,6 +163,11 @@ func (s *AuthService) generateToken(user *domain.User, duration time.Duration) (
 		},
 	}
 
+	// Accounts, holders and transfers know a customer by their user ID
+	if user.Role == domain.RoleCustomer {
+		claims.CustomerID = user.ID.String()
+	}
+
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString(s.jwtSecret)
 }
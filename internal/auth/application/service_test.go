@@ -0,0 +1,95 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"nordic-bank/internal/auth/domain"
+	sharedauth "nordic-bank/internal/shared/auth"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryUsers struct {
+	domain.UserRepository
+	users []*domain.User
+}
+
+func (r *memoryUsers) Create(_ context.Context, user *domain.User) error {
+	user.ID = uuid.New()
+	r.users = append(r.users, user)
+	return nil
+}
+
+func (r *memoryUsers) find(match func(*domain.User) bool) (*domain.User, error) {
+	for _, user := range r.users {
+		if match(user) {
+			return user, nil
+		}
+	}
+	return nil, errors.New("user not found")
+}
+
+func (r *memoryUsers) GetByEmail(_ context.Context, email string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Email == email })
+}
+
+func (r *memoryUsers) GetByUsername(_ context.Context, username string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Username == username })
+}
+
+func (r *memoryUsers) GetByEmailOrUsername(_ context.Context, identifier string) (*domain.User, error) {
+	return r.find(func(u *domain.User) bool { return u.Email == identifier || u.Username == identifier })
+}
+
+func (r *memoryUsers) Update(context.Context, *domain.User) error { return nil }
+
+type memorySessions struct {
+	domain.SessionRepository
+}
+
+func (memorySessions) Create(context.Context, *domain.Session) error { return nil }
+
+func TestLogin_TokensIdentifyTheCustomerToOtherServices(t *testing.T) {
+	const secret = "test-secret"
+	service := NewAuthService(&memoryUsers{}, memorySessions{}, secret)
+	ctx := context.Background()
+
+	customer, err := service.Register(ctx, "alice", "alice@example.com", "correct horse", domain.RoleCustomer)
+	require.NoError(t, err)
+	require.NoError(t, service.SeedDefaultEmployee(ctx, "bob", "bob@example.com", "battery staple"))
+
+	// What the account and transaction services see once they verify the token
+	seen := func(identifier, password string) gin.H {
+		_, token, _, err := service.Login(ctx, identifier, password, "", "")
+		require.NoError(t, err)
+
+		gin.SetMode(gin.TestMode)
+		router := gin.New()
+		router.GET("/", sharedauth.AuthMiddleware([]byte(secret)), func(c *gin.Context) {
+			c.JSON(http.StatusOK, gin.H{"user": c.GetString("userID"), "role": c.GetString("role"), "customer": c.GetString("customerID")})
+		})
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var got gin.H
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &got))
+		return got
+	}
+
+	assert.Equal(t, gin.H{"user": customer.ID.String(), "role": "customer", "customer": customer.ID.String()}, seen("alice", "correct horse"))
+
+	employee := seen("bob@example.com", "battery staple")
+	assert.Equal(t, "employee", employee["role"])
+	assert.Empty(t, employee["customer"], "employees act for the bank, not as a customer")
+}
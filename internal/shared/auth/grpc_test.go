@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	secret := []byte("test-secret")
+	interceptor := UnaryServerInterceptor(secret, ServiceRole)
+
+	call := func(ctx context.Context) (*CustomClaims, error) {
+		var seen *CustomClaims
+		_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+			seen, _ = ClaimsFrom(ctx)
+			return nil, nil
+		})
+		return seen, err
+	}
+	withToken := func(secret []byte, service string) context.Context {
+		md, err := ServiceCredentials(secret, service).GetRequestMetadata(context.Background())
+		require.NoError(t, err)
+		return metadata.NewIncomingContext(context.Background(), metadata.New(md))
+	}
+
+	claims, err := call(withToken(secret, "account-service"))
+	require.NoError(t, err)
+	assert.Equal(t, ServiceRole, claims.Role)
+	assert.Equal(t, "account-service", claims.UserID)
+
+	_, err = call(context.Background())
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = call(withToken([]byte("another-secret"), "account-service"))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = UnaryServerInterceptor(secret, "employee")(withToken(secret, "account-service"), nil, &grpc.UnaryServerInfo{},
+		func(context.Context, interface{}) (interface{}, error) { return nil, nil })
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+}
@@ -10,51 +10,85 @@ import (
 
 // CustomClaims reflects the structure of the JWT claims
 type CustomClaims struct {
-	UserID   string `json:"user_id"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     string `json:"role"`
+	UserID     string `json:"user_id"`
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	Role       string `json:"role"`
+	CustomerID string `json:"customer_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // AuthMiddleware verifies the JWT token in the Authorization header
 func AuthMiddleware(jwtSecret []byte) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if c.GetHeader("Authorization") == "" {
 			c.JSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is required"})
 			c.Abort()
 			return
 		}
 
-		parts := strings.Split(authHeader, " ")
-		if len(parts) != 2 || parts[0] != "Bearer" {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
-			c.Abort()
+		if authenticate(c, jwtSecret) {
+			c.Next()
+		}
+	}
+}
+
+// OptionalAuthMiddleware verifies the JWT token when one is sent and lets
+// anonymous requests through, so handlers can restrict what customers see
+// without locking out internal callers.
+func OptionalAuthMiddleware(jwtSecret []byte) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
 			return
 		}
 
-		tokenString := parts[1]
-		claims := &CustomClaims{}
+		if authenticate(c, jwtSecret) {
+			c.Next()
+		}
+	}
+}
+
+// authenticate parses the bearer token and stores its claims in the context.
+// It aborts the request and returns false when the token is not valid.
+func authenticate(c *gin.Context, jwtSecret []byte) bool {
+	parts := strings.Split(c.GetHeader("Authorization"), " ")
+	if len(parts) != 2 || parts[0] != "Bearer" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid authorization header format"})
+		c.Abort()
+		return false
+	}
 
-		token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
+	claims, err := ParseToken(parts[1], jwtSecret)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		c.Abort()
+		return false
+	}
 
-		if err != nil || !token.Valid {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
-			c.Abort()
-			return
-		}
+	// Store claims in context for later use
+	c.Set("userID", claims.UserID)
+	c.Set("username", claims.Username)
+	c.Set("role", claims.Role)
+	c.Set("email", claims.Email)
+	c.Set("customerID", claims.CustomerID)
 
-		// Store claims in context for later use
-		c.Set("userID", claims.UserID)
-		c.Set("username", claims.Username)
-		c.Set("role", claims.Role)
-		c.Set("email", claims.Email)
+	return true
+}
 
-		c.Next()
+// ParseToken verifies a signed token and returns its claims.
+func ParseToken(tokenString string, jwtSecret []byte) (*CustomClaims, error) {
+	claims := &CustomClaims{}
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		return jwtSecret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}))
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenInvalidClaims
 	}
+	return claims, nil
 }
 
 // RoleMiddleware restricts access based on user role
@@ -0,0 +1,21 @@
+// Package blob stores rendered documents such as account statements.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned by Open when no object exists under the key.
+var ErrNotFound = errors.New("blob not found")
+
+// Store is a flat key/value store for binary objects. Keys are slash
+// separated paths, e.g. "statements/<account>/<statement>/da.pdf".
+type Store interface {
+	// Put writes the object under key, replacing any previous version, and
+	// returns the URL it can be referenced by.
+	Put(ctx context.Context, key, contentType string, r io.Reader) (string, error)
+	// Open returns the object stored under key, or ErrNotFound.
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
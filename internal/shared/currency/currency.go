@@ -0,0 +1,46 @@
+// Package currency knows the ISO 4217 currencies the bank keeps balances in
+// and how many minor units each one has.
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var ErrUnsupported = errors.New("currency is not supported")
+
+// exponents holds the ISO 4217 minor-unit exponent of every currency an
+// account can hold: 2 means amounts are kept in hundredths.
+var exponents = map[string]int{
+	"DKK": 2,
+	"EUR": 2,
+	"SEK": 2,
+	"NOK": 2,
+	"USD": 2,
+}
+
+// Default is the currency of accounts opened without asking for another.
+const Default = "DKK"
+
+// Normalize upper-cases and trims a currency code.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Validate checks that code is a supported currency.
+func Validate(code string) error {
+	if _, ok := exponents[Normalize(code)]; !ok {
+		return fmt.Errorf("%w: %q", ErrUnsupported, code)
+	}
+	return nil
+}
+
+// Exponent returns the number of decimals in the currency's minor unit.
+func Exponent(code string) (int, error) {
+	exp, ok := exponents[Normalize(code)]
+	if !ok {
+		return 0, fmt.Errorf("%w: %q", ErrUnsupported, code)
+	}
+	return exp, nil
+}
@@ -0,0 +1,26 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	for _, code := range []string{"DKK", "EUR", "SEK", "NOK", "USD", " eur "} {
+		assert.NoError(t, Validate(code), code)
+	}
+	for _, code := range []string{"", "GBP", "DK", "EURO"} {
+		assert.ErrorIs(t, Validate(code), ErrUnsupported, code)
+	}
+}
+
+func TestExponent(t *testing.T) {
+	exp, err := Exponent("sek")
+	require.NoError(t, err)
+	assert.Equal(t, 2, exp)
+
+	_, err = Exponent("JPY")
+	assert.ErrorIs(t, err, ErrUnsupported)
+}
@@ -0,0 +1,105 @@
+// Package iban builds and validates International Bank Account Numbers as
+// defined by ISO 13616, with the national formats of the SEPA countries.
+package iban
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrTooShort        = errors.New("iban is too short")
+	ErrInvalidChars    = errors.New("iban may only contain letters and digits")
+	ErrUnknownCountry  = errors.New("iban country is not supported")
+	ErrInvalidLength   = errors.New("iban has the wrong length for its country")
+	ErrInvalidBBAN     = errors.New("iban account part does not match the national format")
+	ErrInvalidChecksum = errors.New("iban check digits are wrong")
+)
+
+// Normalize removes spaces and upper-cases s, turning the paper format
+// "DK50 0040 0440 1162 43" into the electronic one.
+func Normalize(s string) string {
+	return strings.ToUpper(strings.Join(strings.Fields(s), ""))
+}
+
+// Validate checks an IBAN in electronic or paper format: the country must be
+// a SEPA country, the length and account part must match its national format
+// and the mod-97 check digits must be right.
+func Validate(s string) error {
+	s = Normalize(s)
+	if len(s) < 5 {
+		return ErrTooShort
+	}
+	for _, r := range s {
+		if !isDigit(r) && !isUpper(r) {
+			return ErrInvalidChars
+		}
+	}
+
+	format, ok := formats[s[:2]]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrUnknownCountry, s[:2])
+	}
+	if len(s) != format.length {
+		return fmt.Errorf("%w: %s needs %d characters", ErrInvalidLength, s[:2], format.length)
+	}
+	if !isDigit(rune(s[2])) || !isDigit(rune(s[3])) || !format.bban.MatchString(s[4:]) {
+		return ErrInvalidBBAN
+	}
+	if mod97(s[4:]+s[:4]) != 1 {
+		return ErrInvalidChecksum
+	}
+	return nil
+}
+
+// New builds the IBAN for a national account number (BBAN), computing the
+// check digits.
+func New(country, bban string) (string, error) {
+	country, bban = Normalize(country), Normalize(bban)
+	iban := country + CheckDigits(country, bban) + bban
+	if err := Validate(iban); err != nil {
+		return "", err
+	}
+	return iban, nil
+}
+
+// CheckDigits returns the two ISO 13616 check digits for bban in country.
+func CheckDigits(country, bban string) string {
+	return fmt.Sprintf("%02d", 98-mod97(bban+country+"00"))
+}
+
+// Format returns the paper format of an IBAN: groups of four separated by
+// spaces.
+func Format(s string) string {
+	s = Normalize(s)
+	var b strings.Builder
+	for i, r := range s {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// mod97 returns s modulo 97, with letters counting as 10 (A) to 35 (Z).
+func mod97(s string) int {
+	var digits strings.Builder
+	for _, r := range s {
+		if isUpper(r) {
+			fmt.Fprintf(&digits, "%d", r-'A'+10)
+		} else {
+			digits.WriteRune(r)
+		}
+	}
+	n, ok := new(big.Int).SetString(digits.String(), 10)
+	if !ok {
+		return -1
+	}
+	return int(new(big.Int).Mod(n, big.NewInt(97)).Int64())
+}
+
+func isDigit(r rune) bool { return r >= '0' && r <= '9' }
+func isUpper(r rune) bool { return r >= 'A' && r <= 'Z' }
@@ -0,0 +1,62 @@
+package iban
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate_AcceptsRegistryExamples(t *testing.T) {
+	for _, s := range []string{
+		"DK5000400440116243",
+		"DE89370400440532013000",
+		"GB29 NWBK 6016 1331 9268 19",
+		"NL91ABNA0417164300",
+		"FR1420041010050500013M02606",
+		"NO9386011117947",
+		"SE4550000000058398257466",
+		"FI2112345600000785",
+		"IT60X0542811101000000123456",
+		"ES9121000418450200051332",
+		"PL61109010140000071219812874",
+		"be68 5390 0754 7034",
+	} {
+		assert.NoError(t, Validate(s), s)
+	}
+}
+
+func TestValidate_Rejects(t *testing.T) {
+	cases := map[string]error{
+		"DK":                         ErrTooShort,
+		"DK50-0040-0440-1162-43":     ErrInvalidChars,
+		"US12345678901234567890":     ErrUnknownCountry,
+		"DK50004004401162431":        ErrInvalidLength,
+		"DK500040044011624X":         ErrInvalidBBAN,
+		"DK5100400440116243":         ErrInvalidChecksum,
+		"DE89370400440532013001":     ErrInvalidChecksum,
+		"GB29NWBK60161331926818":     ErrInvalidChecksum,
+		"NL91ABNA041716430A":         ErrInvalidBBAN,
+		"FR1420041010050500013M0260": ErrInvalidLength,
+	}
+	for s, want := range cases {
+		assert.ErrorIs(t, Validate(s), want, s)
+	}
+}
+
+func TestNew_ComputesCheckDigits(t *testing.T) {
+	iban, err := New("DK", "00400440116243")
+	require.NoError(t, err)
+	assert.Equal(t, "DK5000400440116243", iban)
+
+	iban, err = New("de", "370400440532013000")
+	require.NoError(t, err)
+	assert.Equal(t, "DE89370400440532013000", iban)
+
+	_, err = New("DK", "0040044011624")
+	assert.ErrorIs(t, err, ErrInvalidLength)
+}
+
+func TestFormat(t *testing.T) {
+	assert.Equal(t, "DK50 0040 0440 1162 43", Format("dk5000400440116243"))
+}
@@ -0,0 +1,111 @@
+package idempotency
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// Header carries the key. Requests without it may send the key as
+	// "idempotency_key" in their JSON body instead.
+	Header = "Idempotency-Key"
+	// ReplayedHeader is set on responses replayed from an earlier request.
+	ReplayedHeader = "Idempotent-Replayed"
+)
+
+// Middleware guards POST requests that carry a key. It must run after the
+// authentication middleware, as keys belong to the authenticated user;
+// requests without one are refused.
+//
+// Responses below 500 are kept and replayed, except 202 Accepted: the request
+// is still being worked on, so a retry goes back to the handler to read its
+// current state. Server errors release the key for another attempt.
+func (g *Guard) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := c.GetString("userID")
+		if scope == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "authentication required"})
+			return
+		}
+		c.Request = c.Request.WithContext(WithScope(c.Request.Context(), scope))
+		if c.Request.Method != http.MethodPost {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{"error": "could not read request body"})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key := c.GetHeader(Header)
+		if key == "" {
+			var keyed struct {
+				IdempotencyKey string `json:"idempotency_key"`
+			}
+			_ = json.Unmarshal(body, &keyed)
+			key = keyed.IdempotencyKey
+		}
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		ctx := c.Request.Context()
+		fingerprint := Fingerprint([]byte(c.Request.Method), []byte(c.Request.URL.Path), body)
+		rec, err := g.Begin(ctx, scope, key, fingerprint)
+		switch {
+		case errors.Is(err, ErrKeyReused):
+			c.AbortWithStatusJSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, ErrInFlight):
+			c.AbortWithStatusJSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		case err != nil:
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		case rec != nil:
+			c.Header(ReplayedHeader, "true")
+			c.Data(rec.StatusCode, rec.ContentType, rec.Response)
+			c.Abort()
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusAccepted {
+			err = g.Release(ctx, scope, key)
+		} else {
+			err = g.Complete(ctx, scope, key, status, c.Writer.Header().Get("Content-Type"), recorder.body.Bytes())
+		}
+		if err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the response body for replays.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,74 @@
+package idempotency
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GormStore keeps keys in a table of the service's own schema.
+type GormStore struct {
+	db    *gorm.DB
+	table string
+}
+
+// NewGormStore keeps keys in table, e.g. "transaction.idempotency_keys".
+func NewGormStore(db *gorm.DB, table string) *GormStore {
+	return &GormStore{db: db, table: table}
+}
+
+// Migrate creates or updates the table.
+func (s *GormStore) Migrate() error {
+	return s.db.Table(s.table).AutoMigrate(&Record{})
+}
+
+func (s *GormStore) Claim(ctx context.Context, rec *Record, staleBefore time.Time) (*Record, error) {
+	// Columns of the existing row are qualified by the bare table name
+	name := s.table[strings.LastIndex(s.table, ".")+1:]
+	res := s.db.WithContext(ctx).Table(s.table).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "scope"}, {Name: "key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"fingerprint", "status", "status_code", "content_type", "response", "created_at", "expires_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{gorm.Expr(
+			name+".expires_at < ? OR ("+name+".status = ? AND "+name+".created_at < ?)",
+			rec.CreatedAt, StatusInProgress, staleBefore,
+		)}},
+	}).Create(rec)
+	if res.Error != nil {
+		return nil, res.Error
+	}
+	if res.RowsAffected == 1 {
+		return nil, nil
+	}
+
+	var existing Record
+	if err := s.db.WithContext(ctx).Table(s.table).
+		First(&existing, "scope = ? AND key = ?", rec.Scope, rec.Key).Error; err != nil {
+		return nil, err
+	}
+	return &existing, nil
+}
+
+func (s *GormStore) Complete(ctx context.Context, rec *Record) error {
+	return s.db.WithContext(ctx).Table(s.table).
+		Where("scope = ? AND key = ?", rec.Scope, rec.Key).
+		Updates(map[string]any{
+			"status":       rec.Status,
+			"status_code":  rec.StatusCode,
+			"content_type": rec.ContentType,
+			"response":     rec.Response,
+		}).Error
+}
+
+func (s *GormStore) Release(ctx context.Context, scope, key string) error {
+	return s.db.WithContext(ctx).Table(s.table).
+		Where("scope = ? AND key = ? AND status = ?", scope, key, StatusInProgress).
+		Delete(&Record{}).Error
+}
+
+func (s *GormStore) Purge(ctx context.Context, now time.Time) (int64, error) {
+	res := s.db.WithContext(ctx).Table(s.table).Where("expires_at < ?", now).Delete(&Record{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,72 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+
+	sharedauth "nordic-bank/internal/shared/auth"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/anypb"
+)
+
+// UnaryServerInterceptor guards calls whose request has a non-empty
+// idempotency_key field. It must run after the authentication interceptor,
+// as keys belong to the authenticated caller. Only successful responses are
+// kept; a failed call releases its key for another attempt.
+func (g *Guard) UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		claims, ok := sharedauth.ClaimsFrom(ctx)
+		if !ok || claims.UserID == "" {
+			return nil, status.Error(codes.Unauthenticated, "idempotency keys need an authenticated caller")
+		}
+		scope := claims.UserID
+		ctx = WithScope(ctx, scope)
+
+		keyed, ok := req.(interface{ GetIdempotencyKey() string })
+		msg, isProto := req.(proto.Message)
+		if !ok || !isProto || keyed.GetIdempotencyKey() == "" {
+			return handler(ctx, req)
+		}
+		key := keyed.GetIdempotencyKey()
+
+		body, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		rec, err := g.Begin(ctx, scope, key, Fingerprint([]byte(info.FullMethod), body))
+		switch {
+		case errors.Is(err, ErrKeyReused):
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		case errors.Is(err, ErrInFlight):
+			return nil, status.Error(codes.Aborted, err.Error())
+		case err != nil:
+			return nil, status.Error(codes.Internal, err.Error())
+		case rec != nil:
+			var stored anypb.Any
+			if err := proto.Unmarshal(rec.Response, &stored); err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			return stored.UnmarshalNew()
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			_ = g.Release(ctx, scope, key)
+			return resp, err
+		}
+		if out, ok := resp.(proto.Message); ok {
+			if stored, merr := anypb.New(out); merr == nil {
+				if data, merr := proto.Marshal(stored); merr == nil {
+					_ = g.Complete(ctx, scope, key, int(codes.OK), "application/grpc+proto", data)
+					return resp, nil
+				}
+			}
+		}
+		_ = g.Release(ctx, scope, key)
+		return resp, nil
+	}
+}
@@ -0,0 +1,184 @@
+// Package idempotency lets a caller retry a POST safely. The first request
+// under a key claims it and its response is kept; retries with the same body
+// get that response back, while a different body under the same key, or a
+// retry racing the first request, is refused. Keys are scoped to the caller,
+// so two callers never see each other's responses.
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"time"
+)
+
+var (
+	// ErrKeyReused is returned when a key comes back with a different request.
+	ErrKeyReused = errors.New("idempotency key was already used for a different request")
+	// ErrInFlight is returned while the first request under a key is running.
+	ErrInFlight = errors.New("a request with this idempotency key is still in progress")
+)
+
+const (
+	DefaultRetention   = 24 * time.Hour
+	DefaultLockTimeout = time.Minute
+)
+
+type Status string
+
+const (
+	StatusInProgress Status = "in_progress"
+	StatusCompleted  Status = "completed"
+)
+
+// Record is a key claimed by a caller and, once the request finished, the
+// response to replay.
+type Record struct {
+	Scope       string `gorm:"primaryKey;size:100"`
+	Key         string `gorm:"primaryKey;size:255"`
+	Fingerprint string `gorm:"size:64;not null"` // SHA-256 of the request
+	Status      Status `gorm:"size:20;not null"`
+	StatusCode  int
+	ContentType string `gorm:"size:100"`
+	Response    []byte
+
+	CreatedAt time.Time `gorm:"not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+}
+
+// Store keeps claimed keys.
+type Store interface {
+	// Claim inserts rec unless a live record holds its key, in which case
+	// that record is returned. A record lives until it expires; one still in
+	// progress is abandoned once it was claimed before staleBefore.
+	Claim(ctx context.Context, rec *Record, staleBefore time.Time) (*Record, error)
+	// Complete stores the response of a claimed key.
+	Complete(ctx context.Context, rec *Record) error
+	// Release gives up a key still in progress, so the request can be retried.
+	Release(ctx context.Context, scope, key string) error
+	// Purge deletes the records expired at now.
+	Purge(ctx context.Context, now time.Time) (int64, error)
+}
+
+type Options struct {
+	// Retention is how long a key and its response are remembered.
+	Retention time.Duration
+	// LockTimeout is how long a request may hold its key before a retry is
+	// allowed to take it over, in case the first one died.
+	LockTimeout time.Duration
+}
+
+// Guard checks requests against the keys in a store. Its HTTP middleware and
+// gRPC interceptor share the same rules.
+type Guard struct {
+	store Store
+	opts  Options
+	now   func() time.Time
+}
+
+// New returns a guard over store. Zero options take their defaults.
+func New(store Store, opts Options) *Guard {
+	if opts.Retention <= 0 {
+		opts.Retention = DefaultRetention
+	}
+	if opts.LockTimeout <= 0 {
+		opts.LockTimeout = DefaultLockTimeout
+	}
+	return &Guard{store: store, opts: opts, now: time.Now}
+}
+
+// Retention is how long keys are remembered.
+func (g *Guard) Retention() time.Duration {
+	return g.opts.Retention
+}
+
+// Begin claims the caller's key for a request with the given fingerprint. It
+// returns nil when the request should go ahead, and the completed record when
+// its response should be replayed instead.
+func (g *Guard) Begin(ctx context.Context, scope, key, fingerprint string) (*Record, error) {
+	now := g.now()
+	rec := &Record{
+		Scope:       scope,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      StatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(g.opts.Retention),
+	}
+	existing, err := g.store.Claim(ctx, rec, now.Add(-g.opts.LockTimeout))
+	if err != nil || existing == nil {
+		return nil, err
+	}
+	switch {
+	case existing.Fingerprint != fingerprint:
+		return nil, ErrKeyReused
+	case existing.Status != StatusCompleted:
+		return nil, ErrInFlight
+	}
+	return existing, nil
+}
+
+// Complete keeps the response for replays.
+func (g *Guard) Complete(ctx context.Context, scope, key string, statusCode int, contentType string, response []byte) error {
+	return g.store.Complete(ctx, &Record{
+		Scope:       scope,
+		Key:         key,
+		Status:      StatusCompleted,
+		StatusCode:  statusCode,
+		ContentType: contentType,
+		Response:    response,
+	})
+}
+
+// Release gives the key up, for requests whose outcome should not be replayed.
+func (g *Guard) Release(ctx context.Context, scope, key string) error {
+	return g.store.Release(ctx, scope, key)
+}
+
+// RunPurger deletes expired keys on every tick until ctx is cancelled.
+func (g *Guard) RunPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			n, err := g.store.Purge(ctx, now)
+			if err != nil {
+				log.Printf("idempotency purger: %v", err)
+			}
+			if n > 0 {
+				log.Printf("idempotency purger: purged %d keys", n)
+			}
+		}
+	}
+}
+
+// Fingerprint hashes what identifies a request: where it was sent and its
+// body.
+func Fingerprint(parts ...[]byte) string {
+	h := sha256.New()
+	for _, part := range parts {
+		h.Write(part)
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+type scopeKey struct{}
+
+// WithScope records the caller that idempotency keys belong to.
+func WithScope(ctx context.Context, scope string) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFrom returns the caller recorded by WithScope, or "" for internal
+// calls that were never scoped.
+func ScopeFrom(ctx context.Context) string {
+	scope, _ := ctx.Value(scopeKey{}).(string)
+	return scope
+}
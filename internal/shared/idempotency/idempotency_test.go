@@ -0,0 +1,215 @@
+package idempotency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	sharedauth "nordic-bank/internal/shared/auth"
+	commonpb "nordic-bank/pkg/pb/common/v1"
+	pb "nordic-bank/pkg/pb/transaction/v1"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+type memoryStore struct {
+	records map[[2]string]*Record
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{records: map[[2]string]*Record{}}
+}
+
+func (s *memoryStore) Claim(_ context.Context, rec *Record, staleBefore time.Time) (*Record, error) {
+	id := [2]string{rec.Scope, rec.Key}
+	if existing, ok := s.records[id]; ok {
+		abandoned := existing.Status == StatusInProgress && existing.CreatedAt.Before(staleBefore)
+		if !existing.ExpiresAt.Before(rec.CreatedAt) && !abandoned {
+			copied := *existing
+			return &copied, nil
+		}
+	}
+	copied := *rec
+	s.records[id] = &copied
+	return nil, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, rec *Record) error {
+	existing := s.records[[2]string{rec.Scope, rec.Key}]
+	existing.Status = rec.Status
+	existing.StatusCode = rec.StatusCode
+	existing.ContentType = rec.ContentType
+	existing.Response = rec.Response
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, scope, key string) error {
+	id := [2]string{scope, key}
+	if existing, ok := s.records[id]; ok && existing.Status == StatusInProgress {
+		delete(s.records, id)
+	}
+	return nil
+}
+
+func (s *memoryStore) Purge(_ context.Context, now time.Time) (int64, error) {
+	var n int64
+	for id, rec := range s.records {
+		if rec.ExpiresAt.Before(now) {
+			delete(s.records, id)
+			n++
+		}
+	}
+	return n, nil
+}
+
+// newTestRouter serves POST /transfers, answering with the number of times
+// the handler ran. The caller is taken from the X-User header.
+func newTestRouter(guard *Guard, status *int) (*gin.Engine, *int) {
+	gin.SetMode(gin.TestMode)
+	calls := 0
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("userID", c.GetHeader("X-User"))
+	}, guard.Middleware())
+	router.POST("/transfers", func(c *gin.Context) {
+		calls++
+		c.JSON(*status, gin.H{"call": calls})
+	})
+	return router, &calls
+}
+
+func post(router *gin.Engine, user, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/transfers", strings.NewReader(body))
+	req.Header.Set("X-User", user)
+	if key != "" {
+		req.Header.Set(Header, key)
+	}
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestMiddleware_ReplaysAndRejects(t *testing.T) {
+	guard := New(newMemoryStore(), Options{})
+	status := http.StatusCreated
+	router, calls := newTestRouter(guard, &status)
+
+	first := post(router, "alice", "k1", `{"amount":100}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	replay := post(router, "alice", "k1", `{"amount":100}`)
+	assert.Equal(t, http.StatusCreated, replay.Code)
+	assert.Equal(t, first.Body.String(), replay.Body.String())
+	assert.Equal(t, "true", replay.Header().Get(ReplayedHeader))
+	assert.Equal(t, 1, *calls)
+
+	// A different request under the same key
+	assert.Equal(t, http.StatusUnprocessableEntity, post(router, "alice", "k1", `{"amount":999}`).Code)
+
+	// Another caller's key is their own
+	other := post(router, "bob", "k1", `{"amount":100}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Equal(t, 2, *calls)
+
+	// The key can also come in the body
+	assert.Equal(t, http.StatusCreated, post(router, "alice", "", `{"idempotency_key":"k2"}`).Code)
+	assert.Equal(t, "true", post(router, "alice", "", `{"idempotency_key":"k2"}`).Header().Get(ReplayedHeader))
+
+	// Requests without a key are not guarded
+	post(router, "alice", "", `{}`)
+	post(router, "alice", "", `{}`)
+	assert.Equal(t, 5, *calls)
+}
+
+func TestMiddleware_InFlightAndExpiry(t *testing.T) {
+	store := newMemoryStore()
+	guard := New(store, Options{Retention: time.Hour, LockTimeout: time.Minute})
+	now := time.Now()
+	guard.now = func() time.Time { return now }
+	status := http.StatusCreated
+	router, calls := newTestRouter(guard, &status)
+
+	// The first request is still running
+	_, err := guard.Begin(context.Background(), "alice", "k1",
+		Fingerprint([]byte(http.MethodPost), []byte("/transfers"), []byte(`{}`)))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, post(router, "alice", "k1", `{}`).Code)
+
+	// ... until it is presumed dead
+	now = now.Add(2 * time.Minute)
+	assert.Equal(t, http.StatusCreated, post(router, "alice", "k1", `{}`).Code)
+	assert.Equal(t, 1, *calls)
+
+	// Keys are forgotten after the retention window
+	now = now.Add(2 * time.Hour)
+	assert.Equal(t, http.StatusCreated, post(router, "alice", "k1", `{"amount":5}`).Code)
+	assert.Equal(t, 2, *calls)
+
+	n, err := store.Purge(context.Background(), now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+}
+
+func TestMiddleware_ServerErrorsAndAcceptedAreNotKept(t *testing.T) {
+	guard := New(newMemoryStore(), Options{})
+	status := http.StatusInternalServerError
+	router, calls := newTestRouter(guard, &status)
+
+	post(router, "alice", "k1", `{}`)
+	status = http.StatusAccepted
+	post(router, "alice", "k1", `{}`)
+	status = http.StatusCreated
+	post(router, "alice", "k1", `{}`)
+	assert.Equal(t, 3, *calls)
+}
+
+func TestMiddleware_RefusesAnonymousCallers(t *testing.T) {
+	guard := New(newMemoryStore(), Options{})
+	status := http.StatusCreated
+	router, calls := newTestRouter(guard, &status)
+
+	w := post(router, "", "k1", `{}`)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Zero(t, *calls)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	guard := New(newMemoryStore(), Options{})
+	interceptor := guard.UnaryServerInterceptor()
+	info := &grpc.UnaryServerInfo{FullMethod: "/transaction.v1.TransactionService/CreateTransfer"}
+	calls := 0
+	handler := func(ctx context.Context, req any) (any, error) {
+		calls++
+		assert.Equal(t, "account-service", ScopeFrom(ctx))
+		return &pb.CreateTransferResponse{Transaction: &pb.Transaction{Id: "tx1"}}, nil
+	}
+	ctx := sharedauth.WithClaims(context.Background(), &sharedauth.CustomClaims{UserID: "account-service", Role: sharedauth.ServiceRole})
+	req := &pb.CreateTransferRequest{IdempotencyKey: "k1", Amount: &commonpb.Money{Amount: 100, Currency: "DKK"}}
+
+	// Metadata naming a caller is not an identity
+	anonymous := metadata.NewIncomingContext(context.Background(), metadata.Pairs("x-caller-id", "account-service"))
+	_, err := interceptor(anonymous, req, info, handler)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Zero(t, calls)
+
+	first, err := interceptor(ctx, req, info, handler)
+	require.NoError(t, err)
+	replay, err := interceptor(ctx, req, info, handler)
+	require.NoError(t, err)
+	assert.True(t, proto.Equal(first.(proto.Message), replay.(proto.Message)))
+	assert.Equal(t, 1, calls)
+
+	changed := &pb.CreateTransferRequest{IdempotencyKey: "k1", Amount: &commonpb.Money{Amount: 999, Currency: "DKK"}}
+	_, err = interceptor(ctx, changed, info, handler)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
@@ -0,0 +1,35 @@
+// Package notify delivers messages to customers, e.g. advance notice before
+// an account is marked dormant.
+package notify
+
+import (
+	"context"
+	"log"
+
+	"github.com/google/uuid"
+)
+
+// Message is a notification to a customer about one of their accounts or
+// other entities.
+type Message struct {
+	CustomerID    uuid.UUID
+	Subject       string
+	Body          string
+	ReferenceType string // account, card, transaction, ...
+	ReferenceID   uuid.UUID
+}
+
+// Notifier hands messages over for delivery. A nil error means the message
+// was accepted, not that the customer has read it.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// LogNotifier writes messages to the service log. It stands in for a real
+// delivery channel in development.
+type LogNotifier struct{}
+
+func (LogNotifier) Notify(_ context.Context, msg Message) error {
+	log.Printf("notify customer %s about %s %s: %s", msg.CustomerID, msg.ReferenceType, msg.ReferenceID, msg.Subject)
+	return nil
+}
@@ -0,0 +1,76 @@
+// Package pan generates and checks payment card numbers (Primary Account
+// Numbers) as defined by ISO/IEC 7812.
+package pan
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// New returns a random card number of the given length that starts with
+// prefix (the issuer's BIN) and ends with a Luhn check digit.
+func New(prefix string, length int) (string, error) {
+	if length <= len(prefix) || !digitsOnly(prefix) {
+		return "", fmt.Errorf("pan: cannot build a %d digit number on prefix %q", length, prefix)
+	}
+
+	var b strings.Builder
+	b.WriteString(prefix)
+	for b.Len() < length-1 {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		b.WriteByte(byte('0' + n.Int64()))
+	}
+
+	body := b.String()
+	return body + string(rune('0'+checkDigit(body))), nil
+}
+
+// Valid reports whether s is a 12 to 19 digit number with a correct Luhn
+// check digit.
+func Valid(s string) bool {
+	if len(s) < 12 || len(s) > 19 || !digitsOnly(s) {
+		return false
+	}
+	return checkDigit(s[:len(s)-1]) == int(s[len(s)-1]-'0')
+}
+
+// LastFour returns the last four digits, the part of a card number that may
+// be shown to the customer.
+func LastFour(s string) string {
+	if len(s) < 4 {
+		return s
+	}
+	return s[len(s)-4:]
+}
+
+// checkDigit computes the Luhn digit to append to body.
+func checkDigit(body string) int {
+	sum := 0
+	double := true
+	for i := len(body) - 1; i >= 0; i-- {
+		d := int(body[i] - '0')
+		if double {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		double = !double
+	}
+	return (10 - sum%10) % 10
+}
+
+func digitsOnly(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,31 @@
+package pan
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValid(t *testing.T) {
+	assert.True(t, Valid("4111111111111111"))
+	assert.True(t, Valid("5555555555554444"))
+	assert.True(t, Valid("4571000000000001"))
+	assert.False(t, Valid("4111111111111112"))
+	assert.False(t, Valid("4111 1111 1111 1111"))
+	assert.False(t, Valid("41111111111"))
+}
+
+func TestNew(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		n, err := New("457199", 16)
+		require.NoError(t, err)
+		assert.Len(t, n, 16)
+		assert.True(t, strings.HasPrefix(n, "457199"))
+		assert.True(t, Valid(n), n)
+	}
+
+	_, err := New("45x", 16)
+	assert.Error(t, err)
+}
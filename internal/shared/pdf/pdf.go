@@ -0,0 +1,216 @@
+// Package pdf writes simple single-column PDF documents: text in the standard
+// Helvetica faces, filled rectangles and lines. It exists so statements can
+// be rendered without pulling in a layout engine.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+type Font int
+
+const (
+	Helvetica Font = iota
+	HelveticaBold
+)
+
+func (f Font) resource() string {
+	if f == HelveticaBold {
+		return "F2"
+	}
+	return "F1"
+}
+
+// Color is an RGB colour with components between 0 and 1.
+type Color struct{ R, G, B float64 }
+
+func RGB(r, g, b uint8) Color {
+	return Color{float64(r) / 255, float64(g) / 255, float64(b) / 255}
+}
+
+var Black = Color{}
+
+// Style describes how a piece of text is drawn.
+type Style struct {
+	Font  Font
+	Size  float64
+	Color Color
+}
+
+// Document is a PDF under construction. Coordinates have their origin in the
+// bottom left corner of the page, as in PDF itself.
+type Document struct {
+	title string
+	pages []*Page
+}
+
+func New(title string) *Document {
+	return &Document{title: title}
+}
+
+// AddPage appends an empty A4 page and returns it.
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// Pages returns the pages added so far, in order. It is useful for drawing
+// page footers once the page count is known.
+func (d *Document) Pages() []*Page {
+	return d.pages
+}
+
+type Page struct {
+	content bytes.Buffer
+}
+
+// Text draws s with its baseline starting at (x, y).
+func (p *Page) Text(x, y float64, style Style, s string) {
+	p.setFill(style.Color)
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s Td (%s) Tj ET\n",
+		style.Font.resource(), num(style.Size), num(x), num(y), escape(s))
+}
+
+// TextRight draws s so that it ends at x.
+func (p *Page) TextRight(x, y float64, style Style, s string) {
+	p.Text(x-TextWidth(s, style.Size), y, style, s)
+}
+
+// Rect fills a rectangle whose bottom left corner is at (x, y).
+func (p *Page) Rect(x, y, w, h float64, c Color) {
+	p.setFill(c)
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n", num(x), num(y), num(w), num(h))
+}
+
+// Line strokes a straight line.
+func (p *Page) Line(x1, y1, x2, y2, width float64, c Color) {
+	fmt.Fprintf(&p.content, "%s %s %s RG %s w %s %s m %s %s l S\n",
+		num(c.R), num(c.G), num(c.B), num(width), num(x1), num(y1), num(x2), num(y2))
+}
+
+func (p *Page) setFill(c Color) {
+	fmt.Fprintf(&p.content, "%s %s %s rg\n", num(c.R), num(c.G), num(c.B))
+}
+
+// WriteTo serialises the document.
+func (d *Document) WriteTo(w io.Writer) (int64, error) {
+	var (
+		buf     bytes.Buffer
+		offsets []int
+	)
+	obj := func(body string) {
+		offsets = append(offsets, buf.Len())
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", len(offsets), body)
+	}
+
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Objects 1-5 are fixed; each page then takes two: the page and its
+	// content stream.
+	const firstPage = 6
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPage+2*i)
+	}
+
+	buf.WriteString("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	obj("<< /Type /Catalog /Pages 2 0 R >>")
+	obj(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)))
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	obj("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	obj(fmt.Sprintf("<< /Title (%s) /Producer (Nordic Bank) >>", escape(d.title)))
+	for i, p := range pages {
+		obj(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			num(PageWidth), num(PageHeight), firstPage+2*i+1))
+		obj(fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()))
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R /Info 5 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref)
+
+	return buf.WriteTo(w)
+}
+
+// TextWidth returns the width of s in points when set in Helvetica at size.
+// Bold text is measured with the regular metrics, which is close enough for
+// the digits and punctuation it is used for.
+func TextWidth(s string, size float64) float64 {
+	var units int
+	for _, r := range s {
+		if r >= 32 && r <= 126 {
+			units += helveticaWidths[r-32]
+		} else {
+			units += 556
+		}
+	}
+	return float64(units) * size / 1000
+}
+
+// escape converts s to WinAnsi (Windows-1252) and escapes it for use in a PDF
+// string literal. Characters outside the encoding become '?'.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		var c byte
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			c = byte(r)
+		case r < 0x80 || (r >= 0xa0 && r <= 0xff):
+			c = byte(r)
+		case r == '€':
+			c = 0x80
+		case r == '–':
+			c = 0x96
+		case r == '—':
+			c = 0x97
+		default:
+			c = '?'
+		}
+		if c < 0x20 || c >= 0x7f {
+			fmt.Fprintf(&b, "\\%03o", c)
+			continue
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
+
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "" || s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// Advance widths of the printable ASCII characters in Helvetica, in 1/1000 em.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278, // space - /
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, // 0 - 9
+	278, 278, 584, 584, 584, 556, 1015, // : - @
+	667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, // A - M
+	722, 778, 667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, // N - Z
+	278, 278, 278, 469, 556, 333, // [ - `
+	556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, // a - m
+	556, 556, 556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, // n - z
+	334, 260, 334, 584, // { - ~
+}
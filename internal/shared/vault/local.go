@@ -0,0 +1,111 @@
+package vault
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"nordic-bank/internal/shared/blob"
+)
+
+const tokenPrefix = "tok_"
+
+// LocalVault keeps card numbers encrypted with AES-256-GCM in a blob store.
+// Tokens are a keyed hash of the number, so they reveal nothing about it
+// without the vault secret.
+type LocalVault struct {
+	store  blob.Store
+	aead   cipher.AEAD
+	macKey []byte
+}
+
+// NewLocalVault returns a vault over store. The encryption and token keys are
+// both derived from secret, which must be kept out of the database.
+func NewLocalVault(store blob.Store, secret string) (*LocalVault, error) {
+	if secret == "" {
+		return nil, errors.New("vault: secret is required")
+	}
+
+	block, err := aes.NewCipher(deriveKey(secret, "encryption"))
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return &LocalVault{store: store, aead: aead, macKey: deriveKey(secret, "token")}, nil
+}
+
+func (v *LocalVault) Tokenize(ctx context.Context, pan string) (string, error) {
+	token := v.token(pan)
+
+	nonce := make([]byte, v.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	// The token is the additional data, so a ciphertext cannot be moved to
+	// another token.
+	sealed := v.aead.Seal(nonce, nonce, []byte(pan), []byte(token))
+
+	if _, err := v.store.Put(ctx, objectKey(token), "application/octet-stream", bytes.NewReader(sealed)); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+func (v *LocalVault) Detokenize(ctx context.Context, token string) (string, error) {
+	if !strings.HasPrefix(token, tokenPrefix) {
+		return "", ErrTokenNotFound
+	}
+
+	r, err := v.store.Open(ctx, objectKey(token))
+	if errors.Is(err, blob.ErrNotFound) {
+		return "", ErrTokenNotFound
+	}
+	if err != nil {
+		return "", err
+	}
+	defer r.Close()
+
+	sealed, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+	if len(sealed) < v.aead.NonceSize() {
+		return "", fmt.Errorf("vault: entry for %s is corrupt", token)
+	}
+
+	nonce, ciphertext := sealed[:v.aead.NonceSize()], sealed[v.aead.NonceSize():]
+	pan, err := v.aead.Open(nil, nonce, ciphertext, []byte(token))
+	if err != nil {
+		return "", fmt.Errorf("vault: cannot decrypt %s: %w", token, err)
+	}
+	return string(pan), nil
+}
+
+func (v *LocalVault) token(pan string) string {
+	mac := hmac.New(sha256.New, v.macKey)
+	mac.Write([]byte(pan))
+	return tokenPrefix + hex.EncodeToString(mac.Sum(nil))[:32]
+}
+
+func objectKey(token string) string {
+	return "pans/" + token
+}
+
+func deriveKey(secret, purpose string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("nordic-bank vault " + purpose))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,68 @@
+package vault
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"nordic-bank/internal/shared/blob"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestVault(t *testing.T, dir string) *LocalVault {
+	t.Helper()
+	store, err := blob.NewLocalStore(dir)
+	require.NoError(t, err)
+	v, err := NewLocalVault(store, "test-secret")
+	require.NoError(t, err)
+	return v
+}
+
+func TestLocalVault_RoundTrip(t *testing.T) {
+	dir := t.TempDir()
+	v := newTestVault(t, dir)
+	ctx := context.Background()
+
+	token, err := v.Tokenize(ctx, "4571991234567890")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(token, "tok_"))
+	assert.NotContains(t, token, "4571991234567890")
+
+	again, err := v.Tokenize(ctx, "4571991234567890")
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+
+	pan, err := v.Detokenize(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, "4571991234567890", pan)
+
+	// Nothing on disk contains the card number in the clear
+	raw, err := os.ReadFile(filepath.Join(dir, "pans", token))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "4571991234567890")
+}
+
+func TestLocalVault_UnknownAndForeignTokens(t *testing.T) {
+	dir := t.TempDir()
+	v := newTestVault(t, dir)
+	ctx := context.Background()
+
+	_, err := v.Detokenize(ctx, "tok_00000000000000000000000000000000")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+	_, err = v.Detokenize(ctx, "../../etc/passwd")
+	assert.ErrorIs(t, err, ErrTokenNotFound)
+
+	token, err := v.Tokenize(ctx, "4571991234567890")
+	require.NoError(t, err)
+
+	store, err := blob.NewLocalStore(dir)
+	require.NoError(t, err)
+	other, err := NewLocalVault(store, "another-secret")
+	require.NoError(t, err)
+	_, err = other.Detokenize(ctx, token)
+	assert.Error(t, err)
+}
@@ -0,0 +1,19 @@
+// Package vault tokenises card numbers so that services can refer to a card
+// without ever storing its number.
+package vault
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrTokenNotFound is returned by Detokenize for tokens the vault never issued.
+var ErrTokenNotFound = errors.New("token not found in vault")
+
+// Vault swaps card numbers for opaque tokens and back. Tokenising the same
+// number twice yields the same token, so a number presented at a terminal can
+// be matched to the card it belongs to.
+type Vault interface {
+	Tokenize(ctx context.Context, pan string) (string, error)
+	Detokenize(ctx context.Context, token string) (string, error)
+}
@@ -2,11 +2,15 @@ package adapter
 
 import (
 	"context"
+	"errors"
+	"strings"
+	"time"
 
 	"nordic-bank/internal/transaction/domain"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type PostgresTransactionRepository struct {
@@ -17,6 +21,12 @@ func NewPostgresTransactionRepository(db *gorm.DB) *PostgresTransactionRepositor
 	return &PostgresTransactionRepository{db: db}
 }
 
+func (r *PostgresTransactionRepository) WithTx(ctx context.Context, fn func(repo domain.TransactionRepository) error) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(&PostgresTransactionRepository{db: tx})
+	})
+}
+
 func (r *PostgresTransactionRepository) Create(ctx context.Context, tx *domain.Transaction) error {
 	return r.db.WithContext(ctx).Create(tx).Error
 }
@@ -29,27 +39,295 @@ func (r *PostgresTransactionRepository) GetByID(ctx context.Context, id uuid.UUI
 	return &tx, nil
 }
 
-func (r *PostgresTransactionRepository) GetByIdempotencyKey(ctx context.Context, key string) (*domain.Transaction, error) {
+func (r *PostgresTransactionRepository) GetByIdempotencyKey(ctx context.Context, scope, key string, since time.Time) (*domain.Transaction, error) {
 	var tx domain.Transaction
-	if err := r.db.WithContext(ctx).First(&tx, "idempotency_key = ?", key).Error; err != nil {
+	err := r.db.WithContext(ctx).
+		Where("idempotency_scope = ? AND idempotency_key = ? AND created_at >= ?", scope, key, since).
+		Order("created_at DESC").
+		First(&tx).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTransactionNotFound
+	}
+	if err != nil {
 		return nil, err
 	}
 	return &tx, nil
 }
 
-func (r *PostgresTransactionRepository) ListByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*domain.Transaction, int64, error) {
-	var txs []*domain.Transaction
-	var total int64
-
-	query := r.db.WithContext(ctx).Model(&domain.Transaction{}).
-		Where("source_account_id = ? OR destination_account_id = ?", accountID, accountID)
+func (r *PostgresTransactionRepository) Search(ctx context.Context, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	query := r.db.WithContext(ctx).Model(&domain.Transaction{})
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+	if filter.MinAmount != nil {
+		query = query.Where("amount >= ?", *filter.MinAmount)
+	}
+	if filter.MaxAmount != nil {
+		query = query.Where("amount <= ?", *filter.MaxAmount)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	switch {
+	case filter.AccountID != nil && filter.Counterparty != nil:
+		query = query.Where("(source_account_id = ? AND destination_account_id = ?) OR (source_account_id = ? AND destination_account_id = ?)",
+			*filter.AccountID, *filter.Counterparty, *filter.Counterparty, *filter.AccountID)
+	case filter.AccountID != nil:
+		query = query.Where("source_account_id = ? OR destination_account_id = ?", *filter.AccountID, *filter.AccountID)
+	case filter.Counterparty != nil:
+		query = query.Where("source_account_id = ? OR destination_account_id = ?", *filter.Counterparty, *filter.Counterparty)
+	}
+	if filter.Category != "" {
+		query = query.Where("category = ?", filter.Category)
+	}
+	if filter.Reference != "" {
+		query = query.Where("reference ILIKE ?", likePrefix(filter.Reference))
+	}
+	if filter.Text != "" {
+		query = query.Where("description_tsv @@ websearch_to_tsquery('simple', ?)", filter.Text)
+	}
+	if filter.Before != nil {
+		query = query.Where("(created_at, id) < (?, ?)", filter.Before.At, filter.Before.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
 
-	query.Count(&total)
-	err := query.Order("created_at DESC").Limit(limit).Offset(offset).Find(&txs).Error
+	var txs []*domain.Transaction
+	err := query.Order("created_at DESC, id DESC").Find(&txs).Error
+	return txs, err
+}
 
-	return txs, total, err
+// likePrefix escapes s for use as the prefix of a LIKE pattern.
+func likePrefix(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
 }
 
 func (r *PostgresTransactionRepository) UpdateStatus(ctx context.Context, id uuid.UUID, status domain.TransactionStatus) error {
 	return r.db.WithContext(ctx).Model(&domain.Transaction{}).Where("id = ?", id).Update("status", status).Error
 }
+
+func (r *PostgresTransactionRepository) Update(ctx context.Context, tx *domain.Transaction) error {
+	return r.db.WithContext(ctx).Save(tx).Error
+}
+
+func (r *PostgresTransactionRepository) CreateCardAuthorization(ctx context.Context, auth *domain.CardAuthorization) error {
+	return r.db.WithContext(ctx).Create(auth).Error
+}
+
+func (r *PostgresTransactionRepository) GetCardAuthorizationByRRN(ctx context.Context, rrn string) (*domain.CardAuthorization, error) {
+	var auth domain.CardAuthorization
+	err := r.db.WithContext(ctx).First(&auth, "rrn = ?", rrn).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAuthorizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+func (r *PostgresTransactionRepository) GetCardAuthorizationForUpdate(ctx context.Context, id uuid.UUID) (*domain.CardAuthorization, error) {
+	var auth domain.CardAuthorization
+	err := r.db.WithContext(ctx).Clauses(clause.Locking{Strength: "UPDATE"}).First(&auth, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrAuthorizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &auth, nil
+}
+
+func (r *PostgresTransactionRepository) UpdateCardAuthorization(ctx context.Context, auth *domain.CardAuthorization) error {
+	return r.db.WithContext(ctx).Save(auth).Error
+}
+
+func (r *PostgresTransactionRepository) CreateCardHold(ctx context.Context, hold *domain.CardHold) error {
+	return r.db.WithContext(ctx).Create(hold).Error
+}
+
+func (r *PostgresTransactionRepository) ListCardHolds(ctx context.Context, authorizationID uuid.UUID) ([]*domain.CardHold, error) {
+	var holds []*domain.CardHold
+	err := r.db.WithContext(ctx).Where("authorization_id = ?", authorizationID).Order("created_at").Find(&holds).Error
+	return holds, err
+}
+
+func (r *PostgresTransactionRepository) SumCardSpend(ctx context.Context, cardID uuid.UUID, since time.Time) (int64, int64, error) {
+	var sums struct {
+		Total int64
+		ATM   int64
+	}
+	err := r.db.WithContext(ctx).Model(&domain.CardAuthorization{}).
+		Select(`COALESCE(SUM(CASE WHEN status = ? THEN cleared_amount ELSE authorized_amount END), 0) AS total,
+			COALESCE(SUM(CASE WHEN status = ? THEN cleared_amount ELSE authorized_amount END) FILTER (WHERE channel = ?), 0) AS atm`,
+			domain.AuthorizationCleared, domain.AuthorizationCleared, domain.CardChannelATM).
+		Where("card_id = ? AND status <> ? AND created_at >= ?", cardID, domain.AuthorizationReversed, since).
+		Scan(&sums).Error
+	return sums.Total, sums.ATM, err
+}
+
+func (r *PostgresTransactionRepository) GetCardMessage(ctx context.Context, rrn, stan, mti string) (*domain.CardMessage, error) {
+	var msg domain.CardMessage
+	err := r.db.WithContext(ctx).First(&msg, "rrn = ? AND stan = ? AND mti = ?", rrn, stan, mti).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrCardMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &msg, nil
+}
+
+func (r *PostgresTransactionRepository) CreateCardMessage(ctx context.Context, msg *domain.CardMessage) error {
+	return r.db.WithContext(ctx).Create(msg).Error
+}
+
+func (r *PostgresTransactionRepository) ListDailyFlows(ctx context.Context, accountID uuid.UUID, currency string, from, to time.Time) ([]domain.Flow, error) {
+	var flows []domain.Flow
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT date_trunc('day', created_at AT TIME ZONE 'UTC') AS start,
+			COALESCE(SUM(CASE WHEN destination_account_id = @account THEN amount ELSE 0 END), 0) AS inflow,
+			COALESCE(SUM(CASE WHEN source_account_id = @account THEN amount ELSE 0 END), 0) AS outflow,
+			COUNT(*) AS count
+		FROM transaction.transactions
+		WHERE (source_account_id = @account OR destination_account_id = @account)
+			AND status = @status AND currency = @currency
+			AND created_at >= @from AND created_at < @to
+		GROUP BY 1
+		ORDER BY 1`,
+		map[string]any{"account": accountID, "status": domain.StatusCompleted, "currency": currency, "from": from, "to": to},
+	).Scan(&flows).Error
+	for i := range flows {
+		// date_trunc drops the zone; the day is a UTC one
+		flows[i].Start = time.Date(flows[i].Start.Year(), flows[i].Start.Month(), flows[i].Start.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	return flows, err
+}
+
+func (r *PostgresTransactionRepository) TopCounterparties(ctx context.Context, accountID uuid.UUID, currency string, from, to time.Time, limit int) ([]domain.Counterparty, error) {
+	var parties []domain.Counterparty
+	err := r.db.WithContext(ctx).Raw(`
+		SELECT kind, account_id, name,
+			SUM(inflow) AS inflow, SUM(outflow) AS outflow, COUNT(*) AS count
+		FROM (
+			SELECT
+				CASE
+					WHEN t.source_account_id = @account AND t.destination_account_id IS NOT NULL THEN 'account'
+					WHEN t.destination_account_id = @account AND t.source_account_id IS NOT NULL THEN 'account'
+					WHEN ca.id IS NOT NULL THEN 'merchant'
+					ELSE 'cash'
+				END AS kind,
+				CASE WHEN t.source_account_id = @account THEN t.destination_account_id ELSE t.source_account_id END AS account_id,
+				COALESCE(ca.merchant_name, '') AS name,
+				CASE WHEN t.destination_account_id = @account THEN t.amount ELSE 0 END AS inflow,
+				CASE WHEN t.source_account_id = @account THEN t.amount ELSE 0 END AS outflow
+			FROM transaction.transactions t
+			LEFT JOIN transaction.card_authorizations ca ON ca.transaction_id = t.id
+			WHERE (t.source_account_id = @account OR t.destination_account_id = @account)
+				AND t.status = @status AND t.currency = @currency
+				AND t.created_at >= @from AND t.created_at < @to
+		) parties
+		GROUP BY kind, account_id, name
+		ORDER BY SUM(inflow) + SUM(outflow) DESC
+		LIMIT @limit`,
+		map[string]any{"account": accountID, "status": domain.StatusCompleted, "currency": currency, "from": from, "to": to, "limit": limit},
+	).Scan(&parties).Error
+	return parties, err
+}
+
+func (r *PostgresTransactionRepository) ListTransferSteps(ctx context.Context, transactionID uuid.UUID) ([]*domain.TransferStep, error) {
+	var steps []*domain.TransferStep
+	err := r.db.WithContext(ctx).Where("transaction_id = ?", transactionID).Order("created_at").Find(&steps).Error
+	return steps, err
+}
+
+func (r *PostgresTransactionRepository) SaveTransferStep(ctx context.Context, step *domain.TransferStep) error {
+	step.UpdatedAt = time.Now()
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "transaction_id"}, {Name: "step"}},
+		DoUpdates: clause.AssignmentColumns([]string{"status", "reservation_id", "error", "attempts", "updated_at"}),
+	}).Create(step).Error
+}
+
+func (r *PostgresTransactionRepository) ClaimStaleTransfers(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Transaction, error) {
+	var txs []*domain.Transaction
+	err := r.db.WithContext(ctx).Raw(`
+		UPDATE transaction.transactions SET lease_until = ?
+		WHERE id IN (
+			SELECT id FROM transaction.transactions
+			WHERE type = ? AND status = ? AND lease_until < ?
+			ORDER BY lease_until
+			LIMIT ?
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING *`,
+		now.Add(lease), domain.TypeTransfer, domain.StatusPending, now, limit,
+	).Scan(&txs).Error
+	return txs, err
+}
+
+func (r *PostgresTransactionRepository) CreateTillSession(ctx context.Context, session *domain.TillSession) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+func (r *PostgresTransactionRepository) GetTillSession(ctx context.Context, id uuid.UUID) (*domain.TillSession, error) {
+	var session domain.TillSession
+	err := r.db.WithContext(ctx).First(&session, "id = ?", id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTillSessionNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresTransactionRepository) GetOpenTillSession(ctx context.Context, tellerID uuid.UUID) (*domain.TillSession, error) {
+	var session domain.TillSession
+	err := r.db.WithContext(ctx).First(&session, "teller_id = ? AND closed_at IS NULL", tellerID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, domain.ErrTillNotOpen
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+func (r *PostgresTransactionRepository) IsTillOpen(ctx context.Context, branchCode, tillID string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&domain.TillSession{}).
+		Where("branch_code = ? AND till_id = ? AND closed_at IS NULL", branchCode, tillID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+func (r *PostgresTransactionRepository) UpdateTillSession(ctx context.Context, session *domain.TillSession) error {
+	return r.db.WithContext(ctx).Save(session).Error
+}
+
+func (r *PostgresTransactionRepository) SumTillPostings(ctx context.Context, sessionID uuid.UUID) (domain.TillTotals, error) {
+	var totals domain.TillTotals
+	err := r.db.WithContext(ctx).Model(&domain.Transaction{}).
+		Select(`COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS deposits,
+			COUNT(*) FILTER (WHERE type = ?) AS deposit_count,
+			COALESCE(SUM(amount) FILTER (WHERE type = ?), 0) AS withdrawals,
+			COUNT(*) FILTER (WHERE type = ?) AS withdrawal_count`,
+			domain.TypeDeposit, domain.TypeDeposit, domain.TypeWithdrawal, domain.TypeWithdrawal).
+		Where("till_session_id = ? AND status = ?", sessionID, domain.StatusCompleted).
+		Scan(&totals).Error
+	return totals, err
+}
+
+func (r *PostgresTransactionRepository) ListTillTransactions(ctx context.Context, sessionID uuid.UUID) ([]*domain.Transaction, error) {
+	var txs []*domain.Transaction
+	err := r.db.WithContext(ctx).Where("till_session_id = ?", sessionID).Order("created_at").Find(&txs).Error
+	return txs, err
+}
@@ -0,0 +1,217 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	sharedcurrency "nordic-bank/internal/shared/currency"
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// defaultAnalyticsRange is looked back over when no start is given
+	defaultAnalyticsRange = 90 * 24 * time.Hour
+	// maxAnalyticsRange bounds a query to a little over a year of days
+	maxAnalyticsRange     = 366 * 24 * time.Hour
+	defaultCounterparties = 5
+	maxCounterparties     = 50
+)
+
+// TransactionStats totals an account's completed transactions over a range.
+type TransactionStats struct {
+	Currency string
+	Inflow   int64
+	Outflow  int64
+	Count    int64
+}
+
+// FlowBucket is money in and out of an account over [Start, End).
+type FlowBucket struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Inflow  int64     `json:"inflow"`
+	Outflow int64     `json:"outflow"`
+	Net     int64     `json:"net"`
+	Count   int64     `json:"count"`
+}
+
+type Counterparty struct {
+	Kind      domain.CounterpartyKind `json:"kind"`
+	AccountID *uuid.UUID              `json:"account_id,omitempty"`
+	Name      string                  `json:"name,omitempty"`
+	Inflow    int64                   `json:"inflow"`
+	Outflow   int64                   `json:"outflow"`
+	Count     int64                   `json:"count"`
+}
+
+// AccountAnalytics is what the dashboard charts: an account's money in and
+// out per day, week and month, and who it moved the most money with. Every
+// period in the range has a bucket, empty or not.
+type AccountAnalytics struct {
+	AccountID         uuid.UUID      `json:"account_id"`
+	Currency          string         `json:"currency"`
+	From              time.Time      `json:"from"`
+	To                time.Time      `json:"to"`
+	Inflow            int64          `json:"inflow"`
+	Outflow           int64          `json:"outflow"`
+	Count             int64          `json:"count"`
+	Daily             []FlowBucket   `json:"daily"`
+	Weekly            []FlowBucket   `json:"weekly"`
+	Monthly           []FlowBucket   `json:"monthly"`
+	TopCounterparties []Counterparty `json:"top_counterparties"`
+}
+
+// AnalyticsQuery selects the account and range to analyse. A zero To means
+// now and a zero From the 90 days before To; an empty currency means the
+// account's own. When ViewedBy is set the customer must hold the account
+// with the view_transactions permission.
+type AnalyticsQuery struct {
+	AccountID      uuid.UUID
+	From           time.Time
+	To             time.Time
+	Currency       string
+	Counterparties int
+	ViewedBy       uuid.UUID
+}
+
+// GetTransactionStats totals the account's completed transactions in
+// [from, to), in the account's currency.
+func (s *TransactionService) GetTransactionStats(ctx context.Context, accountID uuid.UUID, from, to time.Time) (*TransactionStats, error) {
+	if !from.Before(to) {
+		return nil, domain.ErrInvalidDateRange
+	}
+	currency, err := s.accountCurrency(ctx, accountID, "")
+	if err != nil {
+		return nil, err
+	}
+	flows, err := s.repo.ListDailyFlows(ctx, accountID, currency, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &TransactionStats{Currency: currency}
+	for _, flow := range flows {
+		stats.Inflow += flow.Inflow
+		stats.Outflow += flow.Outflow
+		stats.Count += flow.Count
+	}
+	return stats, nil
+}
+
+// GetAccountAnalytics buckets the account's completed transactions by day,
+// week and month. Days are UTC days; a bucket at either end of the range only
+// covers the part inside it.
+func (s *TransactionService) GetAccountAnalytics(ctx context.Context, q AnalyticsQuery) (*AccountAnalytics, error) {
+	if q.To.IsZero() {
+		q.To = time.Now()
+	}
+	if q.From.IsZero() {
+		q.From = q.To.Add(-defaultAnalyticsRange)
+	}
+	if !q.From.Before(q.To) || q.To.Sub(q.From) > maxAnalyticsRange {
+		return nil, fmt.Errorf("%w: at most %d days, from before to", domain.ErrInvalidDateRange, int(maxAnalyticsRange.Hours()/24))
+	}
+	if q.Counterparties <= 0 {
+		q.Counterparties = defaultCounterparties
+	}
+	q.Counterparties = min(q.Counterparties, maxCounterparties)
+
+	if q.ViewedBy != uuid.Nil {
+		if err := s.checkViewer(ctx, q.AccountID, q.ViewedBy); err != nil {
+			return nil, err
+		}
+	}
+
+	currency, err := s.accountCurrency(ctx, q.AccountID, q.Currency)
+	if err != nil {
+		return nil, err
+	}
+	flows, err := s.repo.ListDailyFlows(ctx, q.AccountID, currency, q.From, q.To)
+	if err != nil {
+		return nil, err
+	}
+	parties, err := s.repo.TopCounterparties(ctx, q.AccountID, currency, q.From, q.To, q.Counterparties)
+	if err != nil {
+		return nil, err
+	}
+
+	analytics := &AccountAnalytics{
+		AccountID:         q.AccountID,
+		Currency:          currency,
+		From:              q.From,
+		To:                q.To,
+		Daily:             bucketFlows(flows, domain.PeriodDay, q.From, q.To),
+		Weekly:            bucketFlows(flows, domain.PeriodWeek, q.From, q.To),
+		Monthly:           bucketFlows(flows, domain.PeriodMonth, q.From, q.To),
+		TopCounterparties: make([]Counterparty, 0, len(parties)),
+	}
+	for _, flow := range flows {
+		analytics.Inflow += flow.Inflow
+		analytics.Outflow += flow.Outflow
+		analytics.Count += flow.Count
+	}
+	for _, p := range parties {
+		analytics.TopCounterparties = append(analytics.TopCounterparties, Counterparty{
+			Kind:      p.Kind,
+			AccountID: p.AccountID,
+			Name:      p.Name,
+			Inflow:    p.Inflow,
+			Outflow:   p.Outflow,
+			Count:     p.Count,
+		})
+	}
+	return analytics, nil
+}
+
+// accountCurrency validates the requested currency, or looks up the
+// account's own when none was asked for.
+func (s *TransactionService) accountCurrency(ctx context.Context, accountID uuid.UUID, currency string) (string, error) {
+	if currency != "" {
+		if err := sharedcurrency.Validate(currency); err != nil {
+			return "", fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, currency)
+		}
+		return sharedcurrency.Normalize(currency), nil
+	}
+	res, err := s.accountClient.GetAccount(ctx, &accountpb.GetAccountRequest{AccountId: accountID.String()})
+	if err != nil {
+		return "", err
+	}
+	return res.Account.Currency, nil
+}
+
+// bucketFlows rolls daily flows up into consecutive periods covering
+// [from, to), clipping the first and last to the range.
+func bucketFlows(daily []domain.Flow, period domain.FlowPeriod, from, to time.Time) []FlowBucket {
+	var buckets []FlowBucket
+	for start := period.Start(from); start.Before(to); start = period.Next(start) {
+		bucket := FlowBucket{Start: start, End: period.Next(start)}
+		if bucket.Start.Before(from) {
+			bucket.Start = from
+		}
+		if bucket.End.After(to) {
+			bucket.End = to
+		}
+		buckets = append(buckets, bucket)
+	}
+
+	i := 0
+	for _, flow := range daily {
+		for i < len(buckets) && !flow.Start.Before(period.Next(period.Start(buckets[i].Start))) {
+			i++
+		}
+		if i == len(buckets) {
+			break
+		}
+		buckets[i].Inflow += flow.Inflow
+		buckets[i].Outflow += flow.Outflow
+		buckets[i].Count += flow.Count
+	}
+	for i := range buckets {
+		buckets[i].Net = buckets[i].Inflow - buckets[i].Outflow
+	}
+	return buckets
+}
@@ -0,0 +1,117 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func (r *memoryRepository) ListDailyFlows(_ context.Context, accountID uuid.UUID, currency string, from, to time.Time) ([]domain.Flow, error) {
+	days := map[time.Time]*domain.Flow{}
+	for _, tx := range r.txs {
+		if tx.Status != domain.StatusCompleted || tx.Currency != currency ||
+			tx.CreatedAt.Before(from) || !tx.CreatedAt.Before(to) {
+			continue
+		}
+		in := tx.DestinationAccountID != nil && *tx.DestinationAccountID == accountID
+		out := tx.SourceAccountID != nil && *tx.SourceAccountID == accountID
+		if !in && !out {
+			continue
+		}
+		day := domain.PeriodDay.Start(tx.CreatedAt)
+		if days[day] == nil {
+			days[day] = &domain.Flow{Start: day}
+		}
+		if in {
+			days[day].Inflow += tx.Amount
+		}
+		if out {
+			days[day].Outflow += tx.Amount
+		}
+		days[day].Count++
+	}
+
+	var flows []domain.Flow
+	for _, flow := range days {
+		flows = append(flows, *flow)
+	}
+	sort.Slice(flows, func(i, j int) bool { return flows[i].Start.Before(flows[j].Start) })
+	return flows, nil
+}
+
+func (r *memoryRepository) TopCounterparties(context.Context, uuid.UUID, string, time.Time, time.Time, int) ([]domain.Counterparty, error) {
+	return nil, nil
+}
+
+func date(s string) time.Time {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestBucketFlows_FillsAndClipsPeriods(t *testing.T) {
+	daily := []domain.Flow{
+		{Start: date("2026-01-30"), Inflow: 100, Count: 1},
+		{Start: date("2026-02-02"), Outflow: 40, Count: 1}, // A Monday
+		{Start: date("2026-02-03"), Inflow: 10, Outflow: 5, Count: 2},
+	}
+	from, to := date("2026-01-30"), date("2026-02-05")
+
+	days := bucketFlows(daily, domain.PeriodDay, from, to)
+	require.Len(t, days, 6)
+	assert.Equal(t, int64(100), days[0].Inflow)
+	assert.Zero(t, days[1].Count)
+	assert.Equal(t, int64(-40), days[3].Net)
+
+	weeks := bucketFlows(daily, domain.PeriodWeek, from, to)
+	require.Len(t, weeks, 2)
+	assert.Equal(t, from, weeks[0].Start, "clipped to the range")
+	assert.Equal(t, date("2026-02-02"), weeks[0].End)
+	assert.Equal(t, int64(100), weeks[0].Inflow)
+	assert.Equal(t, int64(45), weeks[1].Outflow)
+	assert.Equal(t, int64(3), weeks[1].Count)
+	assert.Equal(t, to, weeks[1].End)
+
+	months := bucketFlows(daily, domain.PeriodMonth, from, to)
+	require.Len(t, months, 2)
+	assert.Equal(t, int64(100), months[0].Net)
+	assert.Equal(t, date("2026-02-01"), months[1].Start)
+	assert.Equal(t, int64(-35), months[1].Net)
+}
+
+func TestGetTransactionStats(t *testing.T) {
+	service, repo, client := newCardTestService(0)
+	account := uuid.MustParse(client.account.Id)
+	other := uuid.New()
+	day := date("2026-03-10")
+
+	for _, tx := range []*domain.Transaction{
+		{SourceAccountID: &other, DestinationAccountID: &account, Amount: 5_000, Currency: "DKK", Status: domain.StatusCompleted},
+		{SourceAccountID: &account, DestinationAccountID: &other, Amount: 2_000, Currency: "DKK", Status: domain.StatusCompleted},
+		{SourceAccountID: &account, Amount: 700, Currency: "DKK", Status: domain.StatusCompleted},
+		{SourceAccountID: &account, Amount: 900, Currency: "DKK", Status: domain.StatusFailed},
+		{SourceAccountID: &account, Amount: 300, Currency: "EUR", Status: domain.StatusCompleted},
+	} {
+		require.NoError(t, repo.Create(context.Background(), tx))
+		tx.CreatedAt = day.Add(12 * time.Hour)
+	}
+
+	stats, err := service.GetTransactionStats(context.Background(), account, day, day.AddDate(0, 0, 1))
+	require.NoError(t, err)
+	assert.Equal(t, "DKK", stats.Currency)
+	assert.Equal(t, int64(5_000), stats.Inflow)
+	assert.Equal(t, int64(2_700), stats.Outflow)
+	assert.Equal(t, int64(3), stats.Count)
+
+	_, err = service.GetTransactionStats(context.Background(), account, day, day)
+	assert.ErrorIs(t, err, domain.ErrInvalidDateRange)
+}
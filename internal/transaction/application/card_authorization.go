@@ -0,0 +1,548 @@
+package application
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"log"
+	"math/big"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+)
+
+// cardHoldTTL is how long a card authorization keeps funds on hold while the
+// merchant has not cleared it, matching what schemes allow for most
+// merchants.
+const cardHoldTTL = 7 * 24 * time.Hour
+
+// legCardClearing is the ledger leg that debits the part of a clearing whose
+// holds lapsed before it arrived.
+const legCardClearing = "card_clearing"
+
+// CardMessage is an ISO 8583-style message from the card scheme, with the
+// card number replaced by its vault token. Field numbers refer to ISO 8583.
+type CardMessage struct {
+	MTI            string `json:"mti"`             // Message type indicator: 0100, 0220 or 0400
+	STAN           string `json:"stan"`            // 11: system trace audit number
+	RRN            string `json:"rrn"`             // 37: retrieval reference number
+	CardToken      string `json:"card_token"`      // 2: primary account number, tokenised
+	ProcessingCode string `json:"processing_code"` // 3: 00 purchase, 01 cash withdrawal
+	Amount         int64  `json:"amount"`          // 4: minor units
+	Currency       string `json:"currency"`        // 49
+	MerchantName   string `json:"merchant_name"`   // 43
+	MCC            string `json:"mcc"`             // 18: merchant category code
+	TerminalID     string `json:"terminal_id"`     // 41
+	PIN            string `json:"pin,omitempty"`   // 52: only when the cardholder entered one
+}
+
+// CardResponse answers a CardMessage.
+type CardResponse struct {
+	MTI              string     `json:"mti"` // Request MTI + 10
+	STAN             string     `json:"stan"`
+	RRN              string     `json:"rrn"`
+	ResponseCode     string     `json:"response_code"` // 39
+	AuthCode         string     `json:"auth_code,omitempty"`
+	Approved         bool       `json:"approved"`
+	AuthorizedAmount int64      `json:"authorized_amount"`
+	TransactionID    *uuid.UUID `json:"transaction_id,omitempty"`
+	Duplicate        bool       `json:"duplicate,omitempty"`
+}
+
+// ProcessCardMessage runs a scheme message against the card and its account.
+// Declines are answered with a response code, not an error; errors mean the
+// message could not be processed at all. Every message is logged, and a
+// retransmission gets the logged answer without being processed again.
+func (s *TransactionService) ProcessCardMessage(ctx context.Context, msg CardMessage) (*CardResponse, error) {
+	if msg.RRN == "" || msg.STAN == "" {
+		return nil, fmt.Errorf("%w: rrn and stan are required", domain.ErrInvalidCardMessage)
+	}
+
+	if logged, err := s.repo.GetCardMessage(ctx, msg.RRN, msg.STAN, msg.MTI); err == nil {
+		res, err := s.respondFromLog(ctx, logged)
+		if err != nil {
+			return nil, err
+		}
+		res.Duplicate = true
+		return res, nil
+	} else if !errors.Is(err, domain.ErrCardMessageNotFound) {
+		return nil, err
+	}
+
+	var (
+		auth *domain.CardAuthorization
+		code string
+		err  error
+	)
+	switch msg.MTI {
+	case domain.MTIAuthorization:
+		auth, code, err = s.authorizeCard(ctx, msg)
+	case domain.MTIReversal:
+		auth, code, err = s.reverseCard(ctx, msg)
+	case domain.MTIClearing:
+		auth, code, err = s.clearCard(ctx, msg)
+	default:
+		return nil, fmt.Errorf("%w: unsupported mti %q", domain.ErrInvalidCardMessage, msg.MTI)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logged := &domain.CardMessage{
+		MTI:          msg.MTI,
+		STAN:         msg.STAN,
+		RRN:          msg.RRN,
+		CardToken:    msg.CardToken,
+		Amount:       msg.Amount,
+		Currency:     msg.Currency,
+		ResponseCode: code,
+	}
+	if auth != nil {
+		logged.AuthorizationID = &auth.ID
+		if code == domain.ResponseApproved {
+			logged.AuthCode = auth.AuthCode
+		}
+	}
+	if err := s.repo.CreateCardMessage(ctx, logged); err != nil {
+		return nil, err
+	}
+
+	return cardResponse(logged, auth), nil
+}
+
+// authorizeCard handles an authorization request. When the RRN is already
+// authorized the amount is added to it as an incremental authorization.
+func (s *TransactionService) authorizeCard(ctx context.Context, msg CardMessage) (*domain.CardAuthorization, string, error) {
+	auth, err := s.repo.GetCardAuthorizationByRRN(ctx, msg.RRN)
+	if err != nil && !errors.Is(err, domain.ErrAuthorizationNotFound) {
+		return nil, "", err
+	}
+	if auth != nil && auth.Status != domain.AuthorizationApproved {
+		return auth, domain.ResponseInvalidTransaction, nil
+	}
+	if msg.Amount <= 0 {
+		return auth, domain.ResponseInvalidAmount, nil
+	}
+
+	cardRes, err := s.accountClient.GetCardByToken(ctx, &accountpb.GetCardByTokenRequest{CardNumberToken: msg.CardToken})
+	if err != nil {
+		return auth, domain.ResponseInvalidCard, nil
+	}
+	card := cardRes.Card
+	if auth != nil && auth.CardID.String() != card.Id {
+		return auth, domain.ResponseInvalidTransaction, nil
+	}
+	if code := cardStatusCode(card, time.Now()); code != domain.ResponseApproved {
+		return auth, code, nil
+	}
+
+	accountRes, err := s.accountClient.GetAccount(ctx, &accountpb.GetAccountRequest{AccountId: card.AccountId})
+	if err != nil {
+		return nil, "", err
+	}
+	account := accountRes.Account
+	if msg.Currency != account.Currency {
+		return auth, domain.ResponseInvalidTransaction, nil
+	}
+
+	if msg.PIN != "" {
+		pinRes, err := s.accountClient.VerifyCardPin(ctx, &accountpb.VerifyCardPinRequest{CardId: card.Id, Pin: msg.PIN})
+		if err != nil {
+			return auth, domain.ResponseDoNotHonour, nil
+		}
+		if !pinRes.Verified {
+			if pinRes.LockedUntil != nil {
+				return auth, domain.ResponsePINTriesExceeded, nil
+			}
+			return auth, domain.ResponseIncorrectPIN, nil
+		}
+	}
+
+	channel := domain.CardChannelPOS
+	if msg.ProcessingCode == domain.ProcessingCashWithdrawal {
+		channel = domain.CardChannelATM
+	}
+	if auth != nil {
+		channel = auth.Channel
+	}
+	if code, err := s.checkCardLimits(ctx, card, channel, msg.Amount); err != nil || code != domain.ResponseApproved {
+		return auth, code, err
+	}
+
+	// The same floor the account service applies: minimum balance less any
+	// overdraft facility.
+	floor := account.MinimumBalance.GetAmount() - account.OverdraftLimit.GetAmount()
+	if account.AvailableBalance.GetAmount()-msg.Amount < floor {
+		return auth, domain.ResponseInsufficientFunds, nil
+	}
+
+	if auth == nil {
+		return s.openCardAuthorization(ctx, msg, card, channel)
+	}
+	return s.incrementCardAuthorization(ctx, msg, auth)
+}
+
+func (s *TransactionService) openCardAuthorization(ctx context.Context, msg CardMessage, card *accountpb.Card, channel domain.CardChannel) (*domain.CardAuthorization, string, error) {
+	accountID, err := uuid.Parse(card.AccountId)
+	if err != nil {
+		return nil, "", err
+	}
+	cardID, err := uuid.Parse(card.Id)
+	if err != nil {
+		return nil, "", err
+	}
+
+	txType := domain.TypePayment
+	if channel == domain.CardChannelATM {
+		txType = domain.TypeWithdrawal
+	}
+	tx := &domain.Transaction{
+		SourceAccountID: &accountID,
+		Amount:          msg.Amount,
+		Currency:        msg.Currency,
+		Type:            txType,
+		Category:        domain.CategoryForMCC(msg.MCC),
+		Status:          domain.StatusPending,
+		Reference:       msg.RRN,
+		Description:     cardDescription(msg, card),
+		IdempotencyKey:  "card:" + msg.RRN,
+	}
+	if err := s.repo.Create(ctx, tx); err != nil {
+		return nil, "", err
+	}
+
+	hold, err := s.accountClient.ReserveFunds(ctx, &accountpb.ReserveFundsRequest{
+		AccountId:     accountID.String(),
+		TransactionId: tx.ID.String(),
+		Amount:        msg.Amount,
+		Currency:      msg.Currency,
+		TtlSeconds:    int32(cardHoldTTL.Seconds()),
+	})
+	if err != nil {
+		// The balance check passed a moment ago, so another debit got there
+		// first or the account changed state.
+		_ = s.repo.UpdateStatus(ctx, tx.ID, domain.StatusFailed)
+		return nil, domain.ResponseDoNotHonour, nil
+	}
+
+	authCode, err := newAuthCode()
+	if err != nil {
+		return nil, "", err
+	}
+	auth := &domain.CardAuthorization{
+		TransactionID:        tx.ID,
+		CardID:               cardID,
+		AccountID:            accountID,
+		RRN:                  msg.RRN,
+		AuthCode:             authCode,
+		Channel:              channel,
+		MerchantName:         msg.MerchantName,
+		MerchantCategoryCode: msg.MCC,
+		Currency:             msg.Currency,
+		AuthorizedAmount:     msg.Amount,
+		Status:               domain.AuthorizationApproved,
+	}
+	if err := s.repo.CreateCardAuthorization(ctx, auth); err != nil {
+		return nil, "", err
+	}
+	if err := addCardHold(ctx, s.repo, auth, hold.Reservation.Id, msg.Amount); err != nil {
+		return nil, "", err
+	}
+
+	return auth, domain.ResponseApproved, nil
+}
+
+// incrementCardAuthorization adds to an approved authorization. Its row stays
+// locked from reading the authorized amount to writing the new one, so
+// concurrent increments all count.
+func (s *TransactionService) incrementCardAuthorization(ctx context.Context, msg CardMessage, auth *domain.CardAuthorization) (*domain.CardAuthorization, string, error) {
+	code := domain.ResponseApproved
+	err := s.repo.WithTx(ctx, func(repo domain.TransactionRepository) error {
+		locked, err := repo.GetCardAuthorizationForUpdate(ctx, auth.ID)
+		if err != nil {
+			return err
+		}
+		auth = locked
+		if auth.Status != domain.AuthorizationApproved {
+			code = domain.ResponseInvalidTransaction
+			return nil
+		}
+
+		hold, err := s.accountClient.ReserveFunds(ctx, &accountpb.ReserveFundsRequest{
+			AccountId:     auth.AccountID.String(),
+			TransactionId: auth.TransactionID.String(),
+			Amount:        msg.Amount,
+			Currency:      auth.Currency,
+			TtlSeconds:    int32(cardHoldTTL.Seconds()),
+		})
+		if err != nil {
+			code = domain.ResponseDoNotHonour
+			return nil
+		}
+		if err := addCardHold(ctx, repo, auth, hold.Reservation.Id, msg.Amount); err != nil {
+			return err
+		}
+
+		auth.AuthorizedAmount += msg.Amount
+		if err := repo.UpdateCardAuthorization(ctx, auth); err != nil {
+			return err
+		}
+		return setTransactionAmount(ctx, repo, auth.TransactionID, auth.AuthorizedAmount, domain.StatusPending)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return auth, code, nil
+}
+
+// reverseCard cancels an authorization in full and gives the held funds back.
+func (s *TransactionService) reverseCard(ctx context.Context, msg CardMessage) (*domain.CardAuthorization, string, error) {
+	auth, err := s.repo.GetCardAuthorizationByRRN(ctx, msg.RRN)
+	if errors.Is(err, domain.ErrAuthorizationNotFound) {
+		return nil, domain.ResponseOriginalNotFound, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if auth.Status != domain.AuthorizationApproved {
+		return auth, domain.ResponseInvalidTransaction, nil
+	}
+
+	holds, err := s.repo.ListCardHolds(ctx, auth.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	for _, hold := range holds {
+		if _, err := s.accountClient.ReleaseReservation(ctx, &accountpb.ReleaseReservationRequest{
+			ReservationId: hold.ReservationID.String(),
+			Reason:        "card_reversal",
+		}); err != nil {
+			// An expired hold has already been given back by the sweeper
+			log.Printf("card reversal %s: releasing hold %s: %v", auth.RRN, hold.ReservationID, err)
+		}
+	}
+
+	auth.Status = domain.AuthorizationReversed
+	if err := s.repo.UpdateCardAuthorization(ctx, auth); err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.UpdateStatus(ctx, auth.TransactionID, domain.StatusCancelled); err != nil {
+		return nil, "", err
+	}
+
+	return auth, domain.ResponseApproved, nil
+}
+
+// clearCard settles an authorization for the final amount, which may be less
+// than what was authorized. Holds are captured oldest first; whatever is not
+// needed is released. The merchant is paid even when a hold lapsed before
+// clearing arrived: that part is debited outright, past the funds check.
+func (s *TransactionService) clearCard(ctx context.Context, msg CardMessage) (*domain.CardAuthorization, string, error) {
+	auth, err := s.repo.GetCardAuthorizationByRRN(ctx, msg.RRN)
+	if errors.Is(err, domain.ErrAuthorizationNotFound) {
+		return nil, domain.ResponseOriginalNotFound, nil
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if auth.Status != domain.AuthorizationApproved {
+		return auth, domain.ResponseInvalidTransaction, nil
+	}
+	if msg.Amount <= 0 || msg.Amount > auth.AuthorizedAmount {
+		return auth, domain.ResponseInvalidAmount, nil
+	}
+
+	holds, err := s.repo.ListCardHolds(ctx, auth.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	description := "Card payment: " + auth.MerchantName
+	remaining := msg.Amount
+	var lapsed int64
+	for _, hold := range holds {
+		if remaining == 0 {
+			if _, err := s.accountClient.ReleaseReservation(ctx, &accountpb.ReleaseReservationRequest{
+				ReservationId: hold.ReservationID.String(),
+				Reason:        "card_cleared",
+			}); err != nil {
+				log.Printf("card clearing %s: releasing hold %s: %v", auth.RRN, hold.ReservationID, err)
+			}
+			continue
+		}
+
+		capture := min(remaining, hold.Amount)
+		if _, err := s.accountClient.CaptureReservation(ctx, &accountpb.CaptureReservationRequest{
+			ReservationId: hold.ReservationID.String(),
+			Amount:        capture,
+			Reference:     auth.TransactionID.String(),
+			Description:   description,
+		}); err != nil {
+			if inDoubt(err) {
+				return nil, "", fmt.Errorf("capturing hold %s: %w", hold.ReservationID, err)
+			}
+			log.Printf("card clearing %s: hold %s lapsed, debiting instead: %v", auth.RRN, hold.ReservationID, err)
+			lapsed += capture
+		}
+		remaining -= capture
+	}
+
+	// Posted as one leg of the transaction, so clearing again after a
+	// failure further on does not debit twice
+	if lapsed > 0 {
+		if _, err := s.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
+			AccountId:        auth.AccountID.String(),
+			AmountAdjustment: -lapsed,
+			Currency:         auth.Currency,
+			Reference:        auth.TransactionID.String(),
+			Description:      description,
+			TransactionId:    auth.TransactionID.String(),
+			Leg:              legCardClearing,
+			Force:            true,
+		}); err != nil {
+			return nil, "", fmt.Errorf("debiting lapsed holds: %w", err)
+		}
+	}
+
+	auth.ClearedAmount = msg.Amount
+	auth.Status = domain.AuthorizationCleared
+	if err := s.repo.UpdateCardAuthorization(ctx, auth); err != nil {
+		return nil, "", err
+	}
+	if err := setTransactionAmount(ctx, s.repo, auth.TransactionID, msg.Amount, domain.StatusCompleted); err != nil {
+		return nil, "", err
+	}
+
+	return auth, domain.ResponseApproved, nil
+}
+
+// checkCardLimits compares the card's spending today and this month, with the
+// new amount added, against its limits.
+func (s *TransactionService) checkCardLimits(ctx context.Context, card *accountpb.Card, channel domain.CardChannel, amount int64) (string, error) {
+	cardID, err := uuid.Parse(card.Id)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	dayStart := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+
+	today, atmToday, err := s.repo.SumCardSpend(ctx, cardID, dayStart)
+	if err != nil {
+		return "", err
+	}
+	month, _, err := s.repo.SumCardSpend(ctx, cardID, monthStart)
+	if err != nil {
+		return "", err
+	}
+
+	if today+amount > card.DailyLimit.GetAmount() || month+amount > card.MonthlyLimit.GetAmount() {
+		return domain.ResponseExceedsLimit, nil
+	}
+	if channel == domain.CardChannelATM && atmToday+amount > card.AtmDailyLimit.GetAmount() {
+		return domain.ResponseExceedsLimit, nil
+	}
+	return domain.ResponseApproved, nil
+}
+
+func addCardHold(ctx context.Context, repo domain.TransactionRepository, auth *domain.CardAuthorization, reservationID string, amount int64) error {
+	id, err := uuid.Parse(reservationID)
+	if err != nil {
+		return err
+	}
+	return repo.CreateCardHold(ctx, &domain.CardHold{
+		AuthorizationID: auth.ID,
+		ReservationID:   id,
+		Amount:          amount,
+	})
+}
+
+func setTransactionAmount(ctx context.Context, repo domain.TransactionRepository, id uuid.UUID, amount int64, status domain.TransactionStatus) error {
+	tx, err := repo.GetByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	tx.Amount = amount
+	tx.Status = status
+	return repo.Update(ctx, tx)
+}
+
+func (s *TransactionService) respondFromLog(ctx context.Context, logged *domain.CardMessage) (*CardResponse, error) {
+	var auth *domain.CardAuthorization
+	if logged.AuthorizationID != nil {
+		var err error
+		auth, err = s.repo.GetCardAuthorizationByRRN(ctx, logged.RRN)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return cardResponse(logged, auth), nil
+}
+
+func cardResponse(logged *domain.CardMessage, auth *domain.CardAuthorization) *CardResponse {
+	res := &CardResponse{
+		MTI:          responseMTI(logged.MTI),
+		STAN:         logged.STAN,
+		RRN:          logged.RRN,
+		ResponseCode: logged.ResponseCode,
+		AuthCode:     logged.AuthCode,
+		Approved:     logged.ResponseCode == domain.ResponseApproved,
+	}
+	if auth != nil {
+		res.AuthorizedAmount = auth.AuthorizedAmount
+		res.TransactionID = &auth.TransactionID
+	}
+	return res
+}
+
+// cardStatusCode declines cards that cannot be used right now.
+func cardStatusCode(card *accountpb.Card, now time.Time) string {
+	switch card.Status {
+	case "active":
+	case "inactive":
+		return domain.ResponseInactiveCard
+	case "blocked":
+		return domain.ResponseRestrictedCard
+	case "expired":
+		return domain.ResponseExpiredCard
+	default:
+		return domain.ResponseNotPermitted
+	}
+
+	expiresAt := time.Date(int(card.ExpiryYear), time.Month(card.ExpiryMonth)+1, 1, 0, 0, 0, 0, time.UTC)
+	if !now.Before(expiresAt) {
+		return domain.ResponseExpiredCard
+	}
+	return domain.ResponseApproved
+}
+
+func cardDescription(msg CardMessage, card *accountpb.Card) string {
+	if msg.ProcessingCode == domain.ProcessingCashWithdrawal {
+		return fmt.Sprintf("Cash withdrawal %s (card ending %s)", msg.MerchantName, card.LastFour)
+	}
+	return fmt.Sprintf("%s (card ending %s)", msg.MerchantName, card.LastFour)
+}
+
+// responseMTI turns a request MTI into its response, e.g. 0100 into 0110.
+func responseMTI(mti string) string {
+	if len(mti) != 4 {
+		return mti
+	}
+	return mti[:2] + string(mti[2]+1) + mti[3:]
+}
+
+var authCodeRange = big.NewInt(1_000_000)
+
+func newAuthCode() (string, error) {
+	n, err := rand.Int(rand.Reader, authCodeRange)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%06d", n.Int64()), nil
+}
@@ -0,0 +1,475 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+	commonpb "nordic-bank/pkg/pb/common/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const testCardToken = "tok_test"
+
+type memoryRepository struct {
+	domain.TransactionRepository
+	txs      map[uuid.UUID]*domain.Transaction
+	auths    []*domain.CardAuthorization
+	holds    []*domain.CardHold
+	messages []*domain.CardMessage
+	tills    []*domain.TillSession
+	steps    []*domain.TransferStep
+}
+
+func newMemoryRepository() *memoryRepository {
+	return &memoryRepository{txs: map[uuid.UUID]*domain.Transaction{}}
+}
+
+func (r *memoryRepository) WithTx(ctx context.Context, fn func(repo domain.TransactionRepository) error) error {
+	return fn(r)
+}
+
+func (r *memoryRepository) Create(_ context.Context, tx *domain.Transaction) error {
+	tx.ID = uuid.New()
+	tx.CreatedAt = time.Now()
+	r.txs[tx.ID] = tx
+	return nil
+}
+
+func (r *memoryRepository) GetByID(_ context.Context, id uuid.UUID) (*domain.Transaction, error) {
+	tx, ok := r.txs[id]
+	if !ok {
+		return nil, domain.ErrTransactionNotFound
+	}
+	return tx, nil
+}
+
+func (r *memoryRepository) Update(_ context.Context, tx *domain.Transaction) error {
+	r.txs[tx.ID] = tx
+	return nil
+}
+
+func (r *memoryRepository) UpdateStatus(_ context.Context, id uuid.UUID, status domain.TransactionStatus) error {
+	r.txs[id].Status = status
+	return nil
+}
+
+func (r *memoryRepository) CreateCardAuthorization(_ context.Context, auth *domain.CardAuthorization) error {
+	auth.ID = uuid.New()
+	auth.CreatedAt = time.Now()
+	r.auths = append(r.auths, auth)
+	return nil
+}
+
+func (r *memoryRepository) GetCardAuthorizationByRRN(_ context.Context, rrn string) (*domain.CardAuthorization, error) {
+	for _, auth := range r.auths {
+		if auth.RRN == rrn {
+			return auth, nil
+		}
+	}
+	return nil, domain.ErrAuthorizationNotFound
+}
+
+func (r *memoryRepository) GetCardAuthorizationForUpdate(_ context.Context, id uuid.UUID) (*domain.CardAuthorization, error) {
+	for _, auth := range r.auths {
+		if auth.ID == id {
+			return auth, nil
+		}
+	}
+	return nil, domain.ErrAuthorizationNotFound
+}
+
+func (r *memoryRepository) UpdateCardAuthorization(context.Context, *domain.CardAuthorization) error {
+	return nil
+}
+
+func (r *memoryRepository) CreateCardHold(_ context.Context, hold *domain.CardHold) error {
+	hold.ID = uuid.New()
+	r.holds = append(r.holds, hold)
+	return nil
+}
+
+func (r *memoryRepository) ListCardHolds(_ context.Context, authorizationID uuid.UUID) ([]*domain.CardHold, error) {
+	var holds []*domain.CardHold
+	for _, hold := range r.holds {
+		if hold.AuthorizationID == authorizationID {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+func (r *memoryRepository) SumCardSpend(_ context.Context, cardID uuid.UUID, since time.Time) (int64, int64, error) {
+	var total, atm int64
+	for _, auth := range r.auths {
+		if auth.CardID != cardID || auth.Status == domain.AuthorizationReversed || auth.CreatedAt.Before(since) {
+			continue
+		}
+		amount := auth.AuthorizedAmount
+		if auth.Status == domain.AuthorizationCleared {
+			amount = auth.ClearedAmount
+		}
+		total += amount
+		if auth.Channel == domain.CardChannelATM {
+			atm += amount
+		}
+	}
+	return total, atm, nil
+}
+
+func (r *memoryRepository) GetCardMessage(_ context.Context, rrn, stan, mti string) (*domain.CardMessage, error) {
+	for _, msg := range r.messages {
+		if msg.RRN == rrn && msg.STAN == stan && msg.MTI == mti {
+			return msg, nil
+		}
+	}
+	return nil, domain.ErrCardMessageNotFound
+}
+
+func (r *memoryRepository) CreateCardMessage(_ context.Context, msg *domain.CardMessage) error {
+	msg.ID = uuid.New()
+	r.messages = append(r.messages, msg)
+	return nil
+}
+
+// fakeAccountClient plays the account service for one card and its account.
+// Holds reduce the available balance; captures move them into the balance.
+type fakeAccountClient struct {
+	accountpb.AccountServiceClient
+	card         *accountpb.Card
+	account      *accountpb.Account
+	pin          string
+	reservations map[string]int64
+	captured     int64
+}
+
+func newFakeAccountClient(balance int64) *fakeAccountClient {
+	accountID := uuid.NewString()
+	expiry := time.Now().AddDate(2, 0, 0)
+	return &fakeAccountClient{
+		card: &accountpb.Card{
+			Id:              uuid.NewString(),
+			AccountId:       accountID,
+			CardNumberToken: testCardToken,
+			LastFour:        "4242",
+			ExpiryMonth:     int32(expiry.Month()),
+			ExpiryYear:      int32(expiry.Year()),
+			DailyLimit:      &commonpb.Money{Amount: 1_000_000, Currency: "DKK"},
+			MonthlyLimit:    &commonpb.Money{Amount: 5_000_000, Currency: "DKK"},
+			AtmDailyLimit:   &commonpb.Money{Amount: 300_000, Currency: "DKK"},
+			Status:          "active",
+		},
+		account: &accountpb.Account{
+			Id:               accountID,
+			Currency:         "DKK",
+			Balance:          &commonpb.Money{Amount: balance, Currency: "DKK"},
+			AvailableBalance: &commonpb.Money{Amount: balance, Currency: "DKK"},
+			OverdraftLimit:   &commonpb.Money{Currency: "DKK"},
+			MinimumBalance:   &commonpb.Money{Currency: "DKK"},
+		},
+		pin:          "1234",
+		reservations: map[string]int64{},
+	}
+}
+
+func (f *fakeAccountClient) GetCardByToken(_ context.Context, in *accountpb.GetCardByTokenRequest, _ ...grpc.CallOption) (*accountpb.GetCardByTokenResponse, error) {
+	if in.CardNumberToken != f.card.CardNumberToken {
+		return nil, status.Error(codes.NotFound, "card not found")
+	}
+	return &accountpb.GetCardByTokenResponse{Card: f.card}, nil
+}
+
+func (f *fakeAccountClient) GetAccount(context.Context, *accountpb.GetAccountRequest, ...grpc.CallOption) (*accountpb.GetAccountResponse, error) {
+	return &accountpb.GetAccountResponse{Account: f.account}, nil
+}
+
+func (f *fakeAccountClient) VerifyCardPin(_ context.Context, in *accountpb.VerifyCardPinRequest, _ ...grpc.CallOption) (*accountpb.VerifyCardPinResponse, error) {
+	return &accountpb.VerifyCardPinResponse{Verified: in.Pin == f.pin}, nil
+}
+
+func (f *fakeAccountClient) ReserveFunds(_ context.Context, in *accountpb.ReserveFundsRequest, _ ...grpc.CallOption) (*accountpb.ReserveFundsResponse, error) {
+	if f.account.AvailableBalance.Amount < in.Amount {
+		return nil, status.Error(codes.FailedPrecondition, "insufficient funds")
+	}
+	id := uuid.NewString()
+	f.reservations[id] = in.Amount
+	f.account.AvailableBalance.Amount -= in.Amount
+	return &accountpb.ReserveFundsResponse{Reservation: &accountpb.FundReservation{Id: id}}, nil
+}
+
+func (f *fakeAccountClient) ReleaseReservation(_ context.Context, in *accountpb.ReleaseReservationRequest, _ ...grpc.CallOption) (*accountpb.ReleaseReservationResponse, error) {
+	amount, ok := f.reservations[in.ReservationId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "reservation not found")
+	}
+	delete(f.reservations, in.ReservationId)
+	f.account.AvailableBalance.Amount += amount
+	return &accountpb.ReleaseReservationResponse{}, nil
+}
+
+func (f *fakeAccountClient) CaptureReservation(_ context.Context, in *accountpb.CaptureReservationRequest, _ ...grpc.CallOption) (*accountpb.CaptureReservationResponse, error) {
+	amount, ok := f.reservations[in.ReservationId]
+	if !ok {
+		return nil, status.Error(codes.NotFound, "reservation not found")
+	}
+	if in.Amount > amount {
+		return nil, errors.New("capture exceeds hold")
+	}
+	delete(f.reservations, in.ReservationId)
+	f.account.Balance.Amount -= in.Amount
+	f.account.AvailableBalance.Amount += amount - in.Amount
+	f.captured += in.Amount
+	return &accountpb.CaptureReservationResponse{}, nil
+}
+
+// lapseHolds gives every hold back the way the expiry sweeper does.
+func (f *fakeAccountClient) lapseHolds() {
+	for id, amount := range f.reservations {
+		delete(f.reservations, id)
+		f.account.AvailableBalance.Amount += amount
+	}
+}
+
+func newCardTestService(balance int64) (*TransactionService, *memoryRepository, *fakeAccountClient) {
+	repo := newMemoryRepository()
+	client := newFakeAccountClient(balance)
+	return NewTransactionService(repo, client), repo, client
+}
+
+func purchase(mti, stan, rrn string, amount int64) CardMessage {
+	return CardMessage{
+		MTI:            mti,
+		STAN:           stan,
+		RRN:            rrn,
+		CardToken:      testCardToken,
+		ProcessingCode: domain.ProcessingPurchase,
+		Amount:         amount,
+		Currency:       "DKK",
+		MerchantName:   "Netto",
+		MCC:            "5411",
+	}
+}
+
+func TestProcessCardMessage_AuthorizeIncrementAndClear(t *testing.T) {
+	service, repo, client := newCardTestService(100_000)
+	ctx := context.Background()
+
+	res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+	require.NoError(t, err)
+	assert.True(t, res.Approved)
+	assert.Equal(t, "0110", res.MTI)
+	assert.Len(t, res.AuthCode, 6)
+	require.NotNil(t, res.TransactionID)
+	assert.Equal(t, int64(90_000), client.account.AvailableBalance.Amount)
+
+	res, err = service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "2", "rrn1", 5_000))
+	require.NoError(t, err)
+	assert.True(t, res.Approved)
+	assert.Equal(t, int64(15_000), res.AuthorizedAmount)
+	assert.Len(t, repo.holds, 2)
+
+	res, err = service.ProcessCardMessage(ctx, purchase(domain.MTIClearing, "3", "rrn1", 12_000))
+	require.NoError(t, err)
+	assert.True(t, res.Approved)
+	assert.Equal(t, "0230", res.MTI)
+
+	assert.Equal(t, int64(12_000), client.captured)
+	assert.Equal(t, int64(88_000), client.account.Balance.Amount)
+	assert.Equal(t, int64(88_000), client.account.AvailableBalance.Amount)
+	assert.Empty(t, client.reservations)
+
+	tx := repo.txs[*res.TransactionID]
+	assert.Equal(t, domain.TypePayment, tx.Type)
+	assert.Equal(t, domain.StatusCompleted, tx.Status)
+	assert.Equal(t, int64(12_000), tx.Amount)
+}
+
+func TestProcessCardMessage_ClearingAfterHoldLapsedStillPays(t *testing.T) {
+	service, repo, client := newCardTestService(20_000)
+	ctx := context.Background()
+
+	_, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+	require.NoError(t, err)
+	_, err = service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "2", "rrn1", 5_000))
+	require.NoError(t, err)
+
+	// The holds lapse and the money is spent elsewhere before clearing
+	client.lapseHolds()
+	client.account.Balance.Amount, client.account.AvailableBalance.Amount = 2_000, 2_000
+
+	res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIClearing, "3", "rrn1", 12_000))
+	require.NoError(t, err)
+	assert.True(t, res.Approved)
+	assert.Equal(t, int64(-10_000), client.account.Balance.Amount, "the merchant is paid regardless")
+	assert.Zero(t, client.captured)
+	assert.Equal(t, domain.StatusCompleted, repo.txs[*res.TransactionID].Status)
+	assert.Equal(t, int64(12_000), repo.txs[*res.TransactionID].Amount)
+}
+
+func TestProcessCardMessage_Reversal(t *testing.T) {
+	service, repo, client := newCardTestService(100_000)
+	ctx := context.Background()
+
+	_, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+	require.NoError(t, err)
+
+	res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIReversal, "2", "rrn1", 10_000))
+	require.NoError(t, err)
+	assert.True(t, res.Approved)
+	assert.Equal(t, "0410", res.MTI)
+	assert.Equal(t, int64(100_000), client.account.AvailableBalance.Amount)
+	assert.Equal(t, domain.StatusCancelled, repo.txs[*res.TransactionID].Status)
+
+	// A reversed authorization can no longer be cleared
+	res, err = service.ProcessCardMessage(ctx, purchase(domain.MTIClearing, "3", "rrn1", 10_000))
+	require.NoError(t, err)
+	assert.Equal(t, domain.ResponseInvalidTransaction, res.ResponseCode)
+}
+
+func TestProcessCardMessage_Declines(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("insufficient funds", func(t *testing.T) {
+		service, _, _ := newCardTestService(5_000)
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+		require.NoError(t, err)
+		assert.False(t, res.Approved)
+		assert.Equal(t, domain.ResponseInsufficientFunds, res.ResponseCode)
+	})
+
+	t.Run("overdraft covers the shortfall", func(t *testing.T) {
+		service, _, client := newCardTestService(5_000)
+		client.account.OverdraftLimit.Amount = 10_000
+		client.account.AvailableBalance.Amount += 10_000
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+		require.NoError(t, err)
+		assert.True(t, res.Approved)
+	})
+
+	t.Run("daily limit", func(t *testing.T) {
+		service, _, client := newCardTestService(10_000_000)
+		client.card.DailyLimit.Amount = 15_000
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 10_000))
+		require.NoError(t, err)
+		assert.True(t, res.Approved)
+		res, err = service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "2", "rrn2", 10_000))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseExceedsLimit, res.ResponseCode)
+	})
+
+	t.Run("atm limit", func(t *testing.T) {
+		service, repo, _ := newCardTestService(10_000_000)
+		msg := purchase(domain.MTIAuthorization, "1", "rrn1", 400_000)
+		msg.ProcessingCode = domain.ProcessingCashWithdrawal
+		res, err := service.ProcessCardMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseExceedsLimit, res.ResponseCode)
+		assert.Empty(t, repo.txs)
+	})
+
+	t.Run("blocked card", func(t *testing.T) {
+		service, _, client := newCardTestService(100_000)
+		client.card.Status = "blocked"
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 1_000))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseRestrictedCard, res.ResponseCode)
+	})
+
+	t.Run("expired card", func(t *testing.T) {
+		service, _, client := newCardTestService(100_000)
+		client.card.ExpiryYear = int32(time.Now().Year() - 1)
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 1_000))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseExpiredCard, res.ResponseCode)
+	})
+
+	t.Run("unknown card", func(t *testing.T) {
+		service, _, _ := newCardTestService(100_000)
+		msg := purchase(domain.MTIAuthorization, "1", "rrn1", 1_000)
+		msg.CardToken = "tok_unknown"
+		res, err := service.ProcessCardMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseInvalidCard, res.ResponseCode)
+	})
+
+	t.Run("wrong pin", func(t *testing.T) {
+		service, _, client := newCardTestService(100_000)
+		msg := purchase(domain.MTIAuthorization, "1", "rrn1", 1_000)
+		msg.PIN = "0000"
+		res, err := service.ProcessCardMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseIncorrectPIN, res.ResponseCode)
+		assert.Empty(t, client.reservations)
+	})
+
+	t.Run("pin locked", func(t *testing.T) {
+		service, _, client := newCardTestService(100_000)
+		service.accountClient = &lockingPinClient{fakeAccountClient: client}
+		msg := purchase(domain.MTIAuthorization, "1", "rrn1", 1_000)
+		msg.PIN = "0000"
+		res, err := service.ProcessCardMessage(ctx, msg)
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponsePINTriesExceeded, res.ResponseCode)
+	})
+
+	t.Run("clearing above authorized amount", func(t *testing.T) {
+		service, _, _ := newCardTestService(100_000)
+		_, err := service.ProcessCardMessage(ctx, purchase(domain.MTIAuthorization, "1", "rrn1", 1_000))
+		require.NoError(t, err)
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIClearing, "2", "rrn1", 1_001))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseInvalidAmount, res.ResponseCode)
+	})
+
+	t.Run("reversal of unknown authorization", func(t *testing.T) {
+		service, _, _ := newCardTestService(100_000)
+		res, err := service.ProcessCardMessage(ctx, purchase(domain.MTIReversal, "1", "rrn1", 1_000))
+		require.NoError(t, err)
+		assert.Equal(t, domain.ResponseOriginalNotFound, res.ResponseCode)
+	})
+}
+
+type lockingPinClient struct {
+	*fakeAccountClient
+}
+
+func (c *lockingPinClient) VerifyCardPin(context.Context, *accountpb.VerifyCardPinRequest, ...grpc.CallOption) (*accountpb.VerifyCardPinResponse, error) {
+	return &accountpb.VerifyCardPinResponse{Attempts: 3, LockedUntil: timestamppb.New(time.Now().Add(24 * time.Hour))}, nil
+}
+
+func TestProcessCardMessage_RetransmissionIsAnsweredFromLog(t *testing.T) {
+	service, repo, client := newCardTestService(100_000)
+	ctx := context.Background()
+
+	msg := purchase(domain.MTIAuthorization, "1", "rrn1", 10_000)
+	first, err := service.ProcessCardMessage(ctx, msg)
+	require.NoError(t, err)
+
+	again, err := service.ProcessCardMessage(ctx, msg)
+	require.NoError(t, err)
+	assert.True(t, again.Duplicate)
+	assert.Equal(t, first.AuthCode, again.AuthCode)
+	assert.Equal(t, first.ResponseCode, again.ResponseCode)
+	assert.Len(t, repo.holds, 1)
+	assert.Equal(t, int64(90_000), client.account.AvailableBalance.Amount)
+}
+
+func TestProcessCardMessage_RejectsMalformedMessages(t *testing.T) {
+	service, _, _ := newCardTestService(100_000)
+
+	_, err := service.ProcessCardMessage(context.Background(), CardMessage{MTI: domain.MTIAuthorization})
+	assert.ErrorIs(t, err, domain.ErrInvalidCardMessage)
+
+	_, err = service.ProcessCardMessage(context.Background(), purchase("0200", "1", "rrn1", 1_000))
+	assert.ErrorIs(t, err, domain.ErrInvalidCardMessage)
+}
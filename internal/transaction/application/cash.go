@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	sharedcurrency "nordic-bank/internal/shared/currency"
+	"nordic-bank/internal/shared/idempotency"
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+)
+
+// CashRequest is cash paid in or taken out at a teller's till.
+type CashRequest struct {
+	AccountID      uuid.UUID
+	Amount         int64
+	Currency       string
+	Reference      string
+	Description    string
+	IdempotencyKey string
+	TellerID       uuid.UUID
+}
+
+// TillReport shows a till session against the postings made at it, so the
+// cashier can reconcile the drawer.
+type TillReport struct {
+	Session      *domain.TillSession   `json:"session"`
+	Totals       domain.TillTotals     `json:"totals"`
+	ExpectedCash int64                 `json:"expected_cash"`
+	Transactions []*domain.Transaction `json:"transactions"`
+}
+
+// OpenTill starts the teller's session at a till with the float counted into
+// the drawer. A teller works one till at a time and a till has one teller.
+func (s *TransactionService) OpenTill(ctx context.Context, tellerID uuid.UUID, branchCode, tillID, currency string, openingFloat int64) (*domain.TillSession, error) {
+	if openingFloat < 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+	if err := sharedcurrency.Validate(currency); err != nil {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, currency)
+	}
+
+	if _, err := s.repo.GetOpenTillSession(ctx, tellerID); err == nil {
+		return nil, fmt.Errorf("%w: close your current till first", domain.ErrTillAlreadyOpen)
+	} else if !errors.Is(err, domain.ErrTillNotOpen) {
+		return nil, err
+	}
+	open, err := s.repo.IsTillOpen(ctx, branchCode, tillID)
+	if err != nil {
+		return nil, err
+	}
+	if open {
+		return nil, fmt.Errorf("%w: %s/%s", domain.ErrTillAlreadyOpen, branchCode, tillID)
+	}
+
+	session := &domain.TillSession{
+		BranchCode:   branchCode,
+		TillID:       tillID,
+		TellerID:     tellerID,
+		Currency:     sharedcurrency.Normalize(currency),
+		OpeningFloat: openingFloat,
+		Status:       domain.TillOpen,
+		OpenedAt:     time.Now(),
+	}
+	if err := s.repo.CreateTillSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// CreateDeposit credits cash handed in at the teller's open till.
+func (s *TransactionService) CreateDeposit(ctx context.Context, req CashRequest) (*domain.Transaction, error) {
+	return s.createCashTransaction(ctx, domain.TypeDeposit, req)
+}
+
+// CreateWithdrawal debits cash paid out at the teller's open till. The account
+// service refuses it when the account cannot cover it.
+func (s *TransactionService) CreateWithdrawal(ctx context.Context, req CashRequest) (*domain.Transaction, error) {
+	return s.createCashTransaction(ctx, domain.TypeWithdrawal, req)
+}
+
+func (s *TransactionService) createCashTransaction(ctx context.Context, txType domain.TransactionType, req CashRequest) (*domain.Transaction, error) {
+	if req.Amount <= 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+	if err := sharedcurrency.Validate(req.Currency); err != nil {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, req.Currency)
+	}
+	currency := sharedcurrency.Normalize(req.Currency)
+
+	tx := &domain.Transaction{
+		Amount:           req.Amount,
+		Currency:         currency,
+		Type:             txType,
+		Category:         domain.CategoryCash,
+		Status:           domain.StatusPending,
+		Reference:        req.Reference,
+		Description:      req.Description,
+		IdempotencyKey:   req.IdempotencyKey,
+		IdempotencyScope: idempotency.ScopeFrom(ctx),
+		TellerID:         &req.TellerID,
+	}
+	adjustment, label := req.Amount, "Cash deposit"
+	if txType == domain.TypeDeposit {
+		tx.DestinationAccountID = &req.AccountID
+	} else {
+		tx.SourceAccountID = &req.AccountID
+		adjustment, label = -req.Amount, "Cash withdrawal"
+	}
+	if err := tx.CheckAccounts(); err != nil {
+		return nil, err
+	}
+	if existing, err := s.replay(ctx, tx); existing != nil || err != nil {
+		return existing, err
+	}
+
+	session, err := s.repo.GetOpenTillSession(ctx, req.TellerID)
+	if err != nil {
+		return nil, err
+	}
+	if currency != session.Currency {
+		return nil, fmt.Errorf("%w: till holds %s", domain.ErrCurrencyMismatch, session.Currency)
+	}
+	tx.BranchCode = session.BranchCode
+	tx.TillID = session.TillID
+	tx.TillSessionID = &session.ID
+
+	if err := s.repo.Create(ctx, tx); err != nil {
+		return nil, err
+	}
+
+	description := fmt.Sprintf("%s at %s till %s", label, session.BranchCode, session.TillID)
+	if req.Description != "" {
+		description += ": " + req.Description
+	}
+	_, err = s.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
+		AccountId:        req.AccountID.String(),
+		AmountAdjustment: adjustment,
+		Currency:         currency,
+		Reference:        tx.ID.String(),
+		Description:      description,
+	})
+	if err != nil {
+		tx.Status = domain.StatusFailed
+		_ = s.repo.UpdateStatus(ctx, tx.ID, domain.StatusFailed)
+		return tx, fmt.Errorf("%s failed: %w", txType, err)
+	}
+
+	tx.Status = domain.StatusCompleted
+	if err := s.repo.UpdateStatus(ctx, tx.ID, domain.StatusCompleted); err != nil {
+		return tx, err
+	}
+	return tx, nil
+}
+
+// GetTillReport shows the teller's open till as it stands.
+func (s *TransactionService) GetTillReport(ctx context.Context, tellerID uuid.UUID) (*TillReport, error) {
+	session, err := s.repo.GetOpenTillSession(ctx, tellerID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tillReport(ctx, session)
+}
+
+// GetTillSessionReport shows any till session, open or closed, for
+// supervisors following up on a discrepancy.
+func (s *TransactionService) GetTillSessionReport(ctx context.Context, sessionID uuid.UUID) (*TillReport, error) {
+	session, err := s.repo.GetTillSession(ctx, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.tillReport(ctx, session)
+}
+
+// CloseTill balances the teller's till at the end of the day: the cash
+// counted in the drawer is compared with the opening float plus the completed
+// deposits less the completed withdrawals. Any difference is recorded as the
+// session's variance rather than refused, so the drawer can always be closed.
+func (s *TransactionService) CloseTill(ctx context.Context, tellerID uuid.UUID, countedCash int64, note string) (*TillReport, error) {
+	if countedCash < 0 {
+		return nil, domain.ErrInvalidAmount
+	}
+
+	session, err := s.repo.GetOpenTillSession(ctx, tellerID)
+	if err != nil {
+		return nil, err
+	}
+	totals, err := s.repo.SumTillPostings(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	session.Close(countedCash, totals, note, time.Now())
+	if err := s.repo.UpdateTillSession(ctx, session); err != nil {
+		return nil, err
+	}
+	return s.tillReport(ctx, session)
+}
+
+func (s *TransactionService) tillReport(ctx context.Context, session *domain.TillSession) (*TillReport, error) {
+	totals, err := s.repo.SumTillPostings(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	txs, err := s.repo.ListTillTransactions(ctx, session.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &TillReport{
+		Session:      session,
+		Totals:       totals,
+		ExpectedCash: session.Expected(totals),
+		Transactions: txs,
+	}, nil
+}
@@ -0,0 +1,179 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (r *memoryRepository) GetByIdempotencyKey(_ context.Context, scope, key string, since time.Time) (*domain.Transaction, error) {
+	for _, tx := range r.txs {
+		if tx.IdempotencyScope == scope && tx.IdempotencyKey == key && !tx.CreatedAt.Before(since) {
+			return tx, nil
+		}
+	}
+	return nil, domain.ErrTransactionNotFound
+}
+
+func (r *memoryRepository) CreateTillSession(_ context.Context, session *domain.TillSession) error {
+	session.ID = uuid.New()
+	r.tills = append(r.tills, session)
+	return nil
+}
+
+func (r *memoryRepository) GetTillSession(_ context.Context, id uuid.UUID) (*domain.TillSession, error) {
+	for _, session := range r.tills {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+	return nil, domain.ErrTillSessionNotFound
+}
+
+func (r *memoryRepository) GetOpenTillSession(_ context.Context, tellerID uuid.UUID) (*domain.TillSession, error) {
+	for _, session := range r.tills {
+		if session.TellerID == tellerID && session.ClosedAt == nil {
+			return session, nil
+		}
+	}
+	return nil, domain.ErrTillNotOpen
+}
+
+func (r *memoryRepository) IsTillOpen(_ context.Context, branchCode, tillID string) (bool, error) {
+	for _, session := range r.tills {
+		if session.BranchCode == branchCode && session.TillID == tillID && session.ClosedAt == nil {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (r *memoryRepository) UpdateTillSession(context.Context, *domain.TillSession) error {
+	return nil
+}
+
+func (r *memoryRepository) SumTillPostings(_ context.Context, sessionID uuid.UUID) (domain.TillTotals, error) {
+	var totals domain.TillTotals
+	for _, tx := range r.txs {
+		if tx.TillSessionID == nil || *tx.TillSessionID != sessionID || tx.Status != domain.StatusCompleted {
+			continue
+		}
+		switch tx.Type {
+		case domain.TypeDeposit:
+			totals.Deposits += tx.Amount
+			totals.DepositCount++
+		case domain.TypeWithdrawal:
+			totals.Withdrawals += tx.Amount
+			totals.WithdrawalCount++
+		}
+	}
+	return totals, nil
+}
+
+func (r *memoryRepository) ListTillTransactions(_ context.Context, sessionID uuid.UUID) ([]*domain.Transaction, error) {
+	var txs []*domain.Transaction
+	for _, tx := range r.txs {
+		if tx.TillSessionID != nil && *tx.TillSessionID == sessionID {
+			txs = append(txs, tx)
+		}
+	}
+	return txs, nil
+}
+
+func (f *fakeAccountClient) AdjustBalance(_ context.Context, in *accountpb.AdjustBalanceRequest, _ ...grpc.CallOption) (*accountpb.AdjustBalanceResponse, error) {
+	if !in.Force && f.account.AvailableBalance.Amount+in.AmountAdjustment < 0 {
+		return nil, status.Error(codes.FailedPrecondition, "insufficient funds")
+	}
+	f.account.Balance.Amount += in.AmountAdjustment
+	f.account.AvailableBalance.Amount += in.AmountAdjustment
+	return &accountpb.AdjustBalanceResponse{NewBalance: f.account.Balance}, nil
+}
+
+func TestCashDesk_DepositWithdrawAndBalanceTill(t *testing.T) {
+	service, repo, client := newCardTestService(50_000)
+	ctx := context.Background()
+	teller := uuid.New()
+	accountID := uuid.MustParse(client.account.Id)
+
+	_, err := service.CreateDeposit(ctx, CashRequest{AccountID: accountID, Amount: 1_000, Currency: "DKK", IdempotencyKey: "d0", TellerID: teller})
+	assert.ErrorIs(t, err, domain.ErrTillNotOpen)
+
+	session, err := service.OpenTill(ctx, teller, "CPH01", "T3", "dkk", 200_000)
+	require.NoError(t, err)
+	assert.Equal(t, "DKK", session.Currency)
+
+	_, err = service.OpenTill(ctx, uuid.New(), "CPH01", "T3", "DKK", 0)
+	assert.ErrorIs(t, err, domain.ErrTillAlreadyOpen)
+
+	deposit, err := service.CreateDeposit(ctx, CashRequest{AccountID: accountID, Amount: 30_000, Currency: "DKK", IdempotencyKey: "d1", TellerID: teller})
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCompleted, deposit.Status)
+	assert.Nil(t, deposit.SourceAccountID)
+	assert.Equal(t, accountID, *deposit.DestinationAccountID)
+	assert.Equal(t, teller, *deposit.TellerID)
+	assert.Equal(t, "T3", deposit.TillID)
+
+	again, err := service.CreateDeposit(ctx, CashRequest{AccountID: accountID, Amount: 30_000, Currency: "DKK", IdempotencyKey: "d1", TellerID: teller})
+	require.NoError(t, err)
+	assert.Equal(t, deposit.ID, again.ID)
+	assert.Equal(t, int64(80_000), client.account.Balance.Amount)
+
+	withdrawal, err := service.CreateWithdrawal(ctx, CashRequest{AccountID: accountID, Amount: 20_000, Currency: "DKK", IdempotencyKey: "w1", TellerID: teller})
+	require.NoError(t, err)
+	assert.Equal(t, accountID, *withdrawal.SourceAccountID)
+	assert.Nil(t, withdrawal.DestinationAccountID)
+
+	// A refused withdrawal is recorded but does not count towards the till
+	failed, err := service.CreateWithdrawal(ctx, CashRequest{AccountID: accountID, Amount: 500_000, Currency: "DKK", IdempotencyKey: "w2", TellerID: teller})
+	require.Error(t, err)
+	assert.Equal(t, domain.StatusFailed, failed.Status)
+
+	_, err = service.CreateDeposit(ctx, CashRequest{AccountID: accountID, Amount: 100, Currency: "EUR", IdempotencyKey: "d2", TellerID: teller})
+	assert.ErrorIs(t, err, domain.ErrCurrencyMismatch)
+
+	report, err := service.CloseTill(ctx, teller, 209_500, "Short a 500 note")
+	require.NoError(t, err)
+	assert.Equal(t, int64(210_000), report.ExpectedCash)
+	assert.Equal(t, domain.TillTotals{Deposits: 30_000, DepositCount: 1, Withdrawals: 20_000, WithdrawalCount: 1}, report.Totals)
+	assert.Len(t, report.Transactions, 3)
+	assert.Equal(t, domain.TillDiscrepancy, report.Session.Status)
+	assert.Equal(t, int64(-500), *report.Session.Variance)
+	assert.NotNil(t, repo.tills[0].ClosedAt)
+
+	_, err = service.GetTillReport(ctx, teller)
+	assert.ErrorIs(t, err, domain.ErrTillNotOpen)
+}
+
+func TestTransaction_CheckAccounts(t *testing.T) {
+	a, b := uuid.New(), uuid.New()
+	cases := []struct {
+		tx domain.Transaction
+		ok bool
+	}{
+		{domain.Transaction{Type: domain.TypeDeposit, DestinationAccountID: &a}, true},
+		{domain.Transaction{Type: domain.TypeDeposit, SourceAccountID: &b, DestinationAccountID: &a}, false},
+		{domain.Transaction{Type: domain.TypeWithdrawal, SourceAccountID: &a}, true},
+		{domain.Transaction{Type: domain.TypeWithdrawal, DestinationAccountID: &a}, false},
+		{domain.Transaction{Type: domain.TypeTransfer, SourceAccountID: &a, DestinationAccountID: &b}, true},
+		{domain.Transaction{Type: domain.TypeTransfer, SourceAccountID: &a, DestinationAccountID: &a}, false},
+		{domain.Transaction{Type: domain.TypePayment, SourceAccountID: &a}, true},
+	}
+	for _, tc := range cases {
+		err := tc.tx.CheckAccounts()
+		if tc.ok {
+			assert.NoError(t, err, tc.tx.Type)
+		} else {
+			assert.ErrorIs(t, err, domain.ErrInvalidAccounts, tc.tx.Type)
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package application
+
+import (
+	"encoding/base64"
+	"strconv"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/google/uuid"
+)
+
+// encodeCursor makes an opaque page cursor from a row's position.
+func encodeCursor(cursor domain.Cursor) string {
+	raw := strconv.FormatInt(cursor.At.UnixNano(), 10) + "|" + cursor.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeCursor(s string) (*domain.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	nanos, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, domain.ErrInvalidCursor
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrInvalidCursor
+	}
+	return &domain.Cursor{At: time.Unix(0, n).UTC(), ID: parsed}, nil
+}
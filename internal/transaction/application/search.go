@@ -0,0 +1,101 @@
+package application
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/google/uuid"
+)
+
+const (
+	defaultSearchPageSize = 50
+	maxSearchPageSize     = 500
+)
+
+// SearchQuery selects transactions for back-office investigations. Cursor is
+// the NextCursor of the previous page, or empty for the first page.
+type SearchQuery struct {
+	From         *time.Time // Inclusive
+	To           *time.Time // Exclusive
+	MinAmount    *int64
+	MaxAmount    *int64
+	Type         string
+	Status       string
+	AccountID    *uuid.UUID
+	Counterparty *uuid.UUID
+	Category     string
+	Reference    string // Case-insensitive prefix
+	Text         string // Words in the description; quotes, OR and -word work as in web searches
+	Cursor       string
+	Limit        int
+}
+
+// SearchPage is one page of transactions, newest first. NextCursor is empty
+// on the last page.
+type SearchPage struct {
+	Transactions []*domain.Transaction `json:"transactions"`
+	NextCursor   string                `json:"next_cursor"`
+}
+
+// SearchTransactions finds transactions across all accounts, paging by
+// creation time and ID so pages stay stable while new transactions arrive.
+func (s *TransactionService) SearchTransactions(ctx context.Context, query SearchQuery) (*SearchPage, error) {
+	filter := domain.TransactionFilter{
+		From:         query.From,
+		To:           query.To,
+		MinAmount:    query.MinAmount,
+		MaxAmount:    query.MaxAmount,
+		Type:         domain.TransactionType(query.Type),
+		Status:       domain.TransactionStatus(query.Status),
+		AccountID:    query.AccountID,
+		Counterparty: query.Counterparty,
+		Category:     domain.TransactionCategory(query.Category),
+		Reference:    query.Reference,
+		Text:         strings.TrimSpace(query.Text),
+	}
+	switch {
+	case filter.Type != "" && !filter.Type.Valid():
+		return nil, fmt.Errorf("%w: unknown type %q", domain.ErrInvalidFilter, query.Type)
+	case filter.Status != "" && !filter.Status.Valid():
+		return nil, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidFilter, query.Status)
+	case filter.Category != "" && !filter.Category.Valid():
+		return nil, fmt.Errorf("%w: unknown category %q", domain.ErrInvalidFilter, query.Category)
+	case filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To):
+		return nil, fmt.Errorf("%w: from must be before to", domain.ErrInvalidFilter)
+	case filter.MinAmount != nil && filter.MaxAmount != nil && *filter.MinAmount > *filter.MaxAmount:
+		return nil, fmt.Errorf("%w: min_amount is above max_amount", domain.ErrInvalidFilter)
+	}
+
+	if query.Cursor != "" {
+		before, err := decodeCursor(query.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.Before = before
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = defaultSearchPageSize
+	}
+	limit = min(limit, maxSearchPageSize)
+
+	// One extra row tells us whether there is another page
+	filter.Limit = limit + 1
+	txs, err := s.repo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &SearchPage{Transactions: txs}
+	if len(txs) > limit {
+		page.Transactions = txs[:limit]
+		last := page.Transactions[limit-1]
+		page.NextCursor = encodeCursor(domain.Cursor{At: last.CreatedAt, ID: last.ID})
+	}
+	return page, nil
+}
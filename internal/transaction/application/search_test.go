@@ -0,0 +1,169 @@
+package application
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Search filters on type, account and amount and pages like the Postgres
+// repository; the other filters are left to the database.
+func (r *memoryRepository) Search(_ context.Context, filter domain.TransactionFilter) ([]*domain.Transaction, error) {
+	var txs []*domain.Transaction
+	for _, tx := range r.txs {
+		if filter.Type != "" && tx.Type != filter.Type {
+			continue
+		}
+		if filter.AccountID != nil &&
+			(tx.SourceAccountID == nil || *tx.SourceAccountID != *filter.AccountID) &&
+			(tx.DestinationAccountID == nil || *tx.DestinationAccountID != *filter.AccountID) {
+			continue
+		}
+		if filter.MinAmount != nil && tx.Amount < *filter.MinAmount {
+			continue
+		}
+		if filter.Before != nil && !before(tx, filter.Before) {
+			continue
+		}
+		txs = append(txs, tx)
+	}
+	sort.Slice(txs, func(i, j int) bool {
+		return before(txs[j], &domain.Cursor{At: txs[i].CreatedAt, ID: txs[i].ID})
+	})
+	if filter.Limit > 0 && len(txs) > filter.Limit {
+		txs = txs[:filter.Limit]
+	}
+	return txs, nil
+}
+
+// before reports whether tx comes after the cursor in a newest-first listing.
+func before(tx *domain.Transaction, cursor *domain.Cursor) bool {
+	if tx.CreatedAt.Equal(cursor.At) {
+		return tx.ID.String() < cursor.ID.String()
+	}
+	return tx.CreatedAt.Before(cursor.At)
+}
+
+func TestSearchTransactions_PagesWithCursor(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewTransactionService(repo, nil)
+	account := uuid.New()
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	// Five transfers, two of them made in the same instant
+	for i, offset := range []int{0, 1, 2, 2, 3} {
+		tx := &domain.Transaction{SourceAccountID: &account, Amount: int64(100 * (i + 1)), Type: domain.TypeTransfer}
+		require.NoError(t, repo.Create(context.Background(), tx))
+		tx.CreatedAt = at.Add(time.Duration(offset) * time.Minute)
+	}
+	require.NoError(t, repo.Create(context.Background(), &domain.Transaction{SourceAccountID: &account, Amount: 50, Type: domain.TypeWithdrawal}))
+
+	var seen []*domain.Transaction
+	query := SearchQuery{AccountID: &account, Type: "transfer", Limit: 2}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 3)
+		page, err := service.SearchTransactions(context.Background(), query)
+		require.NoError(t, err)
+		seen = append(seen, page.Transactions...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+
+	require.Len(t, seen, 5)
+	for i := 1; i < len(seen); i++ {
+		assert.True(t, before(seen[i], &domain.Cursor{At: seen[i-1].CreatedAt, ID: seen[i-1].ID}), "newest first without repeats")
+	}
+}
+
+func TestSearchTransactions_RejectsBadFilters(t *testing.T) {
+	service := NewTransactionService(newMemoryRepository(), nil)
+	ctx := context.Background()
+	low, high := int64(500), int64(100)
+
+	for _, query := range []SearchQuery{
+		{Type: "refund"},
+		{Status: "done"},
+		{Category: "gambling"},
+		{MinAmount: &low, MaxAmount: &high},
+	} {
+		_, err := service.SearchTransactions(ctx, query)
+		assert.ErrorIs(t, err, domain.ErrInvalidFilter)
+	}
+
+	_, err := service.SearchTransactions(ctx, SearchQuery{Cursor: "not-a-cursor"})
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestListTransactions_PagesTheAccountWithCursor(t *testing.T) {
+	repo := newMemoryRepository()
+	service := NewTransactionService(repo, nil)
+	account, other := uuid.New(), uuid.New()
+	at := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 3 {
+		tx := &domain.Transaction{DestinationAccountID: &account, Amount: 100}
+		require.NoError(t, repo.Create(context.Background(), tx))
+		tx.CreatedAt = at.Add(time.Duration(i) * time.Minute)
+	}
+	require.NoError(t, repo.Create(context.Background(), &domain.Transaction{SourceAccountID: &other, Amount: 100}))
+
+	first, err := service.ListTransactions(context.Background(), account, "", 2, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, first.Transactions, 2)
+	assert.Equal(t, at.Add(2*time.Minute), first.Transactions[0].CreatedAt)
+	require.NotEmpty(t, first.NextCursor)
+
+	last, err := service.ListTransactions(context.Background(), account, first.NextCursor, 2, uuid.Nil)
+	require.NoError(t, err)
+	require.Len(t, last.Transactions, 1)
+	assert.Equal(t, at, last.Transactions[0].CreatedAt)
+	assert.Empty(t, last.NextCursor)
+
+	_, err = service.ListTransactions(context.Background(), account, "not-a-cursor", 2, uuid.Nil)
+	assert.ErrorIs(t, err, domain.ErrInvalidCursor)
+}
+
+func TestTransactions_CustomersOnlySeeAccountsTheyMayView(t *testing.T) {
+	bank := newFakeBank(nil)
+	repo := newMemoryRepository()
+	service := NewTransactionService(repo, bank)
+	ctx := context.Background()
+	mine, theirs := uuid.New(), uuid.New()
+	me, stranger := uuid.New(), uuid.New()
+	bank.owners[mine.String()] = me.String()
+
+	paid := &domain.Transaction{SourceAccountID: &theirs, DestinationAccountID: &mine, Amount: 100}
+	require.NoError(t, repo.Create(ctx, paid))
+	private := &domain.Transaction{SourceAccountID: &theirs, Amount: 100}
+	require.NoError(t, repo.Create(ctx, private))
+
+	page, err := service.ListTransactions(ctx, mine, "", 10, me)
+	require.NoError(t, err)
+	assert.Len(t, page.Transactions, 1)
+	_, err = service.ListTransactions(ctx, mine, "", 10, stranger)
+	assert.ErrorIs(t, err, domain.ErrViewNotPermitted)
+	_, err = service.ListTransactions(ctx, theirs, "", 10, me)
+	assert.ErrorIs(t, err, domain.ErrViewNotPermitted)
+
+	// Either side of a transaction may look it up
+	tx, err := service.GetTransaction(ctx, paid.ID, me)
+	require.NoError(t, err)
+	assert.Equal(t, paid.ID, tx.ID)
+	_, err = service.GetTransaction(ctx, paid.ID, stranger)
+	assert.ErrorIs(t, err, domain.ErrViewNotPermitted)
+	_, err = service.GetTransaction(ctx, private.ID, me)
+	assert.ErrorIs(t, err, domain.ErrViewNotPermitted)
+
+	// Employees are not checked
+	_, err = service.GetTransaction(ctx, private.ID, uuid.Nil)
+	require.NoError(t, err)
+}
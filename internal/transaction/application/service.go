@@ -2,43 +2,97 @@ package application
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
+	sharedcurrency "nordic-bank/internal/shared/currency"
+	"nordic-bank/internal/shared/iban"
+	"nordic-bank/internal/shared/idempotency"
 	"nordic-bank/internal/transaction/domain"
 	accountpb "nordic-bank/pkg/pb/account/v1"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
+// transferHoldTTL bounds how long a transfer may keep funds on hold before the
+// account service's sweeper gives them back.
+const transferHoldTTL = 5 * time.Minute
+
 type TransactionService struct {
 	repo          domain.TransactionRepository
 	accountClient accountpb.AccountServiceClient
+	recoverAfter  time.Duration
+	// keyRetention is how long idempotency keys are remembered
+	keyRetention time.Duration
 }
 
 func NewTransactionService(repo domain.TransactionRepository, accountClient accountpb.AccountServiceClient) *TransactionService {
 	return &TransactionService{
 		repo:          repo,
 		accountClient: accountClient,
+		recoverAfter:  DefaultTransferRecoveryDelay,
+		keyRetention:  idempotency.DefaultRetention,
 	}
 }
 
-func (s *TransactionService) CreateTransfer(ctx context.Context, srcID, dstID uuid.UUID, amount int64, currency, reference, description, idempotencyKey string) (*domain.Transaction, error) {
-	// 1. Check idempotency
-	if existing, err := s.repo.GetByIdempotencyKey(ctx, idempotencyKey); err == nil {
-		return existing, nil
+// Initiator is who asked for a transfer. The zero value is nobody and is
+// refused.
+type Initiator struct {
+	// CustomerID is the customer making the transfer, who must hold the
+	// source account with the transfer permission.
+	CustomerID uuid.UUID
+	// Bank is set for employees and internal services, which are not held to
+	// account holder permissions.
+	Bank bool
+}
+
+// CustomerInitiator is a transfer made by the customer.
+func CustomerInitiator(customerID uuid.UUID) Initiator {
+	return Initiator{CustomerID: customerID}
+}
+
+// BankInitiator is a transfer made by an employee or internal service.
+var BankInitiator = Initiator{Bank: true}
+
+// CreateTransfer moves money between two accounts on behalf of by.
+func (s *TransactionService) CreateTransfer(ctx context.Context, srcID, dstID uuid.UUID, amount int64, currency, reference, description, idempotencyKey string, by Initiator) (*domain.Transaction, error) {
+	if err := s.checkInitiator(ctx, srcID, by); err != nil {
+		return nil, err
+	}
+
+	if err := sharedcurrency.Validate(currency); err != nil {
+		return nil, fmt.Errorf("%w: %q", domain.ErrUnsupportedCurrency, currency)
 	}
+	currency = sharedcurrency.Normalize(currency)
 
-	// 2. Initial Transaction Record (Pending)
+	// 2. Initial Transaction Record (Pending), handed to recovery if this
+	// call does not see it through
+	lease := time.Now().Add(s.recoverAfter)
 	tx := &domain.Transaction{
 		SourceAccountID:      &srcID,
 		DestinationAccountID: &dstID,
 		Amount:               amount,
 		Currency:             currency,
 		Type:                 domain.TypeTransfer,
+		Category:             domain.CategoryTransfer,
 		Status:               domain.StatusPending,
 		Reference:            reference,
 		Description:          description,
 		IdempotencyKey:       idempotencyKey,
+		IdempotencyScope:     idempotency.ScopeFrom(ctx),
+		LeaseUntil:           &lease,
+	}
+
+	if err := tx.CheckAccounts(); err != nil {
+		return nil, err
+	}
+
+	// 1. Check idempotency
+	if existing, err := s.replay(ctx, tx); existing != nil || err != nil {
+		return existing, err
 	}
 
 	if err := s.repo.Create(ctx, tx); err != nil {
@@ -46,60 +100,130 @@ func (s *TransactionService) CreateTransfer(ctx context.Context, srcID, dstID uu
 	}
 
 	// 3. Perform the actual balance updates via Account Service
+	return tx, s.runTransfer(ctx, tx)
+}
 
-	// Step 1: Debit Source
-	_, err := s.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
-		AccountId:        srcID.String(),
-		AmountAdjustment: -amount,
-		Reference:        tx.ID.String(),
-		Description:      fmt.Sprintf("Transfer to %s: %s", dstID.String(), description),
-	})
+// checkInitiator fails unless by may move money out of the source account.
+func (s *TransactionService) checkInitiator(ctx context.Context, srcID uuid.UUID, by Initiator) error {
+	if by.Bank {
+		return nil
+	}
+	if by.CustomerID == uuid.Nil {
+		return domain.ErrTransferNotPermitted
+	}
 
-	if err != nil {
-		tx.Status = domain.StatusFailed
-		tx.Description = fmt.Sprintf("Debit failed: %v", err)
-		_ = s.repo.UpdateStatus(ctx, tx.ID, domain.StatusFailed)
-		return tx, fmt.Errorf("debit failed: %w", err)
-	}
-
-	// Step 2: Credit Destination
-	_, err = s.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
-		AccountId:        dstID.String(),
-		AmountAdjustment: amount,
-		Reference:        tx.ID.String(),
-		Description:      fmt.Sprintf("Transfer from %s: %s", srcID.String(), description),
+	res, err := s.accountClient.CheckHolderPermission(ctx, &accountpb.CheckHolderPermissionRequest{
+		AccountId:  srcID.String(),
+		CustomerId: by.CustomerID.String(),
+		Permission: "transfer",
 	})
+	if err != nil {
+		return err
+	}
+	if !res.Allowed {
+		return domain.ErrTransferNotPermitted
+	}
+	return nil
+}
+
+// SetIdempotencyRetention replaces how long a caller's idempotency key
+// returns the transaction first made with it.
+func (s *TransactionService) SetIdempotencyRetention(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("idempotency retention must be positive")
+	}
+	s.keyRetention = d
+	return nil
+}
 
+// replay returns the transaction the caller already made with tx's
+// idempotency key, or nil when there is none. Reusing the key for a different
+// transaction is refused rather than answered with the earlier one.
+func (s *TransactionService) replay(ctx context.Context, tx *domain.Transaction) (*domain.Transaction, error) {
+	existing, err := s.repo.GetByIdempotencyKey(ctx, tx.IdempotencyScope, tx.IdempotencyKey, time.Now().Add(-s.keyRetention))
+	if errors.Is(err, domain.ErrTransactionNotFound) {
+		return nil, nil
+	}
 	if err != nil {
-		// Compensation: Re-credit Source
-		_, rollbackErr := s.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
-			AccountId:        srcID.String(),
-			AmountAdjustment: amount,
-			Reference:        tx.ID.String(),
-			Description:      "ROLLBACK: Credit failed",
-		})
+		return nil, err
+	}
+	if !existing.SameRequest(tx) {
+		return nil, domain.ErrIdempotencyKeyReused
+	}
+	return existing, nil
+}
 
-		tx.Status = domain.StatusFailed
-		tx.Description = fmt.Sprintf("Credit failed: %v. Rollback success: %v", err, rollbackErr == nil)
-		_ = s.repo.UpdateStatus(ctx, tx.ID, domain.StatusFailed)
+// ResolveIBAN returns the ID of the account with the given IBAN. The IBAN is
+// validated first, so malformed numbers never reach the account service.
+func (s *TransactionService) ResolveIBAN(ctx context.Context, number string) (uuid.UUID, error) {
+	if err := iban.Validate(number); err != nil {
+		return uuid.Nil, fmt.Errorf("%w: %v", domain.ErrInvalidIBAN, err)
+	}
 
-		return tx, fmt.Errorf("credit failed, attempt rollback: %w", err)
+	res, err := s.accountClient.GetAccountByNumber(ctx, &accountpb.GetAccountByNumberRequest{
+		AccountNumber: iban.Normalize(number),
+	})
+	if status.Code(err) == codes.NotFound {
+		return uuid.Nil, fmt.Errorf("%w: %v", domain.ErrUnknownIBAN, err)
+	}
+	if err != nil {
+		return uuid.Nil, err
 	}
 
-	// Step 3: Success
-	tx.Status = domain.StatusCompleted
-	if err := s.repo.UpdateStatus(ctx, tx.ID, domain.StatusCompleted); err != nil {
-		return tx, err
+	return uuid.Parse(res.Account.Id)
+}
+
+// GetTransaction returns the transaction. When viewedBy is set the customer
+// must be able to view the transactions of one of its accounts.
+func (s *TransactionService) GetTransaction(ctx context.Context, id, viewedBy uuid.UUID) (*domain.Transaction, error) {
+	tx, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if viewedBy == uuid.Nil {
+		return tx, nil
 	}
 
-	return tx, nil
+	for _, accountID := range []*uuid.UUID{tx.SourceAccountID, tx.DestinationAccountID} {
+		if accountID == nil {
+			continue
+		}
+		err := s.checkViewer(ctx, *accountID, viewedBy)
+		if err == nil {
+			return tx, nil
+		}
+		if !errors.Is(err, domain.ErrViewNotPermitted) {
+			return nil, err
+		}
+	}
+	return nil, domain.ErrViewNotPermitted
 }
 
-func (s *TransactionService) GetTransaction(ctx context.Context, id uuid.UUID) (*domain.Transaction, error) {
-	return s.repo.GetByID(ctx, id)
+// ListTransactions returns a page of the account's transactions, newest
+// first. Cursor is the NextCursor of the previous page, or empty for the
+// first page. When viewedBy is set the customer must be able to view the
+// account's transactions.
+func (s *TransactionService) ListTransactions(ctx context.Context, accountID uuid.UUID, cursor string, limit int, viewedBy uuid.UUID) (*SearchPage, error) {
+	if viewedBy != uuid.Nil {
+		if err := s.checkViewer(ctx, accountID, viewedBy); err != nil {
+			return nil, err
+		}
+	}
+	return s.SearchTransactions(ctx, SearchQuery{AccountID: &accountID, Cursor: cursor, Limit: limit})
 }
 
-func (s *TransactionService) ListTransactions(ctx context.Context, accountID uuid.UUID, page, pageSize int) ([]*domain.Transaction, int64, error) {
-	offset := (page - 1) * pageSize
-	return s.repo.ListByAccountID(ctx, accountID, pageSize, offset)
+// checkViewer refuses customers who may not view the account's transactions.
+func (s *TransactionService) checkViewer(ctx context.Context, accountID, customerID uuid.UUID) error {
+	res, err := s.accountClient.CheckHolderPermission(ctx, &accountpb.CheckHolderPermissionRequest{
+		AccountId:  accountID.String(),
+		CustomerId: customerID.String(),
+		Permission: "view_transactions",
+	})
+	if err != nil {
+		return err
+	}
+	if !res.Allowed {
+		return domain.ErrViewNotPermitted
+	}
+	return nil
 }
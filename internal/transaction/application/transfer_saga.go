@@ -0,0 +1,324 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// DefaultTransferRecoveryDelay is how long a transfer may stay pending before
+// recovery takes it over.
+const DefaultTransferRecoveryDelay = 30 * time.Second
+
+const recoveryBatchSize = 50
+
+// Legs of a transfer in the account service's ledger. Each is posted at most
+// once per transfer, which makes every call of the saga safe to repeat.
+const (
+	legHold           = "hold"
+	legCredit         = "credit"
+	legDebit          = "debit"
+	legCreditReversal = "credit_reversal"
+)
+
+// SetTransferRecoveryDelay replaces how long a transfer may stay pending
+// before recovery resumes or compensates it.
+func (s *TransactionService) SetTransferRecoveryDelay(d time.Duration) error {
+	if d <= 0 {
+		return fmt.Errorf("transfer recovery delay must be positive")
+	}
+	s.recoverAfter = d
+	return nil
+}
+
+// RecoverTransfers resumes or compensates every pending transfer whose lease
+// lapsed before now, typically because the service stopped part way through
+// it. Transfers made before their steps were recorded have no lease and are
+// left to operations. It returns how many transfers were settled.
+func (s *TransactionService) RecoverTransfers(ctx context.Context, now time.Time) (int, error) {
+	settled := 0
+	for {
+		batch, err := s.repo.ClaimStaleTransfers(ctx, now, s.recoverAfter, recoveryBatchSize)
+		if err != nil {
+			return settled, err
+		}
+		for _, tx := range batch {
+			if err := s.runTransfer(ctx, tx); err != nil {
+				log.Printf("transfer recovery: %s: %v", tx.ID, err)
+			}
+			if tx.Status != domain.StatusPending {
+				settled++
+			}
+		}
+		if len(batch) < recoveryBatchSize {
+			return settled, nil
+		}
+	}
+}
+
+// RunTransferRecovery recovers stuck transfers once at startup and then on
+// every tick until ctx is cancelled.
+func (s *TransactionService) RunTransferRecovery(ctx context.Context, interval time.Duration) {
+	sweep := func(now time.Time) {
+		n, err := s.RecoverTransfers(ctx, now)
+		if err != nil {
+			log.Printf("transfer recovery: %v", err)
+		}
+		if n > 0 {
+			log.Printf("transfer recovery: settled %d transfers", n)
+		}
+	}
+	sweep(time.Now())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			sweep(now)
+		}
+	}
+}
+
+// runTransfer drives the transfer saga on from wherever it stopped: hold the
+// funds on the source, credit the destination, then capture the hold. A
+// definitive failure compensates whatever was done and fails the transfer.
+// When the outcome of a call is unknown the transfer is left pending and
+// ErrTransferPending returned; recovery repeats the call later.
+func (s *TransactionService) runTransfer(ctx context.Context, tx *domain.Transaction) error {
+	steps, err := s.repo.ListTransferSteps(ctx, tx.ID)
+	if err != nil {
+		return fmt.Errorf("%w: %v", domain.ErrTransferPending, err)
+	}
+	saga := &transferSaga{service: s, tx: tx, steps: make(map[domain.SagaStep]*domain.TransferStep)}
+	for _, step := range steps {
+		saga.steps[step.Step] = step
+	}
+
+	for _, step := range []domain.SagaStep{domain.StepReserve, domain.StepCredit, domain.StepDebit} {
+		if saga.failed(step) {
+			return saga.compensate(ctx, fmt.Errorf("%s failed: %s", step, saga.steps[step].Error))
+		}
+	}
+
+	if err := saga.run(ctx, domain.StepReserve, saga.reserve); err != nil {
+		return saga.fail(ctx, "debit failed", err)
+	}
+	if err := saga.run(ctx, domain.StepCredit, saga.credit); err != nil {
+		return saga.fail(ctx, "credit failed", err)
+	}
+	if !saga.done(domain.StepDebit) {
+		if err := saga.run(ctx, domain.StepCapture, saga.capture); err != nil {
+			if inDoubt(err) {
+				return pending(err)
+			}
+			// The hold lapsed before it could be captured; debit the source
+			// outright instead
+			if err := saga.run(ctx, domain.StepDebit, saga.debit); err != nil {
+				return saga.fail(ctx, "debit failed", err)
+			}
+		}
+	}
+
+	return saga.finish(ctx, domain.StatusCompleted, nil)
+}
+
+type transferSaga struct {
+	service *TransactionService
+	tx      *domain.Transaction
+	steps   map[domain.SagaStep]*domain.TransferStep
+}
+
+func (g *transferSaga) done(step domain.SagaStep) bool {
+	return g.steps[step] != nil && g.steps[step].Status == domain.StepDone
+}
+
+func (g *transferSaga) failed(step domain.SagaStep) bool {
+	return g.steps[step] != nil && g.steps[step].Status == domain.StepFailed
+}
+
+// run performs the step unless it is already done, recording it as started
+// before the call so a crash leaves a trace of it.
+func (g *transferSaga) run(ctx context.Context, name domain.SagaStep, call func(context.Context) (*uuid.UUID, error)) error {
+	if g.done(name) {
+		return nil
+	}
+	step := g.steps[name]
+	if step == nil {
+		step = &domain.TransferStep{TransactionID: g.tx.ID, Step: name}
+		g.steps[name] = step
+	}
+
+	step.Status = domain.StepStarted
+	step.Attempts++
+	if err := g.service.repo.SaveTransferStep(ctx, step); err != nil {
+		return pending(err)
+	}
+
+	reservationID, err := call(ctx)
+	if err != nil {
+		if !inDoubt(err) {
+			step.Status = domain.StepFailed
+		}
+		step.Error = err.Error()
+		_ = g.service.repo.SaveTransferStep(ctx, step)
+		return err
+	}
+
+	step.Status = domain.StepDone
+	step.Error = ""
+	if reservationID != nil {
+		step.ReservationID = reservationID
+	}
+	if err := g.service.repo.SaveTransferStep(ctx, step); err != nil {
+		return pending(err)
+	}
+	return nil
+}
+
+// fail compensates after a forward step failed for good, or leaves the
+// transfer pending when the step's outcome is unknown.
+func (g *transferSaga) fail(ctx context.Context, what string, err error) error {
+	if inDoubt(err) {
+		return pending(err)
+	}
+	return g.compensate(ctx, fmt.Errorf("%s: %w", what, err))
+}
+
+// compensate takes back the credit and drops the hold, then fails the
+// transfer with cause. A compensation that cannot be made leaves the transfer
+// pending, so recovery keeps trying.
+func (g *transferSaga) compensate(ctx context.Context, cause error) error {
+	if g.done(domain.StepCredit) {
+		if err := g.run(ctx, domain.StepReverseCredit, g.reverseCredit); err != nil {
+			return pending(fmt.Errorf("reversing credit after %v: %v", cause, err))
+		}
+	}
+	if g.done(domain.StepReserve) {
+		if err := g.run(ctx, domain.StepRelease, g.release); err != nil {
+			return pending(fmt.Errorf("releasing hold after %v: %v", cause, err))
+		}
+	}
+
+	if err := g.finish(ctx, domain.StatusFailed, cause); err != nil {
+		return err
+	}
+	return cause
+}
+
+func (g *transferSaga) finish(ctx context.Context, status domain.TransactionStatus, cause error) error {
+	g.tx.Status = status
+	g.tx.LeaseUntil = nil
+	if cause != nil {
+		g.tx.Description = cause.Error()
+	}
+	if err := g.service.repo.Update(ctx, g.tx); err != nil {
+		g.tx.Status = domain.StatusPending
+		return pending(err)
+	}
+	return nil
+}
+
+func (g *transferSaga) reservationID() string {
+	if step := g.steps[domain.StepReserve]; step != nil && step.ReservationID != nil {
+		return step.ReservationID.String()
+	}
+	return ""
+}
+
+func (g *transferSaga) reserve(ctx context.Context) (*uuid.UUID, error) {
+	res, err := g.service.accountClient.ReserveFunds(ctx, &accountpb.ReserveFundsRequest{
+		AccountId:     g.tx.SourceAccountID.String(),
+		TransactionId: g.tx.ID.String(),
+		Amount:        g.tx.Amount,
+		Currency:      g.tx.Currency,
+		TtlSeconds:    int32(transferHoldTTL.Seconds()),
+		Leg:           legHold,
+	})
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.Parse(res.Reservation.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &id, nil
+}
+
+func (g *transferSaga) credit(ctx context.Context) (*uuid.UUID, error) {
+	return nil, g.adjust(ctx, *g.tx.DestinationAccountID, g.tx.Amount, legCredit,
+		fmt.Sprintf("Transfer from %s: %s", g.tx.SourceAccountID, g.tx.Description))
+}
+
+func (g *transferSaga) capture(ctx context.Context) (*uuid.UUID, error) {
+	_, err := g.service.accountClient.CaptureReservation(ctx, &accountpb.CaptureReservationRequest{
+		ReservationId: g.reservationID(),
+		Reference:     g.tx.ID.String(),
+		Description:   fmt.Sprintf("Transfer to %s: %s", g.tx.DestinationAccountID, g.tx.Description),
+	})
+	return nil, err
+}
+
+func (g *transferSaga) debit(ctx context.Context) (*uuid.UUID, error) {
+	return nil, g.adjust(ctx, *g.tx.SourceAccountID, -g.tx.Amount, legDebit,
+		fmt.Sprintf("Transfer to %s: %s", g.tx.DestinationAccountID, g.tx.Description))
+}
+
+func (g *transferSaga) reverseCredit(ctx context.Context) (*uuid.UUID, error) {
+	return nil, g.adjust(ctx, *g.tx.DestinationAccountID, -g.tx.Amount, legCreditReversal, "ROLLBACK: Transfer failed")
+}
+
+func (g *transferSaga) release(ctx context.Context) (*uuid.UUID, error) {
+	_, err := g.service.accountClient.ReleaseReservation(ctx, &accountpb.ReleaseReservationRequest{
+		ReservationId: g.reservationID(),
+		Reason:        "transfer_failed",
+	})
+	return nil, err
+}
+
+func (g *transferSaga) adjust(ctx context.Context, accountID uuid.UUID, amount int64, leg, description string) error {
+	_, err := g.service.accountClient.AdjustBalance(ctx, &accountpb.AdjustBalanceRequest{
+		AccountId:        accountID.String(),
+		AmountAdjustment: amount,
+		Currency:         g.tx.Currency,
+		Reference:        g.tx.ID.String(),
+		Description:      description,
+		TransactionId:    g.tx.ID.String(),
+		Leg:              leg,
+	})
+	return err
+}
+
+// inDoubt reports whether a call may or may not have taken effect, so it
+// must be repeated rather than compensated.
+func inDoubt(err error) bool {
+	if errors.Is(err, domain.ErrTransferPending) ||
+		errors.Is(err, context.Canceled) ||
+		errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.Canceled, codes.Aborted, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
+
+func pending(err error) error {
+	if errors.Is(err, domain.ErrTransferPending) {
+		return err
+	}
+	return fmt.Errorf("%w: %v", domain.ErrTransferPending, err)
+}
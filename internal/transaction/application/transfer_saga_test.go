@@ -0,0 +1,431 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"nordic-bank/internal/shared/idempotency"
+	"nordic-bank/internal/transaction/domain"
+	accountpb "nordic-bank/pkg/pb/account/v1"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (r *memoryRepository) ListTransferSteps(_ context.Context, transactionID uuid.UUID) ([]*domain.TransferStep, error) {
+	var steps []*domain.TransferStep
+	for _, step := range r.steps {
+		if step.TransactionID == transactionID {
+			copied := *step
+			steps = append(steps, &copied)
+		}
+	}
+	return steps, nil
+}
+
+func (r *memoryRepository) SaveTransferStep(_ context.Context, step *domain.TransferStep) error {
+	copied := *step
+	for i, existing := range r.steps {
+		if existing.TransactionID == step.TransactionID && existing.Step == step.Step {
+			r.steps[i] = &copied
+			return nil
+		}
+	}
+	r.steps = append(r.steps, &copied)
+	return nil
+}
+
+func (r *memoryRepository) ClaimStaleTransfers(_ context.Context, now time.Time, lease time.Duration, limit int) ([]*domain.Transaction, error) {
+	var claimed []*domain.Transaction
+	for _, tx := range r.txs {
+		if len(claimed) == limit {
+			break
+		}
+		if tx.Type != domain.TypeTransfer || tx.Status != domain.StatusPending ||
+			tx.LeaseUntil == nil || !tx.LeaseUntil.Before(now) {
+			continue
+		}
+		until := now.Add(lease)
+		tx.LeaseUntil = &until
+		claimed = append(claimed, tx)
+	}
+	return claimed, nil
+}
+
+type fakeHold struct {
+	accountID string
+	amount    int64
+	status    string
+}
+
+// fakeBank plays the account service for transfers between its accounts. Like
+// the real one it posts each (transaction, leg) once, replays captures of a
+// consumed hold and ignores releases of holds that are no longer active.
+type fakeBank struct {
+	accountpb.AccountServiceClient
+	balances map[string]int64
+	legs     map[string]bool
+	holds    map[string]*fakeHold
+	frozen   map[string]bool
+	owners   map[string]string // Account to the customer allowed to transfer from and view it
+	ibans    map[string]string // IBAN to account
+
+	// faults make the next call of a method fail with Unavailable, either
+	// before it reaches the ledger or after it was applied, as when the
+	// response is lost or the caller crashes before recording it.
+	faults map[string]bool
+	// beforeCall runs ahead of each call, so tests can change the bank mid
+	// transfer.
+	beforeCall func(method string)
+}
+
+func newFakeBank(balances map[string]int64) *fakeBank {
+	return &fakeBank{
+		balances: balances,
+		legs:     map[string]bool{},
+		holds:    map[string]*fakeHold{},
+		frozen:   map[string]bool{},
+		owners:   map[string]string{},
+		ibans:    map[string]string{},
+		faults:   map[string]bool{},
+	}
+}
+
+// call applies the method's effect unless a fault stops it first.
+func (b *fakeBank) call(method string, apply func() error) error {
+	if b.beforeCall != nil {
+		b.beforeCall(method)
+	}
+	applied, ok := b.faults[method]
+	if !ok {
+		return apply()
+	}
+	delete(b.faults, method)
+	if applied {
+		if err := apply(); err != nil {
+			return err
+		}
+	}
+	return status.Error(codes.Unavailable, "connection reset")
+}
+
+func (b *fakeBank) available(accountID string) int64 {
+	available := b.balances[accountID]
+	for _, hold := range b.holds {
+		if hold.accountID == accountID && hold.status == "active" {
+			available -= hold.amount
+		}
+	}
+	return available
+}
+
+func (b *fakeBank) activeHolds() int {
+	n := 0
+	for _, hold := range b.holds {
+		if hold.status == "active" {
+			n++
+		}
+	}
+	return n
+}
+
+func (b *fakeBank) ReserveFunds(_ context.Context, in *accountpb.ReserveFundsRequest, _ ...grpc.CallOption) (*accountpb.ReserveFundsResponse, error) {
+	id := in.TransactionId + ":" + in.Leg
+	err := b.call("ReserveFunds", func() error {
+		if b.holds[id] != nil {
+			return nil
+		}
+		if b.available(in.AccountId) < in.Amount {
+			return status.Error(codes.FailedPrecondition, "insufficient funds")
+		}
+		b.holds[id] = &fakeHold{accountID: in.AccountId, amount: in.Amount, status: "active"}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &accountpb.ReserveFundsResponse{Reservation: &accountpb.FundReservation{Id: uuid.NewSHA1(uuid.Nil, []byte(id)).String()}}, nil
+}
+
+func (b *fakeBank) hold(reservationID string) *fakeHold {
+	for id, hold := range b.holds {
+		if uuid.NewSHA1(uuid.Nil, []byte(id)).String() == reservationID {
+			return hold
+		}
+	}
+	return nil
+}
+
+func (b *fakeBank) CaptureReservation(_ context.Context, in *accountpb.CaptureReservationRequest, _ ...grpc.CallOption) (*accountpb.CaptureReservationResponse, error) {
+	err := b.call("CaptureReservation", func() error {
+		hold := b.hold(in.ReservationId)
+		switch {
+		case hold == nil:
+			return status.Error(codes.NotFound, "reservation not found")
+		case hold.status == "consumed":
+			return nil
+		case hold.status != "active":
+			return status.Error(codes.FailedPrecondition, "reservation is "+hold.status)
+		}
+		hold.status = "consumed"
+		b.balances[hold.accountID] -= hold.amount
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &accountpb.CaptureReservationResponse{}, nil
+}
+
+func (b *fakeBank) ReleaseReservation(_ context.Context, in *accountpb.ReleaseReservationRequest, _ ...grpc.CallOption) (*accountpb.ReleaseReservationResponse, error) {
+	err := b.call("ReleaseReservation", func() error {
+		if hold := b.hold(in.ReservationId); hold != nil && hold.status == "active" {
+			hold.status = "released"
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &accountpb.ReleaseReservationResponse{}, nil
+}
+
+func (b *fakeBank) AdjustBalance(_ context.Context, in *accountpb.AdjustBalanceRequest, _ ...grpc.CallOption) (*accountpb.AdjustBalanceResponse, error) {
+	err := b.call("AdjustBalance:"+in.Leg, func() error {
+		key := in.TransactionId + ":" + in.Leg
+		if b.legs[key] {
+			return nil
+		}
+		if b.frozen[in.AccountId] {
+			return status.Error(codes.FailedPrecondition, "account is frozen")
+		}
+		if b.available(in.AccountId)+in.AmountAdjustment < 0 {
+			return status.Error(codes.FailedPrecondition, "insufficient funds")
+		}
+		b.legs[key] = true
+		b.balances[in.AccountId] += in.AmountAdjustment
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &accountpb.AdjustBalanceResponse{}, nil
+}
+
+func newTransferTestService() (*TransactionService, *memoryRepository, *fakeBank, uuid.UUID, uuid.UUID) {
+	src, dst := uuid.New(), uuid.New()
+	bank := newFakeBank(map[string]int64{src.String(): 100_000, dst.String(): 0})
+	repo := newMemoryRepository()
+	return NewTransactionService(repo, bank), repo, bank, src, dst
+}
+
+// recoverLater runs recovery once the transfer's lease has lapsed.
+func recoverLater(t *testing.T, service *TransactionService) {
+	t.Helper()
+	n, err := service.RecoverTransfers(context.Background(), time.Now().Add(2*DefaultTransferRecoveryDelay))
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+}
+
+func TestTransfer_RecoversFromCrashAtEveryStep(t *testing.T) {
+	for _, method := range []string{"ReserveFunds", "AdjustBalance:" + legCredit, "CaptureReservation"} {
+		for _, applied := range []bool{false, true} {
+			name := method + "/before"
+			if applied {
+				name = method + "/after"
+			}
+			t.Run(name, func(t *testing.T) {
+				service, repo, bank, src, dst := newTransferTestService()
+				bank.faults[method] = applied
+
+				tx, err := service.CreateTransfer(context.Background(), src, dst, 25_000, "DKK", "", "rent", uuid.NewString(), BankInitiator)
+				require.ErrorIs(t, err, domain.ErrTransferPending)
+				assert.Equal(t, domain.StatusPending, tx.Status)
+
+				// Nothing happens before the lease lapses
+				n, err := service.RecoverTransfers(context.Background(), time.Now())
+				require.NoError(t, err)
+				assert.Zero(t, n)
+
+				recoverLater(t, service)
+				assert.Equal(t, domain.StatusCompleted, repo.txs[tx.ID].Status)
+				assert.Nil(t, repo.txs[tx.ID].LeaseUntil)
+				assert.Equal(t, int64(75_000), bank.balances[src.String()])
+				assert.Equal(t, int64(25_000), bank.balances[dst.String()])
+				assert.Zero(t, bank.activeHolds())
+
+				// A settled transfer is not picked up again
+				n, err = service.RecoverTransfers(context.Background(), time.Now().Add(time.Hour))
+				require.NoError(t, err)
+				assert.Zero(t, n)
+			})
+		}
+	}
+}
+
+func TestTransfer_CreditFailureIsCompensated(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	bank.frozen[dst.String()] = true
+
+	tx, err := service.CreateTransfer(context.Background(), src, dst, 25_000, "DKK", "", "rent", uuid.NewString(), BankInitiator)
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrTransferPending)
+	assert.Equal(t, domain.StatusFailed, repo.txs[tx.ID].Status)
+	assert.Contains(t, repo.txs[tx.ID].Description, "credit failed")
+	assert.Equal(t, int64(100_000), bank.available(src.String()))
+	assert.Zero(t, bank.activeHolds())
+}
+
+func TestTransfer_CrashDuringCompensationIsRecovered(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	bank.frozen[dst.String()] = true
+	bank.faults["ReleaseReservation"] = false
+
+	tx, err := service.CreateTransfer(context.Background(), src, dst, 25_000, "DKK", "", "rent", uuid.NewString(), BankInitiator)
+	require.ErrorIs(t, err, domain.ErrTransferPending)
+	assert.Equal(t, 1, bank.activeHolds())
+
+	recoverLater(t, service)
+	assert.Equal(t, domain.StatusFailed, repo.txs[tx.ID].Status)
+	assert.Equal(t, int64(100_000), bank.available(src.String()))
+	assert.Zero(t, bank.activeHolds())
+}
+
+func TestTransfer_LapsedHoldFallsBackToDebit(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	bank.beforeCall = func(method string) {
+		if method == "CaptureReservation" {
+			for _, hold := range bank.holds {
+				hold.status = "expired"
+			}
+		}
+	}
+
+	tx, err := service.CreateTransfer(context.Background(), src, dst, 25_000, "DKK", "", "rent", uuid.NewString(), BankInitiator)
+	require.NoError(t, err)
+	assert.Equal(t, domain.StatusCompleted, repo.txs[tx.ID].Status)
+	assert.Equal(t, int64(75_000), bank.balances[src.String()])
+	assert.Equal(t, int64(25_000), bank.balances[dst.String()])
+}
+
+func TestTransfer_LapsedHoldWithoutFundsIsReversed(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	bank.beforeCall = func(method string) {
+		if method == "CaptureReservation" {
+			for _, hold := range bank.holds {
+				hold.status = "expired"
+			}
+			// The source was emptied once the hold had gone
+			bank.balances[src.String()] = 10_000
+		}
+	}
+	bank.faults["AdjustBalance:"+legCreditReversal] = true
+
+	tx, err := service.CreateTransfer(context.Background(), src, dst, 25_000, "DKK", "", "rent", uuid.NewString(), BankInitiator)
+	require.ErrorIs(t, err, domain.ErrTransferPending)
+
+	bank.beforeCall = nil
+	recoverLater(t, service)
+	assert.Equal(t, domain.StatusFailed, repo.txs[tx.ID].Status)
+	assert.Equal(t, int64(10_000), bank.balances[src.String()])
+	assert.Zero(t, bank.balances[dst.String()])
+	assert.Zero(t, bank.activeHolds())
+}
+
+func TestCreateTransfer_IdempotencyKeysAreScopedToTheCaller(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	alice := idempotency.WithScope(context.Background(), "alice")
+	bob := idempotency.WithScope(context.Background(), "bob")
+
+	first, err := service.CreateTransfer(alice, src, dst, 10_000, "DKK", "", "rent", "k1", BankInitiator)
+	require.NoError(t, err)
+
+	again, err := service.CreateTransfer(alice, src, dst, 10_000, "DKK", "", "rent", "k1", BankInitiator)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID, again.ID)
+
+	_, err = service.CreateTransfer(alice, src, dst, 20_000, "DKK", "", "rent", "k1", BankInitiator)
+	assert.ErrorIs(t, err, domain.ErrIdempotencyKeyReused)
+
+	other, err := service.CreateTransfer(bob, src, dst, 20_000, "DKK", "", "rent", "k1", BankInitiator)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.ID, other.ID)
+	assert.Len(t, repo.txs, 2)
+	assert.Equal(t, int64(70_000), bank.balances[src.String()])
+}
+
+// keyLookupFails is a repository that cannot look up idempotency keys.
+type keyLookupFails struct {
+	*memoryRepository
+}
+
+func (r keyLookupFails) GetByIdempotencyKey(context.Context, string, string, time.Time) (*domain.Transaction, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestCreateTransfer_FailedKeyLookupIsNotAFirstAttempt(t *testing.T) {
+	_, repo, bank, src, dst := newTransferTestService()
+	service := NewTransactionService(keyLookupFails{repo}, bank)
+
+	_, err := service.CreateTransfer(context.Background(), src, dst, 10_000, "DKK", "", "rent", "k1", BankInitiator)
+	assert.EqualError(t, err, "connection refused")
+	assert.Empty(t, repo.txs)
+	assert.Equal(t, int64(100_000), bank.balances[src.String()])
+}
+
+func (b *fakeBank) CheckHolderPermission(_ context.Context, in *accountpb.CheckHolderPermissionRequest, _ ...grpc.CallOption) (*accountpb.CheckHolderPermissionResponse, error) {
+	return &accountpb.CheckHolderPermissionResponse{Allowed: b.owners[in.AccountId] == in.CustomerId}, nil
+}
+
+func (b *fakeBank) GetAccountByNumber(_ context.Context, in *accountpb.GetAccountByNumberRequest, _ ...grpc.CallOption) (*accountpb.GetAccountByNumberResponse, error) {
+	var res *accountpb.GetAccountByNumberResponse
+	err := b.call("GetAccountByNumber", func() error {
+		id, ok := b.ibans[in.AccountNumber]
+		if !ok {
+			return status.Error(codes.NotFound, "account not found")
+		}
+		res = &accountpb.GetAccountByNumberResponse{Account: &accountpb.Account{Id: id}}
+		return nil
+	})
+	return res, err
+}
+
+func TestResolveIBAN_OnlyNotFoundIsAnUnknownIBAN(t *testing.T) {
+	service, _, bank, _, dst := newTransferTestService()
+	bank.ibans["DK5000400440116243"] = dst.String()
+
+	id, err := service.ResolveIBAN(context.Background(), "DK50 0040 0440 1162 43")
+	require.NoError(t, err)
+	assert.Equal(t, dst, id)
+
+	_, err = service.ResolveIBAN(context.Background(), "DK9520000123456789")
+	assert.ErrorIs(t, err, domain.ErrUnknownIBAN)
+
+	// An outage is not the customer's mistake
+	bank.faults["GetAccountByNumber"] = false
+	_, err = service.ResolveIBAN(context.Background(), "DK5000400440116243")
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.NotErrorIs(t, err, domain.ErrUnknownIBAN)
+}
+
+func TestCreateTransfer_RefusesCallersWithoutPermission(t *testing.T) {
+	service, repo, bank, src, dst := newTransferTestService()
+	owner := uuid.New()
+	bank.owners[src.String()] = owner.String()
+
+	for _, by := range []Initiator{{}, CustomerInitiator(uuid.New()), CustomerInitiator(uuid.Nil)} {
+		_, err := service.CreateTransfer(context.Background(), src, dst, 10_000, "DKK", "", "rent", uuid.NewString(), by)
+		assert.ErrorIs(t, err, domain.ErrTransferNotPermitted)
+	}
+	assert.Empty(t, repo.txs)
+
+	_, err := service.CreateTransfer(context.Background(), src, dst, 10_000, "DKK", "", "rent", uuid.NewString(), CustomerInitiator(owner))
+	require.NoError(t, err)
+	assert.Equal(t, int64(90_000), bank.balances[src.String()])
+}
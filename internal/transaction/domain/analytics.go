@@ -0,0 +1,66 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Flow is the money that came into and went out of an account over a day or
+// longer. Only completed transactions count.
+type Flow struct {
+	Start   time.Time
+	Inflow  int64
+	Outflow int64
+	Count   int64
+}
+
+type FlowPeriod string
+
+const (
+	PeriodDay   FlowPeriod = "day"
+	PeriodWeek  FlowPeriod = "week" // Starting Monday, as in ISO 8601
+	PeriodMonth FlowPeriod = "month"
+)
+
+// Start returns the UTC start of the period holding t.
+func (p FlowPeriod) Start(t time.Time) time.Time {
+	y, m, d := t.UTC().Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	switch p {
+	case PeriodWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case PeriodMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	}
+	return day
+}
+
+// Next returns the start of the period after the one starting at start.
+func (p FlowPeriod) Next(start time.Time) time.Time {
+	switch p {
+	case PeriodWeek:
+		return start.AddDate(0, 0, 7)
+	case PeriodMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+type CounterpartyKind string
+
+const (
+	CounterpartyAccount  CounterpartyKind = "account"  // Another account in the bank
+	CounterpartyMerchant CounterpartyKind = "merchant" // Paid by card
+	CounterpartyCash     CounterpartyKind = "cash"     // Paid in or out at a till
+)
+
+// Counterparty sums an account's completed transactions with one other party.
+type Counterparty struct {
+	Kind      CounterpartyKind
+	AccountID *uuid.UUID
+	Name      string // The merchant for card payments
+	Inflow    int64
+	Outflow   int64
+	Count     int64
+}
@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message type indicators of the ISO 8583 messages the card authorizer
+// understands. An authorization request for a retrieval reference number that
+// is already authorized is an incremental authorization.
+const (
+	MTIAuthorization = "0100"
+	MTIClearing      = "0220"
+	MTIReversal      = "0400"
+)
+
+// Processing codes (ISO 8583 field 3, transaction type digits)
+const (
+	ProcessingPurchase       = "00"
+	ProcessingCashWithdrawal = "01"
+)
+
+// Response codes (ISO 8583 field 39)
+const (
+	ResponseApproved           = "00"
+	ResponseDoNotHonour        = "05"
+	ResponseInvalidTransaction = "12"
+	ResponseInvalidAmount      = "13"
+	ResponseInvalidCard        = "14"
+	ResponseOriginalNotFound   = "25"
+	ResponseInsufficientFunds  = "51"
+	ResponseExpiredCard        = "54"
+	ResponseIncorrectPIN       = "55"
+	ResponseNotPermitted       = "57"
+	ResponseExceedsLimit       = "61"
+	ResponseRestrictedCard     = "62"
+	ResponsePINTriesExceeded   = "75"
+	ResponseInactiveCard       = "78"
+	ResponseSystemError        = "96"
+)
+
+type CardChannel string
+
+const (
+	CardChannelPOS CardChannel = "pos"
+	CardChannelATM CardChannel = "atm"
+)
+
+type AuthorizationStatus string
+
+const (
+	AuthorizationApproved AuthorizationStatus = "approved"
+	AuthorizationReversed AuthorizationStatus = "reversed"
+	AuthorizationCleared  AuthorizationStatus = "cleared"
+)
+
+// CardAuthorization follows a card payment from the first authorization to
+// its clearing or reversal. All messages about the payment carry the same
+// retrieval reference number (RRN).
+type CardAuthorization struct {
+	ID                   uuid.UUID           `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TransactionID        uuid.UUID           `gorm:"type:uuid;not null;index"`
+	CardID               uuid.UUID           `gorm:"type:uuid;not null;index"`
+	AccountID            uuid.UUID           `gorm:"type:uuid;not null"`
+	RRN                  string              `gorm:"column:rrn;size:12;not null;uniqueIndex"`
+	AuthCode             string              `gorm:"size:6;not null"`
+	Channel              CardChannel         `gorm:"size:10;not null"`
+	MerchantName         string              `gorm:"size:255"`
+	MerchantCategoryCode string              `gorm:"size:4"`
+	Currency             string              `gorm:"size:3;not null"`
+	AuthorizedAmount     int64               `gorm:"not null"` // Sum of all holds, minor units
+	ClearedAmount        int64               `gorm:"not null;default:0"`
+	Status               AuthorizationStatus `gorm:"size:20;not null;index"`
+
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP;index"`
+	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (CardAuthorization) TableName() string {
+	return "transaction.card_authorizations"
+}
+
+// CardHold is one fund reservation placed for an authorization. Incremental
+// authorizations add further holds.
+type CardHold struct {
+	ID              uuid.UUID `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	AuthorizationID uuid.UUID `gorm:"type:uuid;not null;index"`
+	ReservationID   uuid.UUID `gorm:"type:uuid;not null"`
+	Amount          int64     `gorm:"not null"`
+	CreatedAt       time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (CardHold) TableName() string {
+	return "transaction.card_holds"
+}
+
+// CardMessage logs every scheme message with the answer it got. A message is
+// identified by its RRN, system trace audit number (STAN) and type, so a
+// retransmission is answered from the log instead of being processed twice.
+type CardMessage struct {
+	ID              uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	MTI             string     `gorm:"column:mti;size:4;not null;uniqueIndex:idx_card_message"`
+	STAN            string     `gorm:"column:stan;size:6;not null;uniqueIndex:idx_card_message"`
+	RRN             string     `gorm:"column:rrn;size:12;not null;uniqueIndex:idx_card_message"`
+	CardToken       string     `gorm:"size:255"`
+	Amount          int64      `gorm:"not null;default:0"`
+	Currency        string     `gorm:"size:3"`
+	ResponseCode    string     `gorm:"size:2;not null"`
+	AuthCode        string     `gorm:"size:6"`
+	AuthorizationID *uuid.UUID `gorm:"type:uuid"`
+	CreatedAt       time.Time  `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (CardMessage) TableName() string {
+	return "transaction.card_messages"
+}
@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TillStatus string
+
+const (
+	TillOpen        TillStatus = "open"
+	TillBalanced    TillStatus = "balanced"
+	TillDiscrepancy TillStatus = "discrepancy"
+)
+
+// TillSession is one teller's shift at a cash drawer, from the float counted
+// in when the till is opened to the cash counted out when it is closed. Cash
+// deposits and withdrawals are booked against the teller's open session.
+type TillSession struct {
+	ID           uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	BranchCode   string     `gorm:"size:20;not null"`
+	TillID       string     `gorm:"size:20;not null"`
+	TellerID     uuid.UUID  `gorm:"type:uuid;not null;index"`
+	Currency     string     `gorm:"size:3;not null"`
+	OpeningFloat int64      `gorm:"not null"` // Cash in the drawer at opening, minor units
+	Status       TillStatus `gorm:"size:20;not null;index"`
+
+	// Filled in when the till is closed
+	CountedCash  *int64
+	ExpectedCash *int64 // Opening float plus deposits less withdrawals
+	Variance     *int64 // Counted less expected; negative when cash is short
+	Note         string `gorm:"type:text"`
+
+	OpenedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	ClosedAt *time.Time
+}
+
+func (TillSession) TableName() string {
+	return "transaction.till_sessions"
+}
+
+// TillTotals sums the completed cash postings of a till session.
+type TillTotals struct {
+	Deposits        int64
+	DepositCount    int64
+	Withdrawals     int64
+	WithdrawalCount int64
+}
+
+// Expected is the cash the drawer should hold given the session's postings.
+func (s *TillSession) Expected(totals TillTotals) int64 {
+	return s.OpeningFloat + totals.Deposits - totals.Withdrawals
+}
+
+// Close records the counted cash against what the postings say the drawer
+// should hold.
+func (s *TillSession) Close(counted int64, totals TillTotals, note string, now time.Time) {
+	expected := s.Expected(totals)
+	variance := counted - expected
+
+	s.CountedCash = &counted
+	s.ExpectedCash = &expected
+	s.Variance = &variance
+	s.Note = note
+	s.ClosedAt = &now
+	s.Status = TillBalanced
+	if variance != 0 {
+		s.Status = TillDiscrepancy
+	}
+}
@@ -0,0 +1,64 @@
+package domain
+
+type TransactionCategory string
+
+const (
+	CategoryTransfer      TransactionCategory = "transfer"
+	CategoryCash          TransactionCategory = "cash"
+	CategoryGroceries     TransactionCategory = "groceries"
+	CategoryDining        TransactionCategory = "dining"
+	CategoryTransport     TransactionCategory = "transport"
+	CategoryTravel        TransactionCategory = "travel"
+	CategoryUtilities     TransactionCategory = "utilities"
+	CategoryHealth        TransactionCategory = "health"
+	CategoryEntertainment TransactionCategory = "entertainment"
+	CategoryShopping      TransactionCategory = "shopping"
+	CategoryOther         TransactionCategory = "other"
+)
+
+// MCCRange maps a range of merchant category codes to a category.
+type MCCRange struct {
+	From, To string // Inclusive, four digits
+	Category TransactionCategory
+}
+
+// MCCCategories categorises card payments. The first range holding a code
+// wins, so narrow ranges come before the broad ones they sit in.
+var MCCCategories = []MCCRange{
+	{"3000", "3999", CategoryTravel}, // Airlines, hotels and car rental
+	{"4111", "4131", CategoryTransport},
+	{"4411", "4411", CategoryTravel},
+	{"4511", "4582", CategoryTravel},
+	{"4722", "4722", CategoryTravel},
+	{"4784", "4789", CategoryTransport},
+	{"4800", "4999", CategoryUtilities},
+	{"5411", "5499", CategoryGroceries},
+	{"5541", "5542", CategoryTransport}, // Fuel
+	{"5811", "5814", CategoryDining},
+	{"5912", "5912", CategoryHealth},
+	{"5200", "5999", CategoryShopping},
+	{"6010", "6011", CategoryCash},
+	{"7011", "7012", CategoryTravel},
+	{"7800", "7999", CategoryEntertainment},
+	{"8000", "8099", CategoryHealth},
+}
+
+// CategoryForMCC returns the category of card payments with the merchant
+// category code.
+func CategoryForMCC(mcc string) TransactionCategory {
+	for _, r := range MCCCategories {
+		if len(mcc) == 4 && mcc >= r.From && mcc <= r.To {
+			return r.Category
+		}
+	}
+	return CategoryOther
+}
+
+func (c TransactionCategory) Valid() bool {
+	switch c {
+	case CategoryTransfer, CategoryCash, CategoryGroceries, CategoryDining, CategoryTransport, CategoryTravel,
+		CategoryUtilities, CategoryHealth, CategoryEntertainment, CategoryShopping, CategoryOther:
+		return true
+	}
+	return false
+}
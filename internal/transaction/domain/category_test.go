@@ -0,0 +1,24 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCategoryForMCC(t *testing.T) {
+	for mcc, want := range map[string]TransactionCategory{
+		"5411": CategoryGroceries,
+		"5812": CategoryDining,
+		"5541": CategoryTransport, // Fuel, not shopping
+		"5912": CategoryHealth,
+		"5732": CategoryShopping,
+		"6011": CategoryCash,
+		"3058": CategoryTravel,
+		"9999": CategoryOther,
+		"":     CategoryOther,
+		"541":  CategoryOther,
+	} {
+		assert.Equal(t, want, CategoryForMCC(mcc), mcc)
+	}
+}
@@ -0,0 +1,32 @@
+package domain
+
+import "errors"
+
+var (
+	ErrInvalidIBAN = errors.New("invalid iban")
+	ErrUnknownIBAN = errors.New("no account with this iban")
+
+	ErrTransferNotPermitted = errors.New("customer may not make transfers from this account")
+	ErrViewNotPermitted     = errors.New("customer may not view this account's transactions")
+	ErrInvalidDateRange     = errors.New("invalid date range")
+	ErrInvalidFilter        = errors.New("invalid search filter")
+	ErrInvalidCursor        = errors.New("invalid page cursor")
+	ErrUnsupportedCurrency  = errors.New("currency is not supported")
+
+	ErrInvalidAmount   = errors.New("amount must be positive")
+	ErrInvalidAccounts = errors.New("accounts do not match the transaction type")
+
+	ErrTillNotOpen         = errors.New("teller has no open till")
+	ErrTillAlreadyOpen     = errors.New("till is already open")
+	ErrTillSessionNotFound = errors.New("till session not found")
+	ErrCurrencyMismatch    = errors.New("currency does not match the till")
+
+	ErrIdempotencyKeyReused = errors.New("idempotency key was already used for a different transaction")
+
+	ErrTransferPending = errors.New("transfer is still pending and will be completed or reversed by recovery")
+
+	ErrTransactionNotFound   = errors.New("transaction not found")
+	ErrInvalidCardMessage    = errors.New("invalid card message")
+	ErrAuthorizationNotFound = errors.New("card authorization not found")
+	ErrCardMessageNotFound   = errors.New("card message not found")
+)
@@ -2,14 +2,76 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type TransactionRepository interface {
+	// WithTx runs fn inside a single database transaction. The repository
+	// handed to fn is bound to that transaction; returning an error from fn
+	// rolls everything back.
+	WithTx(ctx context.Context, fn func(repo TransactionRepository) error) error
+
 	Create(ctx context.Context, tx *Transaction) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Transaction, error)
-	GetByIdempotencyKey(ctx context.Context, key string) (*Transaction, error)
-	ListByAccountID(ctx context.Context, accountID uuid.UUID, limit, offset int) ([]*Transaction, int64, error)
+	// GetByIdempotencyKey returns the caller's latest transaction made with
+	// the key since the given instant, or ErrTransactionNotFound.
+	GetByIdempotencyKey(ctx context.Context, scope, key string, since time.Time) (*Transaction, error)
+	// Search returns the transactions matching the filter, newest first.
+	Search(ctx context.Context, filter TransactionFilter) ([]*Transaction, error)
 	UpdateStatus(ctx context.Context, id uuid.UUID, status TransactionStatus) error
+	Update(ctx context.Context, tx *Transaction) error
+
+	// Card authorizations
+	CreateCardAuthorization(ctx context.Context, auth *CardAuthorization) error
+	// GetCardAuthorizationByRRN returns ErrAuthorizationNotFound when no
+	// authorization carries the RRN.
+	GetCardAuthorizationByRRN(ctx context.Context, rrn string) (*CardAuthorization, error)
+	// GetCardAuthorizationForUpdate loads the authorization and locks its row
+	// until the surrounding transaction ends. Only meaningful inside WithTx.
+	GetCardAuthorizationForUpdate(ctx context.Context, id uuid.UUID) (*CardAuthorization, error)
+	UpdateCardAuthorization(ctx context.Context, auth *CardAuthorization) error
+	CreateCardHold(ctx context.Context, hold *CardHold) error
+	// ListCardHolds returns the holds of an authorization, oldest first.
+	ListCardHolds(ctx context.Context, authorizationID uuid.UUID) ([]*CardHold, error)
+	// SumCardSpend adds up what the card has authorized or cleared since the
+	// given instant, in total and at ATMs. Reversed authorizations do not count.
+	SumCardSpend(ctx context.Context, cardID uuid.UUID, since time.Time) (total, atm int64, err error)
+	// GetCardMessage returns ErrCardMessageNotFound for messages not seen before.
+	GetCardMessage(ctx context.Context, rrn, stan, mti string) (*CardMessage, error)
+	CreateCardMessage(ctx context.Context, msg *CardMessage) error
+
+	// Analytics
+	// ListDailyFlows sums the account's completed transactions in the
+	// currency per UTC day, for days in [from, to) with any. Days come in
+	// order.
+	ListDailyFlows(ctx context.Context, accountID uuid.UUID, currency string, from, to time.Time) ([]Flow, error)
+	// TopCounterparties returns the parties the account moved the most money
+	// with in [from, to), largest first.
+	TopCounterparties(ctx context.Context, accountID uuid.UUID, currency string, from, to time.Time, limit int) ([]Counterparty, error)
+
+	// Transfer saga
+	ListTransferSteps(ctx context.Context, transactionID uuid.UUID) ([]*TransferStep, error)
+	// SaveTransferStep creates or replaces the step of the transfer.
+	SaveTransferStep(ctx context.Context, step *TransferStep) error
+	// ClaimStaleTransfers takes up to limit pending transfers whose lease
+	// lapsed before now and extends their lease by lease, so concurrent
+	// recoveries do not pick the same transfer.
+	ClaimStaleTransfers(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*Transaction, error)
+
+	// Till sessions
+	CreateTillSession(ctx context.Context, session *TillSession) error
+	// GetTillSession returns ErrTillSessionNotFound for unknown sessions.
+	GetTillSession(ctx context.Context, id uuid.UUID) (*TillSession, error)
+	// GetOpenTillSession returns the teller's open session, or ErrTillNotOpen.
+	GetOpenTillSession(ctx context.Context, tellerID uuid.UUID) (*TillSession, error)
+	// IsTillOpen reports whether any teller has the branch's till open.
+	IsTillOpen(ctx context.Context, branchCode, tillID string) (bool, error)
+	UpdateTillSession(ctx context.Context, session *TillSession) error
+	// SumTillPostings totals the session's completed deposits and withdrawals.
+	SumTillPostings(ctx context.Context, sessionID uuid.UUID) (TillTotals, error)
+	// ListTillTransactions returns the session's cash transactions, whatever
+	// their status, oldest first.
+	ListTillTransactions(ctx context.Context, sessionID uuid.UUID) ([]*Transaction, error)
 }
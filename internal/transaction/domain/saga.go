@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SagaStep string
+
+const (
+	StepReserve SagaStep = "reserve" // Hold the amount on the source
+	StepCredit  SagaStep = "credit"  // Credit the destination
+	StepCapture SagaStep = "capture" // Turn the hold into the debit
+	StepDebit   SagaStep = "debit"   // Debit the source directly once the hold has lapsed
+
+	// Compensations
+	StepReverseCredit SagaStep = "reverse_credit"
+	StepRelease       SagaStep = "release"
+)
+
+type StepStatus string
+
+const (
+	StepStarted StepStatus = "started"
+	StepDone    StepStatus = "done"
+	StepFailed  StepStatus = "failed"
+)
+
+// TransferStep records how far a transfer got, so one interrupted by a crash
+// can be resumed or compensated. A step left started may or may not have
+// reached the account service; its calls are idempotent, so it is repeated.
+type TransferStep struct {
+	ID            uuid.UUID  `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	TransactionID uuid.UUID  `gorm:"type:uuid;not null;uniqueIndex:idx_transfer_step"`
+	Step          SagaStep   `gorm:"size:20;not null;uniqueIndex:idx_transfer_step"`
+	Status        StepStatus `gorm:"size:10;not null"`
+	ReservationID *uuid.UUID `gorm:"type:uuid"` // Set by the reserve step
+	Error         string     `gorm:"type:text"`
+	Attempts      int        `gorm:"not null;default:0"`
+
+	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
+}
+
+func (TransferStep) TableName() string {
+	return "transaction.transfer_steps"
+}
@@ -0,0 +1,51 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Cursor is a position in a listing ordered by creation time and then by ID,
+// used for keyset pagination.
+type Cursor struct {
+	At time.Time
+	ID uuid.UUID
+}
+
+// TransactionFilter narrows a search of transactions, listed newest first.
+// AccountID matches either side of a transaction; with Counterparty as well
+// only transactions between the two match. Reference matches as a
+// case-insensitive prefix and Text is a web-search style query over the
+// description. Before continues a listing from where a previous page ended.
+type TransactionFilter struct {
+	From         *time.Time // Inclusive
+	To           *time.Time // Exclusive
+	MinAmount    *int64
+	MaxAmount    *int64
+	Type         TransactionType
+	Status       TransactionStatus
+	AccountID    *uuid.UUID
+	Counterparty *uuid.UUID
+	Category     TransactionCategory
+	Reference    string
+	Text         string
+	Before       *Cursor
+	Limit        int
+}
+
+func (t TransactionType) Valid() bool {
+	switch t {
+	case TypeTransfer, TypeDeposit, TypeWithdrawal, TypePayment:
+		return true
+	}
+	return false
+}
+
+func (s TransactionStatus) Valid() bool {
+	switch s {
+	case StatusPending, StatusCompleted, StatusFailed, StatusCancelled:
+		return true
+	}
+	return false
+}
@@ -25,16 +25,30 @@ const (
 )
 
 type Transaction struct {
-	ID                   uuid.UUID         `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
-	SourceAccountID      *uuid.UUID        `gorm:"type:uuid;index"`
-	DestinationAccountID *uuid.UUID        `gorm:"type:uuid;index"`
-	Amount               int64             `gorm:"not null"` // Smallest unit (e.g. øre)
-	Currency             string            `gorm:"size:3;not null"`
-	Type                 TransactionType   `gorm:"type:transaction.transaction_type;not null"`
-	Status               TransactionStatus `gorm:"type:transaction.transaction_status;default:'pending'"`
-	Reference            string            `gorm:"size:100"`
-	Description          string            `gorm:"type:text"`
-	IdempotencyKey       string            `gorm:"size:255;uniqueIndex"`
+	ID                   uuid.UUID           `gorm:"type:uuid;primaryKey;default:uuid_generate_v4()"`
+	SourceAccountID      *uuid.UUID          `gorm:"type:uuid;index"`
+	DestinationAccountID *uuid.UUID          `gorm:"type:uuid;index"`
+	Amount               int64               `gorm:"not null"` // Smallest unit (e.g. øre)
+	Currency             string              `gorm:"size:3;not null"`
+	Type                 TransactionType     `gorm:"type:transaction.transaction_type;not null"`
+	Status               TransactionStatus   `gorm:"type:transaction.transaction_status;default:'pending'"`
+	Reference            string              `gorm:"size:100"`
+	Description          string              `gorm:"type:text"`
+	Category             TransactionCategory `gorm:"size:20;index"`
+	IdempotencyKey       string              `gorm:"size:255;index:idx_transactions_idempotency_scope,priority:2"`
+	// IdempotencyScope is the caller the key belongs to; "" for internal
+	// callers.
+	IdempotencyScope string `gorm:"size:100;not null;default:'';index:idx_transactions_idempotency_scope,priority:1"`
+
+	// Cash desk details, set on deposits and withdrawals made at a till
+	TellerID      *uuid.UUID `gorm:"type:uuid"`
+	BranchCode    string     `gorm:"size:20"`
+	TillID        string     `gorm:"size:20"`
+	TillSessionID *uuid.UUID `gorm:"type:uuid;index"`
+
+	// LeaseUntil is when a pending transfer is handed to recovery if whoever
+	// is running it has not finished by then.
+	LeaseUntil *time.Time `gorm:"index"`
 
 	CreatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
 	UpdatedAt time.Time `gorm:"default:CURRENT_TIMESTAMP"`
@@ -43,3 +57,43 @@ type Transaction struct {
 func (Transaction) TableName() string {
 	return "transaction.transactions"
 }
+
+// SameRequest reports whether other asks for the same movement of money, so
+// a replayed idempotency key may return t for it.
+func (t *Transaction) SameRequest(other *Transaction) bool {
+	return t.Type == other.Type &&
+		t.Amount == other.Amount &&
+		t.Currency == other.Currency &&
+		sameAccount(t.SourceAccountID, other.SourceAccountID) &&
+		sameAccount(t.DestinationAccountID, other.DestinationAccountID)
+}
+
+func sameAccount(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// CheckAccounts applies the schema's valid_accounts rule: a deposit only has a
+// destination, a withdrawal only a source, and a transfer moves money between
+// two different accounts. Card payments settle to merchants outside the bank,
+// so a payment needs only its source.
+func (t *Transaction) CheckAccounts() error {
+	src, dst := t.SourceAccountID != nil, t.DestinationAccountID != nil
+	var ok bool
+	switch t.Type {
+	case TypeDeposit:
+		ok = !src && dst
+	case TypeWithdrawal:
+		ok = src && !dst
+	case TypeTransfer:
+		ok = src && dst && *t.SourceAccountID != *t.DestinationAccountID
+	case TypePayment:
+		ok = src
+	}
+	if !ok {
+		return ErrInvalidAccounts
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+
+	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
+	pb "nordic-bank/pkg/pb/transaction/v1"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *TransactionServiceServer) SearchTransactions(ctx context.Context, req *pb.SearchTransactionsRequest) (*pb.SearchTransactionsResponse, error) {
+	query, err := searchQuery(req.Filter)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	query.Cursor = req.Cursor
+	query.Limit = int(req.Limit)
+
+	page, err := s.service.SearchTransactions(ctx, query)
+	if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	txs := make([]*pb.Transaction, len(page.Transactions))
+	for i, tx := range page.Transactions {
+		txs[i] = mapTransactionToPb(tx)
+	}
+	return &pb.SearchTransactionsResponse{
+		Transactions: txs,
+		NextCursor:   page.NextCursor,
+	}, nil
+}
+
+func searchQuery(filter *pb.TransactionFilter) (application.SearchQuery, error) {
+	var query application.SearchQuery
+	if filter == nil {
+		return query, nil
+	}
+	if filter.From != nil {
+		from := filter.From.AsTime()
+		query.From = &from
+	}
+	if filter.To != nil {
+		to := filter.To.AsTime()
+		query.To = &to
+	}
+	if filter.AccountId != "" {
+		id, err := uuid.Parse(filter.AccountId)
+		if err != nil {
+			return query, errors.New("invalid account_id")
+		}
+		query.AccountID = &id
+	}
+	if filter.CounterpartyAccountId != "" {
+		id, err := uuid.Parse(filter.CounterpartyAccountId)
+		if err != nil {
+			return query, errors.New("invalid counterparty_account_id")
+		}
+		query.Counterparty = &id
+	}
+	query.MinAmount = filter.MinAmount
+	query.MaxAmount = filter.MaxAmount
+	query.Type = filter.Type
+	query.Status = filter.Status
+	query.Category = filter.Category
+	query.Reference = filter.Reference
+	query.Text = filter.Text
+	return query, nil
+}
@@ -2,13 +2,17 @@ package grpc
 
 import (
 	"context"
+	"errors"
 
+	sharedauth "nordic-bank/internal/shared/auth"
 	"nordic-bank/internal/transaction/application"
 	"nordic-bank/internal/transaction/domain"
 	commonpb "nordic-bank/pkg/pb/common/v1"
 	pb "nordic-bank/pkg/pb/transaction/v1"
 
 	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
@@ -26,28 +30,61 @@ func (s *TransactionServiceServer) CreateTransfer(ctx context.Context, req *pb.C
 	if err != nil {
 		return nil, err
 	}
-	dstID, err := uuid.Parse(req.DestinationAccountId)
+	var dstID uuid.UUID
+	if req.DestinationIban != "" {
+		dstID, err = s.service.ResolveIBAN(ctx, req.DestinationIban)
+	} else {
+		dstID, err = uuid.Parse(req.DestinationAccountId)
+	}
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := s.service.CreateTransfer(ctx, srcID, dstID, req.Amount.Amount, req.Amount.Currency, req.Reference, req.Description, req.IdempotencyKey)
+	by, err := initiator(ctx, req.InitiatedByCustomerId)
 	if err != nil {
 		return nil, err
 	}
 
+	tx, err := s.service.CreateTransfer(ctx, srcID, dstID, req.Amount.Amount, req.Amount.Currency, req.Reference, req.Description, req.IdempotencyKey, by)
+	// A pending transfer is returned as such; recovery settles it later
+	if errors.Is(err, domain.ErrTransferNotPermitted) {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil && !errors.Is(err, domain.ErrTransferPending) {
+		return nil, err
+	}
+
 	return &pb.CreateTransferResponse{
 		Transaction: mapTransactionToPb(tx),
 	}, nil
 }
 
+// initiator is who the authenticated caller makes a transfer for. Employees
+// and services act for the bank unless they name the customer who asked for
+// the transfer.
+func initiator(ctx context.Context, onBehalfOf string) (application.Initiator, error) {
+	claims, ok := sharedauth.ClaimsFrom(ctx)
+	if !ok || (claims.Role != "employee" && claims.Role != sharedauth.ServiceRole) {
+		return application.Initiator{}, status.Error(codes.PermissionDenied, domain.ErrTransferNotPermitted.Error())
+	}
+	if onBehalfOf == "" {
+		return application.BankInitiator, nil
+	}
+
+	id, err := uuid.Parse(onBehalfOf)
+	if err != nil {
+		return application.Initiator{}, status.Error(codes.InvalidArgument, "invalid initiated_by_customer_id")
+	}
+	return application.CustomerInitiator(id), nil
+}
+
 func (s *TransactionServiceServer) GetTransaction(ctx context.Context, req *pb.GetTransactionRequest) (*pb.GetTransactionResponse, error) {
 	id, err := uuid.Parse(req.TransactionId)
 	if err != nil {
 		return nil, err
 	}
 
-	tx, err := s.service.GetTransaction(ctx, id)
+	tx, err := s.service.GetTransaction(ctx, id, uuid.Nil)
 	if err != nil {
 		return nil, err
 	}
@@ -60,36 +97,49 @@ func (s *TransactionServiceServer) GetTransaction(ctx context.Context, req *pb.G
 func (s *TransactionServiceServer) ListTransactions(ctx context.Context, req *pb.ListTransactionsRequest) (*pb.ListTransactionsResponse, error) {
 	accountID, err := uuid.Parse(req.AccountId)
 	if err != nil {
-		return nil, err
+		return nil, status.Error(codes.InvalidArgument, "invalid account id")
 	}
 
-	page := int(req.Pagination.Page)
-	if page <= 0 {
-		page = 1
-	}
-	pageSize := int(req.Pagination.PageSize)
-	if pageSize <= 0 {
-		pageSize = 10
+	page, err := s.service.ListTransactions(ctx, accountID, req.Cursor, int(req.Limit), uuid.Nil)
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
 	}
-
-	txs, total, err := s.service.ListTransactions(ctx, accountID, page, pageSize)
 	if err != nil {
 		return nil, err
 	}
 
-	pbTxs := make([]*pb.Transaction, len(txs))
-	for i, tx := range txs {
+	pbTxs := make([]*pb.Transaction, len(page.Transactions))
+	for i, tx := range page.Transactions {
 		pbTxs[i] = mapTransactionToPb(tx)
 	}
 
 	return &pb.ListTransactionsResponse{
 		Transactions: pbTxs,
-		Pagination: &commonpb.PaginationResponse{
-			Page:       int32(page),
-			PageSize:   int32(pageSize),
-			TotalItems: int32(total),
-			TotalPages: int32((total + int64(pageSize) - 1) / int64(pageSize)),
-		},
+		NextCursor:   page.NextCursor,
+	}, nil
+}
+
+func (s *TransactionServiceServer) GetTransactionStats(ctx context.Context, req *pb.GetTransactionStatsRequest) (*pb.GetTransactionStatsResponse, error) {
+	accountID, err := uuid.Parse(req.AccountId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid account id")
+	}
+	if req.StartDate == nil || req.EndDate == nil {
+		return nil, status.Error(codes.InvalidArgument, "start_date and end_date are required")
+	}
+
+	stats, err := s.service.GetTransactionStats(ctx, accountID, req.StartDate.AsTime(), req.EndDate.AsTime())
+	if errors.Is(err, domain.ErrInvalidDateRange) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.GetTransactionStatsResponse{
+		TotalInflow:  &commonpb.Money{Amount: stats.Inflow, Currency: stats.Currency},
+		TotalOutflow: &commonpb.Money{Amount: stats.Outflow, Currency: stats.Currency},
+		Count:        int32(stats.Count),
 	}, nil
 }
 
@@ -116,6 +166,7 @@ func mapTransactionToPb(t *domain.Transaction) *pb.Transaction {
 		Reference:      t.Reference,
 		Description:    t.Description,
 		IdempotencyKey: t.IdempotencyKey,
+		Category:       string(t.Category),
 		CreatedAt:      timestamppb.New(t.CreatedAt),
 		UpdatedAt:      timestamppb.New(t.UpdatedAt),
 	}
@@ -0,0 +1,64 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// getAccountAnalytics charts an account's inflow and outflow. from and to are
+// dates and both are included; they default to the last 90 days.
+func (h *Handler) getAccountAnalytics(c *gin.Context) {
+	accountID, err := uuid.Parse(c.Param("accountId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account id"})
+		return
+	}
+
+	q := application.AnalyticsQuery{AccountID: accountID, Currency: c.Query("currency")}
+	if v := c.Query("from"); v != "" {
+		if q.From, err = time.Parse("2006-01-02", v); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "from must be a date (YYYY-MM-DD)"})
+			return
+		}
+	}
+	if v := c.Query("to"); v != "" {
+		to, err := time.Parse("2006-01-02", v)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "to must be a date (YYYY-MM-DD)"})
+			return
+		}
+		q.To = to.AddDate(0, 0, 1)
+	}
+	if v := c.Query("counterparties"); v != "" {
+		if q.Counterparties, err = strconv.Atoi(v); err != nil || q.Counterparties <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "counterparties must be a positive number"})
+			return
+		}
+	}
+
+	// Customers only see accounts they may view the transactions of
+	var ok bool
+	if q.ViewedBy, ok = viewer(c); !ok {
+		return
+	}
+
+	analytics, err := h.service.GetAccountAnalytics(c.Request.Context(), q)
+	switch {
+	case errors.Is(err, domain.ErrViewNotPermitted):
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+	case errors.Is(err, domain.ErrInvalidDateRange), errors.Is(err, domain.ErrUnsupportedCurrency):
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	case err != nil:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	default:
+		c.JSON(http.StatusOK, analytics)
+	}
+}
@@ -0,0 +1,33 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/gin-gonic/gin"
+)
+
+// processCardMessage takes a scheme message and answers it with a response
+// code. Declines are still 200: the scheme reads the outcome from the code.
+func (h *Handler) processCardMessage(c *gin.Context) {
+	var msg application.CardMessage
+	if err := c.ShouldBindJSON(&msg); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	res, err := h.service.ProcessCardMessage(c.Request.Context(), msg)
+	if errors.Is(err, domain.ErrInvalidCardMessage) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, res)
+}
@@ -0,0 +1,202 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// tellerID is the employee working the cash desk.
+func tellerID(c *gin.Context) (uuid.UUID, bool) {
+	id, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": "teller not identified"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+func respondCashError(c *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, domain.ErrInvalidAmount),
+		errors.Is(err, domain.ErrInvalidAccounts),
+		errors.Is(err, domain.ErrUnsupportedCurrency),
+		errors.Is(err, domain.ErrCurrencyMismatch),
+		errors.Is(err, domain.ErrInvalidIBAN):
+		status = http.StatusBadRequest
+	case errors.Is(err, domain.ErrUnknownIBAN),
+		errors.Is(err, domain.ErrTillSessionNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, domain.ErrIdempotencyKeyReused):
+		status = http.StatusUnprocessableEntity
+	case errors.Is(err, domain.ErrTillNotOpen),
+		errors.Is(err, domain.ErrTillAlreadyOpen):
+		status = http.StatusConflict
+	}
+	c.JSON(status, gin.H{"error": err.Error()})
+}
+
+func (h *Handler) openTill(c *gin.Context) {
+	teller, ok := tellerID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		BranchCode   string `json:"branch_code" binding:"required"`
+		TillID       string `json:"till_id" binding:"required"`
+		Currency     string `json:"currency" binding:"required"`
+		OpeningFloat int64  `json:"opening_float" binding:"gte=0"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	session, err := h.service.OpenTill(c.Request.Context(), teller, req.BranchCode, req.TillID, req.Currency, req.OpeningFloat)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, session)
+}
+
+// getTill shows the teller's open till with its postings so far.
+func (h *Handler) getTill(c *gin.Context) {
+	teller, ok := tellerID(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.service.GetTillReport(c.Request.Context(), teller)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+// closeTill balances the teller's drawer against the postings. A variance is
+// reported in the response, not refused.
+func (h *Handler) closeTill(c *gin.Context) {
+	teller, ok := tellerID(c)
+	if !ok {
+		return
+	}
+
+	var req struct {
+		CountedCash *int64 `json:"counted_cash" binding:"required"`
+		Note        string `json:"note"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := h.service.CloseTill(c.Request.Context(), teller, *req.CountedCash, req.Note)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+func (h *Handler) getTillSession(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("sessionId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid till session id"})
+		return
+	}
+
+	report, err := h.service.GetTillSessionReport(c.Request.Context(), id)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, report)
+}
+
+type cashRequest struct {
+	AccountID      string `json:"account_id" binding:"required_without=AccountIBAN"`
+	AccountIBAN    string `json:"account_iban"`
+	Amount         int64  `json:"amount" binding:"required,gt=0"`
+	Currency       string `json:"currency" binding:"required"`
+	Reference      string `json:"reference"`
+	Description    string `json:"description"`
+	IdempotencyKey string `json:"idempotency_key" binding:"required"`
+}
+
+func (h *Handler) createDeposit(c *gin.Context) {
+	req, ok := h.cashRequest(c)
+	if !ok {
+		return
+	}
+
+	tx, err := h.service.CreateDeposit(c.Request.Context(), req)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, tx)
+}
+
+func (h *Handler) createWithdrawal(c *gin.Context) {
+	req, ok := h.cashRequest(c)
+	if !ok {
+		return
+	}
+
+	tx, err := h.service.CreateWithdrawal(c.Request.Context(), req)
+	if err != nil {
+		respondCashError(c, err)
+		return
+	}
+	c.JSON(http.StatusCreated, tx)
+}
+
+// cashRequest reads a deposit or withdrawal. The account is given by ID or,
+// as on a paying-in slip, by IBAN.
+func (h *Handler) cashRequest(c *gin.Context) (application.CashRequest, bool) {
+	teller, ok := tellerID(c)
+	if !ok {
+		return application.CashRequest{}, false
+	}
+
+	var body cashRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return application.CashRequest{}, false
+	}
+
+	var accountID uuid.UUID
+	var err error
+	if body.AccountIBAN != "" {
+		accountID, err = h.service.ResolveIBAN(c.Request.Context(), body.AccountIBAN)
+		if err != nil {
+			respondCashError(c, err)
+			return application.CashRequest{}, false
+		}
+	} else {
+		accountID, err = uuid.Parse(body.AccountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid account_id"})
+			return application.CashRequest{}, false
+		}
+	}
+
+	return application.CashRequest{
+		AccountID:      accountID,
+		Amount:         body.Amount,
+		Currency:       body.Currency,
+		Reference:      body.Reference,
+		Description:    body.Description,
+		IdempotencyKey: body.IdempotencyKey,
+		TellerID:       teller,
+	}, true
+}
@@ -1,36 +1,72 @@
 package http
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 
+	sharedauth "nordic-bank/internal/shared/auth"
+	"nordic-bank/internal/shared/idempotency"
 	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
 )
 
 type Handler struct {
-	service *application.TransactionService
+	service       *application.TransactionService
+	jwtSecret     []byte
+	keys          *idempotency.Guard
+	cardSimulator bool
 }
 
-func NewHandler(service *application.TransactionService) *Handler {
-	return &Handler{service: service}
+func NewHandler(service *application.TransactionService, jwtSecret string, keys *idempotency.Guard) *Handler {
+	return &Handler{
+		service:   service,
+		jwtSecret: []byte(jwtSecret),
+		keys:      keys,
+	}
+}
+
+// EnableCardSimulator mounts the card network simulator, which authorizes
+// and clears real charges. Leave it off in production.
+func (h *Handler) EnableCardSimulator() {
+	h.cardSimulator = true
 }
 
 func (h *Handler) RegisterRoutes(router *gin.Engine) {
-	tx := router.Group("/api/v1/transactions")
+	tx := router.Group("/api/v1/transactions", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("customer", "employee"), h.keys.Middleware())
 	{
 		tx.POST("/transfer", h.createTransfer)
 		tx.GET("/:id", h.getTransaction)
 		tx.GET("/account/:accountId", h.listTransactions)
+		tx.GET("/account/:accountId/analytics", h.getAccountAnalytics)
 		// Support query parameter version for frontend compatibility
 		tx.GET("", h.listTransactionsByQuery)
 	}
+
+	router.GET("/api/v1/transactions/search", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"), h.searchTransactions)
+
+	if h.cardSimulator {
+		router.POST("/api/v1/card-authorizations", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"), h.processCardMessage)
+	}
+
+	desk := router.Group("/api/v1/cash-desk", sharedauth.AuthMiddleware(h.jwtSecret), sharedauth.RoleMiddleware("employee"), h.keys.Middleware())
+	{
+		desk.POST("/till", h.openTill)
+		desk.GET("/till", h.getTill)
+		desk.POST("/till/close", h.closeTill)
+		desk.GET("/tills/:sessionId", h.getTillSession)
+		desk.POST("/deposits", h.createDeposit)
+		desk.POST("/withdrawals", h.createWithdrawal)
+	}
 }
 
 type createTransferRequest struct {
 	SourceAccountID      string `json:"source_account_id" binding:"required"`
-	DestinationAccountID string `json:"destination_account_id" binding:"required"`
+	DestinationAccountID string `json:"destination_account_id" binding:"required_without=DestinationIBAN"`
+	DestinationIBAN      string `json:"destination_iban"`
 	Amount               int64  `json:"amount" binding:"required,gt=0"`
 	Currency             string `json:"currency" binding:"required"`
 	Reference            string `json:"reference"`
@@ -50,13 +86,57 @@ func (h *Handler) createTransfer(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid source_account_id"})
 		return
 	}
-	dstID, err := uuid.Parse(req.DestinationAccountID)
-	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid destination_account_id"})
-		return
+	var dstID uuid.UUID
+	if req.DestinationIBAN != "" {
+		dstID, err = h.service.ResolveIBAN(c.Request.Context(), req.DestinationIBAN)
+		switch {
+		case errors.Is(err, domain.ErrInvalidIBAN):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		case errors.Is(err, domain.ErrUnknownIBAN):
+			c.JSON(http.StatusNotFound, gin.H{"error": "no account with this destination_iban"})
+			return
+		case err != nil:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	} else {
+		dstID, err = uuid.Parse(req.DestinationAccountID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid destination_account_id"})
+			return
+		}
 	}
 
-	tx, err := h.service.CreateTransfer(c.Request.Context(), srcID, dstID, req.Amount, req.Currency, req.Reference, req.Description, req.IdempotencyKey)
+	// Customers are held to their permissions on the source account
+	by := application.BankInitiator
+	if c.GetString("role") == "customer" {
+		customerID, err := uuid.Parse(c.GetString("customerID"))
+		if err != nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": domain.ErrTransferNotPermitted.Error()})
+			return
+		}
+		by = application.CustomerInitiator(customerID)
+	}
+
+	tx, err := h.service.CreateTransfer(c.Request.Context(), srcID, dstID, req.Amount, req.Currency, req.Reference, req.Description, req.IdempotencyKey, by)
+	if errors.Is(err, domain.ErrTransferNotPermitted) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrUnsupportedCurrency) || errors.Is(err, domain.ErrInvalidAccounts) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrIdempotencyKeyReused) {
+		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrTransferPending) {
+		// Accepted, but the outcome is settled later by recovery
+		c.JSON(http.StatusAccepted, tx)
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
@@ -73,7 +153,15 @@ func (h *Handler) getTransaction(c *gin.Context) {
 		return
 	}
 
-	tx, err := h.service.GetTransaction(c.Request.Context(), id)
+	viewedBy, ok := viewer(c)
+	if !ok {
+		return
+	}
+	tx, err := h.service.GetTransaction(c.Request.Context(), id, viewedBy)
+	if errors.Is(err, domain.ErrViewNotPermitted) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusNotFound, gin.H{"error": "transaction not found"})
 		return
@@ -90,17 +178,55 @@ func (h *Handler) listTransactions(c *gin.Context) {
 		return
 	}
 
-	// Simple pagination for now
-	txs, total, err := h.service.ListTransactions(c.Request.Context(), accID, 1, 50)
+	h.respondTransactionPage(c, accID)
+}
+
+// respondTransactionPage answers with a page of the account's transactions,
+// 50 unless limit asks for up to 200. Pass the returned next_cursor as cursor
+// to get the next page.
+func (h *Handler) respondTransactionPage(c *gin.Context, accountID uuid.UUID) {
+	limit := 50
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n <= 0 || n > 200 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be between 1 and 200"})
+			return
+		}
+		limit = n
+	}
+
+	viewedBy, ok := viewer(c)
+	if !ok {
+		return
+	}
+	page, err := h.service.ListTransactions(c.Request.Context(), accountID, c.Query("cursor"), limit, viewedBy)
+	if errors.Is(err, domain.ErrViewNotPermitted) {
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+	if errors.Is(err, domain.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+	c.JSON(http.StatusOK, page)
+}
 
-	c.JSON(http.StatusOK, gin.H{
-		"transactions": txs,
-		"total":        total,
-	})
+// viewer returns the customer whose view of transactions must be checked,
+// or uuid.Nil for employees, who see every account.
+func viewer(c *gin.Context) (uuid.UUID, bool) {
+	if c.GetString("role") != "customer" {
+		return uuid.Nil, true
+	}
+	customerID, err := uuid.Parse(c.GetString("customerID"))
+	if err != nil {
+		c.JSON(http.StatusForbidden, gin.H{"error": domain.ErrViewNotPermitted.Error()})
+		return uuid.Nil, false
+	}
+	return customerID, true
 }
 
 // listTransactionsByQuery handles GET /transactions?account_id=xxx
@@ -117,15 +243,5 @@ func (h *Handler) listTransactionsByQuery(c *gin.Context) {
 		return
 	}
 
-	// Simple pagination for now
-	txs, total, err := h.service.ListTransactions(c.Request.Context(), accID, 1, 50)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	c.JSON(http.StatusOK, gin.H{
-		"transactions": txs,
-		"total":        total,
-	})
+	h.respondTransactionPage(c, accID)
 }
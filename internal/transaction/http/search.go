@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"nordic-bank/internal/transaction/application"
+	"nordic-bank/internal/transaction/domain"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// searchTransactions lets back-office staff search every transaction. Filters
+// are query parameters; q searches the descriptions. Pass the returned
+// next_cursor as cursor to get the next page.
+func (h *Handler) searchTransactions(c *gin.Context) {
+	var query application.SearchQuery
+	var ok bool
+	if query.From, ok = timeParam(c, "from", false); !ok {
+		return
+	}
+	if query.To, ok = timeParam(c, "to", true); !ok {
+		return
+	}
+	if query.MinAmount, ok = amountParam(c, "min_amount"); !ok {
+		return
+	}
+	if query.MaxAmount, ok = amountParam(c, "max_amount"); !ok {
+		return
+	}
+	if query.AccountID, ok = accountParam(c, "account_id"); !ok {
+		return
+	}
+	if query.Counterparty, ok = accountParam(c, "counterparty_account_id"); !ok {
+		return
+	}
+	if v := c.Query("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive number"})
+			return
+		}
+		query.Limit = limit
+	}
+	query.Type = c.Query("type")
+	query.Status = c.Query("status")
+	query.Category = c.Query("category")
+	query.Reference = c.Query("reference")
+	query.Text = c.Query("q")
+	query.Cursor = c.Query("cursor")
+
+	page, err := h.service.SearchTransactions(c.Request.Context(), query)
+	if errors.Is(err, domain.ErrInvalidFilter) || errors.Is(err, domain.ErrInvalidCursor) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, page)
+}
+
+// timeParam reads a date or an RFC 3339 time. A date given as the end of a
+// range includes that whole day.
+func timeParam(c *gin.Context, name string, end bool) (*time.Time, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	if t, err := time.Parse("2006-01-02", v); err == nil {
+		if end {
+			t = t.AddDate(0, 0, 1)
+		}
+		return &t, true
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", use YYYY-MM-DD or RFC 3339"})
+		return nil, false
+	}
+	return &t, true
+}
+
+func amountParam(c *gin.Context, name string) (*int64, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	amount, err := strconv.ParseInt(v, 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name + ", use minor units"})
+		return nil, false
+	}
+	return &amount, true
+}
+
+func accountParam(c *gin.Context, name string) (*uuid.UUID, bool) {
+	v := c.Query(name)
+	if v == "" {
+		return nil, true
+	}
+	id, err := uuid.Parse(v)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid " + name})
+		return nil, false
+	}
+	return &id, true
+}
@@ -25,7 +25,7 @@ CREATE TABLE account.accounts (
     minimum_balance DECIMAL(15, 2) DEFAULT 0.00,
     
     -- Interest (for savings accounts)
-    interest_rate DECIMAL(5, 4),  -- e.g., 0.0250 = 2.50%
+    interest_rate_bps BIGINT NOT NULL DEFAULT 0,  -- e.g., 250 = 2.50%
     interest_accrued DECIMAL(15, 2) DEFAULT 0.00,
     last_interest_calculation_date DATE,
     
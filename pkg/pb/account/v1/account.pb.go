@@ -23,31 +23,2566 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type CheckHolderPermissionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	Permission    string                 `protobuf:"bytes,3,opt,name=permission,proto3" json:"permission,omitempty"` // withdraw, transfer, view_balance, view_transactions
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckHolderPermissionRequest) Reset() {
+	*x = CheckHolderPermissionRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckHolderPermissionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHolderPermissionRequest) ProtoMessage() {}
+
+func (x *CheckHolderPermissionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHolderPermissionRequest.ProtoReflect.Descriptor instead.
+func (*CheckHolderPermissionRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CheckHolderPermissionRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *CheckHolderPermissionRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *CheckHolderPermissionRequest) GetPermission() string {
+	if x != nil {
+		return x.Permission
+	}
+	return ""
+}
+
+type CheckHolderPermissionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckHolderPermissionResponse) Reset() {
+	*x = CheckHolderPermissionResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckHolderPermissionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckHolderPermissionResponse) ProtoMessage() {}
+
+func (x *CheckHolderPermissionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckHolderPermissionResponse.ProtoReflect.Descriptor instead.
+func (*CheckHolderPermissionResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CheckHolderPermissionResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
 type AdjustBalanceRequest struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
 	AccountId        string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	AmountAdjustment int64                  `protobuf:"varint,2,opt,name=amount_adjustment,json=amountAdjustment,proto3" json:"amount_adjustment,omitempty"` // Positive for credit, negative for debit
 	Reference        string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
 	Description      string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Currency         string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                                // ISO 4217; the account's own currency or one of its sub-balances
+	ValueDate        string                 `protobuf:"bytes,6,opt,name=value_date,json=valueDate,proto3" json:"value_date,omitempty"`             // YYYY-MM-DD, when the money counts from; empty for today
+	TransactionId    string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // With leg, posts the adjustment at most once
+	Leg              string                 `protobuf:"bytes,8,opt,name=leg,proto3" json:"leg,omitempty"`
+	Force            bool                   `protobuf:"varint,9,opt,name=force,proto3" json:"force,omitempty"` // Debit even past the funds check, for settlements the bank must honour
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
-func (x *AdjustBalanceRequest) Reset() {
-	*x = AdjustBalanceRequest{}
-	mi := &file_account_v1_account_proto_msgTypes[0]
+func (x *AdjustBalanceRequest) Reset() {
+	*x = AdjustBalanceRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceRequest) ProtoMessage() {}
+
+func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceRequest.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *AdjustBalanceRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetAmountAdjustment() int64 {
+	if x != nil {
+		return x.AmountAdjustment
+	}
+	return 0
+}
+
+func (x *AdjustBalanceRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetValueDate() string {
+	if x != nil {
+		return x.ValueDate
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetLeg() string {
+	if x != nil {
+		return x.Leg
+	}
+	return ""
+}
+
+func (x *AdjustBalanceRequest) GetForce() bool {
+	if x != nil {
+		return x.Force
+	}
+	return false
+}
+
+type AdjustBalanceResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	NewBalance    *v1.Money              `protobuf:"bytes,1,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustBalanceResponse) Reset() {
+	*x = AdjustBalanceResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustBalanceResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustBalanceResponse) ProtoMessage() {}
+
+func (x *AdjustBalanceResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustBalanceResponse.ProtoReflect.Descriptor instead.
+func (*AdjustBalanceResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AdjustBalanceResponse) GetNewBalance() *v1.Money {
+	if x != nil {
+		return x.NewBalance
+	}
+	return nil
+}
+
+type FundReservation struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId     string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        *v1.Money              `protobuf:"bytes,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"` // active, released, consumed, expired
+	ReservedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=reserved_at,json=reservedAt,proto3" json:"reserved_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	ReleasedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=released_at,json=releasedAt,proto3" json:"released_at,omitempty"`
+	ReleaseReason string                 `protobuf:"bytes,9,opt,name=release_reason,json=releaseReason,proto3" json:"release_reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FundReservation) Reset() {
+	*x = FundReservation{}
+	mi := &file_account_v1_account_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FundReservation) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FundReservation) ProtoMessage() {}
+
+func (x *FundReservation) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FundReservation.ProtoReflect.Descriptor instead.
+func (*FundReservation) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *FundReservation) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FundReservation) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FundReservation) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *FundReservation) GetAmount() *v1.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *FundReservation) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *FundReservation) GetReservedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReservedAt
+	}
+	return nil
+}
+
+func (x *FundReservation) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *FundReservation) GetReleasedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ReleasedAt
+	}
+	return nil
+}
+
+func (x *FundReservation) GetReleaseReason() string {
+	if x != nil {
+		return x.ReleaseReason
+	}
+	return ""
+}
+
+type ReserveFundsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	TtlSeconds    int32                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Defaults to 15 minutes when zero
+	Currency      string                 `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`                        // ISO 4217; the account's own currency or one of its sub-balances
+	Leg           string                 `protobuf:"bytes,6,opt,name=leg,proto3" json:"leg,omitempty"`                                  // When set, a retry returns the hold already placed for this leg
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveFundsRequest) Reset() {
+	*x = ReserveFundsRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveFundsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveFundsRequest) ProtoMessage() {}
+
+func (x *ReserveFundsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveFundsRequest.ProtoReflect.Descriptor instead.
+func (*ReserveFundsRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReserveFundsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *ReserveFundsRequest) GetTtlSeconds() int32 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+func (x *ReserveFundsRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *ReserveFundsRequest) GetLeg() string {
+	if x != nil {
+		return x.Leg
+	}
+	return ""
+}
+
+type ReserveFundsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *FundReservation       `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReserveFundsResponse) Reset() {
+	*x = ReserveFundsResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReserveFundsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReserveFundsResponse) ProtoMessage() {}
+
+func (x *ReserveFundsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReserveFundsResponse.ProtoReflect.Descriptor instead.
+func (*ReserveFundsResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReserveFundsResponse) GetReservation() *FundReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type ReleaseReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationRequest) Reset() {
+	*x = ReleaseReservationRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationRequest) ProtoMessage() {}
+
+func (x *ReleaseReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ReleaseReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *ReleaseReservationRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ReleaseReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *FundReservation       `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseReservationResponse) Reset() {
+	*x = ReleaseReservationResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseReservationResponse) ProtoMessage() {}
+
+func (x *ReleaseReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseReservationResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseReservationResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *ReleaseReservationResponse) GetReservation() *FundReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+type CaptureReservationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ReservationId string                 `protobuf:"bytes,1,opt,name=reservation_id,json=reservationId,proto3" json:"reservation_id,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"` // Zero captures the full hold
+	Reference     string                 `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureReservationRequest) Reset() {
+	*x = CaptureReservationRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureReservationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureReservationRequest) ProtoMessage() {}
+
+func (x *CaptureReservationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureReservationRequest.ProtoReflect.Descriptor instead.
+func (*CaptureReservationRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CaptureReservationRequest) GetReservationId() string {
+	if x != nil {
+		return x.ReservationId
+	}
+	return ""
+}
+
+func (x *CaptureReservationRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CaptureReservationRequest) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *CaptureReservationRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type CaptureReservationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Reservation   *FundReservation       `protobuf:"bytes,1,opt,name=reservation,proto3" json:"reservation,omitempty"`
+	NewBalance    *v1.Money              `protobuf:"bytes,2,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CaptureReservationResponse) Reset() {
+	*x = CaptureReservationResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CaptureReservationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CaptureReservationResponse) ProtoMessage() {}
+
+func (x *CaptureReservationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CaptureReservationResponse.ProtoReflect.Descriptor instead.
+func (*CaptureReservationResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CaptureReservationResponse) GetReservation() *FundReservation {
+	if x != nil {
+		return x.Reservation
+	}
+	return nil
+}
+
+func (x *CaptureReservationResponse) GetNewBalance() *v1.Money {
+	if x != nil {
+		return x.NewBalance
+	}
+	return nil
+}
+
+type Statement struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId        string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	StatementDate    string                 `protobuf:"bytes,3,opt,name=statement_date,json=statementDate,proto3" json:"statement_date,omitempty"`         // YYYY-MM-DD
+	PeriodStartDate  string                 `protobuf:"bytes,4,opt,name=period_start_date,json=periodStartDate,proto3" json:"period_start_date,omitempty"` // YYYY-MM-DD
+	PeriodEndDate    string                 `protobuf:"bytes,5,opt,name=period_end_date,json=periodEndDate,proto3" json:"period_end_date,omitempty"`       // YYYY-MM-DD, inclusive
+	OpeningBalance   *v1.Money              `protobuf:"bytes,6,opt,name=opening_balance,json=openingBalance,proto3" json:"opening_balance,omitempty"`
+	ClosingBalance   *v1.Money              `protobuf:"bytes,7,opt,name=closing_balance,json=closingBalance,proto3" json:"closing_balance,omitempty"`
+	TotalCredits     *v1.Money              `protobuf:"bytes,8,opt,name=total_credits,json=totalCredits,proto3" json:"total_credits,omitempty"`
+	TotalDebits      *v1.Money              `protobuf:"bytes,9,opt,name=total_debits,json=totalDebits,proto3" json:"total_debits,omitempty"`
+	TransactionCount int32                  `protobuf:"varint,10,opt,name=transaction_count,json=transactionCount,proto3" json:"transaction_count,omitempty"`
+	InterestEarned   *v1.Money              `protobuf:"bytes,11,opt,name=interest_earned,json=interestEarned,proto3" json:"interest_earned,omitempty"`
+	DocumentUrl      string                 `protobuf:"bytes,12,opt,name=document_url,json=documentUrl,proto3" json:"document_url,omitempty"`
+	IsFinalized      bool                   `protobuf:"varint,13,opt,name=is_finalized,json=isFinalized,proto3" json:"is_finalized,omitempty"`
+	FinalizedAt      *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=finalized_at,json=finalizedAt,proto3" json:"finalized_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Statement) Reset() {
+	*x = Statement{}
+	mi := &file_account_v1_account_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Statement) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Statement) ProtoMessage() {}
+
+func (x *Statement) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Statement.ProtoReflect.Descriptor instead.
+func (*Statement) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Statement) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Statement) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Statement) GetStatementDate() string {
+	if x != nil {
+		return x.StatementDate
+	}
+	return ""
+}
+
+func (x *Statement) GetPeriodStartDate() string {
+	if x != nil {
+		return x.PeriodStartDate
+	}
+	return ""
+}
+
+func (x *Statement) GetPeriodEndDate() string {
+	if x != nil {
+		return x.PeriodEndDate
+	}
+	return ""
+}
+
+func (x *Statement) GetOpeningBalance() *v1.Money {
+	if x != nil {
+		return x.OpeningBalance
+	}
+	return nil
+}
+
+func (x *Statement) GetClosingBalance() *v1.Money {
+	if x != nil {
+		return x.ClosingBalance
+	}
+	return nil
+}
+
+func (x *Statement) GetTotalCredits() *v1.Money {
+	if x != nil {
+		return x.TotalCredits
+	}
+	return nil
+}
+
+func (x *Statement) GetTotalDebits() *v1.Money {
+	if x != nil {
+		return x.TotalDebits
+	}
+	return nil
+}
+
+func (x *Statement) GetTransactionCount() int32 {
+	if x != nil {
+		return x.TransactionCount
+	}
+	return 0
+}
+
+func (x *Statement) GetInterestEarned() *v1.Money {
+	if x != nil {
+		return x.InterestEarned
+	}
+	return nil
+}
+
+func (x *Statement) GetDocumentUrl() string {
+	if x != nil {
+		return x.DocumentUrl
+	}
+	return ""
+}
+
+func (x *Statement) GetIsFinalized() bool {
+	if x != nil {
+		return x.IsFinalized
+	}
+	return false
+}
+
+func (x *Statement) GetFinalizedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FinalizedAt
+	}
+	return nil
+}
+
+type ListStatementsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatementsRequest) Reset() {
+	*x = ListStatementsRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatementsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatementsRequest) ProtoMessage() {}
+
+func (x *ListStatementsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatementsRequest.ProtoReflect.Descriptor instead.
+func (*ListStatementsRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListStatementsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListStatementsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statements    []*Statement           `protobuf:"bytes,1,rep,name=statements,proto3" json:"statements,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStatementsResponse) Reset() {
+	*x = ListStatementsResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStatementsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStatementsResponse) ProtoMessage() {}
+
+func (x *ListStatementsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStatementsResponse.ProtoReflect.Descriptor instead.
+func (*ListStatementsResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListStatementsResponse) GetStatements() []*Statement {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
+type GetStatementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	StatementId   string                 `protobuf:"bytes,2,opt,name=statement_id,json=statementId,proto3" json:"statement_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementRequest) Reset() {
+	*x = GetStatementRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementRequest) ProtoMessage() {}
+
+func (x *GetStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementRequest.ProtoReflect.Descriptor instead.
+func (*GetStatementRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *GetStatementRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetStatementRequest) GetStatementId() string {
+	if x != nil {
+		return x.StatementId
+	}
+	return ""
+}
+
+type GetStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statement     *Statement             `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStatementResponse) Reset() {
+	*x = GetStatementResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStatementResponse) ProtoMessage() {}
+
+func (x *GetStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStatementResponse.ProtoReflect.Descriptor instead.
+func (*GetStatementResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *GetStatementResponse) GetStatement() *Statement {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+type GenerateStatementRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	AccountId       string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	PeriodStartDate string                 `protobuf:"bytes,2,opt,name=period_start_date,json=periodStartDate,proto3" json:"period_start_date,omitempty"` // YYYY-MM-DD
+	PeriodEndDate   string                 `protobuf:"bytes,3,opt,name=period_end_date,json=periodEndDate,proto3" json:"period_end_date,omitempty"`       // YYYY-MM-DD, inclusive
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GenerateStatementRequest) Reset() {
+	*x = GenerateStatementRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStatementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStatementRequest) ProtoMessage() {}
+
+func (x *GenerateStatementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStatementRequest.ProtoReflect.Descriptor instead.
+func (*GenerateStatementRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *GenerateStatementRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GenerateStatementRequest) GetPeriodStartDate() string {
+	if x != nil {
+		return x.PeriodStartDate
+	}
+	return ""
+}
+
+func (x *GenerateStatementRequest) GetPeriodEndDate() string {
+	if x != nil {
+		return x.PeriodEndDate
+	}
+	return ""
+}
+
+type GenerateStatementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Statement     *Statement             `protobuf:"bytes,1,opt,name=statement,proto3" json:"statement,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GenerateStatementResponse) Reset() {
+	*x = GenerateStatementResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GenerateStatementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GenerateStatementResponse) ProtoMessage() {}
+
+func (x *GenerateStatementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GenerateStatementResponse.ProtoReflect.Descriptor instead.
+func (*GenerateStatementResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *GenerateStatementResponse) GetStatement() *Statement {
+	if x != nil {
+		return x.Statement
+	}
+	return nil
+}
+
+type LedgerFilter struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                                   // Inclusive
+	To            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                       // Exclusive
+	EntryType     string                 `protobuf:"bytes,3,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"`        // debit, credit
+	MinAmount     *int64                 `protobuf:"varint,4,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"` // Minor units, whichever way the entry went
+	MaxAmount     *int64                 `protobuf:"varint,5,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	Reference     string                 `protobuf:"bytes,6,opt,name=reference,proto3" json:"reference,omitempty"` // Case-insensitive prefix
+	Currency      string                 `protobuf:"bytes,7,opt,name=currency,proto3" json:"currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LedgerFilter) Reset() {
+	*x = LedgerFilter{}
+	mi := &file_account_v1_account_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerFilter) ProtoMessage() {}
+
+func (x *LedgerFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerFilter.ProtoReflect.Descriptor instead.
+func (*LedgerFilter) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LedgerFilter) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *LedgerFilter) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *LedgerFilter) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *LedgerFilter) GetMinAmount() int64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *LedgerFilter) GetMaxAmount() int64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+func (x *LedgerFilter) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *LedgerFilter) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type LedgerEntry struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Id             string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId      string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	TransactionId  string                 `protobuf:"bytes,3,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	EntryType      string                 `protobuf:"bytes,4,opt,name=entry_type,json=entryType,proto3" json:"entry_type,omitempty"` // debit, credit
+	Amount         *v1.Money              `protobuf:"bytes,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	BalanceBefore  *v1.Money              `protobuf:"bytes,6,opt,name=balance_before,json=balanceBefore,proto3" json:"balance_before,omitempty"`
+	BalanceAfter   *v1.Money              `protobuf:"bytes,7,opt,name=balance_after,json=balanceAfter,proto3" json:"balance_after,omitempty"`
+	RunningBalance *v1.Money              `protobuf:"bytes,8,opt,name=running_balance,json=runningBalance,proto3" json:"running_balance,omitempty"` // Summed from the ledger up to this entry
+	Description    string                 `protobuf:"bytes,9,opt,name=description,proto3" json:"description,omitempty"`
+	Reference      string                 `protobuf:"bytes,10,opt,name=reference,proto3" json:"reference,omitempty"`
+	EntryDate      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=entry_date,json=entryDate,proto3" json:"entry_date,omitempty"`
+	ValueDate      string                 `protobuf:"bytes,12,opt,name=value_date,json=valueDate,proto3" json:"value_date,omitempty"`    // YYYY-MM-DD
+	PostedDate     string                 `protobuf:"bytes,13,opt,name=posted_date,json=postedDate,proto3" json:"posted_date,omitempty"` // YYYY-MM-DD
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LedgerEntry) Reset() {
+	*x = LedgerEntry{}
+	mi := &file_account_v1_account_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LedgerEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LedgerEntry) ProtoMessage() {}
+
+func (x *LedgerEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LedgerEntry.ProtoReflect.Descriptor instead.
+func (*LedgerEntry) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *LedgerEntry) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetEntryType() string {
+	if x != nil {
+		return x.EntryType
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetAmount() *v1.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetBalanceBefore() *v1.Money {
+	if x != nil {
+		return x.BalanceBefore
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetBalanceAfter() *v1.Money {
+	if x != nil {
+		return x.BalanceAfter
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetRunningBalance() *v1.Money {
+	if x != nil {
+		return x.RunningBalance
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetEntryDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EntryDate
+	}
+	return nil
+}
+
+func (x *LedgerEntry) GetValueDate() string {
+	if x != nil {
+		return x.ValueDate
+	}
+	return ""
+}
+
+func (x *LedgerEntry) GetPostedDate() string {
+	if x != nil {
+		return x.PostedDate
+	}
+	return ""
+}
+
+type ListLedgerEntriesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Filter        *LedgerFilter          `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesRequest) Reset() {
+	*x = ListLedgerEntriesRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesRequest) ProtoMessage() {}
+
+func (x *ListLedgerEntriesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesRequest.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListLedgerEntriesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ListLedgerEntriesRequest) GetFilter() *LedgerFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListLedgerEntriesRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListLedgerEntriesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type ListLedgerEntriesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LedgerEntry         `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLedgerEntriesResponse) Reset() {
+	*x = ListLedgerEntriesResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLedgerEntriesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLedgerEntriesResponse) ProtoMessage() {}
+
+func (x *ListLedgerEntriesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLedgerEntriesResponse.ProtoReflect.Descriptor instead.
+func (*ListLedgerEntriesResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListLedgerEntriesResponse) GetEntries() []*LedgerEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *ListLedgerEntriesResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type ExportLedgerCsvRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Filter        *LedgerFilter          `protobuf:"bytes,2,opt,name=filter,proto3" json:"filter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLedgerCsvRequest) Reset() {
+	*x = ExportLedgerCsvRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLedgerCsvRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLedgerCsvRequest) ProtoMessage() {}
+
+func (x *ExportLedgerCsvRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLedgerCsvRequest.ProtoReflect.Descriptor instead.
+func (*ExportLedgerCsvRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ExportLedgerCsvRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ExportLedgerCsvRequest) GetFilter() *LedgerFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+type ExportLedgerCsvChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportLedgerCsvChunk) Reset() {
+	*x = ExportLedgerCsvChunk{}
+	mi := &file_account_v1_account_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportLedgerCsvChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportLedgerCsvChunk) ProtoMessage() {}
+
+func (x *ExportLedgerCsvChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportLedgerCsvChunk.ProtoReflect.Descriptor instead.
+func (*ExportLedgerCsvChunk) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ExportLedgerCsvChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type GetBalanceAsOfRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`             // Defaults to now
+	Basis         string                 `protobuf:"bytes,3,opt,name=basis,proto3" json:"basis,omitempty"`       // booking (default), value
+	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"` // Defaults to the account's own
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAsOfRequest) Reset() {
+	*x = GetBalanceAsOfRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAsOfRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAsOfRequest) ProtoMessage() {}
+
+func (x *GetBalanceAsOfRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAsOfRequest.ProtoReflect.Descriptor instead.
+func (*GetBalanceAsOfRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *GetBalanceAsOfRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *GetBalanceAsOfRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *GetBalanceAsOfRequest) GetBasis() string {
+	if x != nil {
+		return x.Basis
+	}
+	return ""
+}
+
+func (x *GetBalanceAsOfRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type GetBalanceAsOfResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Balance       *v1.Money              `protobuf:"bytes,1,opt,name=balance,proto3" json:"balance,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=at,proto3" json:"at,omitempty"`
+	Basis         string                 `protobuf:"bytes,3,opt,name=basis,proto3" json:"basis,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBalanceAsOfResponse) Reset() {
+	*x = GetBalanceAsOfResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBalanceAsOfResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBalanceAsOfResponse) ProtoMessage() {}
+
+func (x *GetBalanceAsOfResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBalanceAsOfResponse.ProtoReflect.Descriptor instead.
+func (*GetBalanceAsOfResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *GetBalanceAsOfResponse) GetBalance() *v1.Money {
+	if x != nil {
+		return x.Balance
+	}
+	return nil
+}
+
+func (x *GetBalanceAsOfResponse) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *GetBalanceAsOfResponse) GetBasis() string {
+	if x != nil {
+		return x.Basis
+	}
+	return ""
+}
+
+type Card struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Id              string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	AccountId       string                 `protobuf:"bytes,2,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CustomerId      string                 `protobuf:"bytes,3,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	CardNumberToken string                 `protobuf:"bytes,4,opt,name=card_number_token,json=cardNumberToken,proto3" json:"card_number_token,omitempty"`
+	LastFour        string                 `protobuf:"bytes,5,opt,name=last_four,json=lastFour,proto3" json:"last_four,omitempty"`
+	CardType        string                 `protobuf:"bytes,6,opt,name=card_type,json=cardType,proto3" json:"card_type,omitempty"`    // debit
+	CardBrand       string                 `protobuf:"bytes,7,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"` // visa, mastercard
+	ExpiryMonth     int32                  `protobuf:"varint,8,opt,name=expiry_month,json=expiryMonth,proto3" json:"expiry_month,omitempty"`
+	ExpiryYear      int32                  `protobuf:"varint,9,opt,name=expiry_year,json=expiryYear,proto3" json:"expiry_year,omitempty"`
+	DailyLimit      *v1.Money              `protobuf:"bytes,10,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	MonthlyLimit    *v1.Money              `protobuf:"bytes,11,opt,name=monthly_limit,json=monthlyLimit,proto3" json:"monthly_limit,omitempty"`
+	AtmDailyLimit   *v1.Money              `protobuf:"bytes,12,opt,name=atm_daily_limit,json=atmDailyLimit,proto3" json:"atm_daily_limit,omitempty"`
+	Status          string                 `protobuf:"bytes,13,opt,name=status,proto3" json:"status,omitempty"` // inactive, active, blocked, expired, cancelled
+	BlockReason     string                 `protobuf:"bytes,14,opt,name=block_reason,json=blockReason,proto3" json:"block_reason,omitempty"`
+	PinSet          bool                   `protobuf:"varint,15,opt,name=pin_set,json=pinSet,proto3" json:"pin_set,omitempty"`
+	PinLockedUntil  *timestamppb.Timestamp `protobuf:"bytes,16,opt,name=pin_locked_until,json=pinLockedUntil,proto3" json:"pin_locked_until,omitempty"`
+	IssuedAt        *timestamppb.Timestamp `protobuf:"bytes,17,opt,name=issued_at,json=issuedAt,proto3" json:"issued_at,omitempty"`
+	ActivatedAt     *timestamppb.Timestamp `protobuf:"bytes,18,opt,name=activated_at,json=activatedAt,proto3" json:"activated_at,omitempty"`
+	CancelledAt     *timestamppb.Timestamp `protobuf:"bytes,19,opt,name=cancelled_at,json=cancelledAt,proto3" json:"cancelled_at,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Card) Reset() {
+	*x = Card{}
+	mi := &file_account_v1_account_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Card) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Card) ProtoMessage() {}
+
+func (x *Card) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Card.ProtoReflect.Descriptor instead.
+func (*Card) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *Card) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Card) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Card) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *Card) GetCardNumberToken() string {
+	if x != nil {
+		return x.CardNumberToken
+	}
+	return ""
+}
+
+func (x *Card) GetLastFour() string {
+	if x != nil {
+		return x.LastFour
+	}
+	return ""
+}
+
+func (x *Card) GetCardType() string {
+	if x != nil {
+		return x.CardType
+	}
+	return ""
+}
+
+func (x *Card) GetCardBrand() string {
+	if x != nil {
+		return x.CardBrand
+	}
+	return ""
+}
+
+func (x *Card) GetExpiryMonth() int32 {
+	if x != nil {
+		return x.ExpiryMonth
+	}
+	return 0
+}
+
+func (x *Card) GetExpiryYear() int32 {
+	if x != nil {
+		return x.ExpiryYear
+	}
+	return 0
+}
+
+func (x *Card) GetDailyLimit() *v1.Money {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return nil
+}
+
+func (x *Card) GetMonthlyLimit() *v1.Money {
+	if x != nil {
+		return x.MonthlyLimit
+	}
+	return nil
+}
+
+func (x *Card) GetAtmDailyLimit() *v1.Money {
+	if x != nil {
+		return x.AtmDailyLimit
+	}
+	return nil
+}
+
+func (x *Card) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Card) GetBlockReason() string {
+	if x != nil {
+		return x.BlockReason
+	}
+	return ""
+}
+
+func (x *Card) GetPinSet() bool {
+	if x != nil {
+		return x.PinSet
+	}
+	return false
+}
+
+func (x *Card) GetPinLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PinLockedUntil
+	}
+	return nil
+}
+
+func (x *Card) GetIssuedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.IssuedAt
+	}
+	return nil
+}
+
+func (x *Card) GetActivatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ActivatedAt
+	}
+	return nil
+}
+
+func (x *Card) GetCancelledAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CancelledAt
+	}
+	return nil
+}
+
+type IssueCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	CustomerId    string                 `protobuf:"bytes,2,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"` // Cardholder, must hold the account
+	CardBrand     string                 `protobuf:"bytes,3,opt,name=card_brand,json=cardBrand,proto3" json:"card_brand,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCardRequest) Reset() {
+	*x = IssueCardRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCardRequest) ProtoMessage() {}
+
+func (x *IssueCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCardRequest.ProtoReflect.Descriptor instead.
+func (*IssueCardRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *IssueCardRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *IssueCardRequest) GetCustomerId() string {
+	if x != nil {
+		return x.CustomerId
+	}
+	return ""
+}
+
+func (x *IssueCardRequest) GetCardBrand() string {
+	if x != nil {
+		return x.CardBrand
+	}
+	return ""
+}
+
+type IssueCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	Pan           string                 `protobuf:"bytes,2,opt,name=pan,proto3" json:"pan,omitempty"` // Only ever returned here
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueCardResponse) Reset() {
+	*x = IssueCardResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueCardResponse) ProtoMessage() {}
+
+func (x *IssueCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueCardResponse.ProtoReflect.Descriptor instead.
+func (*IssueCardResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *IssueCardResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+func (x *IssueCardResponse) GetPan() string {
+	if x != nil {
+		return x.Pan
+	}
+	return ""
+}
+
+type GetCardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardRequest) Reset() {
+	*x = GetCardRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardRequest) ProtoMessage() {}
+
+func (x *GetCardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardRequest.ProtoReflect.Descriptor instead.
+func (*GetCardRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetCardRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+type GetCardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardResponse) Reset() {
+	*x = GetCardResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardResponse) ProtoMessage() {}
+
+func (x *GetCardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardResponse.ProtoReflect.Descriptor instead.
+func (*GetCardResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *GetCardResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type GetCardByTokenRequest struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	CardNumberToken string                 `protobuf:"bytes,1,opt,name=card_number_token,json=cardNumberToken,proto3" json:"card_number_token,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *GetCardByTokenRequest) Reset() {
+	*x = GetCardByTokenRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardByTokenRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardByTokenRequest) ProtoMessage() {}
+
+func (x *GetCardByTokenRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardByTokenRequest.ProtoReflect.Descriptor instead.
+func (*GetCardByTokenRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *GetCardByTokenRequest) GetCardNumberToken() string {
+	if x != nil {
+		return x.CardNumberToken
+	}
+	return ""
+}
+
+type GetCardByTokenResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetCardByTokenResponse) Reset() {
+	*x = GetCardByTokenResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetCardByTokenResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetCardByTokenResponse) ProtoMessage() {}
+
+func (x *GetCardByTokenResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetCardByTokenResponse.ProtoReflect.Descriptor instead.
+func (*GetCardByTokenResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetCardByTokenResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type ListCardsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsRequest) Reset() {
+	*x = ListCardsRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsRequest) ProtoMessage() {}
+
+func (x *ListCardsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsRequest.ProtoReflect.Descriptor instead.
+func (*ListCardsRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListCardsRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ListCardsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cards         []*Card                `protobuf:"bytes,1,rep,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListCardsResponse) Reset() {
+	*x = ListCardsResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListCardsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListCardsResponse) ProtoMessage() {}
+
+func (x *ListCardsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListCardsResponse.ProtoReflect.Descriptor instead.
+func (*ListCardsResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListCardsResponse) GetCards() []*Card {
+	if x != nil {
+		return x.Cards
+	}
+	return nil
+}
+
+type UpdateCardStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Action        string                 `protobuf:"bytes,2,opt,name=action,proto3" json:"action,omitempty"` // activate, block, unblock, cancel
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardStatusRequest) Reset() {
+	*x = UpdateCardStatusRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardStatusRequest) ProtoMessage() {}
+
+func (x *UpdateCardStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardStatusRequest.ProtoReflect.Descriptor instead.
+func (*UpdateCardStatusRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *UpdateCardStatusRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *UpdateCardStatusRequest) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *UpdateCardStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type UpdateCardStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateCardStatusResponse) Reset() {
+	*x = UpdateCardStatusResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateCardStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateCardStatusResponse) ProtoMessage() {}
+
+func (x *UpdateCardStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateCardStatusResponse.ProtoReflect.Descriptor instead.
+func (*UpdateCardStatusResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *UpdateCardStatusResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type SetCardPinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Pin           string                 `protobuf:"bytes,2,opt,name=pin,proto3" json:"pin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardPinRequest) Reset() {
+	*x = SetCardPinRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardPinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardPinRequest) ProtoMessage() {}
+
+func (x *SetCardPinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardPinRequest.ProtoReflect.Descriptor instead.
+func (*SetCardPinRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *SetCardPinRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *SetCardPinRequest) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+type SetCardPinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardPinResponse) Reset() {
+	*x = SetCardPinResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardPinResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardPinResponse) ProtoMessage() {}
+
+func (x *SetCardPinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardPinResponse.ProtoReflect.Descriptor instead.
+func (*SetCardPinResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetCardPinResponse) GetCard() *Card {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type VerifyCardPinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	Pin           string                 `protobuf:"bytes,2,opt,name=pin,proto3" json:"pin,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCardPinRequest) Reset() {
+	*x = VerifyCardPinRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyCardPinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyCardPinRequest) ProtoMessage() {}
+
+func (x *VerifyCardPinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyCardPinRequest.ProtoReflect.Descriptor instead.
+func (*VerifyCardPinRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *VerifyCardPinRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
+	}
+	return ""
+}
+
+func (x *VerifyCardPinRequest) GetPin() string {
+	if x != nil {
+		return x.Pin
+	}
+	return ""
+}
+
+type VerifyCardPinResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Verified      bool                   `protobuf:"varint,1,opt,name=verified,proto3" json:"verified,omitempty"`
+	Attempts      int32                  `protobuf:"varint,2,opt,name=attempts,proto3" json:"attempts,omitempty"` // Wrong attempts since the last correct PIN
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyCardPinResponse) Reset() {
+	*x = VerifyCardPinResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdjustBalanceRequest) String() string {
+func (x *VerifyCardPinResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdjustBalanceRequest) ProtoMessage() {}
+func (*VerifyCardPinResponse) ProtoMessage() {}
 
-func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[0]
+func (x *VerifyCardPinResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -58,61 +2593,122 @@ func (x *AdjustBalanceRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdjustBalanceRequest.ProtoReflect.Descriptor instead.
-func (*AdjustBalanceRequest) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{0}
+// Deprecated: Use VerifyCardPinResponse.ProtoReflect.Descriptor instead.
+func (*VerifyCardPinResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{40}
 }
 
-func (x *AdjustBalanceRequest) GetAccountId() string {
+func (x *VerifyCardPinResponse) GetVerified() bool {
 	if x != nil {
-		return x.AccountId
+		return x.Verified
 	}
-	return ""
+	return false
 }
 
-func (x *AdjustBalanceRequest) GetAmountAdjustment() int64 {
+func (x *VerifyCardPinResponse) GetAttempts() int32 {
 	if x != nil {
-		return x.AmountAdjustment
+		return x.Attempts
 	}
 	return 0
 }
 
-func (x *AdjustBalanceRequest) GetReference() string {
+func (x *VerifyCardPinResponse) GetLockedUntil() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Reference
+		return x.LockedUntil
 	}
-	return ""
+	return nil
 }
 
-func (x *AdjustBalanceRequest) GetDescription() string {
+type SetCardLimitsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CardId        string                 `protobuf:"bytes,1,opt,name=card_id,json=cardId,proto3" json:"card_id,omitempty"`
+	DailyLimit    int64                  `protobuf:"varint,2,opt,name=daily_limit,json=dailyLimit,proto3" json:"daily_limit,omitempty"`
+	MonthlyLimit  int64                  `protobuf:"varint,3,opt,name=monthly_limit,json=monthlyLimit,proto3" json:"monthly_limit,omitempty"`
+	AtmDailyLimit int64                  `protobuf:"varint,4,opt,name=atm_daily_limit,json=atmDailyLimit,proto3" json:"atm_daily_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetCardLimitsRequest) Reset() {
+	*x = SetCardLimitsRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetCardLimitsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetCardLimitsRequest) ProtoMessage() {}
+
+func (x *SetCardLimitsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[41]
 	if x != nil {
-		return x.Description
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetCardLimitsRequest.ProtoReflect.Descriptor instead.
+func (*SetCardLimitsRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetCardLimitsRequest) GetCardId() string {
+	if x != nil {
+		return x.CardId
 	}
 	return ""
 }
 
-type AdjustBalanceResponse struct {
+func (x *SetCardLimitsRequest) GetDailyLimit() int64 {
+	if x != nil {
+		return x.DailyLimit
+	}
+	return 0
+}
+
+func (x *SetCardLimitsRequest) GetMonthlyLimit() int64 {
+	if x != nil {
+		return x.MonthlyLimit
+	}
+	return 0
+}
+
+func (x *SetCardLimitsRequest) GetAtmDailyLimit() int64 {
+	if x != nil {
+		return x.AtmDailyLimit
+	}
+	return 0
+}
+
+type SetCardLimitsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	NewBalance    *v1.Money              `protobuf:"bytes,1,opt,name=new_balance,json=newBalance,proto3" json:"new_balance,omitempty"`
+	Card          *Card                  `protobuf:"bytes,1,opt,name=card,proto3" json:"card,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *AdjustBalanceResponse) Reset() {
-	*x = AdjustBalanceResponse{}
-	mi := &file_account_v1_account_proto_msgTypes[1]
+func (x *SetCardLimitsResponse) Reset() {
+	*x = SetCardLimitsResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *AdjustBalanceResponse) String() string {
+func (x *SetCardLimitsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*AdjustBalanceResponse) ProtoMessage() {}
+func (*SetCardLimitsResponse) ProtoMessage() {}
 
-func (x *AdjustBalanceResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[1]
+func (x *SetCardLimitsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -123,14 +2719,14 @@ func (x *AdjustBalanceResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use AdjustBalanceResponse.ProtoReflect.Descriptor instead.
-func (*AdjustBalanceResponse) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{1}
+// Deprecated: Use SetCardLimitsResponse.ProtoReflect.Descriptor instead.
+func (*SetCardLimitsResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{42}
 }
 
-func (x *AdjustBalanceResponse) GetNewBalance() *v1.Money {
+func (x *SetCardLimitsResponse) GetCard() *Card {
 	if x != nil {
-		return x.NewBalance
+		return x.Card
 	}
 	return nil
 }
@@ -148,13 +2744,17 @@ type Account struct {
 	Status           string                 `protobuf:"bytes,9,opt,name=status,proto3" json:"status,omitempty"`
 	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt        *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	OverdraftLimit   *v1.Money              `protobuf:"bytes,12,opt,name=overdraft_limit,json=overdraftLimit,proto3" json:"overdraft_limit,omitempty"`
+	MinimumBalance   *v1.Money              `protobuf:"bytes,13,opt,name=minimum_balance,json=minimumBalance,proto3" json:"minimum_balance,omitempty"`
+	SubBalances      []*v1.Money            `protobuf:"bytes,14,rep,name=sub_balances,json=subBalances,proto3" json:"sub_balances,omitempty"` // Balances held in other currencies
+	ProductCode      string                 `protobuf:"bytes,15,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
 	unknownFields    protoimpl.UnknownFields
 	sizeCache        protoimpl.SizeCache
 }
 
 func (x *Account) Reset() {
 	*x = Account{}
-	mi := &file_account_v1_account_proto_msgTypes[2]
+	mi := &file_account_v1_account_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -166,7 +2766,7 @@ func (x *Account) String() string {
 func (*Account) ProtoMessage() {}
 
 func (x *Account) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[2]
+	mi := &file_account_v1_account_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +2779,7 @@ func (x *Account) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Account.ProtoReflect.Descriptor instead.
 func (*Account) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{2}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *Account) GetId() string {
@@ -259,19 +2859,52 @@ func (x *Account) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Account) GetOverdraftLimit() *v1.Money {
+	if x != nil {
+		return x.OverdraftLimit
+	}
+	return nil
+}
+
+func (x *Account) GetMinimumBalance() *v1.Money {
+	if x != nil {
+		return x.MinimumBalance
+	}
+	return nil
+}
+
+func (x *Account) GetSubBalances() []*v1.Money {
+	if x != nil {
+		return x.SubBalances
+	}
+	return nil
+}
+
+func (x *Account) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
 type CreateAccountRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
-	AccountName   string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"`
-	AccountType   string                 `protobuf:"bytes,3,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
-	Currency      string                 `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	CustomerId  string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
+	AccountName string                 `protobuf:"bytes,2,opt,name=account_name,json=accountName,proto3" json:"account_name,omitempty"` // Defaults to the product's name
+	// Used to pick a default product when product_code is empty.
+	//
+	// Deprecated: Marked as deprecated in account/v1/account.proto.
+	AccountType string `protobuf:"bytes,3,opt,name=account_type,json=accountType,proto3" json:"account_type,omitempty"`
+	// Deprecated: Marked as deprecated in account/v1/account.proto.
+	Currency      string `protobuf:"bytes,4,opt,name=currency,proto3" json:"currency,omitempty"`
+	ProductCode   string `protobuf:"bytes,5,opt,name=product_code,json=productCode,proto3" json:"product_code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CreateAccountRequest) Reset() {
 	*x = CreateAccountRequest{}
-	mi := &file_account_v1_account_proto_msgTypes[3]
+	mi := &file_account_v1_account_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -283,7 +2916,7 @@ func (x *CreateAccountRequest) String() string {
 func (*CreateAccountRequest) ProtoMessage() {}
 
 func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[3]
+	mi := &file_account_v1_account_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -296,7 +2929,7 @@ func (x *CreateAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAccountRequest.ProtoReflect.Descriptor instead.
 func (*CreateAccountRequest) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{3}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *CreateAccountRequest) GetCustomerId() string {
@@ -313,6 +2946,7 @@ func (x *CreateAccountRequest) GetAccountName() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in account/v1/account.proto.
 func (x *CreateAccountRequest) GetAccountType() string {
 	if x != nil {
 		return x.AccountType
@@ -320,6 +2954,7 @@ func (x *CreateAccountRequest) GetAccountType() string {
 	return ""
 }
 
+// Deprecated: Marked as deprecated in account/v1/account.proto.
 func (x *CreateAccountRequest) GetCurrency() string {
 	if x != nil {
 		return x.Currency
@@ -327,6 +2962,13 @@ func (x *CreateAccountRequest) GetCurrency() string {
 	return ""
 }
 
+func (x *CreateAccountRequest) GetProductCode() string {
+	if x != nil {
+		return x.ProductCode
+	}
+	return ""
+}
+
 type CreateAccountResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
@@ -336,7 +2978,7 @@ type CreateAccountResponse struct {
 
 func (x *CreateAccountResponse) Reset() {
 	*x = CreateAccountResponse{}
-	mi := &file_account_v1_account_proto_msgTypes[4]
+	mi := &file_account_v1_account_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -348,7 +2990,7 @@ func (x *CreateAccountResponse) String() string {
 func (*CreateAccountResponse) ProtoMessage() {}
 
 func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[4]
+	mi := &file_account_v1_account_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -361,7 +3003,7 @@ func (x *CreateAccountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateAccountResponse.ProtoReflect.Descriptor instead.
 func (*CreateAccountResponse) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{4}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{45}
 }
 
 func (x *CreateAccountResponse) GetAccount() *Account {
@@ -380,7 +3022,7 @@ type GetAccountRequest struct {
 
 func (x *GetAccountRequest) Reset() {
 	*x = GetAccountRequest{}
-	mi := &file_account_v1_account_proto_msgTypes[5]
+	mi := &file_account_v1_account_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -392,7 +3034,7 @@ func (x *GetAccountRequest) String() string {
 func (*GetAccountRequest) ProtoMessage() {}
 
 func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[5]
+	mi := &file_account_v1_account_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -405,7 +3047,7 @@ func (x *GetAccountRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAccountRequest.ProtoReflect.Descriptor instead.
 func (*GetAccountRequest) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{5}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *GetAccountRequest) GetAccountId() string {
@@ -424,7 +3066,7 @@ type GetAccountResponse struct {
 
 func (x *GetAccountResponse) Reset() {
 	*x = GetAccountResponse{}
-	mi := &file_account_v1_account_proto_msgTypes[6]
+	mi := &file_account_v1_account_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -436,7 +3078,7 @@ func (x *GetAccountResponse) String() string {
 func (*GetAccountResponse) ProtoMessage() {}
 
 func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[6]
+	mi := &file_account_v1_account_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -449,7 +3091,7 @@ func (x *GetAccountResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetAccountResponse.ProtoReflect.Descriptor instead.
 func (*GetAccountResponse) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{6}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{47}
 }
 
 func (x *GetAccountResponse) GetAccount() *Account {
@@ -459,6 +3101,94 @@ func (x *GetAccountResponse) GetAccount() *Account {
 	return nil
 }
 
+type GetAccountByNumberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountNumber string                 `protobuf:"bytes,1,opt,name=account_number,json=accountNumber,proto3" json:"account_number,omitempty"` // IBAN, spaces allowed
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountByNumberRequest) Reset() {
+	*x = GetAccountByNumberRequest{}
+	mi := &file_account_v1_account_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountByNumberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountByNumberRequest) ProtoMessage() {}
+
+func (x *GetAccountByNumberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountByNumberRequest.ProtoReflect.Descriptor instead.
+func (*GetAccountByNumberRequest) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *GetAccountByNumberRequest) GetAccountNumber() string {
+	if x != nil {
+		return x.AccountNumber
+	}
+	return ""
+}
+
+type GetAccountByNumberResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetAccountByNumberResponse) Reset() {
+	*x = GetAccountByNumberResponse{}
+	mi := &file_account_v1_account_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetAccountByNumberResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetAccountByNumberResponse) ProtoMessage() {}
+
+func (x *GetAccountByNumberResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_account_v1_account_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetAccountByNumberResponse.ProtoReflect.Descriptor instead.
+func (*GetAccountByNumberResponse) Descriptor() ([]byte, []int) {
+	return file_account_v1_account_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *GetAccountByNumberResponse) GetAccount() *Account {
+	if x != nil {
+		return x.Account
+	}
+	return nil
+}
+
 type ListAccountsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	CustomerId    string                 `protobuf:"bytes,1,opt,name=customer_id,json=customerId,proto3" json:"customer_id,omitempty"`
@@ -468,7 +3198,7 @@ type ListAccountsRequest struct {
 
 func (x *ListAccountsRequest) Reset() {
 	*x = ListAccountsRequest{}
-	mi := &file_account_v1_account_proto_msgTypes[7]
+	mi := &file_account_v1_account_proto_msgTypes[50]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -480,7 +3210,7 @@ func (x *ListAccountsRequest) String() string {
 func (*ListAccountsRequest) ProtoMessage() {}
 
 func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[7]
+	mi := &file_account_v1_account_proto_msgTypes[50]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -493,7 +3223,7 @@ func (x *ListAccountsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAccountsRequest.ProtoReflect.Descriptor instead.
 func (*ListAccountsRequest) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{7}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{50}
 }
 
 func (x *ListAccountsRequest) GetCustomerId() string {
@@ -512,7 +3242,7 @@ type ListAccountsResponse struct {
 
 func (x *ListAccountsResponse) Reset() {
 	*x = ListAccountsResponse{}
-	mi := &file_account_v1_account_proto_msgTypes[8]
+	mi := &file_account_v1_account_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -524,7 +3254,7 @@ func (x *ListAccountsResponse) String() string {
 func (*ListAccountsResponse) ProtoMessage() {}
 
 func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[8]
+	mi := &file_account_v1_account_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -537,7 +3267,7 @@ func (x *ListAccountsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListAccountsResponse.ProtoReflect.Descriptor instead.
 func (*ListAccountsResponse) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{8}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{51}
 }
 
 func (x *ListAccountsResponse) GetAccounts() []*Account {
@@ -551,13 +3281,14 @@ type UpdateAccountStatusRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
 	Status        string                 `protobuf:"bytes,2,opt,name=status,proto3" json:"status,omitempty"`
+	Reason        string                 `protobuf:"bytes,3,opt,name=reason,proto3" json:"reason,omitempty"` // Required, kept in the status history
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *UpdateAccountStatusRequest) Reset() {
 	*x = UpdateAccountStatusRequest{}
-	mi := &file_account_v1_account_proto_msgTypes[9]
+	mi := &file_account_v1_account_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +3300,7 @@ func (x *UpdateAccountStatusRequest) String() string {
 func (*UpdateAccountStatusRequest) ProtoMessage() {}
 
 func (x *UpdateAccountStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[9]
+	mi := &file_account_v1_account_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +3313,7 @@ func (x *UpdateAccountStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAccountStatusRequest.ProtoReflect.Descriptor instead.
 func (*UpdateAccountStatusRequest) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{9}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{52}
 }
 
 func (x *UpdateAccountStatusRequest) GetAccountId() string {
@@ -599,6 +3330,13 @@ func (x *UpdateAccountStatusRequest) GetStatus() string {
 	return ""
 }
 
+func (x *UpdateAccountStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
 type UpdateAccountStatusResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Account       *Account               `protobuf:"bytes,1,opt,name=account,proto3" json:"account,omitempty"`
@@ -608,7 +3346,7 @@ type UpdateAccountStatusResponse struct {
 
 func (x *UpdateAccountStatusResponse) Reset() {
 	*x = UpdateAccountStatusResponse{}
-	mi := &file_account_v1_account_proto_msgTypes[10]
+	mi := &file_account_v1_account_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -620,7 +3358,7 @@ func (x *UpdateAccountStatusResponse) String() string {
 func (*UpdateAccountStatusResponse) ProtoMessage() {}
 
 func (x *UpdateAccountStatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_account_v1_account_proto_msgTypes[10]
+	mi := &file_account_v1_account_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -633,7 +3371,7 @@ func (x *UpdateAccountStatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateAccountStatusResponse.ProtoReflect.Descriptor instead.
 func (*UpdateAccountStatusResponse) Descriptor() ([]byte, []int) {
-	return file_account_v1_account_proto_rawDescGZIP(), []int{10}
+	return file_account_v1_account_proto_rawDescGZIP(), []int{53}
 }
 
 func (x *UpdateAccountStatusResponse) GetAccount() *Account {
@@ -648,16 +3386,242 @@ var File_account_v1_account_proto protoreflect.FileDescriptor
 const file_account_v1_account_proto_rawDesc = "" +
 	"\n" +
 	"\x18account/v1/account.proto\x12\n" +
-	"account.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"\xa2\x01\n" +
+	"account.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"~\n" +
+	"\x1cCheckHolderPermissionRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x1e\n" +
+	"\n" +
+	"permission\x18\x03 \x01(\tR\n" +
+	"permission\"9\n" +
+	"\x1dCheckHolderPermissionResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\"\xac\x02\n" +
 	"\x14AdjustBalanceRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12+\n" +
 	"\x11amount_adjustment\x18\x02 \x01(\x03R\x10amountAdjustment\x12\x1c\n" +
 	"\treference\x18\x03 \x01(\tR\treference\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\"J\n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x1d\n" +
+	"\n" +
+	"value_date\x18\x06 \x01(\tR\tvalueDate\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\x12\x10\n" +
+	"\x03leg\x18\b \x01(\tR\x03leg\x12\x14\n" +
+	"\x05force\x18\t \x01(\bR\x05force\"J\n" +
 	"\x15AdjustBalanceResponse\x121\n" +
 	"\vnew_balance\x18\x01 \x01(\v2\x10.common.v1.MoneyR\n" +
-	"newBalance\"\xbc\x03\n" +
+	"newBalance\"\x85\x03\n" +
+	"\x0fFundReservation\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12(\n" +
+	"\x06amount\x18\x04 \x01(\v2\x10.common.v1.MoneyR\x06amount\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12;\n" +
+	"\vreserved_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"reservedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12;\n" +
+	"\vreleased_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"releasedAt\x12%\n" +
+	"\x0erelease_reason\x18\t \x01(\tR\rreleaseReason\"\xc2\x01\n" +
+	"\x13ReserveFundsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\x12\x16\n" +
+	"\x06amount\x18\x03 \x01(\x03R\x06amount\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x05R\n" +
+	"ttlSeconds\x12\x1a\n" +
+	"\bcurrency\x18\x05 \x01(\tR\bcurrency\x12\x10\n" +
+	"\x03leg\x18\x06 \x01(\tR\x03leg\"U\n" +
+	"\x14ReserveFundsResponse\x12=\n" +
+	"\vreservation\x18\x01 \x01(\v2\x1b.account.v1.FundReservationR\vreservation\"Z\n" +
+	"\x19ReleaseReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"[\n" +
+	"\x1aReleaseReservationResponse\x12=\n" +
+	"\vreservation\x18\x01 \x01(\v2\x1b.account.v1.FundReservationR\vreservation\"\x9a\x01\n" +
+	"\x19CaptureReservationRequest\x12%\n" +
+	"\x0ereservation_id\x18\x01 \x01(\tR\rreservationId\x12\x16\n" +
+	"\x06amount\x18\x02 \x01(\x03R\x06amount\x12\x1c\n" +
+	"\treference\x18\x03 \x01(\tR\treference\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"\x8e\x01\n" +
+	"\x1aCaptureReservationResponse\x12=\n" +
+	"\vreservation\x18\x01 \x01(\v2\x1b.account.v1.FundReservationR\vreservation\x121\n" +
+	"\vnew_balance\x18\x02 \x01(\v2\x10.common.v1.MoneyR\n" +
+	"newBalance\"\x84\x05\n" +
+	"\tStatement\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12%\n" +
+	"\x0estatement_date\x18\x03 \x01(\tR\rstatementDate\x12*\n" +
+	"\x11period_start_date\x18\x04 \x01(\tR\x0fperiodStartDate\x12&\n" +
+	"\x0fperiod_end_date\x18\x05 \x01(\tR\rperiodEndDate\x129\n" +
+	"\x0fopening_balance\x18\x06 \x01(\v2\x10.common.v1.MoneyR\x0eopeningBalance\x129\n" +
+	"\x0fclosing_balance\x18\a \x01(\v2\x10.common.v1.MoneyR\x0eclosingBalance\x125\n" +
+	"\rtotal_credits\x18\b \x01(\v2\x10.common.v1.MoneyR\ftotalCredits\x123\n" +
+	"\ftotal_debits\x18\t \x01(\v2\x10.common.v1.MoneyR\vtotalDebits\x12+\n" +
+	"\x11transaction_count\x18\n" +
+	" \x01(\x05R\x10transactionCount\x129\n" +
+	"\x0finterest_earned\x18\v \x01(\v2\x10.common.v1.MoneyR\x0einterestEarned\x12!\n" +
+	"\fdocument_url\x18\f \x01(\tR\vdocumentUrl\x12!\n" +
+	"\fis_finalized\x18\r \x01(\bR\visFinalized\x12=\n" +
+	"\ffinalized_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\vfinalizedAt\"6\n" +
+	"\x15ListStatementsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\"O\n" +
+	"\x16ListStatementsResponse\x125\n" +
+	"\n" +
+	"statements\x18\x01 \x03(\v2\x15.account.v1.StatementR\n" +
+	"statements\"W\n" +
+	"\x13GetStatementRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12!\n" +
+	"\fstatement_id\x18\x02 \x01(\tR\vstatementId\"K\n" +
+	"\x14GetStatementResponse\x123\n" +
+	"\tstatement\x18\x01 \x01(\v2\x15.account.v1.StatementR\tstatement\"\x8d\x01\n" +
+	"\x18GenerateStatementRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12*\n" +
+	"\x11period_start_date\x18\x02 \x01(\tR\x0fperiodStartDate\x12&\n" +
+	"\x0fperiod_end_date\x18\x03 \x01(\tR\rperiodEndDate\"P\n" +
+	"\x19GenerateStatementResponse\x123\n" +
+	"\tstatement\x18\x01 \x01(\v2\x15.account.v1.StatementR\tstatement\"\xa9\x02\n" +
+	"\fLedgerFilter\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x03 \x01(\tR\tentryType\x12\"\n" +
+	"\n" +
+	"min_amount\x18\x04 \x01(\x03H\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\x05 \x01(\x03H\x01R\tmaxAmount\x88\x01\x01\x12\x1c\n" +
+	"\treference\x18\x06 \x01(\tR\treference\x12\x1a\n" +
+	"\bcurrency\x18\a \x01(\tR\bcurrencyB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amount\"\x92\x04\n" +
+	"\vLedgerEntry\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12%\n" +
+	"\x0etransaction_id\x18\x03 \x01(\tR\rtransactionId\x12\x1d\n" +
+	"\n" +
+	"entry_type\x18\x04 \x01(\tR\tentryType\x12(\n" +
+	"\x06amount\x18\x05 \x01(\v2\x10.common.v1.MoneyR\x06amount\x127\n" +
+	"\x0ebalance_before\x18\x06 \x01(\v2\x10.common.v1.MoneyR\rbalanceBefore\x125\n" +
+	"\rbalance_after\x18\a \x01(\v2\x10.common.v1.MoneyR\fbalanceAfter\x129\n" +
+	"\x0frunning_balance\x18\b \x01(\v2\x10.common.v1.MoneyR\x0erunningBalance\x12 \n" +
+	"\vdescription\x18\t \x01(\tR\vdescription\x12\x1c\n" +
+	"\treference\x18\n" +
+	" \x01(\tR\treference\x129\n" +
+	"\n" +
+	"entry_date\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tentryDate\x12\x1d\n" +
+	"\n" +
+	"value_date\x18\f \x01(\tR\tvalueDate\x12\x1f\n" +
+	"\vposted_date\x18\r \x01(\tR\n" +
+	"postedDate\"\x99\x01\n" +
+	"\x18ListLedgerEntriesRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x120\n" +
+	"\x06filter\x18\x02 \x01(\v2\x18.account.v1.LedgerFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limit\"o\n" +
+	"\x19ListLedgerEntriesResponse\x121\n" +
+	"\aentries\x18\x01 \x03(\v2\x17.account.v1.LedgerEntryR\aentries\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"i\n" +
+	"\x16ExportLedgerCsvRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x120\n" +
+	"\x06filter\x18\x02 \x01(\v2\x18.account.v1.LedgerFilterR\x06filter\"*\n" +
+	"\x14ExportLedgerCsvChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x94\x01\n" +
+	"\x15GetBalanceAsOfRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x14\n" +
+	"\x05basis\x18\x03 \x01(\tR\x05basis\x12\x1a\n" +
+	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"\x86\x01\n" +
+	"\x16GetBalanceAsOfResponse\x12*\n" +
+	"\abalance\x18\x01 \x01(\v2\x10.common.v1.MoneyR\abalance\x12*\n" +
+	"\x02at\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02at\x12\x14\n" +
+	"\x05basis\x18\x03 \x01(\tR\x05basis\"\x94\x06\n" +
+	"\x04Card\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x02 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vcustomer_id\x18\x03 \x01(\tR\n" +
+	"customerId\x12*\n" +
+	"\x11card_number_token\x18\x04 \x01(\tR\x0fcardNumberToken\x12\x1b\n" +
+	"\tlast_four\x18\x05 \x01(\tR\blastFour\x12\x1b\n" +
+	"\tcard_type\x18\x06 \x01(\tR\bcardType\x12\x1d\n" +
+	"\n" +
+	"card_brand\x18\a \x01(\tR\tcardBrand\x12!\n" +
+	"\fexpiry_month\x18\b \x01(\x05R\vexpiryMonth\x12\x1f\n" +
+	"\vexpiry_year\x18\t \x01(\x05R\n" +
+	"expiryYear\x121\n" +
+	"\vdaily_limit\x18\n" +
+	" \x01(\v2\x10.common.v1.MoneyR\n" +
+	"dailyLimit\x125\n" +
+	"\rmonthly_limit\x18\v \x01(\v2\x10.common.v1.MoneyR\fmonthlyLimit\x128\n" +
+	"\x0fatm_daily_limit\x18\f \x01(\v2\x10.common.v1.MoneyR\ratmDailyLimit\x12\x16\n" +
+	"\x06status\x18\r \x01(\tR\x06status\x12!\n" +
+	"\fblock_reason\x18\x0e \x01(\tR\vblockReason\x12\x17\n" +
+	"\apin_set\x18\x0f \x01(\bR\x06pinSet\x12D\n" +
+	"\x10pin_locked_until\x18\x10 \x01(\v2\x1a.google.protobuf.TimestampR\x0epinLockedUntil\x127\n" +
+	"\tissued_at\x18\x11 \x01(\v2\x1a.google.protobuf.TimestampR\bissuedAt\x12=\n" +
+	"\factivated_at\x18\x12 \x01(\v2\x1a.google.protobuf.TimestampR\vactivatedAt\x12=\n" +
+	"\fcancelled_at\x18\x13 \x01(\v2\x1a.google.protobuf.TimestampR\vcancelledAt\"q\n" +
+	"\x10IssueCardRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x1f\n" +
+	"\vcustomer_id\x18\x02 \x01(\tR\n" +
+	"customerId\x12\x1d\n" +
+	"\n" +
+	"card_brand\x18\x03 \x01(\tR\tcardBrand\"K\n" +
+	"\x11IssueCardResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\x12\x10\n" +
+	"\x03pan\x18\x02 \x01(\tR\x03pan\")\n" +
+	"\x0eGetCardRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\"7\n" +
+	"\x0fGetCardResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\"C\n" +
+	"\x15GetCardByTokenRequest\x12*\n" +
+	"\x11card_number_token\x18\x01 \x01(\tR\x0fcardNumberToken\">\n" +
+	"\x16GetCardByTokenResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\"1\n" +
+	"\x10ListCardsRequest\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\";\n" +
+	"\x11ListCardsResponse\x12&\n" +
+	"\x05cards\x18\x01 \x03(\v2\x10.account.v1.CardR\x05cards\"b\n" +
+	"\x17UpdateCardStatusRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x16\n" +
+	"\x06action\x18\x02 \x01(\tR\x06action\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reason\"@\n" +
+	"\x18UpdateCardStatusResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\">\n" +
+	"\x11SetCardPinRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x10\n" +
+	"\x03pin\x18\x02 \x01(\tR\x03pin\":\n" +
+	"\x12SetCardPinResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\"A\n" +
+	"\x14VerifyCardPinRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x10\n" +
+	"\x03pin\x18\x02 \x01(\tR\x03pin\"\x8e\x01\n" +
+	"\x15VerifyCardPinResponse\x12\x1a\n" +
+	"\bverified\x18\x01 \x01(\bR\bverified\x12\x1a\n" +
+	"\battempts\x18\x02 \x01(\x05R\battempts\x12=\n" +
+	"\flocked_until\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vlockedUntil\"\x9d\x01\n" +
+	"\x14SetCardLimitsRequest\x12\x17\n" +
+	"\acard_id\x18\x01 \x01(\tR\x06cardId\x12\x1f\n" +
+	"\vdaily_limit\x18\x02 \x01(\x03R\n" +
+	"dailyLimit\x12#\n" +
+	"\rmonthly_limit\x18\x03 \x01(\x03R\fmonthlyLimit\x12&\n" +
+	"\x0fatm_daily_limit\x18\x04 \x01(\x03R\ratmDailyLimit\"=\n" +
+	"\x15SetCardLimitsResponse\x12$\n" +
+	"\x04card\x18\x01 \x01(\v2\x10.account.v1.CardR\x04card\"\x8a\x05\n" +
 	"\aAccount\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1f\n" +
 	"\vcustomer_id\x18\x02 \x01(\tR\n" +
@@ -673,38 +3637,69 @@ const file_account_v1_account_proto_rawDesc = "" +
 	"created_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\x99\x01\n" +
+	"updated_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x129\n" +
+	"\x0foverdraft_limit\x18\f \x01(\v2\x10.common.v1.MoneyR\x0eoverdraftLimit\x129\n" +
+	"\x0fminimum_balance\x18\r \x01(\v2\x10.common.v1.MoneyR\x0eminimumBalance\x123\n" +
+	"\fsub_balances\x18\x0e \x03(\v2\x10.common.v1.MoneyR\vsubBalances\x12!\n" +
+	"\fproduct_code\x18\x0f \x01(\tR\vproductCode\"\xc4\x01\n" +
 	"\x14CreateAccountRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\tR\n" +
 	"customerId\x12!\n" +
-	"\faccount_name\x18\x02 \x01(\tR\vaccountName\x12!\n" +
-	"\faccount_type\x18\x03 \x01(\tR\vaccountType\x12\x1a\n" +
-	"\bcurrency\x18\x04 \x01(\tR\bcurrency\"F\n" +
+	"\faccount_name\x18\x02 \x01(\tR\vaccountName\x12%\n" +
+	"\faccount_type\x18\x03 \x01(\tB\x02\x18\x01R\vaccountType\x12\x1e\n" +
+	"\bcurrency\x18\x04 \x01(\tB\x02\x18\x01R\bcurrency\x12!\n" +
+	"\fproduct_code\x18\x05 \x01(\tR\vproductCode\"F\n" +
 	"\x15CreateAccountResponse\x12-\n" +
 	"\aaccount\x18\x01 \x01(\v2\x13.account.v1.AccountR\aaccount\"2\n" +
 	"\x11GetAccountRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\"C\n" +
 	"\x12GetAccountResponse\x12-\n" +
+	"\aaccount\x18\x01 \x01(\v2\x13.account.v1.AccountR\aaccount\"B\n" +
+	"\x19GetAccountByNumberRequest\x12%\n" +
+	"\x0eaccount_number\x18\x01 \x01(\tR\raccountNumber\"K\n" +
+	"\x1aGetAccountByNumberResponse\x12-\n" +
 	"\aaccount\x18\x01 \x01(\v2\x13.account.v1.AccountR\aaccount\"6\n" +
 	"\x13ListAccountsRequest\x12\x1f\n" +
 	"\vcustomer_id\x18\x01 \x01(\tR\n" +
 	"customerId\"G\n" +
 	"\x14ListAccountsResponse\x12/\n" +
-	"\baccounts\x18\x01 \x03(\v2\x13.account.v1.AccountR\baccounts\"S\n" +
+	"\baccounts\x18\x01 \x03(\v2\x13.account.v1.AccountR\baccounts\"}\n" +
 	"\x1aUpdateAccountStatusRequest\x12\x1d\n" +
 	"\n" +
 	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\"L\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x16\n" +
+	"\x06reason\x18\x03 \x01(\tR\x06reasonJ\x04\b\x04\x10\x05R\n" +
+	"changed_by\"L\n" +
 	"\x1bUpdateAccountStatusResponse\x12-\n" +
-	"\aaccount\x18\x01 \x01(\v2\x13.account.v1.AccountR\aaccount2\xc4\x03\n" +
+	"\aaccount\x18\x01 \x01(\v2\x13.account.v1.AccountR\aaccount2\xe1\x10\n" +
 	"\x0eAccountService\x12T\n" +
 	"\rCreateAccount\x12 .account.v1.CreateAccountRequest\x1a!.account.v1.CreateAccountResponse\x12K\n" +
 	"\n" +
-	"GetAccount\x12\x1d.account.v1.GetAccountRequest\x1a\x1e.account.v1.GetAccountResponse\x12Q\n" +
+	"GetAccount\x12\x1d.account.v1.GetAccountRequest\x1a\x1e.account.v1.GetAccountResponse\x12c\n" +
+	"\x12GetAccountByNumber\x12%.account.v1.GetAccountByNumberRequest\x1a&.account.v1.GetAccountByNumberResponse\x12Q\n" +
 	"\fListAccounts\x12\x1f.account.v1.ListAccountsRequest\x1a .account.v1.ListAccountsResponse\x12f\n" +
-	"\x13UpdateAccountStatus\x12&.account.v1.UpdateAccountStatusRequest\x1a'.account.v1.UpdateAccountStatusResponse\x12T\n" +
-	"\rAdjustBalance\x12 .account.v1.AdjustBalanceRequest\x1a!.account.v1.AdjustBalanceResponseB\x1fZ\x1dnordic-bank/pkg/pb/account/v1b\x06proto3"
+	"\x13UpdateAccountStatus\x12&.account.v1.UpdateAccountStatusRequest\x1a'.account.v1.UpdateAccountStatusResponse\x12l\n" +
+	"\x15CheckHolderPermission\x12(.account.v1.CheckHolderPermissionRequest\x1a).account.v1.CheckHolderPermissionResponse\x12T\n" +
+	"\rAdjustBalance\x12 .account.v1.AdjustBalanceRequest\x1a!.account.v1.AdjustBalanceResponse\x12Q\n" +
+	"\fReserveFunds\x12\x1f.account.v1.ReserveFundsRequest\x1a .account.v1.ReserveFundsResponse\x12c\n" +
+	"\x12ReleaseReservation\x12%.account.v1.ReleaseReservationRequest\x1a&.account.v1.ReleaseReservationResponse\x12c\n" +
+	"\x12CaptureReservation\x12%.account.v1.CaptureReservationRequest\x1a&.account.v1.CaptureReservationResponse\x12W\n" +
+	"\x0eListStatements\x12!.account.v1.ListStatementsRequest\x1a\".account.v1.ListStatementsResponse\x12Q\n" +
+	"\fGetStatement\x12\x1f.account.v1.GetStatementRequest\x1a .account.v1.GetStatementResponse\x12`\n" +
+	"\x11GenerateStatement\x12$.account.v1.GenerateStatementRequest\x1a%.account.v1.GenerateStatementResponse\x12H\n" +
+	"\tIssueCard\x12\x1c.account.v1.IssueCardRequest\x1a\x1d.account.v1.IssueCardResponse\x12B\n" +
+	"\aGetCard\x12\x1a.account.v1.GetCardRequest\x1a\x1b.account.v1.GetCardResponse\x12W\n" +
+	"\x0eGetCardByToken\x12!.account.v1.GetCardByTokenRequest\x1a\".account.v1.GetCardByTokenResponse\x12H\n" +
+	"\tListCards\x12\x1c.account.v1.ListCardsRequest\x1a\x1d.account.v1.ListCardsResponse\x12]\n" +
+	"\x10UpdateCardStatus\x12#.account.v1.UpdateCardStatusRequest\x1a$.account.v1.UpdateCardStatusResponse\x12K\n" +
+	"\n" +
+	"SetCardPin\x12\x1d.account.v1.SetCardPinRequest\x1a\x1e.account.v1.SetCardPinResponse\x12T\n" +
+	"\rVerifyCardPin\x12 .account.v1.VerifyCardPinRequest\x1a!.account.v1.VerifyCardPinResponse\x12T\n" +
+	"\rSetCardLimits\x12 .account.v1.SetCardLimitsRequest\x1a!.account.v1.SetCardLimitsResponse\x12`\n" +
+	"\x11ListLedgerEntries\x12$.account.v1.ListLedgerEntriesRequest\x1a%.account.v1.ListLedgerEntriesResponse\x12Y\n" +
+	"\x0fExportLedgerCsv\x12\".account.v1.ExportLedgerCsvRequest\x1a .account.v1.ExportLedgerCsvChunk0\x01\x12W\n" +
+	"\x0eGetBalanceAsOf\x12!.account.v1.GetBalanceAsOfRequest\x1a\".account.v1.GetBalanceAsOfResponseB\x1fZ\x1dnordic-bank/pkg/pb/account/v1b\x06proto3"
 
 var (
 	file_account_v1_account_proto_rawDescOnce sync.Once
@@ -718,47 +3713,177 @@ func file_account_v1_account_proto_rawDescGZIP() []byte {
 	return file_account_v1_account_proto_rawDescData
 }
 
-var file_account_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_account_v1_account_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_account_v1_account_proto_goTypes = []any{
-	(*AdjustBalanceRequest)(nil),        // 0: account.v1.AdjustBalanceRequest
-	(*AdjustBalanceResponse)(nil),       // 1: account.v1.AdjustBalanceResponse
-	(*Account)(nil),                     // 2: account.v1.Account
-	(*CreateAccountRequest)(nil),        // 3: account.v1.CreateAccountRequest
-	(*CreateAccountResponse)(nil),       // 4: account.v1.CreateAccountResponse
-	(*GetAccountRequest)(nil),           // 5: account.v1.GetAccountRequest
-	(*GetAccountResponse)(nil),          // 6: account.v1.GetAccountResponse
-	(*ListAccountsRequest)(nil),         // 7: account.v1.ListAccountsRequest
-	(*ListAccountsResponse)(nil),        // 8: account.v1.ListAccountsResponse
-	(*UpdateAccountStatusRequest)(nil),  // 9: account.v1.UpdateAccountStatusRequest
-	(*UpdateAccountStatusResponse)(nil), // 10: account.v1.UpdateAccountStatusResponse
-	(*v1.Money)(nil),                    // 11: common.v1.Money
-	(*timestamppb.Timestamp)(nil),       // 12: google.protobuf.Timestamp
+	(*CheckHolderPermissionRequest)(nil),  // 0: account.v1.CheckHolderPermissionRequest
+	(*CheckHolderPermissionResponse)(nil), // 1: account.v1.CheckHolderPermissionResponse
+	(*AdjustBalanceRequest)(nil),          // 2: account.v1.AdjustBalanceRequest
+	(*AdjustBalanceResponse)(nil),         // 3: account.v1.AdjustBalanceResponse
+	(*FundReservation)(nil),               // 4: account.v1.FundReservation
+	(*ReserveFundsRequest)(nil),           // 5: account.v1.ReserveFundsRequest
+	(*ReserveFundsResponse)(nil),          // 6: account.v1.ReserveFundsResponse
+	(*ReleaseReservationRequest)(nil),     // 7: account.v1.ReleaseReservationRequest
+	(*ReleaseReservationResponse)(nil),    // 8: account.v1.ReleaseReservationResponse
+	(*CaptureReservationRequest)(nil),     // 9: account.v1.CaptureReservationRequest
+	(*CaptureReservationResponse)(nil),    // 10: account.v1.CaptureReservationResponse
+	(*Statement)(nil),                     // 11: account.v1.Statement
+	(*ListStatementsRequest)(nil),         // 12: account.v1.ListStatementsRequest
+	(*ListStatementsResponse)(nil),        // 13: account.v1.ListStatementsResponse
+	(*GetStatementRequest)(nil),           // 14: account.v1.GetStatementRequest
+	(*GetStatementResponse)(nil),          // 15: account.v1.GetStatementResponse
+	(*GenerateStatementRequest)(nil),      // 16: account.v1.GenerateStatementRequest
+	(*GenerateStatementResponse)(nil),     // 17: account.v1.GenerateStatementResponse
+	(*LedgerFilter)(nil),                  // 18: account.v1.LedgerFilter
+	(*LedgerEntry)(nil),                   // 19: account.v1.LedgerEntry
+	(*ListLedgerEntriesRequest)(nil),      // 20: account.v1.ListLedgerEntriesRequest
+	(*ListLedgerEntriesResponse)(nil),     // 21: account.v1.ListLedgerEntriesResponse
+	(*ExportLedgerCsvRequest)(nil),        // 22: account.v1.ExportLedgerCsvRequest
+	(*ExportLedgerCsvChunk)(nil),          // 23: account.v1.ExportLedgerCsvChunk
+	(*GetBalanceAsOfRequest)(nil),         // 24: account.v1.GetBalanceAsOfRequest
+	(*GetBalanceAsOfResponse)(nil),        // 25: account.v1.GetBalanceAsOfResponse
+	(*Card)(nil),                          // 26: account.v1.Card
+	(*IssueCardRequest)(nil),              // 27: account.v1.IssueCardRequest
+	(*IssueCardResponse)(nil),             // 28: account.v1.IssueCardResponse
+	(*GetCardRequest)(nil),                // 29: account.v1.GetCardRequest
+	(*GetCardResponse)(nil),               // 30: account.v1.GetCardResponse
+	(*GetCardByTokenRequest)(nil),         // 31: account.v1.GetCardByTokenRequest
+	(*GetCardByTokenResponse)(nil),        // 32: account.v1.GetCardByTokenResponse
+	(*ListCardsRequest)(nil),              // 33: account.v1.ListCardsRequest
+	(*ListCardsResponse)(nil),             // 34: account.v1.ListCardsResponse
+	(*UpdateCardStatusRequest)(nil),       // 35: account.v1.UpdateCardStatusRequest
+	(*UpdateCardStatusResponse)(nil),      // 36: account.v1.UpdateCardStatusResponse
+	(*SetCardPinRequest)(nil),             // 37: account.v1.SetCardPinRequest
+	(*SetCardPinResponse)(nil),            // 38: account.v1.SetCardPinResponse
+	(*VerifyCardPinRequest)(nil),          // 39: account.v1.VerifyCardPinRequest
+	(*VerifyCardPinResponse)(nil),         // 40: account.v1.VerifyCardPinResponse
+	(*SetCardLimitsRequest)(nil),          // 41: account.v1.SetCardLimitsRequest
+	(*SetCardLimitsResponse)(nil),         // 42: account.v1.SetCardLimitsResponse
+	(*Account)(nil),                       // 43: account.v1.Account
+	(*CreateAccountRequest)(nil),          // 44: account.v1.CreateAccountRequest
+	(*CreateAccountResponse)(nil),         // 45: account.v1.CreateAccountResponse
+	(*GetAccountRequest)(nil),             // 46: account.v1.GetAccountRequest
+	(*GetAccountResponse)(nil),            // 47: account.v1.GetAccountResponse
+	(*GetAccountByNumberRequest)(nil),     // 48: account.v1.GetAccountByNumberRequest
+	(*GetAccountByNumberResponse)(nil),    // 49: account.v1.GetAccountByNumberResponse
+	(*ListAccountsRequest)(nil),           // 50: account.v1.ListAccountsRequest
+	(*ListAccountsResponse)(nil),          // 51: account.v1.ListAccountsResponse
+	(*UpdateAccountStatusRequest)(nil),    // 52: account.v1.UpdateAccountStatusRequest
+	(*UpdateAccountStatusResponse)(nil),   // 53: account.v1.UpdateAccountStatusResponse
+	(*v1.Money)(nil),                      // 54: common.v1.Money
+	(*timestamppb.Timestamp)(nil),         // 55: google.protobuf.Timestamp
 }
 var file_account_v1_account_proto_depIdxs = []int32{
-	11, // 0: account.v1.AdjustBalanceResponse.new_balance:type_name -> common.v1.Money
-	11, // 1: account.v1.Account.balance:type_name -> common.v1.Money
-	11, // 2: account.v1.Account.available_balance:type_name -> common.v1.Money
-	12, // 3: account.v1.Account.created_at:type_name -> google.protobuf.Timestamp
-	12, // 4: account.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
-	2,  // 5: account.v1.CreateAccountResponse.account:type_name -> account.v1.Account
-	2,  // 6: account.v1.GetAccountResponse.account:type_name -> account.v1.Account
-	2,  // 7: account.v1.ListAccountsResponse.accounts:type_name -> account.v1.Account
-	2,  // 8: account.v1.UpdateAccountStatusResponse.account:type_name -> account.v1.Account
-	3,  // 9: account.v1.AccountService.CreateAccount:input_type -> account.v1.CreateAccountRequest
-	5,  // 10: account.v1.AccountService.GetAccount:input_type -> account.v1.GetAccountRequest
-	7,  // 11: account.v1.AccountService.ListAccounts:input_type -> account.v1.ListAccountsRequest
-	9,  // 12: account.v1.AccountService.UpdateAccountStatus:input_type -> account.v1.UpdateAccountStatusRequest
-	0,  // 13: account.v1.AccountService.AdjustBalance:input_type -> account.v1.AdjustBalanceRequest
-	4,  // 14: account.v1.AccountService.CreateAccount:output_type -> account.v1.CreateAccountResponse
-	6,  // 15: account.v1.AccountService.GetAccount:output_type -> account.v1.GetAccountResponse
-	8,  // 16: account.v1.AccountService.ListAccounts:output_type -> account.v1.ListAccountsResponse
-	10, // 17: account.v1.AccountService.UpdateAccountStatus:output_type -> account.v1.UpdateAccountStatusResponse
-	1,  // 18: account.v1.AccountService.AdjustBalance:output_type -> account.v1.AdjustBalanceResponse
-	14, // [14:19] is the sub-list for method output_type
-	9,  // [9:14] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	54, // 0: account.v1.AdjustBalanceResponse.new_balance:type_name -> common.v1.Money
+	54, // 1: account.v1.FundReservation.amount:type_name -> common.v1.Money
+	55, // 2: account.v1.FundReservation.reserved_at:type_name -> google.protobuf.Timestamp
+	55, // 3: account.v1.FundReservation.expires_at:type_name -> google.protobuf.Timestamp
+	55, // 4: account.v1.FundReservation.released_at:type_name -> google.protobuf.Timestamp
+	4,  // 5: account.v1.ReserveFundsResponse.reservation:type_name -> account.v1.FundReservation
+	4,  // 6: account.v1.ReleaseReservationResponse.reservation:type_name -> account.v1.FundReservation
+	4,  // 7: account.v1.CaptureReservationResponse.reservation:type_name -> account.v1.FundReservation
+	54, // 8: account.v1.CaptureReservationResponse.new_balance:type_name -> common.v1.Money
+	54, // 9: account.v1.Statement.opening_balance:type_name -> common.v1.Money
+	54, // 10: account.v1.Statement.closing_balance:type_name -> common.v1.Money
+	54, // 11: account.v1.Statement.total_credits:type_name -> common.v1.Money
+	54, // 12: account.v1.Statement.total_debits:type_name -> common.v1.Money
+	54, // 13: account.v1.Statement.interest_earned:type_name -> common.v1.Money
+	55, // 14: account.v1.Statement.finalized_at:type_name -> google.protobuf.Timestamp
+	11, // 15: account.v1.ListStatementsResponse.statements:type_name -> account.v1.Statement
+	11, // 16: account.v1.GetStatementResponse.statement:type_name -> account.v1.Statement
+	11, // 17: account.v1.GenerateStatementResponse.statement:type_name -> account.v1.Statement
+	55, // 18: account.v1.LedgerFilter.from:type_name -> google.protobuf.Timestamp
+	55, // 19: account.v1.LedgerFilter.to:type_name -> google.protobuf.Timestamp
+	54, // 20: account.v1.LedgerEntry.amount:type_name -> common.v1.Money
+	54, // 21: account.v1.LedgerEntry.balance_before:type_name -> common.v1.Money
+	54, // 22: account.v1.LedgerEntry.balance_after:type_name -> common.v1.Money
+	54, // 23: account.v1.LedgerEntry.running_balance:type_name -> common.v1.Money
+	55, // 24: account.v1.LedgerEntry.entry_date:type_name -> google.protobuf.Timestamp
+	18, // 25: account.v1.ListLedgerEntriesRequest.filter:type_name -> account.v1.LedgerFilter
+	19, // 26: account.v1.ListLedgerEntriesResponse.entries:type_name -> account.v1.LedgerEntry
+	18, // 27: account.v1.ExportLedgerCsvRequest.filter:type_name -> account.v1.LedgerFilter
+	55, // 28: account.v1.GetBalanceAsOfRequest.at:type_name -> google.protobuf.Timestamp
+	54, // 29: account.v1.GetBalanceAsOfResponse.balance:type_name -> common.v1.Money
+	55, // 30: account.v1.GetBalanceAsOfResponse.at:type_name -> google.protobuf.Timestamp
+	54, // 31: account.v1.Card.daily_limit:type_name -> common.v1.Money
+	54, // 32: account.v1.Card.monthly_limit:type_name -> common.v1.Money
+	54, // 33: account.v1.Card.atm_daily_limit:type_name -> common.v1.Money
+	55, // 34: account.v1.Card.pin_locked_until:type_name -> google.protobuf.Timestamp
+	55, // 35: account.v1.Card.issued_at:type_name -> google.protobuf.Timestamp
+	55, // 36: account.v1.Card.activated_at:type_name -> google.protobuf.Timestamp
+	55, // 37: account.v1.Card.cancelled_at:type_name -> google.protobuf.Timestamp
+	26, // 38: account.v1.IssueCardResponse.card:type_name -> account.v1.Card
+	26, // 39: account.v1.GetCardResponse.card:type_name -> account.v1.Card
+	26, // 40: account.v1.GetCardByTokenResponse.card:type_name -> account.v1.Card
+	26, // 41: account.v1.ListCardsResponse.cards:type_name -> account.v1.Card
+	26, // 42: account.v1.UpdateCardStatusResponse.card:type_name -> account.v1.Card
+	26, // 43: account.v1.SetCardPinResponse.card:type_name -> account.v1.Card
+	55, // 44: account.v1.VerifyCardPinResponse.locked_until:type_name -> google.protobuf.Timestamp
+	26, // 45: account.v1.SetCardLimitsResponse.card:type_name -> account.v1.Card
+	54, // 46: account.v1.Account.balance:type_name -> common.v1.Money
+	54, // 47: account.v1.Account.available_balance:type_name -> common.v1.Money
+	55, // 48: account.v1.Account.created_at:type_name -> google.protobuf.Timestamp
+	55, // 49: account.v1.Account.updated_at:type_name -> google.protobuf.Timestamp
+	54, // 50: account.v1.Account.overdraft_limit:type_name -> common.v1.Money
+	54, // 51: account.v1.Account.minimum_balance:type_name -> common.v1.Money
+	54, // 52: account.v1.Account.sub_balances:type_name -> common.v1.Money
+	43, // 53: account.v1.CreateAccountResponse.account:type_name -> account.v1.Account
+	43, // 54: account.v1.GetAccountResponse.account:type_name -> account.v1.Account
+	43, // 55: account.v1.GetAccountByNumberResponse.account:type_name -> account.v1.Account
+	43, // 56: account.v1.ListAccountsResponse.accounts:type_name -> account.v1.Account
+	43, // 57: account.v1.UpdateAccountStatusResponse.account:type_name -> account.v1.Account
+	44, // 58: account.v1.AccountService.CreateAccount:input_type -> account.v1.CreateAccountRequest
+	46, // 59: account.v1.AccountService.GetAccount:input_type -> account.v1.GetAccountRequest
+	48, // 60: account.v1.AccountService.GetAccountByNumber:input_type -> account.v1.GetAccountByNumberRequest
+	50, // 61: account.v1.AccountService.ListAccounts:input_type -> account.v1.ListAccountsRequest
+	52, // 62: account.v1.AccountService.UpdateAccountStatus:input_type -> account.v1.UpdateAccountStatusRequest
+	0,  // 63: account.v1.AccountService.CheckHolderPermission:input_type -> account.v1.CheckHolderPermissionRequest
+	2,  // 64: account.v1.AccountService.AdjustBalance:input_type -> account.v1.AdjustBalanceRequest
+	5,  // 65: account.v1.AccountService.ReserveFunds:input_type -> account.v1.ReserveFundsRequest
+	7,  // 66: account.v1.AccountService.ReleaseReservation:input_type -> account.v1.ReleaseReservationRequest
+	9,  // 67: account.v1.AccountService.CaptureReservation:input_type -> account.v1.CaptureReservationRequest
+	12, // 68: account.v1.AccountService.ListStatements:input_type -> account.v1.ListStatementsRequest
+	14, // 69: account.v1.AccountService.GetStatement:input_type -> account.v1.GetStatementRequest
+	16, // 70: account.v1.AccountService.GenerateStatement:input_type -> account.v1.GenerateStatementRequest
+	27, // 71: account.v1.AccountService.IssueCard:input_type -> account.v1.IssueCardRequest
+	29, // 72: account.v1.AccountService.GetCard:input_type -> account.v1.GetCardRequest
+	31, // 73: account.v1.AccountService.GetCardByToken:input_type -> account.v1.GetCardByTokenRequest
+	33, // 74: account.v1.AccountService.ListCards:input_type -> account.v1.ListCardsRequest
+	35, // 75: account.v1.AccountService.UpdateCardStatus:input_type -> account.v1.UpdateCardStatusRequest
+	37, // 76: account.v1.AccountService.SetCardPin:input_type -> account.v1.SetCardPinRequest
+	39, // 77: account.v1.AccountService.VerifyCardPin:input_type -> account.v1.VerifyCardPinRequest
+	41, // 78: account.v1.AccountService.SetCardLimits:input_type -> account.v1.SetCardLimitsRequest
+	20, // 79: account.v1.AccountService.ListLedgerEntries:input_type -> account.v1.ListLedgerEntriesRequest
+	22, // 80: account.v1.AccountService.ExportLedgerCsv:input_type -> account.v1.ExportLedgerCsvRequest
+	24, // 81: account.v1.AccountService.GetBalanceAsOf:input_type -> account.v1.GetBalanceAsOfRequest
+	45, // 82: account.v1.AccountService.CreateAccount:output_type -> account.v1.CreateAccountResponse
+	47, // 83: account.v1.AccountService.GetAccount:output_type -> account.v1.GetAccountResponse
+	49, // 84: account.v1.AccountService.GetAccountByNumber:output_type -> account.v1.GetAccountByNumberResponse
+	51, // 85: account.v1.AccountService.ListAccounts:output_type -> account.v1.ListAccountsResponse
+	53, // 86: account.v1.AccountService.UpdateAccountStatus:output_type -> account.v1.UpdateAccountStatusResponse
+	1,  // 87: account.v1.AccountService.CheckHolderPermission:output_type -> account.v1.CheckHolderPermissionResponse
+	3,  // 88: account.v1.AccountService.AdjustBalance:output_type -> account.v1.AdjustBalanceResponse
+	6,  // 89: account.v1.AccountService.ReserveFunds:output_type -> account.v1.ReserveFundsResponse
+	8,  // 90: account.v1.AccountService.ReleaseReservation:output_type -> account.v1.ReleaseReservationResponse
+	10, // 91: account.v1.AccountService.CaptureReservation:output_type -> account.v1.CaptureReservationResponse
+	13, // 92: account.v1.AccountService.ListStatements:output_type -> account.v1.ListStatementsResponse
+	15, // 93: account.v1.AccountService.GetStatement:output_type -> account.v1.GetStatementResponse
+	17, // 94: account.v1.AccountService.GenerateStatement:output_type -> account.v1.GenerateStatementResponse
+	28, // 95: account.v1.AccountService.IssueCard:output_type -> account.v1.IssueCardResponse
+	30, // 96: account.v1.AccountService.GetCard:output_type -> account.v1.GetCardResponse
+	32, // 97: account.v1.AccountService.GetCardByToken:output_type -> account.v1.GetCardByTokenResponse
+	34, // 98: account.v1.AccountService.ListCards:output_type -> account.v1.ListCardsResponse
+	36, // 99: account.v1.AccountService.UpdateCardStatus:output_type -> account.v1.UpdateCardStatusResponse
+	38, // 100: account.v1.AccountService.SetCardPin:output_type -> account.v1.SetCardPinResponse
+	40, // 101: account.v1.AccountService.VerifyCardPin:output_type -> account.v1.VerifyCardPinResponse
+	42, // 102: account.v1.AccountService.SetCardLimits:output_type -> account.v1.SetCardLimitsResponse
+	21, // 103: account.v1.AccountService.ListLedgerEntries:output_type -> account.v1.ListLedgerEntriesResponse
+	23, // 104: account.v1.AccountService.ExportLedgerCsv:output_type -> account.v1.ExportLedgerCsvChunk
+	25, // 105: account.v1.AccountService.GetBalanceAsOf:output_type -> account.v1.GetBalanceAsOfResponse
+	82, // [82:106] is the sub-list for method output_type
+	58, // [58:82] is the sub-list for method input_type
+	58, // [58:58] is the sub-list for extension type_name
+	58, // [58:58] is the sub-list for extension extendee
+	0,  // [0:58] is the sub-list for field type_name
 }
 
 func init() { file_account_v1_account_proto_init() }
@@ -766,13 +3891,14 @@ func file_account_v1_account_proto_init() {
 	if File_account_v1_account_proto != nil {
 		return
 	}
+	file_account_v1_account_proto_msgTypes[18].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_account_v1_account_proto_rawDesc), len(file_account_v1_account_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
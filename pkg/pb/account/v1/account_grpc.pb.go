@@ -19,11 +19,30 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AccountService_CreateAccount_FullMethodName       = "/account.v1.AccountService/CreateAccount"
-	AccountService_GetAccount_FullMethodName          = "/account.v1.AccountService/GetAccount"
-	AccountService_ListAccounts_FullMethodName        = "/account.v1.AccountService/ListAccounts"
-	AccountService_UpdateAccountStatus_FullMethodName = "/account.v1.AccountService/UpdateAccountStatus"
-	AccountService_AdjustBalance_FullMethodName       = "/account.v1.AccountService/AdjustBalance"
+	AccountService_CreateAccount_FullMethodName         = "/account.v1.AccountService/CreateAccount"
+	AccountService_GetAccount_FullMethodName            = "/account.v1.AccountService/GetAccount"
+	AccountService_GetAccountByNumber_FullMethodName    = "/account.v1.AccountService/GetAccountByNumber"
+	AccountService_ListAccounts_FullMethodName          = "/account.v1.AccountService/ListAccounts"
+	AccountService_UpdateAccountStatus_FullMethodName   = "/account.v1.AccountService/UpdateAccountStatus"
+	AccountService_CheckHolderPermission_FullMethodName = "/account.v1.AccountService/CheckHolderPermission"
+	AccountService_AdjustBalance_FullMethodName         = "/account.v1.AccountService/AdjustBalance"
+	AccountService_ReserveFunds_FullMethodName          = "/account.v1.AccountService/ReserveFunds"
+	AccountService_ReleaseReservation_FullMethodName    = "/account.v1.AccountService/ReleaseReservation"
+	AccountService_CaptureReservation_FullMethodName    = "/account.v1.AccountService/CaptureReservation"
+	AccountService_ListStatements_FullMethodName        = "/account.v1.AccountService/ListStatements"
+	AccountService_GetStatement_FullMethodName          = "/account.v1.AccountService/GetStatement"
+	AccountService_GenerateStatement_FullMethodName     = "/account.v1.AccountService/GenerateStatement"
+	AccountService_IssueCard_FullMethodName             = "/account.v1.AccountService/IssueCard"
+	AccountService_GetCard_FullMethodName               = "/account.v1.AccountService/GetCard"
+	AccountService_GetCardByToken_FullMethodName        = "/account.v1.AccountService/GetCardByToken"
+	AccountService_ListCards_FullMethodName             = "/account.v1.AccountService/ListCards"
+	AccountService_UpdateCardStatus_FullMethodName      = "/account.v1.AccountService/UpdateCardStatus"
+	AccountService_SetCardPin_FullMethodName            = "/account.v1.AccountService/SetCardPin"
+	AccountService_VerifyCardPin_FullMethodName         = "/account.v1.AccountService/VerifyCardPin"
+	AccountService_SetCardLimits_FullMethodName         = "/account.v1.AccountService/SetCardLimits"
+	AccountService_ListLedgerEntries_FullMethodName     = "/account.v1.AccountService/ListLedgerEntries"
+	AccountService_ExportLedgerCsv_FullMethodName       = "/account.v1.AccountService/ExportLedgerCsv"
+	AccountService_GetBalanceAsOf_FullMethodName        = "/account.v1.AccountService/GetBalanceAsOf"
 )
 
 // AccountServiceClient is the client API for AccountService service.
@@ -34,12 +53,50 @@ type AccountServiceClient interface {
 	CreateAccount(ctx context.Context, in *CreateAccountRequest, opts ...grpc.CallOption) (*CreateAccountResponse, error)
 	// Get account by ID
 	GetAccount(ctx context.Context, in *GetAccountRequest, opts ...grpc.CallOption) (*GetAccountResponse, error)
+	// Get account by account number (IBAN)
+	GetAccountByNumber(ctx context.Context, in *GetAccountByNumberRequest, opts ...grpc.CallOption) (*GetAccountByNumberResponse, error)
 	// Get accounts by customer ID
 	ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error)
 	// Update account status (freeze/unfreeze/close)
 	UpdateAccountStatus(ctx context.Context, in *UpdateAccountStatusRequest, opts ...grpc.CallOption) (*UpdateAccountStatusResponse, error)
+	// Check that a customer holds an account with the given permission
+	CheckHolderPermission(ctx context.Context, in *CheckHolderPermissionRequest, opts ...grpc.CallOption) (*CheckHolderPermissionResponse, error)
 	// Adjust account balance (for transactions)
 	AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AdjustBalanceResponse, error)
+	// Place a hold on funds for a pending transaction
+	ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*ReserveFundsResponse, error)
+	// Release a hold without debiting the account
+	ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error)
+	// Debit the account for a held amount (full or partial)
+	CaptureReservation(ctx context.Context, in *CaptureReservationRequest, opts ...grpc.CallOption) (*CaptureReservationResponse, error)
+	// List the finalised statements of an account, newest first
+	ListStatements(ctx context.Context, in *ListStatementsRequest, opts ...grpc.CallOption) (*ListStatementsResponse, error)
+	// Get a single statement
+	GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error)
+	// Generate a statement for a closed period
+	GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*GenerateStatementResponse, error)
+	// Issue an inactive debit card on an account
+	IssueCard(ctx context.Context, in *IssueCardRequest, opts ...grpc.CallOption) (*IssueCardResponse, error)
+	// Get a single card
+	GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*GetCardResponse, error)
+	// Get the card behind a card number token
+	GetCardByToken(ctx context.Context, in *GetCardByTokenRequest, opts ...grpc.CallOption) (*GetCardByTokenResponse, error)
+	// List the cards of an account, newest first
+	ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error)
+	// Move a card through its lifecycle (activate, block, unblock, cancel)
+	UpdateCardStatus(ctx context.Context, in *UpdateCardStatusRequest, opts ...grpc.CallOption) (*UpdateCardStatusResponse, error)
+	// Set or change the PIN of a card
+	SetCardPin(ctx context.Context, in *SetCardPinRequest, opts ...grpc.CallOption) (*SetCardPinResponse, error)
+	// Verify a PIN, counting wrong attempts towards the lockout
+	VerifyCardPin(ctx context.Context, in *VerifyCardPinRequest, opts ...grpc.CallOption) (*VerifyCardPinResponse, error)
+	// Replace the spending limits of a card
+	SetCardLimits(ctx context.Context, in *SetCardLimitsRequest, opts ...grpc.CallOption) (*SetCardLimitsResponse, error)
+	// List ledger entries with their running balances, newest first
+	ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesResponse, error)
+	// Stream matching ledger entries as CSV, newest first
+	ExportLedgerCsv(ctx context.Context, in *ExportLedgerCsvRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportLedgerCsvChunk], error)
+	// Rebuild the balance at a point in time from the ledger
+	GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error)
 }
 
 type accountServiceClient struct {
@@ -70,6 +127,16 @@ func (c *accountServiceClient) GetAccount(ctx context.Context, in *GetAccountReq
 	return out, nil
 }
 
+func (c *accountServiceClient) GetAccountByNumber(ctx context.Context, in *GetAccountByNumberRequest, opts ...grpc.CallOption) (*GetAccountByNumberResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetAccountByNumberResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetAccountByNumber_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountServiceClient) ListAccounts(ctx context.Context, in *ListAccountsRequest, opts ...grpc.CallOption) (*ListAccountsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListAccountsResponse)
@@ -90,6 +157,16 @@ func (c *accountServiceClient) UpdateAccountStatus(ctx context.Context, in *Upda
 	return out, nil
 }
 
+func (c *accountServiceClient) CheckHolderPermission(ctx context.Context, in *CheckHolderPermissionRequest, opts ...grpc.CallOption) (*CheckHolderPermissionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckHolderPermissionResponse)
+	err := c.cc.Invoke(ctx, AccountService_CheckHolderPermission_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *accountServiceClient) AdjustBalance(ctx context.Context, in *AdjustBalanceRequest, opts ...grpc.CallOption) (*AdjustBalanceResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(AdjustBalanceResponse)
@@ -100,6 +177,185 @@ func (c *accountServiceClient) AdjustBalance(ctx context.Context, in *AdjustBala
 	return out, nil
 }
 
+func (c *accountServiceClient) ReserveFunds(ctx context.Context, in *ReserveFundsRequest, opts ...grpc.CallOption) (*ReserveFundsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReserveFundsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ReserveFunds_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ReleaseReservation(ctx context.Context, in *ReleaseReservationRequest, opts ...grpc.CallOption) (*ReleaseReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReleaseReservationResponse)
+	err := c.cc.Invoke(ctx, AccountService_ReleaseReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) CaptureReservation(ctx context.Context, in *CaptureReservationRequest, opts ...grpc.CallOption) (*CaptureReservationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CaptureReservationResponse)
+	err := c.cc.Invoke(ctx, AccountService_CaptureReservation_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListStatements(ctx context.Context, in *ListStatementsRequest, opts ...grpc.CallOption) (*ListStatementsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStatementsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListStatements_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetStatement(ctx context.Context, in *GetStatementRequest, opts ...grpc.CallOption) (*GetStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStatementResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GenerateStatement(ctx context.Context, in *GenerateStatementRequest, opts ...grpc.CallOption) (*GenerateStatementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GenerateStatementResponse)
+	err := c.cc.Invoke(ctx, AccountService_GenerateStatement_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) IssueCard(ctx context.Context, in *IssueCardRequest, opts ...grpc.CallOption) (*IssueCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IssueCardResponse)
+	err := c.cc.Invoke(ctx, AccountService_IssueCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetCard(ctx context.Context, in *GetCardRequest, opts ...grpc.CallOption) (*GetCardResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCardResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetCard_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) GetCardByToken(ctx context.Context, in *GetCardByTokenRequest, opts ...grpc.CallOption) (*GetCardByTokenResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCardByTokenResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetCardByToken_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListCards(ctx context.Context, in *ListCardsRequest, opts ...grpc.CallOption) (*ListCardsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListCardsResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListCards_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) UpdateCardStatus(ctx context.Context, in *UpdateCardStatusRequest, opts ...grpc.CallOption) (*UpdateCardStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UpdateCardStatusResponse)
+	err := c.cc.Invoke(ctx, AccountService_UpdateCardStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) SetCardPin(ctx context.Context, in *SetCardPinRequest, opts ...grpc.CallOption) (*SetCardPinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCardPinResponse)
+	err := c.cc.Invoke(ctx, AccountService_SetCardPin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) VerifyCardPin(ctx context.Context, in *VerifyCardPinRequest, opts ...grpc.CallOption) (*VerifyCardPinResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyCardPinResponse)
+	err := c.cc.Invoke(ctx, AccountService_VerifyCardPin_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) SetCardLimits(ctx context.Context, in *SetCardLimitsRequest, opts ...grpc.CallOption) (*SetCardLimitsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetCardLimitsResponse)
+	err := c.cc.Invoke(ctx, AccountService_SetCardLimits_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ListLedgerEntries(ctx context.Context, in *ListLedgerEntriesRequest, opts ...grpc.CallOption) (*ListLedgerEntriesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLedgerEntriesResponse)
+	err := c.cc.Invoke(ctx, AccountService_ListLedgerEntries_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *accountServiceClient) ExportLedgerCsv(ctx context.Context, in *ExportLedgerCsvRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ExportLedgerCsvChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &AccountService_ServiceDesc.Streams[0], AccountService_ExportLedgerCsv_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportLedgerCsvRequest, ExportLedgerCsvChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AccountService_ExportLedgerCsvClient = grpc.ServerStreamingClient[ExportLedgerCsvChunk]
+
+func (c *accountServiceClient) GetBalanceAsOf(ctx context.Context, in *GetBalanceAsOfRequest, opts ...grpc.CallOption) (*GetBalanceAsOfResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetBalanceAsOfResponse)
+	err := c.cc.Invoke(ctx, AccountService_GetBalanceAsOf_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AccountServiceServer is the server API for AccountService service.
 // All implementations must embed UnimplementedAccountServiceServer
 // for forward compatibility.
@@ -108,12 +364,50 @@ type AccountServiceServer interface {
 	CreateAccount(context.Context, *CreateAccountRequest) (*CreateAccountResponse, error)
 	// Get account by ID
 	GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error)
+	// Get account by account number (IBAN)
+	GetAccountByNumber(context.Context, *GetAccountByNumberRequest) (*GetAccountByNumberResponse, error)
 	// Get accounts by customer ID
 	ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error)
 	// Update account status (freeze/unfreeze/close)
 	UpdateAccountStatus(context.Context, *UpdateAccountStatusRequest) (*UpdateAccountStatusResponse, error)
+	// Check that a customer holds an account with the given permission
+	CheckHolderPermission(context.Context, *CheckHolderPermissionRequest) (*CheckHolderPermissionResponse, error)
 	// Adjust account balance (for transactions)
 	AdjustBalance(context.Context, *AdjustBalanceRequest) (*AdjustBalanceResponse, error)
+	// Place a hold on funds for a pending transaction
+	ReserveFunds(context.Context, *ReserveFundsRequest) (*ReserveFundsResponse, error)
+	// Release a hold without debiting the account
+	ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error)
+	// Debit the account for a held amount (full or partial)
+	CaptureReservation(context.Context, *CaptureReservationRequest) (*CaptureReservationResponse, error)
+	// List the finalised statements of an account, newest first
+	ListStatements(context.Context, *ListStatementsRequest) (*ListStatementsResponse, error)
+	// Get a single statement
+	GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error)
+	// Generate a statement for a closed period
+	GenerateStatement(context.Context, *GenerateStatementRequest) (*GenerateStatementResponse, error)
+	// Issue an inactive debit card on an account
+	IssueCard(context.Context, *IssueCardRequest) (*IssueCardResponse, error)
+	// Get a single card
+	GetCard(context.Context, *GetCardRequest) (*GetCardResponse, error)
+	// Get the card behind a card number token
+	GetCardByToken(context.Context, *GetCardByTokenRequest) (*GetCardByTokenResponse, error)
+	// List the cards of an account, newest first
+	ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error)
+	// Move a card through its lifecycle (activate, block, unblock, cancel)
+	UpdateCardStatus(context.Context, *UpdateCardStatusRequest) (*UpdateCardStatusResponse, error)
+	// Set or change the PIN of a card
+	SetCardPin(context.Context, *SetCardPinRequest) (*SetCardPinResponse, error)
+	// Verify a PIN, counting wrong attempts towards the lockout
+	VerifyCardPin(context.Context, *VerifyCardPinRequest) (*VerifyCardPinResponse, error)
+	// Replace the spending limits of a card
+	SetCardLimits(context.Context, *SetCardLimitsRequest) (*SetCardLimitsResponse, error)
+	// List ledger entries with their running balances, newest first
+	ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesResponse, error)
+	// Stream matching ledger entries as CSV, newest first
+	ExportLedgerCsv(*ExportLedgerCsvRequest, grpc.ServerStreamingServer[ExportLedgerCsvChunk]) error
+	// Rebuild the balance at a point in time from the ledger
+	GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error)
 	mustEmbedUnimplementedAccountServiceServer()
 }
 
@@ -130,15 +424,72 @@ func (UnimplementedAccountServiceServer) CreateAccount(context.Context, *CreateA
 func (UnimplementedAccountServiceServer) GetAccount(context.Context, *GetAccountRequest) (*GetAccountResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetAccount not implemented")
 }
+func (UnimplementedAccountServiceServer) GetAccountByNumber(context.Context, *GetAccountByNumberRequest) (*GetAccountByNumberResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetAccountByNumber not implemented")
+}
 func (UnimplementedAccountServiceServer) ListAccounts(context.Context, *ListAccountsRequest) (*ListAccountsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListAccounts not implemented")
 }
 func (UnimplementedAccountServiceServer) UpdateAccountStatus(context.Context, *UpdateAccountStatusRequest) (*UpdateAccountStatusResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateAccountStatus not implemented")
 }
+func (UnimplementedAccountServiceServer) CheckHolderPermission(context.Context, *CheckHolderPermissionRequest) (*CheckHolderPermissionResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CheckHolderPermission not implemented")
+}
 func (UnimplementedAccountServiceServer) AdjustBalance(context.Context, *AdjustBalanceRequest) (*AdjustBalanceResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method AdjustBalance not implemented")
 }
+func (UnimplementedAccountServiceServer) ReserveFunds(context.Context, *ReserveFundsRequest) (*ReserveFundsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReserveFunds not implemented")
+}
+func (UnimplementedAccountServiceServer) ReleaseReservation(context.Context, *ReleaseReservationRequest) (*ReleaseReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReleaseReservation not implemented")
+}
+func (UnimplementedAccountServiceServer) CaptureReservation(context.Context, *CaptureReservationRequest) (*CaptureReservationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CaptureReservation not implemented")
+}
+func (UnimplementedAccountServiceServer) ListStatements(context.Context, *ListStatementsRequest) (*ListStatementsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStatements not implemented")
+}
+func (UnimplementedAccountServiceServer) GetStatement(context.Context, *GetStatementRequest) (*GetStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStatement not implemented")
+}
+func (UnimplementedAccountServiceServer) GenerateStatement(context.Context, *GenerateStatementRequest) (*GenerateStatementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GenerateStatement not implemented")
+}
+func (UnimplementedAccountServiceServer) IssueCard(context.Context, *IssueCardRequest) (*IssueCardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method IssueCard not implemented")
+}
+func (UnimplementedAccountServiceServer) GetCard(context.Context, *GetCardRequest) (*GetCardResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCard not implemented")
+}
+func (UnimplementedAccountServiceServer) GetCardByToken(context.Context, *GetCardByTokenRequest) (*GetCardByTokenResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCardByToken not implemented")
+}
+func (UnimplementedAccountServiceServer) ListCards(context.Context, *ListCardsRequest) (*ListCardsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListCards not implemented")
+}
+func (UnimplementedAccountServiceServer) UpdateCardStatus(context.Context, *UpdateCardStatusRequest) (*UpdateCardStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UpdateCardStatus not implemented")
+}
+func (UnimplementedAccountServiceServer) SetCardPin(context.Context, *SetCardPinRequest) (*SetCardPinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCardPin not implemented")
+}
+func (UnimplementedAccountServiceServer) VerifyCardPin(context.Context, *VerifyCardPinRequest) (*VerifyCardPinResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyCardPin not implemented")
+}
+func (UnimplementedAccountServiceServer) SetCardLimits(context.Context, *SetCardLimitsRequest) (*SetCardLimitsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetCardLimits not implemented")
+}
+func (UnimplementedAccountServiceServer) ListLedgerEntries(context.Context, *ListLedgerEntriesRequest) (*ListLedgerEntriesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLedgerEntries not implemented")
+}
+func (UnimplementedAccountServiceServer) ExportLedgerCsv(*ExportLedgerCsvRequest, grpc.ServerStreamingServer[ExportLedgerCsvChunk]) error {
+	return status.Error(codes.Unimplemented, "method ExportLedgerCsv not implemented")
+}
+func (UnimplementedAccountServiceServer) GetBalanceAsOf(context.Context, *GetBalanceAsOfRequest) (*GetBalanceAsOfResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetBalanceAsOf not implemented")
+}
 func (UnimplementedAccountServiceServer) mustEmbedUnimplementedAccountServiceServer() {}
 func (UnimplementedAccountServiceServer) testEmbeddedByValue()                        {}
 
@@ -196,6 +547,24 @@ func _AccountService_GetAccount_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_GetAccountByNumber_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAccountByNumberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetAccountByNumber(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetAccountByNumber_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetAccountByNumber(ctx, req.(*GetAccountByNumberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountService_ListAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ListAccountsRequest)
 	if err := dec(in); err != nil {
@@ -232,6 +601,24 @@ func _AccountService_UpdateAccountStatus_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_CheckHolderPermission_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckHolderPermissionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).CheckHolderPermission(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_CheckHolderPermission_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).CheckHolderPermission(ctx, req.(*CheckHolderPermissionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _AccountService_AdjustBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(AdjustBalanceRequest)
 	if err := dec(in); err != nil {
@@ -250,6 +637,305 @@ func _AccountService_AdjustBalance_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AccountService_ReserveFunds_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReserveFundsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ReserveFunds(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ReserveFunds_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ReserveFunds(ctx, req.(*ReserveFundsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ReleaseReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReleaseReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ReleaseReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ReleaseReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ReleaseReservation(ctx, req.(*ReleaseReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_CaptureReservation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CaptureReservationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).CaptureReservation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_CaptureReservation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).CaptureReservation(ctx, req.(*CaptureReservationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListStatements_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStatementsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListStatements(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListStatements_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListStatements(ctx, req.(*ListStatementsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetStatement(ctx, req.(*GetStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GenerateStatement_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GenerateStatementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GenerateStatement(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GenerateStatement_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GenerateStatement(ctx, req.(*GenerateStatementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_IssueCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssueCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).IssueCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_IssueCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).IssueCard(ctx, req.(*IssueCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetCard_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetCard(ctx, req.(*GetCardRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_GetCardByToken_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCardByTokenRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetCardByToken(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetCardByToken_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetCardByToken(ctx, req.(*GetCardByTokenRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListCardsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListCards_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListCards(ctx, req.(*ListCardsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_UpdateCardStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateCardStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).UpdateCardStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_UpdateCardStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).UpdateCardStatus(ctx, req.(*UpdateCardStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_SetCardPin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardPinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).SetCardPin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_SetCardPin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).SetCardPin(ctx, req.(*SetCardPinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_VerifyCardPin_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyCardPinRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).VerifyCardPin(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_VerifyCardPin_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).VerifyCardPin(ctx, req.(*VerifyCardPinRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_SetCardLimits_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetCardLimitsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).SetCardLimits(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_SetCardLimits_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).SetCardLimits(ctx, req.(*SetCardLimitsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ListLedgerEntries_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLedgerEntriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).ListLedgerEntries(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_ListLedgerEntries_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).ListLedgerEntries(ctx, req.(*ListLedgerEntriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AccountService_ExportLedgerCsv_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportLedgerCsvRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(AccountServiceServer).ExportLedgerCsv(m, &grpc.GenericServerStream[ExportLedgerCsvRequest, ExportLedgerCsvChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type AccountService_ExportLedgerCsvServer = grpc.ServerStreamingServer[ExportLedgerCsvChunk]
+
+func _AccountService_GetBalanceAsOf_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceAsOfRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AccountServiceServer).GetBalanceAsOf(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AccountService_GetBalanceAsOf_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AccountServiceServer).GetBalanceAsOf(ctx, req.(*GetBalanceAsOfRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AccountService_ServiceDesc is the grpc.ServiceDesc for AccountService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -265,6 +951,10 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetAccount",
 			Handler:    _AccountService_GetAccount_Handler,
 		},
+		{
+			MethodName: "GetAccountByNumber",
+			Handler:    _AccountService_GetAccountByNumber_Handler,
+		},
 		{
 			MethodName: "ListAccounts",
 			Handler:    _AccountService_ListAccounts_Handler,
@@ -273,11 +963,85 @@ var AccountService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateAccountStatus",
 			Handler:    _AccountService_UpdateAccountStatus_Handler,
 		},
+		{
+			MethodName: "CheckHolderPermission",
+			Handler:    _AccountService_CheckHolderPermission_Handler,
+		},
 		{
 			MethodName: "AdjustBalance",
 			Handler:    _AccountService_AdjustBalance_Handler,
 		},
+		{
+			MethodName: "ReserveFunds",
+			Handler:    _AccountService_ReserveFunds_Handler,
+		},
+		{
+			MethodName: "ReleaseReservation",
+			Handler:    _AccountService_ReleaseReservation_Handler,
+		},
+		{
+			MethodName: "CaptureReservation",
+			Handler:    _AccountService_CaptureReservation_Handler,
+		},
+		{
+			MethodName: "ListStatements",
+			Handler:    _AccountService_ListStatements_Handler,
+		},
+		{
+			MethodName: "GetStatement",
+			Handler:    _AccountService_GetStatement_Handler,
+		},
+		{
+			MethodName: "GenerateStatement",
+			Handler:    _AccountService_GenerateStatement_Handler,
+		},
+		{
+			MethodName: "IssueCard",
+			Handler:    _AccountService_IssueCard_Handler,
+		},
+		{
+			MethodName: "GetCard",
+			Handler:    _AccountService_GetCard_Handler,
+		},
+		{
+			MethodName: "GetCardByToken",
+			Handler:    _AccountService_GetCardByToken_Handler,
+		},
+		{
+			MethodName: "ListCards",
+			Handler:    _AccountService_ListCards_Handler,
+		},
+		{
+			MethodName: "UpdateCardStatus",
+			Handler:    _AccountService_UpdateCardStatus_Handler,
+		},
+		{
+			MethodName: "SetCardPin",
+			Handler:    _AccountService_SetCardPin_Handler,
+		},
+		{
+			MethodName: "VerifyCardPin",
+			Handler:    _AccountService_VerifyCardPin_Handler,
+		},
+		{
+			MethodName: "SetCardLimits",
+			Handler:    _AccountService_SetCardLimits_Handler,
+		},
+		{
+			MethodName: "ListLedgerEntries",
+			Handler:    _AccountService_ListLedgerEntries_Handler,
+		},
+		{
+			MethodName: "GetBalanceAsOf",
+			Handler:    _AccountService_GetBalanceAsOf_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ExportLedgerCsv",
+			Handler:       _AccountService_ExportLedgerCsv_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "account/v1/account.proto",
 }
@@ -36,6 +36,7 @@ type Transaction struct {
 	CreatedAt            *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt            *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	IdempotencyKey       string                 `protobuf:"bytes,11,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	Category             string                 `protobuf:"bytes,12,opt,name=category,proto3" json:"category,omitempty"` // transfer, cash, groceries, dining, etc.
 	unknownFields        protoimpl.UnknownFields
 	sizeCache            protoimpl.SizeCache
 }
@@ -147,16 +148,25 @@ func (x *Transaction) GetIdempotencyKey() string {
 	return ""
 }
 
+func (x *Transaction) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
 type CreateTransferRequest struct {
-	state                protoimpl.MessageState `protogen:"open.v1"`
-	SourceAccountId      string                 `protobuf:"bytes,1,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
-	DestinationAccountId string                 `protobuf:"bytes,2,opt,name=destination_account_id,json=destinationAccountId,proto3" json:"destination_account_id,omitempty"`
-	Amount               *v1.Money              `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
-	Reference            string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
-	Description          string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
-	IdempotencyKey       string                 `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
-	unknownFields        protoimpl.UnknownFields
-	sizeCache            protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	SourceAccountId       string                 `protobuf:"bytes,1,opt,name=source_account_id,json=sourceAccountId,proto3" json:"source_account_id,omitempty"`
+	DestinationAccountId  string                 `protobuf:"bytes,2,opt,name=destination_account_id,json=destinationAccountId,proto3" json:"destination_account_id,omitempty"`
+	Amount                *v1.Money              `protobuf:"bytes,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Reference             string                 `protobuf:"bytes,4,opt,name=reference,proto3" json:"reference,omitempty"`
+	Description           string                 `protobuf:"bytes,5,opt,name=description,proto3" json:"description,omitempty"`
+	IdempotencyKey        string                 `protobuf:"bytes,6,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`
+	DestinationIban       string                 `protobuf:"bytes,7,opt,name=destination_iban,json=destinationIban,proto3" json:"destination_iban,omitempty"`                       // Alternative to destination_account_id
+	InitiatedByCustomerId string                 `protobuf:"bytes,8,opt,name=initiated_by_customer_id,json=initiatedByCustomerId,proto3" json:"initiated_by_customer_id,omitempty"` // Customer the transfer is made for, checked against the source account holder permissions
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *CreateTransferRequest) Reset() {
@@ -231,6 +241,20 @@ func (x *CreateTransferRequest) GetIdempotencyKey() string {
 	return ""
 }
 
+func (x *CreateTransferRequest) GetDestinationIban() string {
+	if x != nil {
+		return x.DestinationIban
+	}
+	return ""
+}
+
+func (x *CreateTransferRequest) GetInitiatedByCustomerId() string {
+	if x != nil {
+		return x.InitiatedByCustomerId
+	}
+	return ""
+}
+
 type CreateTransferResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transaction   *Transaction           `protobuf:"bytes,1,opt,name=transaction,proto3" json:"transaction,omitempty"`
@@ -363,10 +387,12 @@ func (x *GetTransactionResponse) GetTransaction() *Transaction {
 	return nil
 }
 
+// Pages are keyed on creation time and ID, like SearchTransactions
 type ListTransactionsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
-	Pagination    *v1.PaginationRequest  `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	Cursor        string                 `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -408,17 +434,24 @@ func (x *ListTransactionsRequest) GetAccountId() string {
 	return ""
 }
 
-func (x *ListTransactionsRequest) GetPagination() *v1.PaginationRequest {
+func (x *ListTransactionsRequest) GetCursor() string {
 	if x != nil {
-		return x.Pagination
+		return x.Cursor
 	}
-	return nil
+	return ""
+}
+
+func (x *ListTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
 }
 
 type ListTransactionsResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
-	Pagination    *v1.PaginationResponse `protobuf:"bytes,2,opt,name=pagination,proto3" json:"pagination,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -460,11 +493,11 @@ func (x *ListTransactionsResponse) GetTransactions() []*Transaction {
 	return nil
 }
 
-func (x *ListTransactionsResponse) GetPagination() *v1.PaginationResponse {
+func (x *ListTransactionsResponse) GetNextCursor() string {
 	if x != nil {
-		return x.Pagination
+		return x.NextCursor
 	}
-	return nil
+	return ""
 }
 
 type GetTransactionStatsRequest struct {
@@ -587,11 +620,247 @@ func (x *GetTransactionStatsResponse) GetCount() int32 {
 	return 0
 }
 
+type TransactionFilter struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	From                  *timestamppb.Timestamp `protobuf:"bytes,1,opt,name=from,proto3" json:"from,omitempty"`                                   // Inclusive
+	To                    *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=to,proto3" json:"to,omitempty"`                                       // Exclusive
+	MinAmount             *int64                 `protobuf:"varint,3,opt,name=min_amount,json=minAmount,proto3,oneof" json:"min_amount,omitempty"` // Minor units
+	MaxAmount             *int64                 `protobuf:"varint,4,opt,name=max_amount,json=maxAmount,proto3,oneof" json:"max_amount,omitempty"`
+	Type                  string                 `protobuf:"bytes,5,opt,name=type,proto3" json:"type,omitempty"`
+	Status                string                 `protobuf:"bytes,6,opt,name=status,proto3" json:"status,omitempty"`
+	AccountId             string                 `protobuf:"bytes,7,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`                                       // Either side of the transaction
+	CounterpartyAccountId string                 `protobuf:"bytes,8,opt,name=counterparty_account_id,json=counterpartyAccountId,proto3" json:"counterparty_account_id,omitempty"` // With account_id, only transactions between the two
+	Category              string                 `protobuf:"bytes,9,opt,name=category,proto3" json:"category,omitempty"`
+	Reference             string                 `protobuf:"bytes,10,opt,name=reference,proto3" json:"reference,omitempty"` // Case-insensitive prefix
+	Text                  string                 `protobuf:"bytes,11,opt,name=text,proto3" json:"text,omitempty"`           // Full-text search of the description
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *TransactionFilter) Reset() {
+	*x = TransactionFilter{}
+	mi := &file_transaction_v1_transaction_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransactionFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransactionFilter) ProtoMessage() {}
+
+func (x *TransactionFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_v1_transaction_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransactionFilter.ProtoReflect.Descriptor instead.
+func (*TransactionFilter) Descriptor() ([]byte, []int) {
+	return file_transaction_v1_transaction_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *TransactionFilter) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *TransactionFilter) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *TransactionFilter) GetMinAmount() int64 {
+	if x != nil && x.MinAmount != nil {
+		return *x.MinAmount
+	}
+	return 0
+}
+
+func (x *TransactionFilter) GetMaxAmount() int64 {
+	if x != nil && x.MaxAmount != nil {
+		return *x.MaxAmount
+	}
+	return 0
+}
+
+func (x *TransactionFilter) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetCounterpartyAccountId() string {
+	if x != nil {
+		return x.CounterpartyAccountId
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetReference() string {
+	if x != nil {
+		return x.Reference
+	}
+	return ""
+}
+
+func (x *TransactionFilter) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type SearchTransactionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *TransactionFilter     `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Cursor        string                 `protobuf:"bytes,2,opt,name=cursor,proto3" json:"cursor,omitempty"` // next_cursor of the previous page
+	Limit         int32                  `protobuf:"varint,3,opt,name=limit,proto3" json:"limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTransactionsRequest) Reset() {
+	*x = SearchTransactionsRequest{}
+	mi := &file_transaction_v1_transaction_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTransactionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTransactionsRequest) ProtoMessage() {}
+
+func (x *SearchTransactionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_v1_transaction_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTransactionsRequest.ProtoReflect.Descriptor instead.
+func (*SearchTransactionsRequest) Descriptor() ([]byte, []int) {
+	return file_transaction_v1_transaction_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *SearchTransactionsRequest) GetFilter() *TransactionFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *SearchTransactionsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *SearchTransactionsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+type SearchTransactionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Transactions  []*Transaction         `protobuf:"bytes,1,rep,name=transactions,proto3" json:"transactions,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"` // Empty on the last page
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTransactionsResponse) Reset() {
+	*x = SearchTransactionsResponse{}
+	mi := &file_transaction_v1_transaction_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTransactionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTransactionsResponse) ProtoMessage() {}
+
+func (x *SearchTransactionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_transaction_v1_transaction_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTransactionsResponse.ProtoReflect.Descriptor instead.
+func (*SearchTransactionsResponse) Descriptor() ([]byte, []int) {
+	return file_transaction_v1_transaction_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SearchTransactionsResponse) GetTransactions() []*Transaction {
+	if x != nil {
+		return x.Transactions
+	}
+	return nil
+}
+
+func (x *SearchTransactionsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
 var File_transaction_v1_transaction_proto protoreflect.FileDescriptor
 
 const file_transaction_v1_transaction_proto_rawDesc = "" +
 	"\n" +
-	" transaction/v1/transaction.proto\x12\x0etransaction.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"\xb4\x03\n" +
+	" transaction/v1/transaction.proto\x12\x0etransaction.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16common/v1/common.proto\"\xd0\x03\n" +
 	"\vTransaction\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12*\n" +
 	"\x11source_account_id\x18\x02 \x01(\tR\x0fsourceAccountId\x124\n" +
@@ -606,30 +875,33 @@ const file_transaction_v1_transaction_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12'\n" +
-	"\x0fidempotency_key\x18\v \x01(\tR\x0eidempotencyKey\"\x8c\x02\n" +
+	"\x0fidempotency_key\x18\v \x01(\tR\x0eidempotencyKey\x12\x1a\n" +
+	"\bcategory\x18\f \x01(\tR\bcategory\"\xf0\x02\n" +
 	"\x15CreateTransferRequest\x12*\n" +
 	"\x11source_account_id\x18\x01 \x01(\tR\x0fsourceAccountId\x124\n" +
 	"\x16destination_account_id\x18\x02 \x01(\tR\x14destinationAccountId\x12(\n" +
 	"\x06amount\x18\x03 \x01(\v2\x10.common.v1.MoneyR\x06amount\x12\x1c\n" +
 	"\treference\x18\x04 \x01(\tR\treference\x12 \n" +
 	"\vdescription\x18\x05 \x01(\tR\vdescription\x12'\n" +
-	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\"W\n" +
+	"\x0fidempotency_key\x18\x06 \x01(\tR\x0eidempotencyKey\x12)\n" +
+	"\x10destination_iban\x18\a \x01(\tR\x0fdestinationIban\x127\n" +
+	"\x18initiated_by_customer_id\x18\b \x01(\tR\x15initiatedByCustomerId\"W\n" +
 	"\x16CreateTransferResponse\x12=\n" +
 	"\vtransaction\x18\x01 \x01(\v2\x1b.transaction.v1.TransactionR\vtransaction\">\n" +
 	"\x15GetTransactionRequest\x12%\n" +
 	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"W\n" +
 	"\x16GetTransactionResponse\x12=\n" +
-	"\vtransaction\x18\x01 \x01(\v2\x1b.transaction.v1.TransactionR\vtransaction\"v\n" +
+	"\vtransaction\x18\x01 \x01(\v2\x1b.transaction.v1.TransactionR\vtransaction\"x\n" +
 	"\x17ListTransactionsRequest\x12\x1d\n" +
 	"\n" +
-	"account_id\x18\x01 \x01(\tR\taccountId\x12<\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x1c.common.v1.PaginationRequestR\n" +
-	"pagination\"\x9a\x01\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x04 \x01(\x05R\x05limitJ\x04\b\x02\x10\x03R\n" +
+	"pagination\"\x8e\x01\n" +
 	"\x18ListTransactionsResponse\x12?\n" +
-	"\ftransactions\x18\x01 \x03(\v2\x1b.transaction.v1.TransactionR\ftransactions\x12=\n" +
-	"\n" +
-	"pagination\x18\x02 \x01(\v2\x1d.common.v1.PaginationResponseR\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.transaction.v1.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursorJ\x04\b\x02\x10\x03R\n" +
 	"pagination\"\xad\x01\n" +
 	"\x1aGetTransactionStatsRequest\x12\x1d\n" +
 	"\n" +
@@ -640,12 +912,39 @@ const file_transaction_v1_transaction_proto_rawDesc = "" +
 	"\x1bGetTransactionStatsResponse\x123\n" +
 	"\ftotal_inflow\x18\x01 \x01(\v2\x10.common.v1.MoneyR\vtotalInflow\x125\n" +
 	"\rtotal_outflow\x18\x02 \x01(\v2\x10.common.v1.MoneyR\ftotalOutflow\x12\x14\n" +
-	"\x05count\x18\x03 \x01(\x05R\x05count2\xad\x03\n" +
+	"\x05count\x18\x03 \x01(\x05R\x05count\"\xa6\x03\n" +
+	"\x11TransactionFilter\x12.\n" +
+	"\x04from\x18\x01 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\"\n" +
+	"\n" +
+	"min_amount\x18\x03 \x01(\x03H\x00R\tminAmount\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"max_amount\x18\x04 \x01(\x03H\x01R\tmaxAmount\x88\x01\x01\x12\x12\n" +
+	"\x04type\x18\x05 \x01(\tR\x04type\x12\x16\n" +
+	"\x06status\x18\x06 \x01(\tR\x06status\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\a \x01(\tR\taccountId\x126\n" +
+	"\x17counterparty_account_id\x18\b \x01(\tR\x15counterpartyAccountId\x12\x1a\n" +
+	"\bcategory\x18\t \x01(\tR\bcategory\x12\x1c\n" +
+	"\treference\x18\n" +
+	" \x01(\tR\treference\x12\x12\n" +
+	"\x04text\x18\v \x01(\tR\x04textB\r\n" +
+	"\v_min_amountB\r\n" +
+	"\v_max_amount\"\x84\x01\n" +
+	"\x19SearchTransactionsRequest\x129\n" +
+	"\x06filter\x18\x01 \x01(\v2!.transaction.v1.TransactionFilterR\x06filter\x12\x16\n" +
+	"\x06cursor\x18\x02 \x01(\tR\x06cursor\x12\x14\n" +
+	"\x05limit\x18\x03 \x01(\x05R\x05limit\"~\n" +
+	"\x1aSearchTransactionsResponse\x12?\n" +
+	"\ftransactions\x18\x01 \x03(\v2\x1b.transaction.v1.TransactionR\ftransactions\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor2\x9a\x04\n" +
 	"\x12TransactionService\x12_\n" +
 	"\x0eCreateTransfer\x12%.transaction.v1.CreateTransferRequest\x1a&.transaction.v1.CreateTransferResponse\x12_\n" +
 	"\x0eGetTransaction\x12%.transaction.v1.GetTransactionRequest\x1a&.transaction.v1.GetTransactionResponse\x12e\n" +
 	"\x10ListTransactions\x12'.transaction.v1.ListTransactionsRequest\x1a(.transaction.v1.ListTransactionsResponse\x12n\n" +
-	"\x13GetTransactionStats\x12*.transaction.v1.GetTransactionStatsRequest\x1a+.transaction.v1.GetTransactionStatsResponseB#Z!nordic-bank/pkg/pb/transaction/v1b\x06proto3"
+	"\x13GetTransactionStats\x12*.transaction.v1.GetTransactionStatsRequest\x1a+.transaction.v1.GetTransactionStatsResponse\x12k\n" +
+	"\x12SearchTransactions\x12).transaction.v1.SearchTransactionsRequest\x1a*.transaction.v1.SearchTransactionsResponseB#Z!nordic-bank/pkg/pb/transaction/v1b\x06proto3"
 
 var (
 	file_transaction_v1_transaction_proto_rawDescOnce sync.Once
@@ -659,7 +958,7 @@ func file_transaction_v1_transaction_proto_rawDescGZIP() []byte {
 	return file_transaction_v1_transaction_proto_rawDescData
 }
 
-var file_transaction_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_transaction_v1_transaction_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_transaction_v1_transaction_proto_goTypes = []any{
 	(*Transaction)(nil),                 // 0: transaction.v1.Transaction
 	(*CreateTransferRequest)(nil),       // 1: transaction.v1.CreateTransferRequest
@@ -670,38 +969,43 @@ var file_transaction_v1_transaction_proto_goTypes = []any{
 	(*ListTransactionsResponse)(nil),    // 6: transaction.v1.ListTransactionsResponse
 	(*GetTransactionStatsRequest)(nil),  // 7: transaction.v1.GetTransactionStatsRequest
 	(*GetTransactionStatsResponse)(nil), // 8: transaction.v1.GetTransactionStatsResponse
-	(*v1.Money)(nil),                    // 9: common.v1.Money
-	(*timestamppb.Timestamp)(nil),       // 10: google.protobuf.Timestamp
-	(*v1.PaginationRequest)(nil),        // 11: common.v1.PaginationRequest
-	(*v1.PaginationResponse)(nil),       // 12: common.v1.PaginationResponse
+	(*TransactionFilter)(nil),           // 9: transaction.v1.TransactionFilter
+	(*SearchTransactionsRequest)(nil),   // 10: transaction.v1.SearchTransactionsRequest
+	(*SearchTransactionsResponse)(nil),  // 11: transaction.v1.SearchTransactionsResponse
+	(*v1.Money)(nil),                    // 12: common.v1.Money
+	(*timestamppb.Timestamp)(nil),       // 13: google.protobuf.Timestamp
 }
 var file_transaction_v1_transaction_proto_depIdxs = []int32{
-	9,  // 0: transaction.v1.Transaction.amount:type_name -> common.v1.Money
-	10, // 1: transaction.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
-	10, // 2: transaction.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
-	9,  // 3: transaction.v1.CreateTransferRequest.amount:type_name -> common.v1.Money
+	12, // 0: transaction.v1.Transaction.amount:type_name -> common.v1.Money
+	13, // 1: transaction.v1.Transaction.created_at:type_name -> google.protobuf.Timestamp
+	13, // 2: transaction.v1.Transaction.updated_at:type_name -> google.protobuf.Timestamp
+	12, // 3: transaction.v1.CreateTransferRequest.amount:type_name -> common.v1.Money
 	0,  // 4: transaction.v1.CreateTransferResponse.transaction:type_name -> transaction.v1.Transaction
 	0,  // 5: transaction.v1.GetTransactionResponse.transaction:type_name -> transaction.v1.Transaction
-	11, // 6: transaction.v1.ListTransactionsRequest.pagination:type_name -> common.v1.PaginationRequest
-	0,  // 7: transaction.v1.ListTransactionsResponse.transactions:type_name -> transaction.v1.Transaction
-	12, // 8: transaction.v1.ListTransactionsResponse.pagination:type_name -> common.v1.PaginationResponse
-	10, // 9: transaction.v1.GetTransactionStatsRequest.start_date:type_name -> google.protobuf.Timestamp
-	10, // 10: transaction.v1.GetTransactionStatsRequest.end_date:type_name -> google.protobuf.Timestamp
-	9,  // 11: transaction.v1.GetTransactionStatsResponse.total_inflow:type_name -> common.v1.Money
-	9,  // 12: transaction.v1.GetTransactionStatsResponse.total_outflow:type_name -> common.v1.Money
-	1,  // 13: transaction.v1.TransactionService.CreateTransfer:input_type -> transaction.v1.CreateTransferRequest
-	3,  // 14: transaction.v1.TransactionService.GetTransaction:input_type -> transaction.v1.GetTransactionRequest
-	5,  // 15: transaction.v1.TransactionService.ListTransactions:input_type -> transaction.v1.ListTransactionsRequest
-	7,  // 16: transaction.v1.TransactionService.GetTransactionStats:input_type -> transaction.v1.GetTransactionStatsRequest
-	2,  // 17: transaction.v1.TransactionService.CreateTransfer:output_type -> transaction.v1.CreateTransferResponse
-	4,  // 18: transaction.v1.TransactionService.GetTransaction:output_type -> transaction.v1.GetTransactionResponse
-	6,  // 19: transaction.v1.TransactionService.ListTransactions:output_type -> transaction.v1.ListTransactionsResponse
-	8,  // 20: transaction.v1.TransactionService.GetTransactionStats:output_type -> transaction.v1.GetTransactionStatsResponse
-	17, // [17:21] is the sub-list for method output_type
-	13, // [13:17] is the sub-list for method input_type
-	13, // [13:13] is the sub-list for extension type_name
-	13, // [13:13] is the sub-list for extension extendee
-	0,  // [0:13] is the sub-list for field type_name
+	0,  // 6: transaction.v1.ListTransactionsResponse.transactions:type_name -> transaction.v1.Transaction
+	13, // 7: transaction.v1.GetTransactionStatsRequest.start_date:type_name -> google.protobuf.Timestamp
+	13, // 8: transaction.v1.GetTransactionStatsRequest.end_date:type_name -> google.protobuf.Timestamp
+	12, // 9: transaction.v1.GetTransactionStatsResponse.total_inflow:type_name -> common.v1.Money
+	12, // 10: transaction.v1.GetTransactionStatsResponse.total_outflow:type_name -> common.v1.Money
+	13, // 11: transaction.v1.TransactionFilter.from:type_name -> google.protobuf.Timestamp
+	13, // 12: transaction.v1.TransactionFilter.to:type_name -> google.protobuf.Timestamp
+	9,  // 13: transaction.v1.SearchTransactionsRequest.filter:type_name -> transaction.v1.TransactionFilter
+	0,  // 14: transaction.v1.SearchTransactionsResponse.transactions:type_name -> transaction.v1.Transaction
+	1,  // 15: transaction.v1.TransactionService.CreateTransfer:input_type -> transaction.v1.CreateTransferRequest
+	3,  // 16: transaction.v1.TransactionService.GetTransaction:input_type -> transaction.v1.GetTransactionRequest
+	5,  // 17: transaction.v1.TransactionService.ListTransactions:input_type -> transaction.v1.ListTransactionsRequest
+	7,  // 18: transaction.v1.TransactionService.GetTransactionStats:input_type -> transaction.v1.GetTransactionStatsRequest
+	10, // 19: transaction.v1.TransactionService.SearchTransactions:input_type -> transaction.v1.SearchTransactionsRequest
+	2,  // 20: transaction.v1.TransactionService.CreateTransfer:output_type -> transaction.v1.CreateTransferResponse
+	4,  // 21: transaction.v1.TransactionService.GetTransaction:output_type -> transaction.v1.GetTransactionResponse
+	6,  // 22: transaction.v1.TransactionService.ListTransactions:output_type -> transaction.v1.ListTransactionsResponse
+	8,  // 23: transaction.v1.TransactionService.GetTransactionStats:output_type -> transaction.v1.GetTransactionStatsResponse
+	11, // 24: transaction.v1.TransactionService.SearchTransactions:output_type -> transaction.v1.SearchTransactionsResponse
+	20, // [20:25] is the sub-list for method output_type
+	15, // [15:20] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_transaction_v1_transaction_proto_init() }
@@ -709,13 +1013,14 @@ func file_transaction_v1_transaction_proto_init() {
 	if File_transaction_v1_transaction_proto != nil {
 		return
 	}
+	file_transaction_v1_transaction_proto_msgTypes[9].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_transaction_v1_transaction_proto_rawDesc), len(file_transaction_v1_transaction_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
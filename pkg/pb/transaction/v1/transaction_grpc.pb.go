@@ -23,6 +23,7 @@ const (
 	TransactionService_GetTransaction_FullMethodName      = "/transaction.v1.TransactionService/GetTransaction"
 	TransactionService_ListTransactions_FullMethodName    = "/transaction.v1.TransactionService/ListTransactions"
 	TransactionService_GetTransactionStats_FullMethodName = "/transaction.v1.TransactionService/GetTransactionStats"
+	TransactionService_SearchTransactions_FullMethodName  = "/transaction.v1.TransactionService/SearchTransactions"
 )
 
 // TransactionServiceClient is the client API for TransactionService service.
@@ -37,6 +38,8 @@ type TransactionServiceClient interface {
 	ListTransactions(ctx context.Context, in *ListTransactionsRequest, opts ...grpc.CallOption) (*ListTransactionsResponse, error)
 	// Get transaction stats
 	GetTransactionStats(ctx context.Context, in *GetTransactionStatsRequest, opts ...grpc.CallOption) (*GetTransactionStatsResponse, error)
+	// Search transactions across accounts, newest first
+	SearchTransactions(ctx context.Context, in *SearchTransactionsRequest, opts ...grpc.CallOption) (*SearchTransactionsResponse, error)
 }
 
 type transactionServiceClient struct {
@@ -87,6 +90,16 @@ func (c *transactionServiceClient) GetTransactionStats(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *transactionServiceClient) SearchTransactions(ctx context.Context, in *SearchTransactionsRequest, opts ...grpc.CallOption) (*SearchTransactionsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchTransactionsResponse)
+	err := c.cc.Invoke(ctx, TransactionService_SearchTransactions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // TransactionServiceServer is the server API for TransactionService service.
 // All implementations must embed UnimplementedTransactionServiceServer
 // for forward compatibility.
@@ -99,6 +112,8 @@ type TransactionServiceServer interface {
 	ListTransactions(context.Context, *ListTransactionsRequest) (*ListTransactionsResponse, error)
 	// Get transaction stats
 	GetTransactionStats(context.Context, *GetTransactionStatsRequest) (*GetTransactionStatsResponse, error)
+	// Search transactions across accounts, newest first
+	SearchTransactions(context.Context, *SearchTransactionsRequest) (*SearchTransactionsResponse, error)
 	mustEmbedUnimplementedTransactionServiceServer()
 }
 
@@ -121,6 +136,9 @@ func (UnimplementedTransactionServiceServer) ListTransactions(context.Context, *
 func (UnimplementedTransactionServiceServer) GetTransactionStats(context.Context, *GetTransactionStatsRequest) (*GetTransactionStatsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetTransactionStats not implemented")
 }
+func (UnimplementedTransactionServiceServer) SearchTransactions(context.Context, *SearchTransactionsRequest) (*SearchTransactionsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchTransactions not implemented")
+}
 func (UnimplementedTransactionServiceServer) mustEmbedUnimplementedTransactionServiceServer() {}
 func (UnimplementedTransactionServiceServer) testEmbeddedByValue()                            {}
 
@@ -214,6 +232,24 @@ func _TransactionService_GetTransactionStats_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _TransactionService_SearchTransactions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchTransactionsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TransactionServiceServer).SearchTransactions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TransactionService_SearchTransactions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TransactionServiceServer).SearchTransactions(ctx, req.(*SearchTransactionsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // TransactionService_ServiceDesc is the grpc.ServiceDesc for TransactionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -237,6 +273,10 @@ var TransactionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetTransactionStats",
 			Handler:    _TransactionService_GetTransactionStats_Handler,
 		},
+		{
+			MethodName: "SearchTransactions",
+			Handler:    _TransactionService_SearchTransactions_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "transaction/v1/transaction.proto",